@@ -0,0 +1,63 @@
+package audit_handler
+
+import (
+	"avitoTest/api/handlers/audit_handler/audit_handler_models"
+	"avitoTest/services/audit_service"
+	"avitoTest/services/audit_service/audit_models"
+	"avitoTest/shared/errors/api_errors"
+	"avitoTest/shared/filter"
+	"avitoTest/shared/pagination"
+	"encoding/json"
+	"net/http"
+)
+
+type AuditHandler struct {
+	service audit_service.AuditService
+}
+
+func NewAuditHandler(service audit_service.AuditService) *AuditHandler {
+	return &AuditHandler{service: service}
+}
+
+// GetAuditEvents handles fetching a filtered page of the audit log
+func (h *AuditHandler) GetAuditEvents(w http.ResponseWriter, r *http.Request) {
+	params, err := pagination.FromRequest(r, pagination.SortCreatedAt)
+	if err != nil {
+		api_errors.WriteProblem(w, r, api_errors.ErrInvalidRequest(err))
+		return
+	}
+
+	f, err := filter.Parse(r.URL.Query(), audit_models.AuditFilterSchema)
+	if err != nil {
+		api_errors.WriteProblem(w, r, api_errors.ErrInvalidRequest(err))
+		return
+	}
+
+	events, err := h.service.GetEvents(r.Context(), f, params)
+	if err != nil {
+		api_errors.WriteError(w, r, err)
+		return
+	}
+
+	resp := pagination.MapPage(events, toAuditEventResponse)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(resp)
+}
+
+func toAuditEventResponse(event *audit_models.AuditEventModel) audit_handler_models.AuditEventResponse {
+	return audit_handler_models.AuditEventResponse{
+		ID:             event.ID,
+		EntityType:     event.EntityType,
+		EntityID:       event.EntityID,
+		OrganizationID: event.OrganizationID,
+		Action:         event.Action,
+		ActorUserID:    event.ActorUserID,
+		ActorClient:    event.ActorClient,
+		RequestID:      event.RequestID,
+		Before:         event.Before,
+		After:          event.After,
+		CreatedAt:      event.CreatedAt,
+	}
+}
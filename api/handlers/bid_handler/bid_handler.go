@@ -5,7 +5,12 @@ import (
 	"avitoTest/services/bid_service"
 	"avitoTest/services/bid_service/bid_models"
 	"avitoTest/shared"
+	"avitoTest/shared/errors/api_errors"
+	"avitoTest/shared/errors/bid_errors"
+	"avitoTest/shared/filter"
+	"avitoTest/shared/pagination"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"strconv"
 
@@ -24,7 +29,7 @@ func NewBidHandler(service bid_service.BidService) *BidHandler {
 func (h *BidHandler) CreateBid(w http.ResponseWriter, r *http.Request) {
 	var req bid_handler_models.CreateBidRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		api_errors.WriteProblem(w, r, api_errors.ErrInvalidRequest(err))
 		return
 	}
 
@@ -42,7 +47,16 @@ func (h *BidHandler) CreateBid(w http.ResponseWriter, r *http.Request) {
 
 	bid, err := h.service.CreateBid(r.Context(), bidCreateModel)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		var eligibilityErr *bid_service.EligibilityError
+		if errors.As(err, &eligibilityErr) {
+			problem := api_errors.NewProblem(http.StatusUnprocessableEntity, bid_errors.ErrNotEligible)
+			api_errors.WriteProblemBody(w, r, problem, bid_handler_models.NotEligibleResponse{
+				ErrResponse: problem,
+				FailedRules: toFailedRuleResponses(eligibilityErr.FailedRules),
+			})
+			return
+		}
+		api_errors.WriteError(w, r, err)
 		return
 	}
 
@@ -68,13 +82,13 @@ func (h *BidHandler) GetBidByID(w http.ResponseWriter, r *http.Request) {
 	bidIDStr := mux.Vars(r)["bidId"]
 	bidID, err := strconv.Atoi(bidIDStr)
 	if err != nil {
-		http.Error(w, "Invalid bid ID", http.StatusBadRequest)
+		api_errors.WriteProblem(w, r, api_errors.ErrBadRequest("Invalid bid ID"))
 		return
 	}
 
 	bid, err := h.service.GetBidByID(r.Context(), bidID)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		api_errors.WriteError(w, r, err)
 		return
 	}
 
@@ -100,31 +114,30 @@ func (h *BidHandler) GetBidsByTenderID(w http.ResponseWriter, r *http.Request) {
 	tenderIDStr := mux.Vars(r)["tenderId"]
 	tenderID, err := strconv.Atoi(tenderIDStr)
 	if err != nil {
-		http.Error(w, "Invalid tender ID", http.StatusBadRequest)
+		api_errors.WriteProblem(w, r, api_errors.ErrBadRequest("Invalid tender ID"))
 		return
 	}
 
-	bids, err := h.service.GetBidsByTenderID(r.Context(), tenderID)
+	params, err := pagination.FromRequest(r, pagination.SortCreatedAt, pagination.SortName, pagination.SortStatus)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		api_errors.WriteProblem(w, r, api_errors.ErrInvalidRequest(err))
 		return
 	}
 
-	var resp []bid_handler_models.BidResponse
-	for _, bid := range bids {
-		resp = append(resp, bid_handler_models.BidResponse{
-			ID:             bid.ID,
-			Name:           bid.Name,
-			Description:    bid.Description,
-			TenderID:       bid.TenderID,
-			OrganizationID: bid.OrganizationID,
-			CreatorID:      bid.CreatorID,
-			Status:         bid.Status,
-			CreatedAt:      bid.CreatedAt,
-			Version:        bid.Version,
-		})
+	f, err := filter.Parse(r.URL.Query(), bid_models.BidFilterSchema)
+	if err != nil {
+		api_errors.WriteProblem(w, r, api_errors.ErrInvalidRequest(err))
+		return
+	}
+
+	bids, err := h.service.GetBidsByTenderID(r.Context(), tenderID, f, params)
+	if err != nil {
+		api_errors.WriteError(w, r, err)
+		return
 	}
 
+	resp := pagination.MapPage(bids, toBidResponse)
+
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
 	json.NewEncoder(w).Encode(resp)
@@ -135,31 +148,24 @@ func (h *BidHandler) GetBidsByUserID(w http.ResponseWriter, r *http.Request) {
 	userIDStr := mux.Vars(r)["userId"]
 	userID, err := strconv.Atoi(userIDStr)
 	if err != nil {
-		http.Error(w, "Invalid user ID", http.StatusBadRequest)
+		api_errors.WriteProblem(w, r, api_errors.ErrBadRequest("Invalid user ID"))
 		return
 	}
 
-	bids, err := h.service.GetBidsByUserID(r.Context(), userID)
+	params, err := pagination.FromRequest(r, pagination.SortCreatedAt, pagination.SortName, pagination.SortStatus)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		api_errors.WriteProblem(w, r, api_errors.ErrInvalidRequest(err))
 		return
 	}
 
-	var resp []bid_handler_models.BidResponse
-	for _, bid := range bids {
-		resp = append(resp, bid_handler_models.BidResponse{
-			ID:             bid.ID,
-			Name:           bid.Name,
-			Description:    bid.Description,
-			TenderID:       bid.TenderID,
-			OrganizationID: bid.OrganizationID,
-			CreatorID:      bid.CreatorID,
-			Status:         bid.Status,
-			CreatedAt:      bid.CreatedAt,
-			Version:        bid.Version,
-		})
+	bids, err := h.service.GetBidsByUserID(r.Context(), userID, params)
+	if err != nil {
+		api_errors.WriteError(w, r, err)
+		return
 	}
 
+	resp := pagination.MapPage(bids, toBidResponse)
+
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
 	json.NewEncoder(w).Encode(resp)
@@ -170,31 +176,24 @@ func (h *BidHandler) GetBidsByUsername(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	username, ok := vars["username"]
 	if !ok {
-		http.Error(w, "Username is required", http.StatusBadRequest)
+		api_errors.WriteProblem(w, r, api_errors.ErrBadRequest("Username is required"))
 		return
 	}
 
-	bids, err := h.service.GetBidsByUsername(r.Context(), username)
+	params, err := pagination.FromRequest(r, pagination.SortCreatedAt, pagination.SortName, pagination.SortStatus)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		api_errors.WriteProblem(w, r, api_errors.ErrInvalidRequest(err))
 		return
 	}
 
-	var resp []bid_handler_models.BidResponse
-	for _, bid := range bids {
-		resp = append(resp, bid_handler_models.BidResponse{
-			ID:             bid.ID,
-			Name:           bid.Name,
-			Description:    bid.Description,
-			TenderID:       bid.TenderID,
-			OrganizationID: bid.OrganizationID,
-			CreatorID:      bid.CreatorID,
-			Status:         bid.Status,
-			CreatedAt:      bid.CreatedAt,
-			Version:        bid.Version,
-		})
+	bids, err := h.service.GetBidsByUsername(r.Context(), username, params)
+	if err != nil {
+		api_errors.WriteError(w, r, err)
+		return
 	}
 
+	resp := pagination.MapPage(bids, toBidResponse)
+
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
 	json.NewEncoder(w).Encode(resp)
@@ -206,14 +205,14 @@ func (h *BidHandler) UpdateBid(w http.ResponseWriter, r *http.Request) {
 	bidIDStr := mux.Vars(r)["bidId"]
 	bidID, err := strconv.Atoi(bidIDStr)
 	if err != nil {
-		http.Error(w, "Invalid bid ID", http.StatusBadRequest)
+		api_errors.WriteProblem(w, r, api_errors.ErrBadRequest("Invalid bid ID"))
 		return
 	}
 
 	// Decode the request body into UpdateBidRequest
 	var req bid_handler_models.UpdateBidRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		api_errors.WriteProblem(w, r, api_errors.ErrInvalidRequest(err))
 		return
 	}
 
@@ -227,7 +226,7 @@ func (h *BidHandler) UpdateBid(w http.ResponseWriter, r *http.Request) {
 	// Call the service to update the bid
 	bid, err := h.service.UpdateBid(r.Context(), bidUpdateModel)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		api_errors.WriteError(w, r, err)
 		return
 	}
 
@@ -255,20 +254,20 @@ func (h *BidHandler) ApproveBid(w http.ResponseWriter, r *http.Request) {
 	bidIDStr := mux.Vars(r)["bidId"]
 	bidID, err := strconv.Atoi(bidIDStr)
 	if err != nil {
-		http.Error(w, "Invalid bid ID", http.StatusBadRequest)
+		api_errors.WriteProblem(w, r, api_errors.ErrBadRequest("Invalid bid ID"))
 		return
 	}
 
 	approverIDStr := mux.Vars(r)["approverId"]
 	approverID, err := strconv.Atoi(approverIDStr)
 	if err != nil {
-		http.Error(w, "Invalid approver ID", http.StatusBadRequest)
+		api_errors.WriteProblem(w, r, api_errors.ErrBadRequest("Invalid approver ID"))
 		return
 	}
 
 	err = h.service.ApproveBid(r.Context(), bidID, approverID)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		api_errors.WriteError(w, r, err)
 		return
 	}
 
@@ -280,20 +279,20 @@ func (h *BidHandler) RejectBid(w http.ResponseWriter, r *http.Request) {
 	bidIDStr := mux.Vars(r)["bidId"]
 	bidID, err := strconv.Atoi(bidIDStr)
 	if err != nil {
-		http.Error(w, "Invalid bid ID", http.StatusBadRequest)
+		api_errors.WriteProblem(w, r, api_errors.ErrBadRequest("Invalid bid ID"))
 		return
 	}
 
 	rejecterIDStr := mux.Vars(r)["rejecterId"]
 	rejecterID, err := strconv.Atoi(rejecterIDStr)
 	if err != nil {
-		http.Error(w, "Invalid rejecter ID", http.StatusBadRequest)
+		api_errors.WriteProblem(w, r, api_errors.ErrBadRequest("Invalid rejecter ID"))
 		return
 	}
 
 	err = h.service.RejectBid(r.Context(), bidID, rejecterID)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		api_errors.WriteError(w, r, err)
 		return
 	}
 
@@ -306,18 +305,18 @@ func (h *BidHandler) RollbackBidVersion(w http.ResponseWriter, r *http.Request)
 	versionStr := mux.Vars(r)["version"]
 	bidID, err := strconv.Atoi(bidIDStr)
 	if err != nil {
-		http.Error(w, "Invalid bid ID", http.StatusBadRequest)
+		api_errors.WriteProblem(w, r, api_errors.ErrBadRequest("Invalid bid ID"))
 		return
 	}
 	version, err := strconv.Atoi(versionStr)
 	if err != nil {
-		http.Error(w, "Invalid version number", http.StatusBadRequest)
+		api_errors.WriteProblem(w, r, api_errors.ErrBadRequest("Invalid version number"))
 		return
 	}
 
 	bid, err := h.service.RollbackBidVersion(r.Context(), bidID, version)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		api_errors.WriteError(w, r, err)
 		return
 	}
 
@@ -343,15 +342,152 @@ func (h *BidHandler) DeleteBid(w http.ResponseWriter, r *http.Request) {
 	bidIDStr := mux.Vars(r)["bidId"]
 	bidID, err := strconv.Atoi(bidIDStr)
 	if err != nil {
-		http.Error(w, "Invalid bid ID", http.StatusBadRequest)
+		api_errors.WriteProblem(w, r, api_errors.ErrBadRequest("Invalid bid ID"))
 		return
 	}
 
 	err = h.service.DeleteBid(r.Context(), bidID)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		api_errors.WriteError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// DeclareConflict records a conflict of interest of a responsible for a bid
+func (h *BidHandler) DeclareConflict(w http.ResponseWriter, r *http.Request) {
+	bidIDStr := mux.Vars(r)["bidId"]
+	bidID, err := strconv.Atoi(bidIDStr)
+	if err != nil {
+		api_errors.WriteProblem(w, r, api_errors.ErrBadRequest("Invalid bid ID"))
+		return
+	}
+
+	userIDStr := mux.Vars(r)["userId"]
+	userID, err := strconv.Atoi(userIDStr)
+	if err != nil {
+		api_errors.WriteProblem(w, r, api_errors.ErrBadRequest("Invalid user ID"))
+		return
+	}
+
+	var req bid_handler_models.DeclareConflictRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		api_errors.WriteProblem(w, r, api_errors.ErrInvalidRequest(err))
+		return
+	}
+
+	conflict, err := h.service.DeclareConflict(r.Context(), bid_models.BidConflictCreateModel{
+		BidID:  bidID,
+		UserID: userID,
+		Reason: req.Reason,
+	})
+	if err != nil {
+		api_errors.WriteError(w, r, err)
+		return
+	}
+
+	resp := bid_handler_models.BidConflictResponse{
+		ID:        conflict.ID,
+		BidID:     conflict.BidID,
+		UserID:    conflict.UserID,
+		Reason:    conflict.Reason,
+		CreatedAt: conflict.CreatedAt,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(resp)
+}
+
+// GetBidConflicts returns conflicts of interest declared for a bid
+func (h *BidHandler) GetBidConflicts(w http.ResponseWriter, r *http.Request) {
+	bidIDStr := mux.Vars(r)["bidId"]
+	bidID, err := strconv.Atoi(bidIDStr)
+	if err != nil {
+		api_errors.WriteProblem(w, r, api_errors.ErrBadRequest("Invalid bid ID"))
+		return
+	}
+
+	conflicts, err := h.service.GetBidConflicts(r.Context(), bidID)
+	if err != nil {
+		api_errors.WriteError(w, r, err)
 		return
 	}
 
+	var resp []bid_handler_models.BidConflictResponse
+	for _, conflict := range conflicts {
+		resp = append(resp, bid_handler_models.BidConflictResponse{
+			ID:        conflict.ID,
+			BidID:     conflict.BidID,
+			UserID:    conflict.UserID,
+			Reason:    conflict.Reason,
+			CreatedAt: conflict.CreatedAt,
+		})
+	}
+
+	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(resp)
+}
+
+// CheckEligibility checks whether an organization may bid on a tender
+func (h *BidHandler) CheckEligibility(w http.ResponseWriter, r *http.Request) {
+	tenderIDStr := mux.Vars(r)["tenderId"]
+	tenderID, err := strconv.Atoi(tenderIDStr)
+	if err != nil {
+		api_errors.WriteProblem(w, r, api_errors.ErrBadRequest("Invalid tender ID"))
+		return
+	}
+
+	organizationID, err := strconv.Atoi(r.URL.Query().Get("organizationId"))
+	if err != nil {
+		api_errors.WriteProblem(w, r, api_errors.ErrBadRequest("Invalid organization ID"))
+		return
+	}
+
+	result, err := h.service.CheckEligibility(r.Context(), tenderID, organizationID)
+	if err != nil {
+		api_errors.WriteError(w, r, err)
+		return
+	}
+
+	resp := bid_handler_models.EligibilityResponse{
+		TenderID:       result.TenderID,
+		OrganizationID: result.OrganizationID,
+		Eligible:       result.Eligible,
+		FailedRules:    toFailedRuleResponses(result.FailedRules),
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(resp)
+}
+
+func toFailedRuleResponses(rules []bid_models.FailedEligibilityRule) []bid_handler_models.FailedRuleResponse {
+	resp := make([]bid_handler_models.FailedRuleResponse, 0, len(rules))
+	for _, rule := range rules {
+		resp = append(resp, bid_handler_models.FailedRuleResponse{
+			RuleType: rule.RuleType,
+			Expected: rule.Expected,
+			Actual:   rule.Actual,
+			Message:  rule.Message,
+		})
+	}
+	return resp
+}
+
+// toBidResponse converts a bid model into its API representation.
+func toBidResponse(bid *bid_models.BidModel) bid_handler_models.BidResponse {
+	return bid_handler_models.BidResponse{
+		ID:             bid.ID,
+		Name:           bid.Name,
+		Description:    bid.Description,
+		TenderID:       bid.TenderID,
+		OrganizationID: bid.OrganizationID,
+		CreatorID:      bid.CreatorID,
+		Status:         bid.Status,
+		CreatedAt:      bid.CreatedAt,
+		Version:        bid.Version,
+	}
 }
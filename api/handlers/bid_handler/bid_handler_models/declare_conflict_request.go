@@ -0,0 +1,5 @@
+package bid_handler_models
+
+type DeclareConflictRequest struct {
+	Reason string `json:"reason"`
+}
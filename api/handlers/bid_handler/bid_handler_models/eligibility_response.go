@@ -0,0 +1,23 @@
+package bid_handler_models
+
+import "avitoTest/shared/errors/api_errors"
+
+type FailedRuleResponse struct {
+	RuleType string `json:"rule_type"`
+	Expected string `json:"expected"`
+	Actual   string `json:"actual"`
+	Message  string `json:"message"`
+}
+
+type EligibilityResponse struct {
+	TenderID       int                  `json:"tender_id"`
+	OrganizationID int                  `json:"organization_id"`
+	Eligible       bool                 `json:"eligible"`
+	FailedRules    []FailedRuleResponse `json:"failed_rules"`
+}
+
+// NotEligibleResponse - problem details listing the eligibility rules the bidder fails.
+type NotEligibleResponse struct {
+	*api_errors.ErrResponse
+	FailedRules []FailedRuleResponse `json:"failed_rules"`
+}
@@ -0,0 +1,13 @@
+package cache_handler
+
+import (
+	"avitoTest/shared/cache"
+	"encoding/json"
+	"net/http"
+)
+
+// CacheStatsHandler returns the hit and miss counters of every cache namespace.
+func CacheStatsHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(cache.AllStats())
+}
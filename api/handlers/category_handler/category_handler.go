@@ -0,0 +1,151 @@
+package category_handler
+
+import (
+	"avitoTest/api/handlers/category_handler/category_handler_models"
+	"avitoTest/services/category_service"
+	"avitoTest/services/category_service/category_models"
+	"avitoTest/shared/errors/api_errors"
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+)
+
+type CategoryHandler struct {
+	service category_service.CategoryService
+}
+
+func NewCategoryHandler(service category_service.CategoryService) *CategoryHandler {
+	return &CategoryHandler{service: service}
+}
+
+// CreateCategory handles creating a new service category
+func (h *CategoryHandler) CreateCategory(w http.ResponseWriter, r *http.Request) {
+	var req category_handler_models.CreateCategoryRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		api_errors.WriteProblem(w, r, api_errors.ErrInvalidRequest(err))
+		return
+	}
+
+	category, err := h.service.CreateCategory(r.Context(), category_models.CategoryCreateModel{
+		Code:     req.Code,
+		ParentID: req.ParentID,
+		IsActive: req.IsActive,
+		Names:    req.Names,
+	})
+	if err != nil {
+		api_errors.WriteError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(toCategoryResponse(category))
+}
+
+// GetCategories handles fetching the service categories
+func (h *CategoryHandler) GetCategories(w http.ResponseWriter, r *http.Request) {
+	includeInactive := r.URL.Query().Get("includeInactive") == "true"
+
+	categories, err := h.service.GetCategories(r.Context(), languageFromRequest(r), includeInactive)
+	if err != nil {
+		api_errors.WriteError(w, r, err)
+		return
+	}
+
+	resp := make([]category_handler_models.CategoryResponse, 0, len(categories))
+	for _, category := range categories {
+		resp = append(resp, toCategoryResponse(category))
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(resp)
+}
+
+// GetCategoryByID handles fetching a service category by ID
+func (h *CategoryHandler) GetCategoryByID(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(mux.Vars(r)["categoryId"])
+	if err != nil {
+		api_errors.WriteProblem(w, r, api_errors.ErrBadRequest("Invalid category ID"))
+		return
+	}
+
+	category, err := h.service.GetCategoryByID(r.Context(), id, languageFromRequest(r))
+	if err != nil {
+		api_errors.WriteError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(toCategoryResponse(category))
+}
+
+// UpdateCategory handles updating a service category
+func (h *CategoryHandler) UpdateCategory(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(mux.Vars(r)["categoryId"])
+	if err != nil {
+		api_errors.WriteProblem(w, r, api_errors.ErrBadRequest("Invalid category ID"))
+		return
+	}
+
+	var req category_handler_models.UpdateCategoryRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		api_errors.WriteProblem(w, r, api_errors.ErrInvalidRequest(err))
+		return
+	}
+
+	category, err := h.service.UpdateCategory(r.Context(), category_models.CategoryUpdateModel{
+		ID:       id,
+		ParentID: req.ParentID,
+		IsActive: req.IsActive,
+		Names:    req.Names,
+	})
+	if err != nil {
+		api_errors.WriteError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(toCategoryResponse(category))
+}
+
+// DeleteCategory handles deleting a service category
+func (h *CategoryHandler) DeleteCategory(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(mux.Vars(r)["categoryId"])
+	if err != nil {
+		api_errors.WriteProblem(w, r, api_errors.ErrBadRequest("Invalid category ID"))
+		return
+	}
+
+	if err := h.service.DeleteCategory(r.Context(), id); err != nil {
+		api_errors.WriteError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// languageFromRequest returns the requested name language, defaulting to Russian.
+func languageFromRequest(r *http.Request) string {
+	if lang := r.URL.Query().Get("lang"); lang != "" {
+		return lang
+	}
+	return category_service.DefaultLanguage
+}
+
+func toCategoryResponse(category *category_models.CategoryModel) category_handler_models.CategoryResponse {
+	return category_handler_models.CategoryResponse{
+		ID:        category.ID,
+		Code:      category.Code,
+		ParentID:  category.ParentID,
+		IsActive:  category.IsActive,
+		Name:      category.Name,
+		Names:     category.Names,
+		CreatedAt: category.CreatedAt,
+		UpdatedAt: category.UpdatedAt,
+	}
+}
@@ -0,0 +1,14 @@
+package category_handler_models
+
+type CreateCategoryRequest struct {
+	Code     string            `json:"code"`
+	ParentID *int              `json:"parent_id"`
+	IsActive *bool             `json:"is_active"`
+	Names    map[string]string `json:"names"`
+}
+
+type UpdateCategoryRequest struct {
+	ParentID *int              `json:"parent_id"`
+	IsActive bool              `json:"is_active"`
+	Names    map[string]string `json:"names"`
+}
@@ -0,0 +1,14 @@
+package category_handler_models
+
+import "time"
+
+type CategoryResponse struct {
+	ID        int               `json:"id"`
+	Code      string            `json:"code"`
+	ParentID  *int              `json:"parent_id"`
+	IsActive  bool              `json:"is_active"`
+	Name      string            `json:"name"`
+	Names     map[string]string `json:"names"`
+	CreatedAt time.Time         `json:"created_at"`
+	UpdatedAt time.Time         `json:"updated_at"`
+}
@@ -3,6 +3,8 @@ package comment_handler
 import (
 	"avitoTest/services/comment_service"
 	"avitoTest/services/comment_service/comment_models"
+	"avitoTest/shared/errors/api_errors"
+	"avitoTest/shared/pagination"
 	"encoding/json"
 	"net/http"
 	"strconv"
@@ -22,20 +24,20 @@ func NewCommentHandler(service comment_service.CommentService) *CommentHandler {
 func (h *CommentHandler) CreateComment(w http.ResponseWriter, r *http.Request) {
 	var req comment_models.CommentCreateModel
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		api_errors.WriteProblem(w, r, api_errors.ErrInvalidRequest(err))
 		return
 	}
 
 	// Проверка, что user_id присутствует
 	if req.UserID == 0 {
-		http.Error(w, "user ID is required", http.StatusBadRequest)
+		api_errors.WriteProblem(w, r, api_errors.ErrBadRequest("user ID is required"))
 		return
 	}
 
 	// Вызов сервиса для создания комментария
 	comment, err := h.service.CreateComment(r.Context(), req)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		api_errors.WriteError(w, r, err)
 		return
 	}
 
@@ -48,7 +50,7 @@ func (h *CommentHandler) CreateComment(w http.ResponseWriter, r *http.Request) {
 func (h *CommentHandler) GetReviews(w http.ResponseWriter, r *http.Request) {
 	authorUsername := r.URL.Query().Get("authorUsername")
 	if authorUsername == "" {
-		http.Error(w, "authorUsername is required", http.StatusBadRequest)
+		api_errors.WriteProblem(w, r, api_errors.ErrBadRequest("authorUsername is required"))
 		return
 	}
 
@@ -58,14 +60,20 @@ func (h *CommentHandler) GetReviews(w http.ResponseWriter, r *http.Request) {
 		var err error
 		organizationID, err = strconv.Atoi(organizationIDStr)
 		if err != nil {
-			http.Error(w, "Invalid organization ID", http.StatusBadRequest)
+			api_errors.WriteProblem(w, r, api_errors.ErrBadRequest("Invalid organization ID"))
 			return
 		}
 	}
 
-	comments, err := h.service.GetCommentsByFilters(r.Context(), authorUsername, organizationID)
+	params, err := pagination.FromRequest(r, pagination.SortCreatedAt, pagination.SortName)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		api_errors.WriteProblem(w, r, api_errors.ErrInvalidRequest(err))
+		return
+	}
+
+	comments, err := h.service.GetCommentsByFilters(r.Context(), authorUsername, organizationID, params)
+	if err != nil {
+		api_errors.WriteError(w, r, err)
 		return
 	}
 
@@ -79,13 +87,13 @@ func (h *CommentHandler) DeleteComment(w http.ResponseWriter, r *http.Request) {
 	commentIDStr := mux.Vars(r)["commentId"]
 	commentID, err := strconv.Atoi(commentIDStr)
 	if err != nil {
-		http.Error(w, "Invalid comment ID", http.StatusBadRequest)
+		api_errors.WriteProblem(w, r, api_errors.ErrBadRequest("Invalid comment ID"))
 		return
 	}
 
 	err = h.service.DeleteComment(r.Context(), commentID)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		api_errors.WriteError(w, r, err)
 		return
 	}
 
@@ -0,0 +1,53 @@
+package export_handler
+
+import (
+	"avitoTest/services/bid_service/bid_models"
+	"avitoTest/services/tender_service/tender_models"
+	"avitoTest/shared/export"
+	"strconv"
+	"time"
+)
+
+var tenderColumns = []export.Column[*tender_models.TenderModel]{
+	{Name: "id", Value: func(t *tender_models.TenderModel) string { return strconv.Itoa(t.ID) }},
+	{Name: "organization_id", Value: func(t *tender_models.TenderModel) string { return strconv.Itoa(t.OrganizationID) }},
+	{Name: "name", Value: func(t *tender_models.TenderModel) string { return t.Name }},
+	{Name: "description", Value: func(t *tender_models.TenderModel) string { return t.Description }},
+	{Name: "service_type", Value: func(t *tender_models.TenderModel) string { return t.ServiceType }},
+	{Name: "budget", Value: func(t *tender_models.TenderModel) string { return formatBudget(t.Budget) }},
+	{Name: "status", Value: func(t *tender_models.TenderModel) string { return string(t.Status) }},
+	{Name: "version", Value: func(t *tender_models.TenderModel) string { return strconv.Itoa(t.Version) }},
+	{Name: "created_at", Value: func(t *tender_models.TenderModel) string { return formatTime(t.CreatedAt) }},
+}
+
+var bidColumns = []export.Column[*bid_models.BidModel]{
+	{Name: "id", Value: func(b *bid_models.BidModel) string { return strconv.Itoa(b.ID) }},
+	{Name: "tender_id", Value: func(b *bid_models.BidModel) string { return strconv.Itoa(b.TenderID) }},
+	{Name: "organization_id", Value: func(b *bid_models.BidModel) string { return strconv.Itoa(b.OrganizationID) }},
+	{Name: "creator_id", Value: func(b *bid_models.BidModel) string { return strconv.Itoa(b.CreatorID) }},
+	{Name: "name", Value: func(b *bid_models.BidModel) string { return b.Name }},
+	{Name: "description", Value: func(b *bid_models.BidModel) string { return b.Description }},
+	{Name: "status", Value: func(b *bid_models.BidModel) string { return b.Status }},
+	{Name: "version", Value: func(b *bid_models.BidModel) string { return strconv.Itoa(b.Version) }},
+	{Name: "created_at", Value: func(b *bid_models.BidModel) string { return formatTime(b.CreatedAt) }},
+}
+
+var decisionColumns = []export.Column[*bid_models.BidDecisionModel]{
+	{Name: "id", Value: func(d *bid_models.BidDecisionModel) string { return strconv.Itoa(d.ID) }},
+	{Name: "bid_id", Value: func(d *bid_models.BidDecisionModel) string { return strconv.Itoa(d.BidID) }},
+	{Name: "tender_id", Value: func(d *bid_models.BidDecisionModel) string { return strconv.Itoa(d.TenderID) }},
+	{Name: "user_id", Value: func(d *bid_models.BidDecisionModel) string { return strconv.Itoa(d.UserID) }},
+	{Name: "decision", Value: func(d *bid_models.BidDecisionModel) string { return d.Decision }},
+	{Name: "created_at", Value: func(d *bid_models.BidDecisionModel) string { return formatTime(d.CreatedAt) }},
+}
+
+func formatBudget(budget *float64) string {
+	if budget == nil {
+		return ""
+	}
+	return strconv.FormatFloat(*budget, 'f', 2, 64)
+}
+
+func formatTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339)
+}
@@ -0,0 +1,131 @@
+package export_handler
+
+import (
+	"avitoTest/services/bid_service"
+	"avitoTest/services/bid_service/bid_models"
+	"avitoTest/services/organization_service"
+	"avitoTest/services/tender_service"
+	"avitoTest/services/tender_service/tender_models"
+	"avitoTest/shared"
+	"avitoTest/shared/errors/api_errors"
+	"avitoTest/shared/export"
+	"avitoTest/shared/filter"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+)
+
+type ExportHandler struct {
+	org_service    organization_service.OrganizationService
+	tender_service tender_service.TenderService
+	bid_service    bid_service.BidService
+}
+
+func NewExportHandler(org_service organization_service.OrganizationService, tender_service tender_service.TenderService, bid_service bid_service.BidService) *ExportHandler {
+	return &ExportHandler{
+		org_service:    org_service,
+		tender_service: tender_service,
+		bid_service:    bid_service,
+	}
+}
+
+// exportRequest is the validated part of an export request.
+type exportRequest struct {
+	orgID  int
+	format export.Format
+	filter filter.Filter
+}
+
+// ExportTenders streams the tenders of an organization with their latest versions
+func (h *ExportHandler) ExportTenders(w http.ResponseWriter, r *http.Request) {
+	req, ok := h.parseRequest(w, r, tender_models.TenderFilterSchema)
+	if !ok {
+		return
+	}
+
+	writer := export.NewWriter(w, req.format, tenderColumns)
+	setExportHeaders(w, req, "tenders")
+	finishExport(w, r, writer, h.tender_service.ExportTenders(r.Context(), req.orgID, req.filter, writer.Write))
+}
+
+// ExportBids streams the bids received by the tenders of an organization
+func (h *ExportHandler) ExportBids(w http.ResponseWriter, r *http.Request) {
+	req, ok := h.parseRequest(w, r, bid_models.BidFilterSchema)
+	if !ok {
+		return
+	}
+
+	writer := export.NewWriter(w, req.format, bidColumns)
+	setExportHeaders(w, req, "bids")
+	finishExport(w, r, writer, h.bid_service.ExportBids(r.Context(), req.orgID, req.filter, writer.Write))
+}
+
+// ExportDecisions streams the approvals and rejections of the bids received by the tenders of an organization
+func (h *ExportHandler) ExportDecisions(w http.ResponseWriter, r *http.Request) {
+	req, ok := h.parseRequest(w, r, bid_models.DecisionFilterSchema)
+	if !ok {
+		return
+	}
+
+	writer := export.NewWriter(w, req.format, decisionColumns)
+	setExportHeaders(w, req, "decisions")
+	finishExport(w, r, writer, h.bid_service.ExportDecisions(r.Context(), req.orgID, req.filter, writer.Write))
+}
+
+// parseRequest reads the organization, the format and the filter, and checks that the organization exists
+func (h *ExportHandler) parseRequest(w http.ResponseWriter, r *http.Request, schema filter.Schema) (exportRequest, bool) {
+	var req exportRequest
+
+	orgID, err := strconv.Atoi(mux.Vars(r)["org_id"])
+	if err != nil {
+		api_errors.WriteProblem(w, r, api_errors.ErrBadRequest("Invalid organization ID"))
+		return req, false
+	}
+
+	format, err := export.ParseFormat(r.URL.Query().Get("format"))
+	if err != nil {
+		api_errors.WriteProblem(w, r, api_errors.ErrInvalidRequest(err))
+		return req, false
+	}
+
+	f, err := filter.Parse(r.URL.Query(), schema)
+	if err != nil {
+		api_errors.WriteProblem(w, r, api_errors.ErrInvalidRequest(err))
+		return req, false
+	}
+
+	if _, err := h.org_service.GetOrganizationByID(r.Context(), orgID); err != nil {
+		api_errors.WriteError(w, r, err)
+		return req, false
+	}
+
+	return exportRequest{orgID: orgID, format: format, filter: f}, true
+}
+
+func setExportHeaders(w http.ResponseWriter, req exportRequest, dataset string) {
+	w.Header().Set("Content-Type", req.format.ContentType())
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="organization-%d-%s.%s"`, req.orgID, dataset, req.format))
+}
+
+// finishExport completes the response. An error before the first record is reported as usual;
+// once records have been sent the status can no longer change, so the connection is aborted
+// to keep the client from mistaking a truncated export for a complete one.
+func finishExport[T any](w http.ResponseWriter, r *http.Request, writer *export.Writer[T], err error) {
+	if err == nil {
+		err = writer.Close()
+		if err == nil {
+			return
+		}
+	}
+
+	if writer.Count() == 0 {
+		w.Header().Del("Content-Disposition")
+		api_errors.WriteError(w, r, err)
+		return
+	}
+
+	shared.Logger.Errorf("Export %s aborted after %d records: %v", r.URL.Path, writer.Count(), err)
+	panic(http.ErrAbortHandler)
+}
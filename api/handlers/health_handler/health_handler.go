@@ -0,0 +1,32 @@
+package health_handler
+
+import (
+	"avitoTest/shared/health"
+	"encoding/json"
+	"net/http"
+)
+
+// LiveHandler reports that the process is up and serving requests. It checks none of
+// the dependencies, so a database outage does not get the service restarted.
+func LiveHandler(w http.ResponseWriter, r *http.Request) {
+	writeReport(w, http.StatusOK, health.Report{Status: health.StatusUp, Components: map[string]health.Component{}})
+}
+
+// ReadyHandler reports whether the service can serve traffic, with the outcome of the
+// check of each component it depends on. It answers 503 when any of them is down.
+func ReadyHandler(w http.ResponseWriter, r *http.Request) {
+	report := health.Run(r.Context())
+
+	status := http.StatusOK
+	if report.Status != health.StatusUp {
+		status = http.StatusServiceUnavailable
+	}
+	writeReport(w, status, report)
+}
+
+func writeReport(w http.ResponseWriter, status int, report health.Report) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(report)
+}
@@ -0,0 +1,11 @@
+package metrics_handler
+
+import (
+	"avitoTest/shared/metrics"
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// MetricsHandler exposes the metrics of the service in the Prometheus text format.
+var MetricsHandler http.Handler = promhttp.HandlerFor(metrics.Registry, promhttp.HandlerOpts{})
@@ -0,0 +1,84 @@
+package notification_handler
+
+import (
+	"avitoTest/api/handlers/notification_handler/notification_handler_models"
+	"avitoTest/services/notification_service"
+	"avitoTest/services/notification_service/notification_models"
+	"avitoTest/shared/errors/api_errors"
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+)
+
+type NotificationHandler struct {
+	service notification_service.NotificationService
+}
+
+func NewNotificationHandler(service notification_service.NotificationService) *NotificationHandler {
+	return &NotificationHandler{service: service}
+}
+
+// GetPreferences handles fetching the notification preferences of a user
+func (h *NotificationHandler) GetPreferences(w http.ResponseWriter, r *http.Request) {
+	userID, err := strconv.Atoi(mux.Vars(r)["user_id"])
+	if err != nil {
+		api_errors.WriteProblem(w, r, api_errors.ErrBadRequest("Invalid user ID"))
+		return
+	}
+
+	prefs, err := h.service.GetPreferences(r.Context(), userID)
+	if err != nil {
+		api_errors.WriteError(w, r, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, toPreferencesResponse(prefs))
+}
+
+// UpdatePreferences handles replacing the email and the events a user is notified about
+func (h *NotificationHandler) UpdatePreferences(w http.ResponseWriter, r *http.Request) {
+	userID, err := strconv.Atoi(mux.Vars(r)["user_id"])
+	if err != nil {
+		api_errors.WriteProblem(w, r, api_errors.ErrBadRequest("Invalid user ID"))
+		return
+	}
+
+	var req notification_handler_models.UpdateNotificationPreferencesRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		api_errors.WriteProblem(w, r, api_errors.ErrInvalidRequest(err))
+		return
+	}
+
+	prefs, err := h.service.UpdatePreferences(r.Context(), notification_models.NotificationPreferencesUpdateModel{
+		UserID:     userID,
+		Email:      req.Email,
+		EventTypes: req.EventTypes,
+	})
+	if err != nil {
+		api_errors.WriteError(w, r, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, toPreferencesResponse(prefs))
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
+
+func toPreferencesResponse(prefs *notification_models.NotificationPreferencesModel) notification_handler_models.NotificationPreferencesResponse {
+	eventTypes := prefs.EventTypes
+	if eventTypes == nil {
+		eventTypes = []string{}
+	}
+	return notification_handler_models.NotificationPreferencesResponse{
+		UserID:     prefs.UserID,
+		Email:      prefs.Email,
+		EventTypes: eventTypes,
+		UpdatedAt:  prefs.UpdatedAt,
+	}
+}
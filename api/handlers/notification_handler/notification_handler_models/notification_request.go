@@ -0,0 +1,7 @@
+package notification_handler_models
+
+// UpdateNotificationPreferencesRequest - API model for replacing the notification preferences of a user.
+type UpdateNotificationPreferencesRequest struct {
+	Email      string   `json:"email"`
+	EventTypes []string `json:"event_types"`
+}
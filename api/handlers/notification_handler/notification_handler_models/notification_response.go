@@ -0,0 +1,11 @@
+package notification_handler_models
+
+import "time"
+
+// NotificationPreferencesResponse - API model for the notification preferences of a user.
+type NotificationPreferencesResponse struct {
+	UserID     int        `json:"user_id"`
+	Email      string     `json:"email"`
+	EventTypes []string   `json:"event_types"`
+	UpdatedAt  *time.Time `json:"updated_at,omitempty"`
+}
@@ -7,23 +7,24 @@ import (
 	"avitoTest/services/organization_service/organization_models"
 	"avitoTest/shared"
 	"avitoTest/shared/errors/api_errors"
+	"avitoTest/shared/pagination"
+	"avitoTest/shared/validation"
 	"net/http"
 	"strconv"
 
 	"github.com/go-chi/render"
-	"github.com/go-playground/validator/v10"
 	"github.com/gorilla/mux"
 )
 
 type OrganizationHandler struct {
 	service  organization_service.OrganizationService
-	validate *validator.Validate
+	validate *validation.Validator
 }
 
 func NewOrganizationHandler(service organization_service.OrganizationService) *OrganizationHandler {
 	return &OrganizationHandler{
 		service:  service,
-		validate: validator.New(),
+		validate: validation.New(nil),
 	}
 }
 
@@ -32,37 +33,39 @@ func (h *OrganizationHandler) CreateOrganization(w http.ResponseWriter, r *http.
 	var req organization_handler_models.CreateOrganizationRequest
 	if err := render.DecodeJSON(r.Body, &req); err != nil {
 		shared.Logger.Errorf("CreateOrganization: Failed to decode request: %v", err)
-		render.Render(w, r, api_errors.ErrInvalidRequest(err))
+		api_errors.WriteProblem(w, r, api_errors.ErrInvalidRequest(err))
 		return
 	}
 
-	if err := h.validate.Struct(req); err != nil {
+	if err := h.validate.Struct(r.Context(), req); err != nil {
 		shared.Logger.Errorf("CreateOrganization: Validation failed: %v", err)
-		render.Render(w, r, api_errors.ErrInvalidRequest(err))
+		api_errors.WriteError(w, r, err)
 		return
 	}
 
 	shared.Logger.Infof("CreateOrganization: Request - Name: %s, Description: %s, Type: %s", req.Name, req.Description, req.Type)
 
 	org, err := h.service.CreateOrganization(r.Context(), organization_models.OrganizationCreateModel{
-		Name:        req.Name,
-		Description: req.Description,
-		Type:        req.Type,
+		Name:         req.Name,
+		Description:  req.Description,
+		Type:         req.Type,
+		ServiceTypes: req.ServiceTypes,
 	})
 	if err != nil {
 		shared.Logger.Errorf("CreateOrganization: Failed to create organization: %v", err)
-		render.Render(w, r, api_errors.ErrInternal(err))
+		api_errors.WriteError(w, r, err)
 		return
 	}
 
 	shared.Logger.Infof("CreateOrganization: Organization created successfully: ID=%d", org.ID)
 	render.JSON(w, r, organization_handler_models.OrganizationResponse{
-		ID:          org.ID,
-		Name:        org.Name,
-		Description: org.Description,
-		Type:        org.Type,
-		CreatedAt:   org.CreatedAt,
-		UpdatedAt:   org.UpdatedAt,
+		ID:           org.ID,
+		Name:         org.Name,
+		Description:  org.Description,
+		Type:         org.Type,
+		ServiceTypes: org.ServiceTypes,
+		CreatedAt:    org.CreatedAt,
+		UpdatedAt:    org.UpdatedAt,
 	})
 }
 
@@ -74,72 +77,80 @@ func (h *OrganizationHandler) UpdateOrganization(w http.ResponseWriter, r *http.
 	id, err := strconv.Atoi(idParam)
 	if err != nil {
 		shared.Logger.Errorf("UpdateOrganization: Invalid organization ID: %v", err)
-		render.Render(w, r, api_errors.ErrInvalidRequest(err))
+		api_errors.WriteProblem(w, r, api_errors.ErrInvalidRequest(err))
 		return
 	}
 
 	var req organization_handler_models.UpdateOrganizationRequest
 	if err := render.DecodeJSON(r.Body, &req); err != nil {
 		shared.Logger.Errorf("UpdateOrganization: Failed to decode request body: %v", err)
-		render.Render(w, r, api_errors.ErrInvalidRequest(err))
+		api_errors.WriteProblem(w, r, api_errors.ErrInvalidRequest(err))
 		return
 	}
 
 	shared.Logger.Infof("UpdateOrganization: Request - Name: %s, Description: %s, Type: %s", req.Name, req.Description, req.Type)
 
-	if err := h.validate.Struct(req); err != nil {
+	if err := h.validate.Struct(r.Context(), req); err != nil {
 		shared.Logger.Errorf("UpdateOrganization: Validation failed: %v", err)
-		render.Render(w, r, api_errors.ErrInvalidRequest(err))
+		api_errors.WriteError(w, r, err)
 		return
 	}
 
 	org, err := h.service.UpdateOrganization(r.Context(), organization_models.OrganizationUpdateModel{
-		ID:          id,
-		Name:        req.Name,
-		Description: req.Description,
-		Type:        req.Type,
+		ID:           id,
+		Name:         req.Name,
+		Description:  req.Description,
+		Type:         req.Type,
+		ServiceTypes: req.ServiceTypes,
 	})
 	if err != nil {
 		shared.Logger.Errorf("UpdateOrganization: Failed to update organization: %v", err)
-		render.Render(w, r, api_errors.ErrInternal(err))
+		api_errors.WriteError(w, r, err)
 		return
 	}
 
 	shared.Logger.Infof("UpdateOrganization: Organization updated successfully: ID=%d", org.ID)
 	render.JSON(w, r, organization_handler_models.OrganizationResponse{
-		ID:          org.ID,
-		Name:        org.Name,
-		Description: org.Description,
-		Type:        org.Type,
-		CreatedAt:   org.CreatedAt,
-		UpdatedAt:   org.UpdatedAt,
+		ID:           org.ID,
+		Name:         org.Name,
+		Description:  org.Description,
+		Type:         org.Type,
+		ServiceTypes: org.ServiceTypes,
+		CreatedAt:    org.CreatedAt,
+		UpdatedAt:    org.UpdatedAt,
 	})
 }
 
 func (h *OrganizationHandler) GetOrganizations(w http.ResponseWriter, r *http.Request) {
 	shared.Logger.Infof("GetOrganizations: Handling request to fetch all organizations")
 
-	organizations, err := h.service.GetOrganizations(r.Context())
+	params, err := pagination.FromRequest(r, pagination.SortCreatedAt, pagination.SortName)
 	if err != nil {
-		shared.Logger.Errorf("GetOrganizations: Failed to fetch organizations: %v", err)
-		render.Render(w, r, api_errors.ErrInternal(err))
+		shared.Logger.Errorf("GetOrganizations: Invalid pagination parameters: %v", err)
+		api_errors.WriteProblem(w, r, api_errors.ErrInvalidRequest(err))
 		return
 	}
 
-	var response []organization_handler_models.OrganizationResponse
-	for _, org := range organizations {
-		shared.Logger.Infof("GetOrganizations: Found organization - ID=%d, Name=%s", org.ID, org.Name)
-		response = append(response, organization_handler_models.OrganizationResponse{
-			ID:          org.ID,
-			Name:        org.Name,
-			Description: org.Description,
-			Type:        org.Type,
-			CreatedAt:   org.CreatedAt,
-			UpdatedAt:   org.UpdatedAt,
-		})
+	organizations, err := h.service.GetOrganizations(r.Context(), params)
+	if err != nil {
+		shared.Logger.Errorf("GetOrganizations: Failed to fetch organizations: %v", err)
+		api_errors.WriteError(w, r, err)
+		return
 	}
 
-	shared.Logger.Infof("GetOrganizations: Successfully fetched %d organizations", len(response))
+	response := pagination.MapPage(organizations, func(org *organization_models.OrganizationModel) organization_handler_models.OrganizationResponse {
+		return organization_handler_models.OrganizationResponse{
+			ID:           org.ID,
+			Name:         org.Name,
+			Description:  org.Description,
+			Type:         org.Type,
+			ServiceTypes: org.ServiceTypes,
+			CreatedAt:    org.CreatedAt,
+			UpdatedAt:    org.UpdatedAt,
+		}
+	})
+
+	shared.Logger.Infof("GetOrganizations: Successfully fetched %d of %d organizations", len(response.Items), response.Total)
 	render.JSON(w, r, response)
 }
 
@@ -154,7 +165,7 @@ func (h *OrganizationHandler) GetOrganizationByID(w http.ResponseWriter, r *http
 	id, err := strconv.Atoi(idParam)
 	if err != nil {
 		shared.Logger.Errorf("GetOrganizationByID: Invalid organization ID: %v", err)
-		render.Render(w, r, api_errors.ErrInvalidRequest(err))
+		api_errors.WriteProblem(w, r, api_errors.ErrInvalidRequest(err))
 		return
 	}
 
@@ -163,7 +174,7 @@ func (h *OrganizationHandler) GetOrganizationByID(w http.ResponseWriter, r *http
 	org, err := h.service.GetOrganizationByID(r.Context(), id)
 	if err != nil {
 		shared.Logger.Errorf("GetOrganizationByID: Failed to get organization by ID: %v", err)
-		render.Render(w, r, api_errors.ErrInternal(err))
+		api_errors.WriteError(w, r, err)
 		return
 	}
 
@@ -172,12 +183,13 @@ func (h *OrganizationHandler) GetOrganizationByID(w http.ResponseWriter, r *http
 
 	// Respond with the organization details
 	render.JSON(w, r, organization_handler_models.OrganizationResponse{
-		ID:          org.ID,
-		Name:        org.Name,
-		Description: org.Description,
-		Type:        org.Type,
-		CreatedAt:   org.CreatedAt,
-		UpdatedAt:   org.UpdatedAt,
+		ID:           org.ID,
+		Name:         org.Name,
+		Description:  org.Description,
+		Type:         org.Type,
+		ServiceTypes: org.ServiceTypes,
+		CreatedAt:    org.CreatedAt,
+		UpdatedAt:    org.UpdatedAt,
 	})
 }
 
@@ -192,7 +204,7 @@ func (h *OrganizationHandler) DeleteOrganization(w http.ResponseWriter, r *http.
 	id, err := strconv.Atoi(idParam)
 	if err != nil {
 		shared.Logger.Errorf("DeleteOrganization: Invalid organization ID: %v", err)
-		render.Render(w, r, api_errors.ErrInvalidRequest(err))
+		api_errors.WriteProblem(w, r, api_errors.ErrInvalidRequest(err))
 		return
 	}
 
@@ -201,7 +213,7 @@ func (h *OrganizationHandler) DeleteOrganization(w http.ResponseWriter, r *http.
 	err = h.service.DeleteOrganization(r.Context(), id)
 	if err != nil {
 		shared.Logger.Errorf("DeleteOrganization: Failed to delete organization: %v", err)
-		render.Render(w, r, api_errors.ErrInternal(err))
+		api_errors.WriteError(w, r, err)
 		return
 	}
 
@@ -220,20 +232,20 @@ func (h *OrganizationHandler) AddResponsible(w http.ResponseWriter, r *http.Requ
 	orgID, err := strconv.Atoi(orgIDParam)
 	if err != nil {
 		shared.Logger.Errorf("AddResponsible: Invalid organization ID: %v", err)
-		render.Render(w, r, api_errors.ErrInvalidRequest(err))
+		api_errors.WriteProblem(w, r, api_errors.ErrInvalidRequest(err))
 		return
 	}
 
 	userID, err := strconv.Atoi(userIDParam)
 	if err != nil {
 		shared.Logger.Errorf("AddResponsible: Invalid user ID: %v", err)
-		render.Render(w, r, api_errors.ErrInvalidRequest(err))
+		api_errors.WriteProblem(w, r, api_errors.ErrInvalidRequest(err))
 		return
 	}
 
 	if err := h.service.AddResponsible(r.Context(), orgID, userID); err != nil {
 		shared.Logger.Errorf("AddResponsible: Failed to add responsible user: %v", err)
-		render.Render(w, r, api_errors.ErrInternal(err))
+		api_errors.WriteError(w, r, err)
 		return
 	}
 
@@ -249,20 +261,20 @@ func (h *OrganizationHandler) DeleteResponsible(w http.ResponseWriter, r *http.R
 	orgID, err := strconv.Atoi(orgIDParam)
 	if err != nil {
 		shared.Logger.Errorf("DeleteResponsible: Invalid organization ID: %v", err)
-		render.Render(w, r, api_errors.ErrInvalidRequest(err))
+		api_errors.WriteProblem(w, r, api_errors.ErrInvalidRequest(err))
 		return
 	}
 
 	userID, err := strconv.Atoi(userIDParam)
 	if err != nil {
 		shared.Logger.Errorf("DeleteResponsible: Invalid user ID: %v", err)
-		render.Render(w, r, api_errors.ErrInvalidRequest(err))
+		api_errors.WriteProblem(w, r, api_errors.ErrInvalidRequest(err))
 		return
 	}
 
 	if err := h.service.DeleteResponsible(r.Context(), orgID, userID); err != nil {
 		shared.Logger.Errorf("DeleteResponsible: Failed to delete responsible user: %v", err)
-		render.Render(w, r, api_errors.ErrInternal(err))
+		api_errors.WriteError(w, r, err)
 		return
 	}
 
@@ -277,14 +289,14 @@ func (h *OrganizationHandler) GetResponsibles(w http.ResponseWriter, r *http.Req
 	orgID, err := strconv.Atoi(orgIDParam)
 	if err != nil {
 		shared.Logger.Errorf("GetResponsibles: Invalid organization ID: %v", err)
-		render.Render(w, r, api_errors.ErrInvalidRequest(err))
+		api_errors.WriteProblem(w, r, api_errors.ErrInvalidRequest(err))
 		return
 	}
 
 	users, err := h.service.GetResponsibles(r.Context(), orgID)
 	if err != nil {
 		shared.Logger.Errorf("GetResponsibles: Failed to get responsible users: %v", err)
-		render.Render(w, r, api_errors.ErrInternal(err))
+		api_errors.WriteError(w, r, err)
 		return
 	}
 
@@ -310,21 +322,21 @@ func (h *OrganizationHandler) GetResponsibleByID(w http.ResponseWriter, r *http.
 	orgID, err := strconv.Atoi(orgIDParam)
 	if err != nil {
 		shared.Logger.Errorf("GetResponsibleByID: Invalid organization ID: %v", err)
-		render.Render(w, r, api_errors.ErrInvalidRequest(err))
+		api_errors.WriteProblem(w, r, api_errors.ErrInvalidRequest(err))
 		return
 	}
 
 	userID, err := strconv.Atoi(userIDParam)
 	if err != nil {
 		shared.Logger.Errorf("GetResponsibleByID: Invalid user ID: %v", err)
-		render.Render(w, r, api_errors.ErrInvalidRequest(err))
+		api_errors.WriteProblem(w, r, api_errors.ErrInvalidRequest(err))
 		return
 	}
 
 	user, err := h.service.GetResponsibleByID(r.Context(), orgID, userID)
 	if err != nil {
 		shared.Logger.Errorf("GetResponsibleByID: Failed to get responsible user: %v", err)
-		render.Render(w, r, api_errors.ErrInternal(err))
+		api_errors.WriteError(w, r, err)
 		return
 	}
 
@@ -0,0 +1,115 @@
+package search_handler
+
+import (
+	"avitoTest/api/handlers/search_handler/search_handler_models"
+	"avitoTest/services/search_service"
+	"avitoTest/services/search_service/search_models"
+	"avitoTest/shared/errors/api_errors"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+)
+
+type SearchHandler struct {
+	service search_service.SearchService
+}
+
+func NewSearchHandler(service search_service.SearchService) *SearchHandler {
+	return &SearchHandler{service: service}
+}
+
+// SearchTenders handles full-text search over tenders
+func (h *SearchHandler) SearchTenders(w http.ResponseWriter, r *http.Request) {
+	query, err := parseSearchQuery(r)
+	if err != nil {
+		api_errors.WriteProblem(w, r, api_errors.ErrInvalidRequest(err))
+		return
+	}
+
+	results, err := h.service.SearchTenders(r.Context(), query)
+	if err != nil {
+		api_errors.WriteError(w, r, err)
+		return
+	}
+
+	resp := make([]search_handler_models.TenderSearchResponse, 0, len(results))
+	for _, result := range results {
+		resp = append(resp, search_handler_models.TenderSearchResponse{
+			TenderID:       result.TenderID,
+			OrganizationID: result.OrganizationID,
+			Name:           result.Name,
+			Description:    result.Description,
+			ServiceType:    result.ServiceType,
+			Status:         result.Status,
+			Version:        result.Version,
+			Rank:           result.Rank,
+			Snippet:        result.Snippet,
+		})
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(resp)
+}
+
+// SearchBids handles full-text search over the bids of an organization
+func (h *SearchHandler) SearchBids(w http.ResponseWriter, r *http.Request) {
+	query, err := parseSearchQuery(r)
+	if err != nil {
+		api_errors.WriteProblem(w, r, api_errors.ErrInvalidRequest(err))
+		return
+	}
+
+	results, err := h.service.SearchBids(r.Context(), query)
+	if err != nil {
+		api_errors.WriteError(w, r, err)
+		return
+	}
+
+	resp := make([]search_handler_models.BidSearchResponse, 0, len(results))
+	for _, result := range results {
+		resp = append(resp, search_handler_models.BidSearchResponse{
+			BidID:          result.BidID,
+			TenderID:       result.TenderID,
+			OrganizationID: result.OrganizationID,
+			Name:           result.Name,
+			Description:    result.Description,
+			Status:         result.Status,
+			Version:        result.Version,
+			Rank:           result.Rank,
+			Snippet:        result.Snippet,
+		})
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(resp)
+}
+
+// parseSearchQuery reads the search parameters from the query string.
+func parseSearchQuery(r *http.Request) (search_models.SearchQueryModel, error) {
+	values := r.URL.Query()
+	query := search_models.SearchQueryModel{
+		Query:    values.Get("q"),
+		Language: values.Get("lang"),
+	}
+
+	if orgIDStr := values.Get("organizationId"); orgIDStr != "" {
+		orgID, err := strconv.Atoi(orgIDStr)
+		if err != nil {
+			return query, errors.New("invalid organization ID")
+		}
+		query.OrganizationID = orgID
+	}
+
+	if limitStr := values.Get("limit"); limitStr != "" {
+		limit, err := strconv.Atoi(limitStr)
+		if err != nil {
+			return query, errors.New("invalid limit")
+		}
+		query.Limit = limit
+	}
+
+	return query, nil
+}
@@ -0,0 +1,25 @@
+package search_handler_models
+
+type TenderSearchResponse struct {
+	TenderID       int     `json:"tender_id"`
+	OrganizationID int     `json:"organization_id"`
+	Name           string  `json:"name"`
+	Description    string  `json:"description"`
+	ServiceType    string  `json:"service_type"`
+	Status         string  `json:"status"`
+	Version        int     `json:"version"`
+	Rank           float64 `json:"rank"`
+	Snippet        string  `json:"snippet"`
+}
+
+type BidSearchResponse struct {
+	BidID          int     `json:"bid_id"`
+	TenderID       int     `json:"tender_id"`
+	OrganizationID int     `json:"organization_id"`
+	Name           string  `json:"name"`
+	Description    string  `json:"description"`
+	Status         string  `json:"status"`
+	Version        int     `json:"version"`
+	Rank           float64 `json:"rank"`
+	Snippet        string  `json:"snippet"`
+}
@@ -6,11 +6,14 @@ import (
 	"avitoTest/services/tender_service/tender_models"
 	"avitoTest/services/user_service"
 	"avitoTest/shared/constants"
-	"avitoTest/shared/errors/tendert_erorrs"
+	"avitoTest/shared/errors/api_errors"
+	"avitoTest/shared/filter"
+	"avitoTest/shared/pagination"
 	"encoding/json"
 	"errors"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/gorilla/mux"
 )
@@ -31,14 +34,14 @@ func NewTenderHandler(tender_service tender_service.TenderService, user_service
 func (h *TenderHandler) CreateTender(w http.ResponseWriter, r *http.Request) {
 	var req tender_handler_models.CreateTenderRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		api_errors.WriteProblem(w, r, api_errors.ErrInvalidRequest(err))
 		return
 	}
 
 	// Get CreatorID based on CreatorUsername
 	user, err := h.user_service.GetUserByUsername(r.Context(), req.CreatorUsername)
 	if err != nil {
-		http.Error(w, "Invalid creator username", http.StatusBadRequest)
+		api_errors.WriteProblem(w, r, api_errors.ErrBadRequest("Invalid creator username"))
 		return
 	}
 
@@ -47,15 +50,22 @@ func (h *TenderHandler) CreateTender(w http.ResponseWriter, r *http.Request) {
 		Name:           req.Name,
 		Description:    req.Description,
 		ServiceType:    req.ServiceType,
+		Budget:         req.Budget,
 		Status:         constants.TenderStatus(req.Status),
 		OrganizationID: req.OrganizationID,
 		CreatorID:      user.ID,
 	}
+	for _, rule := range req.EligibilityRules {
+		tenderCreateModel.EligibilityRules = append(tenderCreateModel.EligibilityRules, tender_models.EligibilityRuleModel{
+			RuleType: constants.EligibilityRuleType(rule.RuleType),
+			Value:    rule.Value,
+		})
+	}
 
 	// Create tender via service
 	tender, err := h.tender_service.CreateTender(r.Context(), tenderCreateModel)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		api_errors.WriteError(w, r, err)
 		return
 	}
 
@@ -65,6 +75,7 @@ func (h *TenderHandler) CreateTender(w http.ResponseWriter, r *http.Request) {
 		Name:           tender.Name,
 		Description:    tender.Description,
 		ServiceType:    tender.ServiceType, // Correctly set ServiceType
+		Budget:         tender.Budget,
 		Status:         string(tender.Status),
 		OrganizationID: tender.OrganizationID,
 		CreatedAt:      tender.CreatedAt,
@@ -76,36 +87,86 @@ func (h *TenderHandler) CreateTender(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(resp)
 }
 
-// GetTenders handles fetching all tenders with optional filtering by service type
-func (h *TenderHandler) GetTenders(w http.ResponseWriter, r *http.Request) {
-	// Getting the filter value from the query parameters
-	serviceTypeFilter := r.URL.Query().Get("serviceType")
+// maxImportSize is the largest import file accepted by ImportTenders.
+const maxImportSize = 10 << 20
 
-	// Calling a service with a filter
-	tenders, err := h.tender_service.GetAllTenders(r.Context(), serviceTypeFilter)
+// ImportTenders handles the bulk import of tenders from a CSV or JSON body
+func (h *TenderHandler) ImportTenders(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	format := query.Get("format")
+	if format == "" {
+		format = importFormatFromContentType(r.Header.Get("Content-Type"))
+	}
+
+	options := tender_models.TenderImportOptions{Mode: query.Get("mode")}
+	if value := query.Get("dryRun"); value != "" {
+		dryRun, err := strconv.ParseBool(value)
+		if err != nil {
+			api_errors.WriteProblem(w, r, api_errors.ErrBadRequest("Invalid dryRun value"))
+			return
+		}
+		options.DryRun = dryRun
+	}
+
+	rows, err := tender_service.ParseTenderImport(http.MaxBytesReader(w, r.Body, maxImportSize), format)
 	if err != nil {
-		if err.Error() == "invalid service type" {
-			http.Error(w, "Invalid service type provided", http.StatusBadRequest)
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			api_errors.WriteProblem(w, r, api_errors.NewProblem(http.StatusRequestEntityTooLarge, errors.New("import file is too large")))
 			return
 		}
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		api_errors.WriteProblem(w, r, api_errors.ErrInvalidRequest(err))
 		return
 	}
 
-	var resp []tender_handler_models.TenderResponse
-	for _, tender := range tenders {
-		resp = append(resp, tender_handler_models.TenderResponse{
-			ID:             tender.ID,
-			Name:           tender.Name,
-			Description:    tender.Description,
-			ServiceType:    tender.ServiceType,
-			Status:         string(tender.Status),
-			OrganizationID: tender.OrganizationID,
-			CreatedAt:      tender.CreatedAt,
-			Version:        tender.Version,
-		})
+	report, err := h.tender_service.ImportTenders(r.Context(), rows, options)
+	if err != nil {
+		api_errors.WriteError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(report)
+}
+
+// importFormatFromContentType picks the import format of a body sent without the format parameter
+func importFormatFromContentType(contentType string) string {
+	if strings.HasPrefix(contentType, "application/json") {
+		return tender_models.ImportFormatJSON
+	}
+	return tender_models.ImportFormatCSV
+}
+
+// GetTenders handles fetching all tenders with optional filtering
+func (h *TenderHandler) GetTenders(w http.ResponseWriter, r *http.Request) {
+	params, err := pagination.FromRequest(r, pagination.SortCreatedAt, pagination.SortName, pagination.SortStatus)
+	if err != nil {
+		api_errors.WriteProblem(w, r, api_errors.ErrInvalidRequest(err))
+		return
+	}
+
+	f, err := filter.Parse(r.URL.Query(), tender_models.TenderFilterSchema)
+	if err != nil {
+		api_errors.WriteProblem(w, r, api_errors.ErrInvalidRequest(err))
+		return
+	}
+
+	// serviceType is the former name of the category filter
+	if serviceType := r.URL.Query().Get("serviceType"); serviceType != "" {
+		f = append(f, filter.Condition{Field: constants.FilterFieldCategory, Operator: filter.OpEq, Values: []any{serviceType}})
+	}
+
+	// Calling a service with a filter
+	tenders, err := h.tender_service.GetAllTenders(r.Context(), f, params)
+	if err != nil {
+		api_errors.WriteError(w, r, err)
+		return
 	}
 
+	resp := pagination.MapPage(tenders, toTenderResponse)
+
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
 	json.NewEncoder(w).Encode(resp)
@@ -116,13 +177,13 @@ func (h *TenderHandler) GetTenderByID(w http.ResponseWriter, r *http.Request) {
 	idStr := mux.Vars(r)["tenderId"]
 	id, err := strconv.Atoi(idStr)
 	if err != nil {
-		http.Error(w, "Invalid tender ID", http.StatusBadRequest)
+		api_errors.WriteProblem(w, r, api_errors.ErrBadRequest("Invalid tender ID"))
 		return
 	}
 
 	tender, err := h.tender_service.GetTenderByID(r.Context(), id)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusNotFound)
+		api_errors.WriteError(w, r, err)
 		return
 	}
 
@@ -131,6 +192,7 @@ func (h *TenderHandler) GetTenderByID(w http.ResponseWriter, r *http.Request) {
 		Name:           tender.Name,
 		Description:    tender.Description,
 		ServiceType:    tender.ServiceType,
+		Budget:         tender.Budget,
 		Status:         string(tender.Status),
 		OrganizationID: tender.OrganizationID,
 		CreatedAt:      tender.CreatedAt,
@@ -146,30 +208,20 @@ func (h *TenderHandler) GetTenderByID(w http.ResponseWriter, r *http.Request) {
 func (h *TenderHandler) GetTendersByUsername(w http.ResponseWriter, r *http.Request) {
 	username := mux.Vars(r)["username"]
 
-	tenders, err := h.tender_service.GetTendersByUsername(r.Context(), username)
+	params, err := pagination.FromRequest(r, pagination.SortCreatedAt, pagination.SortName, pagination.SortStatus)
 	if err != nil {
-		if err.Error() == "user not found" {
-			http.Error(w, "User not found", http.StatusNotFound)
-			return
-		}
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		api_errors.WriteProblem(w, r, api_errors.ErrInvalidRequest(err))
 		return
 	}
 
-	var resp []tender_handler_models.TenderResponse
-	for _, tender := range tenders {
-		resp = append(resp, tender_handler_models.TenderResponse{
-			ID:             tender.ID,
-			Name:           tender.Name,
-			Description:    tender.Description,
-			ServiceType:    tender.ServiceType,
-			Status:         string(tender.Status),
-			OrganizationID: tender.OrganizationID,
-			CreatedAt:      tender.CreatedAt,
-			Version:        tender.Version,
-		})
+	tenders, err := h.tender_service.GetTendersByUsername(r.Context(), username, params)
+	if err != nil {
+		api_errors.WriteError(w, r, err)
+		return
 	}
 
+	resp := pagination.MapPage(tenders, toTenderResponse)
+
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
 	json.NewEncoder(w).Encode(resp)
@@ -180,13 +232,13 @@ func (h *TenderHandler) UpdateTender(w http.ResponseWriter, r *http.Request) {
 	idStr := mux.Vars(r)["tenderId"]
 	id, err := strconv.Atoi(idStr)
 	if err != nil {
-		http.Error(w, "Invalid tender ID", http.StatusBadRequest)
+		api_errors.WriteProblem(w, r, api_errors.ErrBadRequest("Invalid tender ID"))
 		return
 	}
 
 	var req tender_handler_models.UpdateTenderRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		api_errors.WriteProblem(w, r, api_errors.ErrInvalidRequest(err))
 		return
 	}
 
@@ -194,11 +246,12 @@ func (h *TenderHandler) UpdateTender(w http.ResponseWriter, r *http.Request) {
 		ID:          id,
 		Name:        req.Name,
 		Description: req.Description,
+		Budget:      req.Budget,
 	}
 
 	tender, err := h.tender_service.UpdateTender(r.Context(), tenderUpdateModel)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		api_errors.WriteError(w, r, err)
 		return
 	}
 
@@ -207,6 +260,7 @@ func (h *TenderHandler) UpdateTender(w http.ResponseWriter, r *http.Request) {
 		Name:           tender.Name,
 		Description:    tender.Description,
 		ServiceType:    tender.ServiceType,
+		Budget:         tender.Budget,
 		Status:         string(tender.Status),
 		OrganizationID: tender.OrganizationID,
 		CreatedAt:      tender.CreatedAt,
@@ -223,12 +277,12 @@ func (h *TenderHandler) PublishTender(w http.ResponseWriter, r *http.Request) {
 	idStr := mux.Vars(r)["tenderId"]
 	id, err := strconv.Atoi(idStr)
 	if err != nil {
-		http.Error(w, "Invalid tender ID", http.StatusBadRequest)
+		api_errors.WriteProblem(w, r, api_errors.ErrBadRequest("Invalid tender ID"))
 		return
 	}
 
 	if err := h.tender_service.PublishTender(r.Context(), id); err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		api_errors.WriteError(w, r, err)
 		return
 	}
 
@@ -240,12 +294,12 @@ func (h *TenderHandler) CloseTender(w http.ResponseWriter, r *http.Request) {
 	idStr := mux.Vars(r)["tenderId"]
 	id, err := strconv.Atoi(idStr)
 	if err != nil {
-		http.Error(w, "Invalid tender ID", http.StatusBadRequest)
+		api_errors.WriteProblem(w, r, api_errors.ErrBadRequest("Invalid tender ID"))
 		return
 	}
 
 	if err := h.tender_service.CloseTender(r.Context(), id); err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		api_errors.WriteError(w, r, err)
 		return
 	}
 
@@ -257,20 +311,20 @@ func (h *TenderHandler) RollbackTenderVersion(w http.ResponseWriter, r *http.Req
 	tenderIDStr := mux.Vars(r)["tenderId"]
 	tenderID, err := strconv.Atoi(tenderIDStr)
 	if err != nil {
-		http.Error(w, "Invalid tender ID", http.StatusBadRequest)
+		api_errors.WriteProblem(w, r, api_errors.ErrBadRequest("Invalid tender ID"))
 		return
 	}
 
 	versionStr := mux.Vars(r)["version"]
 	version, err := strconv.Atoi(versionStr)
 	if err != nil {
-		http.Error(w, "Invalid version number", http.StatusBadRequest)
+		api_errors.WriteProblem(w, r, api_errors.ErrBadRequest("Invalid version number"))
 		return
 	}
 
 	tender, err := h.tender_service.RollbackTenderVersion(r.Context(), tenderID, version)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		api_errors.WriteError(w, r, err)
 		return
 	}
 
@@ -279,6 +333,7 @@ func (h *TenderHandler) RollbackTenderVersion(w http.ResponseWriter, r *http.Req
 		Name:           tender.Name,
 		Description:    tender.Description,
 		ServiceType:    tender.ServiceType,
+		Budget:         tender.Budget,
 		Status:         string(tender.Status),
 		OrganizationID: tender.OrganizationID,
 		CreatedAt:      tender.CreatedAt,
@@ -295,18 +350,94 @@ func (h *TenderHandler) DeleteTender(w http.ResponseWriter, r *http.Request) {
 	idStr := mux.Vars(r)["tenderId"]
 	id, err := strconv.Atoi(idStr)
 	if err != nil {
-		http.Error(w, "Invalid tender ID", http.StatusBadRequest)
+		api_errors.WriteProblem(w, r, api_errors.ErrBadRequest("Invalid tender ID"))
 		return
 	}
 
 	if err := h.tender_service.DeleteTender(r.Context(), id); err != nil {
-		if errors.Is(err, tendert_erorrs.ErrTenderNotFound) {
-			http.Error(w, "Tender not found", http.StatusNotFound)
-			return
-		}
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		api_errors.WriteError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// SetEligibilityRules handles replacing the eligibility rules of a tender
+func (h *TenderHandler) SetEligibilityRules(w http.ResponseWriter, r *http.Request) {
+	idStr := mux.Vars(r)["tenderId"]
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		api_errors.WriteProblem(w, r, api_errors.ErrBadRequest("Invalid tender ID"))
+		return
+	}
+
+	var req []tender_handler_models.EligibilityRule
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		api_errors.WriteProblem(w, r, api_errors.ErrInvalidRequest(err))
+		return
+	}
+
+	rules := make([]tender_models.EligibilityRuleModel, 0, len(req))
+	for _, rule := range req {
+		rules = append(rules, tender_models.EligibilityRuleModel{
+			RuleType: constants.EligibilityRuleType(rule.RuleType),
+			Value:    rule.Value,
+		})
+	}
+
+	saved, err := h.tender_service.SetEligibilityRules(r.Context(), id, rules)
+	if err != nil {
+		api_errors.WriteError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(toEligibilityRuleResponses(saved))
+}
+
+// GetEligibilityRules handles fetching the eligibility rules of a tender
+func (h *TenderHandler) GetEligibilityRules(w http.ResponseWriter, r *http.Request) {
+	idStr := mux.Vars(r)["tenderId"]
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		api_errors.WriteProblem(w, r, api_errors.ErrBadRequest("Invalid tender ID"))
+		return
+	}
+
+	rules, err := h.tender_service.GetEligibilityRules(r.Context(), id)
+	if err != nil {
+		api_errors.WriteError(w, r, err)
 		return
 	}
 
+	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(toEligibilityRuleResponses(rules))
+}
+
+func toEligibilityRuleResponses(rules []*tender_models.EligibilityRuleModel) []tender_handler_models.EligibilityRule {
+	resp := make([]tender_handler_models.EligibilityRule, 0, len(rules))
+	for _, rule := range rules {
+		resp = append(resp, tender_handler_models.EligibilityRule{
+			RuleType: string(rule.RuleType),
+			Value:    rule.Value,
+		})
+	}
+	return resp
+}
+
+// toTenderResponse converts a tender model into its API representation.
+func toTenderResponse(tender *tender_models.TenderModel) tender_handler_models.TenderResponse {
+	return tender_handler_models.TenderResponse{
+		ID:             tender.ID,
+		Name:           tender.Name,
+		Description:    tender.Description,
+		ServiceType:    tender.ServiceType,
+		Budget:         tender.Budget,
+		Status:         string(tender.Status),
+		OrganizationID: tender.OrganizationID,
+		CreatedAt:      tender.CreatedAt,
+		Version:        tender.Version,
+	}
 }
@@ -2,10 +2,13 @@ package tender_handler_models
 
 // CreateTenderRequest represents the request payload for creating a tender.
 type CreateTenderRequest struct {
-	Name            string `json:"name"`
-	Description     string `json:"description"`
-	ServiceType     string `json:"service_type"`
-	Status          string `json:"status"`
-	OrganizationID  int    `json:"organization_id"`
-	CreatorUsername string `json:"creator_username"`
+	Name            string   `json:"name"`
+	Description     string   `json:"description"`
+	ServiceType     string   `json:"service_type"`
+	Budget          *float64 `json:"budget"`
+	Status          string   `json:"status"`
+	OrganizationID  int      `json:"organization_id"`
+	CreatorUsername string   `json:"creator_username"`
+
+	EligibilityRules []EligibilityRule `json:"eligibility_rules"`
 }
@@ -0,0 +1,7 @@
+package tender_handler_models
+
+// EligibilityRule represents a tender eligibility rule in requests and responses.
+type EligibilityRule struct {
+	RuleType string `json:"rule_type"`
+	Value    string `json:"value"`
+}
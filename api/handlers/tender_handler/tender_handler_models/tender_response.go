@@ -8,6 +8,7 @@ type TenderResponse struct {
 	Name           string    `json:"name"`
 	Description    string    `json:"description"`
 	ServiceType    string    `json:"service_type"`
+	Budget         *float64  `json:"budget,omitempty"`
 	Status         string    `json:"status"`
 	OrganizationID int       `json:"organization_id"`
 	CreatedAt      time.Time `json:"created_at"`
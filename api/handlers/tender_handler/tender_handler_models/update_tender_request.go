@@ -2,6 +2,7 @@ package tender_handler_models
 
 // UpdateTenderRequest represents the request payload for updating a tender.
 type UpdateTenderRequest struct {
-	Name        string `json:"name"`
-	Description string `json:"description"`
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Budget      *float64 `json:"budget"`
 }
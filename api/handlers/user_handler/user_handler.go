@@ -4,6 +4,8 @@ import (
 	"avitoTest/api/handlers/user_handler/user_handler_models"
 	"avitoTest/services/user_service"
 	"avitoTest/services/user_service/user_models"
+	"avitoTest/shared/errors/api_errors"
+	"avitoTest/shared/pagination"
 	"encoding/json"
 	"net/http"
 	"strconv"
@@ -22,7 +24,7 @@ func NewUserHandler(service user_service.UserService) *UserHandler {
 func (h *UserHandler) CreateUser(w http.ResponseWriter, r *http.Request) {
 	var req user_handler_models.CreateUserRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		api_errors.WriteProblem(w, r, api_errors.ErrInvalidRequest(err))
 		return
 	}
 
@@ -35,7 +37,7 @@ func (h *UserHandler) CreateUser(w http.ResponseWriter, r *http.Request) {
 
 	user, err := h.service.CreateUser(r.Context(), userCreateModel)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		api_errors.WriteError(w, r, err)
 		return
 	}
 
@@ -55,24 +57,29 @@ func (h *UserHandler) CreateUser(w http.ResponseWriter, r *http.Request) {
 }
 
 func (h *UserHandler) GetUsers(w http.ResponseWriter, r *http.Request) {
-	users, err := h.service.GetUsers(r.Context())
+	params, err := pagination.FromRequest(r, pagination.SortCreatedAt, pagination.SortName)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		api_errors.WriteProblem(w, r, api_errors.ErrInvalidRequest(err))
+		return
+	}
+
+	users, err := h.service.GetUsers(r.Context(), params)
+	if err != nil {
+		api_errors.WriteError(w, r, err)
 		return
 	}
 
 	// Convert service layer models to API response models
-	var resp []user_handler_models.UserResponse
-	for _, user := range users {
-		resp = append(resp, user_handler_models.UserResponse{
+	resp := pagination.MapPage(users, func(user *user_models.UserModel) user_handler_models.UserResponse {
+		return user_handler_models.UserResponse{
 			ID:        user.ID,
 			Username:  user.Username,
 			FirstName: user.FirstName,
 			LastName:  user.LastName,
 			CreatedAt: user.CreatedAt,
 			UpdatedAt: user.UpdatedAt,
-		})
-	}
+		}
+	})
 
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
@@ -83,13 +90,13 @@ func (h *UserHandler) GetUserByID(w http.ResponseWriter, r *http.Request) {
 	idStr := mux.Vars(r)["id"]
 	id, err := strconv.Atoi(idStr)
 	if err != nil {
-		http.Error(w, "Invalid user ID", http.StatusBadRequest)
+		api_errors.WriteProblem(w, r, api_errors.ErrBadRequest("Invalid user ID"))
 		return
 	}
 
 	user, err := h.service.GetUserByID(r.Context(), id)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusNotFound)
+		api_errors.WriteError(w, r, err)
 		return
 	}
 
@@ -112,13 +119,13 @@ func (h *UserHandler) UpdateUser(w http.ResponseWriter, r *http.Request) {
 	idStr := mux.Vars(r)["id"]
 	id, err := strconv.Atoi(idStr)
 	if err != nil {
-		http.Error(w, "Invalid user ID", http.StatusBadRequest)
+		api_errors.WriteProblem(w, r, api_errors.ErrBadRequest("Invalid user ID"))
 		return
 	}
 
 	var req user_handler_models.UpdateUserRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		api_errors.WriteProblem(w, r, api_errors.ErrInvalidRequest(err))
 		return
 	}
 
@@ -132,7 +139,7 @@ func (h *UserHandler) UpdateUser(w http.ResponseWriter, r *http.Request) {
 
 	user, err := h.service.UpdateUser(r.Context(), userUpdateModel)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		api_errors.WriteError(w, r, err)
 		return
 	}
 
@@ -155,12 +162,12 @@ func (h *UserHandler) DeleteUser(w http.ResponseWriter, r *http.Request) {
 	idStr := mux.Vars(r)["id"]
 	id, err := strconv.Atoi(idStr)
 	if err != nil {
-		http.Error(w, "Invalid user ID", http.StatusBadRequest)
+		api_errors.WriteProblem(w, r, api_errors.ErrBadRequest("Invalid user ID"))
 		return
 	}
 
 	if err := h.service.DeleteUser(r.Context(), id); err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		api_errors.WriteError(w, r, err)
 		return
 	}
 
@@ -0,0 +1,236 @@
+package webhook_handler
+
+import (
+	"avitoTest/api/handlers/webhook_handler/webhook_handler_models"
+	"avitoTest/services/webhook_service"
+	"avitoTest/services/webhook_service/webhook_models"
+	"avitoTest/shared/errors/api_errors"
+	"avitoTest/shared/filter"
+	"avitoTest/shared/pagination"
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+)
+
+type WebhookHandler struct {
+	service webhook_service.WebhookService
+}
+
+func NewWebhookHandler(service webhook_service.WebhookService) *WebhookHandler {
+	return &WebhookHandler{service: service}
+}
+
+// CreateWebhook handles registering a webhook of an organization
+func (h *WebhookHandler) CreateWebhook(w http.ResponseWriter, r *http.Request) {
+	orgID, err := strconv.Atoi(mux.Vars(r)["org_id"])
+	if err != nil {
+		api_errors.WriteProblem(w, r, api_errors.ErrBadRequest("Invalid organization ID"))
+		return
+	}
+
+	var req webhook_handler_models.CreateWebhookRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		api_errors.WriteProblem(w, r, api_errors.ErrInvalidRequest(err))
+		return
+	}
+
+	webhook, err := h.service.CreateWebhook(r.Context(), webhook_models.WebhookCreateModel{
+		OrganizationID: orgID,
+		URL:            req.URL,
+		EventTypes:     req.EventTypes,
+		Secret:         req.Secret,
+	})
+	if err != nil {
+		api_errors.WriteError(w, r, err)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, toWebhookResponse(webhook))
+}
+
+// GetWebhooks handles fetching the webhooks of an organization
+func (h *WebhookHandler) GetWebhooks(w http.ResponseWriter, r *http.Request) {
+	orgID, err := strconv.Atoi(mux.Vars(r)["org_id"])
+	if err != nil {
+		api_errors.WriteProblem(w, r, api_errors.ErrBadRequest("Invalid organization ID"))
+		return
+	}
+
+	webhooks, err := h.service.GetWebhooks(r.Context(), orgID)
+	if err != nil {
+		api_errors.WriteError(w, r, err)
+		return
+	}
+
+	resp := make([]webhook_handler_models.WebhookResponse, 0, len(webhooks))
+	for _, webhook := range webhooks {
+		resp = append(resp, toWebhookResponse(webhook))
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// GetWebhookByID handles fetching a webhook of an organization
+func (h *WebhookHandler) GetWebhookByID(w http.ResponseWriter, r *http.Request) {
+	orgID, webhookID, ok := webhookIDs(w, r)
+	if !ok {
+		return
+	}
+
+	webhook, err := h.service.GetWebhookByID(r.Context(), orgID, webhookID)
+	if err != nil {
+		api_errors.WriteError(w, r, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, toWebhookResponse(webhook))
+}
+
+// UpdateWebhook handles changing the URL, event types, secret or activity of a webhook
+func (h *WebhookHandler) UpdateWebhook(w http.ResponseWriter, r *http.Request) {
+	orgID, webhookID, ok := webhookIDs(w, r)
+	if !ok {
+		return
+	}
+
+	var req webhook_handler_models.UpdateWebhookRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		api_errors.WriteProblem(w, r, api_errors.ErrInvalidRequest(err))
+		return
+	}
+
+	webhook, err := h.service.UpdateWebhook(r.Context(), webhook_models.WebhookUpdateModel{
+		ID:             webhookID,
+		OrganizationID: orgID,
+		URL:            req.URL,
+		EventTypes:     req.EventTypes,
+		Secret:         req.Secret,
+		Active:         req.Active,
+	})
+	if err != nil {
+		api_errors.WriteError(w, r, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, toWebhookResponse(webhook))
+}
+
+// DeleteWebhook handles deleting a webhook with its delivery history
+func (h *WebhookHandler) DeleteWebhook(w http.ResponseWriter, r *http.Request) {
+	orgID, webhookID, ok := webhookIDs(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.service.DeleteWebhook(r.Context(), orgID, webhookID); err != nil {
+		api_errors.WriteError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetDeliveries handles fetching a filtered page of the deliveries of a webhook
+func (h *WebhookHandler) GetDeliveries(w http.ResponseWriter, r *http.Request) {
+	orgID, webhookID, ok := webhookIDs(w, r)
+	if !ok {
+		return
+	}
+
+	params, err := pagination.FromRequest(r, pagination.SortCreatedAt, pagination.SortStatus)
+	if err != nil {
+		api_errors.WriteProblem(w, r, api_errors.ErrInvalidRequest(err))
+		return
+	}
+
+	f, err := filter.Parse(r.URL.Query(), webhook_models.DeliveryFilterSchema)
+	if err != nil {
+		api_errors.WriteProblem(w, r, api_errors.ErrInvalidRequest(err))
+		return
+	}
+
+	deliveries, err := h.service.GetDeliveries(r.Context(), orgID, webhookID, f, params)
+	if err != nil {
+		api_errors.WriteError(w, r, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, pagination.MapPage(deliveries, toDeliveryResponse))
+}
+
+// Redeliver handles queueing the event of a delivery to be sent to the webhook again
+func (h *WebhookHandler) Redeliver(w http.ResponseWriter, r *http.Request) {
+	orgID, webhookID, ok := webhookIDs(w, r)
+	if !ok {
+		return
+	}
+	deliveryID, err := strconv.Atoi(mux.Vars(r)["delivery_id"])
+	if err != nil {
+		api_errors.WriteProblem(w, r, api_errors.ErrBadRequest("Invalid delivery ID"))
+		return
+	}
+
+	delivery, err := h.service.Redeliver(r.Context(), orgID, webhookID, deliveryID)
+	if err != nil {
+		api_errors.WriteError(w, r, err)
+		return
+	}
+
+	writeJSON(w, http.StatusAccepted, toDeliveryResponse(delivery))
+}
+
+// webhookIDs parses the organization and webhook IDs of the route, writing the problem
+// when either is invalid.
+func webhookIDs(w http.ResponseWriter, r *http.Request) (int, int, bool) {
+	orgID, err := strconv.Atoi(mux.Vars(r)["org_id"])
+	if err != nil {
+		api_errors.WriteProblem(w, r, api_errors.ErrBadRequest("Invalid organization ID"))
+		return 0, 0, false
+	}
+	webhookID, err := strconv.Atoi(mux.Vars(r)["webhook_id"])
+	if err != nil {
+		api_errors.WriteProblem(w, r, api_errors.ErrBadRequest("Invalid webhook ID"))
+		return 0, 0, false
+	}
+	return orgID, webhookID, true
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
+
+func toWebhookResponse(webhook *webhook_models.WebhookModel) webhook_handler_models.WebhookResponse {
+	return webhook_handler_models.WebhookResponse{
+		ID:             webhook.ID,
+		OrganizationID: webhook.OrganizationID,
+		URL:            webhook.URL,
+		EventTypes:     webhook.EventTypes,
+		Active:         webhook.Active,
+		Secret:         webhook.Secret,
+		CreatedAt:      webhook.CreatedAt,
+		UpdatedAt:      webhook.UpdatedAt,
+	}
+}
+
+func toDeliveryResponse(delivery *webhook_models.WebhookDeliveryModel) webhook_handler_models.WebhookDeliveryResponse {
+	return webhook_handler_models.WebhookDeliveryResponse{
+		ID:             delivery.ID,
+		WebhookID:      delivery.WebhookID,
+		EventID:        delivery.EventID,
+		EventType:      delivery.EventType,
+		Payload:        delivery.Payload,
+		Status:         delivery.Status,
+		Attempts:       delivery.Attempts,
+		NextAttemptAt:  delivery.NextAttemptAt,
+		LastAttemptAt:  delivery.LastAttemptAt,
+		ResponseStatus: delivery.ResponseStatus,
+		ResponseBody:   delivery.ResponseBody,
+		Error:          delivery.Error,
+		RedeliveryOf:   delivery.RedeliveryOf,
+		DeliveredAt:    delivery.DeliveredAt,
+		CreatedAt:      delivery.CreatedAt,
+	}
+}
@@ -0,0 +1,16 @@
+package webhook_handler_models
+
+// CreateWebhookRequest - API model for registering a webhook of an organization.
+type CreateWebhookRequest struct {
+	URL        string   `json:"url"`
+	EventTypes []string `json:"event_types"`
+	Secret     string   `json:"secret"`
+}
+
+// UpdateWebhookRequest - API model for changing a webhook; omitted fields are kept.
+type UpdateWebhookRequest struct {
+	URL        *string  `json:"url"`
+	EventTypes []string `json:"event_types"`
+	Secret     *string  `json:"secret"`
+	Active     *bool    `json:"active"`
+}
@@ -0,0 +1,42 @@
+package middlewares
+
+import (
+	"avitoTest/shared"
+	"avitoTest/shared/requestid"
+	"net/http"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// AccessLogMiddleware logs every request once it is served, with its status, response size
+// and latency. Server errors are logged as errors and client errors as warnings.
+func AccessLogMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		recorder := recordResponse(w)
+
+		// Requests aborted with a panic are logged as well
+		defer func() {
+			entry := shared.Logger.WithFields(logrus.Fields{
+				"request_id":  requestid.FromContext(r.Context()),
+				"method":      r.Method,
+				"path":        r.URL.Path,
+				"status":      recorder.status,
+				"bytes":       recorder.bytes,
+				"duration_ms": time.Since(start).Milliseconds(),
+				"remote_addr": r.RemoteAddr,
+			})
+			switch {
+			case recorder.status >= http.StatusInternalServerError:
+				entry.Error("request completed")
+			case recorder.status >= http.StatusBadRequest:
+				entry.Warn("request completed")
+			default:
+				entry.Info("request completed")
+			}
+		}()
+
+		next.ServeHTTP(recorder, r)
+	})
+}
@@ -0,0 +1,39 @@
+package middlewares
+
+import (
+	"avitoTest/shared/auth"
+	"avitoTest/shared/errors/api_errors"
+	"avitoTest/shared/ratelimit"
+	"net/http"
+)
+
+// AuthenticationMiddleware stores the principal of every request in its context. Requests
+// with an invalid API key are rejected with 401; each rejection takes a token from the
+// auth bucket of the client IP, and once it is empty the keys sent from that IP are no
+// longer checked, so they cannot be guessed.
+func AuthenticationMiddleware(authenticator *auth.Authenticator, limiter *ratelimit.Limiter) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get(auth.APIKeyHeader) == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			ip := clientIP(r)
+			if decision := limiter.Take(r.Context(), ratelimit.GroupAuth, ip, 0); !decision.Allowed {
+				writeRateLimited(w, r, ratelimit.GroupAuth, decision)
+				return
+			}
+
+			principal, err := authenticator.Authenticate(r)
+			if err != nil {
+				if api_errors.StatusCode(err) == http.StatusUnauthorized {
+					setRateLimitHeaders(w, limiter.Take(r.Context(), ratelimit.GroupAuth, ip, 1))
+				}
+				api_errors.WriteError(w, r, err)
+				return
+			}
+			next.ServeHTTP(w, r.WithContext(auth.NewContext(r.Context(), principal)))
+		})
+	}
+}
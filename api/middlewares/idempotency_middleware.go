@@ -0,0 +1,152 @@
+package middlewares
+
+import (
+	"avitoTest/services/idempotency_service"
+	"avitoTest/services/idempotency_service/idempotency_models"
+	"avitoTest/shared"
+	"avitoTest/shared/errors/api_errors"
+	"avitoTest/shared/errors/idempotency_errors"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+const (
+	// IdempotencyKeyHeader carries the key a client makes a POST request idempotent with.
+	IdempotencyKeyHeader = "Idempotency-Key"
+	// IdempotentReplayedHeader marks responses replayed from a previous request.
+	IdempotentReplayedHeader = "Idempotent-Replayed"
+)
+
+// maxIdempotentBody limits the bodies read to fingerprint requests, for the routes whose
+// body size is left to their handler.
+const maxIdempotentBody = 32 << 20
+
+// IdempotencyMiddleware makes POST requests with an Idempotency-Key header idempotent for
+// their caller: the response to the first request with a key is stored and replayed to
+// retries with the same method, path and body. Reusing a key for a different request is
+// rejected with 422, and retrying while the first request is in progress with 409. Server
+// errors are not stored, so the request can be retried.
+func IdempotencyMiddleware(service idempotency_service.IdempotencyService) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			key := r.Header.Get(IdempotencyKeyHeader)
+			if r.Method != http.MethodPost || len(r.Header.Values(IdempotencyKeyHeader)) == 0 {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxIdempotentBody))
+			if err != nil {
+				api_errors.WriteProblem(w, r, api_errors.ErrInvalidRequest(err))
+				return
+			}
+			r.Body = io.NopCloser(bytes.NewReader(body))
+
+			scope := callerKey(r)
+			stored, claim, err := service.Begin(r.Context(), scope, key, fingerprint(r, body))
+			if errors.Is(err, idempotency_errors.ErrKeyReused) {
+				api_errors.WriteProblem(w, r, api_errors.NewProblem(http.StatusUnprocessableEntity, err))
+				return
+			}
+			if err != nil {
+				api_errors.WriteError(w, r, err)
+				return
+			}
+			if stored != nil {
+				replay(w, stored)
+				return
+			}
+
+			// The key is released unless the response is stored, including when the handler panics
+			ctx := context.WithoutCancel(r.Context())
+			completed := false
+			defer func() {
+				if !completed {
+					if err := service.Release(ctx, scope, key, claim); err != nil {
+						shared.Logger.Errorf("idempotency: failed to release %q: %v", key, err)
+					}
+				}
+			}()
+
+			before := map[string]bool{}
+			for name := range w.Header() {
+				before[name] = true
+			}
+			recorder := &bufferedResponse{ResponseWriter: w}
+			next.ServeHTTP(recorder, r)
+
+			status := recorder.status
+			if status == 0 {
+				status = http.StatusOK
+			}
+			if status >= http.StatusInternalServerError {
+				return
+			}
+
+			// Only the headers of the handler are stored, not those of the request, such as its ID
+			response := idempotency_models.StoredResponse{Status: status, Header: http.Header{}, Body: recorder.body.Bytes()}
+			for name, values := range w.Header() {
+				if !before[name] {
+					response.Header[name] = values
+				}
+			}
+			if err := service.Complete(ctx, scope, key, claim, response); err != nil {
+				shared.Logger.Errorf("idempotency: failed to store the response to %q: %v", key, err)
+				return
+			}
+			completed = true
+		})
+	}
+}
+
+// fingerprint identifies a request by its method, path, query and body.
+func fingerprint(r *http.Request, body []byte) string {
+	hash := sha256.New()
+	io.WriteString(hash, r.Method+" "+r.URL.RequestURI()+"\n")
+	hash.Write(body)
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// replay writes a stored response.
+func replay(w http.ResponseWriter, stored *idempotency_models.StoredResponse) {
+	for name, values := range stored.Header {
+		w.Header()[name] = values
+	}
+	w.Header().Set(IdempotentReplayedHeader, "true")
+	w.WriteHeader(stored.Status)
+	w.Write(stored.Body)
+}
+
+// bufferedResponse keeps a copy of the response written through it.
+type bufferedResponse struct {
+	http.ResponseWriter
+	status int
+	body   bytes.Buffer
+}
+
+func (b *bufferedResponse) WriteHeader(status int) {
+	if b.status == 0 {
+		b.status = status
+	}
+	b.ResponseWriter.WriteHeader(status)
+}
+
+func (b *bufferedResponse) Write(p []byte) (int, error) {
+	if b.status == 0 {
+		b.status = http.StatusOK
+	}
+	b.body.Write(p)
+	return b.ResponseWriter.Write(p)
+}
+
+// Unwrap gives http.ResponseController access to the original writer.
+func (b *bufferedResponse) Unwrap() http.ResponseWriter {
+	return b.ResponseWriter
+}
@@ -0,0 +1,68 @@
+package middlewares
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+)
+
+// BodyLimitMiddleware limits the size of request bodies. limits overrides the default for
+// routes by path template; a zero limit leaves the body of the route to its handler.
+// Reading past the limit fails with *http.MaxBytesError, reported as 413.
+func BodyLimitMiddleware(defaultLimit int64, limits map[string]int64) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			limit, ok := limits[routeTemplate(r)]
+			if !ok {
+				limit = defaultLimit
+			}
+			if limit > 0 && r.Body != nil {
+				r.Body = http.MaxBytesReader(w, r.Body, limit)
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// deadlineMargin is the time left to write the response after the timeout of a request.
+const deadlineMargin = 5 * time.Second
+
+// TimeoutMiddleware bounds the time spent on a request by cancelling its context, which
+// stops the queries made for it. timeouts overrides the default for routes by path template;
+// a zero timeout leaves the route unbounded. The routes overriding the default also move
+// the read and write deadlines of the connection, which the server sets for the default.
+func TimeoutMiddleware(defaultTimeout time.Duration, timeouts map[string]time.Duration) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			timeout, ok := timeouts[routeTemplate(r)]
+			if !ok {
+				timeout = defaultTimeout
+			} else {
+				extendDeadlines(w, timeout)
+			}
+			if timeout <= 0 {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			ctx, cancel := context.WithTimeout(r.Context(), timeout)
+			defer cancel()
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// extendDeadlines gives the connection of w the time to read and write a request taking
+// up to timeout, or no deadlines for an unbounded one. Writers that do not support
+// deadlines, such as test recorders, are left alone.
+func extendDeadlines(w http.ResponseWriter, timeout time.Duration) {
+	var deadline time.Time
+	if timeout > 0 {
+		deadline = time.Now().Add(timeout + deadlineMargin)
+	}
+	controller := http.NewResponseController(w)
+	controller.SetReadDeadline(deadline)
+	controller.SetWriteDeadline(deadline)
+}
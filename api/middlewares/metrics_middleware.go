@@ -0,0 +1,36 @@
+package middlewares
+
+import (
+	"avitoTest/shared/metrics"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// MetricsMiddleware counts and times the requests of every route. Requests whose handler
+// panicked before responding are counted as server errors.
+func MetricsMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		route := routeTemplate(r)
+		recorder := recordResponse(w)
+		completed := false
+
+		defer func() {
+			status := recorder.status
+			if !completed && !recorder.wroteHeader {
+				status = http.StatusInternalServerError
+			}
+			metrics.HTTPRequests.WithLabelValues(route, r.Method, statusClass(status)).Inc()
+			metrics.HTTPRequestDuration.WithLabelValues(route, r.Method).Observe(time.Since(start).Seconds())
+		}()
+
+		next.ServeHTTP(recorder, r)
+		completed = true
+	})
+}
+
+// statusClass groups status codes by their first digit, such as 2xx.
+func statusClass(status int) string {
+	return strconv.Itoa(status/100) + "xx"
+}
@@ -0,0 +1,105 @@
+package middlewares
+
+import (
+	"avitoTest/shared/auth"
+	"avitoTest/shared/errors/api_errors"
+	"avitoTest/shared/metrics"
+	"avitoTest/shared/ratelimit"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+)
+
+// RateLimitMiddleware limits the rate of the requests of every caller: the user a client
+// acts for, the client otherwise, or the IP of anonymous callers. Requests to reading
+// methods are in the reads group and the others in the writes group; groups overrides
+// the group of routes of router by path template, and routes in the "" group are not
+// limited. Requests over the limit are rejected with 429 and a Retry-After header.
+func RateLimitMiddleware(limiter *ratelimit.Limiter, router *mux.Router, groups map[string]string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			group := rateLimitGroup(r, router, groups)
+			if group == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			decision := limiter.Take(r.Context(), group, callerKey(r), 1)
+			if !decision.Allowed {
+				writeRateLimited(w, r, group, decision)
+				return
+			}
+			setRateLimitHeaders(w, decision)
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// rateLimitGroup returns the group of the route r matches, which this middleware runs
+// before the router does.
+func rateLimitGroup(r *http.Request, router *mux.Router, groups map[string]string) string {
+	var match mux.RouteMatch
+	if router.Match(r, &match) && match.Route != nil {
+		template, _ := match.Route.GetPathTemplate()
+		if group, ok := groups[template]; ok {
+			return group
+		}
+	}
+
+	switch r.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return ratelimit.GroupReads
+	}
+	return ratelimit.GroupWrites
+}
+
+// callerKey identifies the caller whose bucket a request takes tokens from.
+func callerKey(r *http.Request) string {
+	principal := auth.FromContext(r.Context())
+	switch {
+	case principal.UserID != 0:
+		return "user:" + strconv.Itoa(principal.UserID)
+	case !principal.Anonymous():
+		return "client:" + principal.Client
+	}
+	return "ip:" + clientIP(r)
+}
+
+// clientIP returns the IP address of the peer of the connection r came in on.
+func clientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// setRateLimitHeaders tells the caller the state of its bucket. Decisions of groups
+// without a policy have no limit to tell.
+func setRateLimitHeaders(w http.ResponseWriter, decision ratelimit.Decision) {
+	if decision.Limit == 0 {
+		return
+	}
+	w.Header().Set("X-RateLimit-Limit", strconv.Itoa(decision.Limit))
+	w.Header().Set("X-RateLimit-Remaining", strconv.Itoa(decision.Remaining))
+	w.Header().Set("X-RateLimit-Reset", strconv.Itoa(seconds(decision.Reset)))
+}
+
+// writeRateLimited rejects a request over the limit of group.
+func writeRateLimited(w http.ResponseWriter, r *http.Request, group string, decision ratelimit.Decision) {
+	metrics.RateLimited.WithLabelValues(group).Inc()
+
+	retryAfter := max(seconds(decision.RetryAfter), 1)
+	setRateLimitHeaders(w, decision)
+	w.Header().Set("Retry-After", strconv.Itoa(retryAfter))
+	api_errors.WriteProblem(w, r, api_errors.ErrTooManyRequests(time.Duration(retryAfter)*time.Second))
+}
+
+// seconds rounds d up to whole seconds.
+func seconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
@@ -0,0 +1,39 @@
+package middlewares
+
+import (
+	"avitoTest/shared"
+	"avitoTest/shared/errors/api_errors"
+	"avitoTest/shared/requestid"
+	"net/http"
+	"runtime/debug"
+)
+
+// RecoveryMiddleware turns a panicking handler into a 500 problem response instead of a
+// dropped connection, logging the panic with its stack. http.ErrAbortHandler is passed on,
+// since handlers panic with it on purpose to abort a response that has already started.
+func RecoveryMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		recorder := recordResponse(w)
+
+		defer func() {
+			recovered := recover()
+			if recovered == nil {
+				return
+			}
+			if recovered == http.ErrAbortHandler {
+				panic(recovered)
+			}
+
+			shared.Logger.WithField("request_id", requestid.FromContext(r.Context())).
+				Errorf("%s %s panicked: %v\n%s", r.Method, r.URL.Path, recovered, debug.Stack())
+
+			// A response that has already started cannot be replaced
+			if recorder.wroteHeader {
+				panic(http.ErrAbortHandler)
+			}
+			api_errors.WriteProblem(recorder, r, api_errors.ErrInternal(nil))
+		}()
+
+		next.ServeHTTP(recorder, r)
+	})
+}
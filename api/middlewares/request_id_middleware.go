@@ -0,0 +1,22 @@
+package middlewares
+
+import (
+	"avitoTest/shared/requestid"
+	"net/http"
+)
+
+// RequestIDMiddleware assigns every request an ID, keeping a valid one sent by the client
+// in the X-Request-ID header. The ID is stored in the request context and returned in the
+// X-Request-ID response header, so it can be followed through logs and other services.
+func RequestIDMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := r.Header.Get(requestid.Header)
+		if !requestid.Valid(id) {
+			id = requestid.New()
+			r.Header.Set(requestid.Header, id)
+		}
+
+		w.Header().Set(requestid.Header, id)
+		next.ServeHTTP(w, r.WithContext(requestid.NewContext(r.Context(), id)))
+	})
+}
@@ -0,0 +1,49 @@
+package middlewares
+
+import (
+	"avitoTest/shared/tracing"
+	"fmt"
+	"net/http"
+
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// TracingMiddleware starts a server span for every routed request, continuing the trace
+// of the caller if the request carries a W3C traceparent header. Server errors and
+// panics mark the span as failed.
+func TracingMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		route := routeTemplate(r)
+		ctx := tracing.Propagator.Extract(r.Context(), propagation.HeaderCarrier(r.Header))
+		ctx, span := tracing.Start(ctx, r.Method+" "+route,
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(
+				semconv.HTTPRequestMethodKey.String(r.Method),
+				semconv.HTTPRoute(route),
+				semconv.URLPath(r.URL.Path),
+			))
+		recorder := recordResponse(w)
+		completed := false
+
+		defer func() {
+			if !completed {
+				if p := recover(); p != nil {
+					span.SetStatus(codes.Error, fmt.Sprint("panic: ", p))
+					span.End()
+					panic(p)
+				}
+			}
+			span.SetAttributes(semconv.HTTPResponseStatusCode(recorder.status))
+			if recorder.status >= http.StatusInternalServerError {
+				span.SetStatus(codes.Error, http.StatusText(recorder.status))
+			}
+			span.End()
+		}()
+
+		next.ServeHTTP(recorder, r.WithContext(ctx))
+		completed = true
+	})
+}
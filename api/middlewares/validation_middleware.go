@@ -0,0 +1,135 @@
+package middlewares
+
+import (
+	"avitoTest/shared/errors/api_errors"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers/gorillamux"
+	"github.com/gorilla/mux"
+)
+
+// rawBodyExtension marks operations whose body is read by the handler itself, such as
+// file uploads, so the validation leaves it alone.
+const rawBodyExtension = "x-raw-body"
+
+// RequestValidationMiddleware rejects requests that do not match the OpenAPI document
+// with a 400 listing every problem found. Requests for routes missing from the document
+// are passed through unchanged.
+func RequestValidationMiddleware(doc *openapi3.T) (mux.MiddlewareFunc, error) {
+	router, err := gorillamux.NewRouter(doc)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			route, pathParams, err := router.FindRoute(r)
+			if err != nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			_, rawBody := route.Operation.Extensions[rawBodyExtension]
+			validated := withJSONContentType(r, route.Operation)
+			input := &openapi3filter.RequestValidationInput{
+				Request:    validated,
+				PathParams: pathParams,
+				Route:      route,
+				Options: &openapi3filter.Options{
+					ExcludeRequestBody:  rawBody,
+					MultiError:          true,
+					SkipSettingDefaults: true,
+					AuthenticationFunc:  openapi3filter.NoopAuthenticationFunc,
+				},
+			}
+			if err := openapi3filter.ValidateRequest(r.Context(), input); err != nil {
+				var maxBytesErr *http.MaxBytesError
+				if errors.As(err, &maxBytesErr) {
+					api_errors.WriteProblem(w, r, api_errors.ErrInvalidRequest(maxBytesErr))
+					return
+				}
+				api_errors.WriteProblem(w, r, api_errors.ErrValidation(validationDetails(err)))
+				return
+			}
+
+			// The validation replaces the body it has read with a copy
+			r.Body = validated.Body
+			next.ServeHTTP(w, r)
+		})
+	}, nil
+}
+
+// withJSONContentType returns the request to validate. The handlers decode JSON bodies
+// whatever their declared type, so a JSON body sent without a Content-Type the operation
+// accepts is validated as JSON rather than rejected.
+func withJSONContentType(r *http.Request, operation *openapi3.Operation) *http.Request {
+	if operation.RequestBody == nil || operation.RequestBody.Value.GetMediaType("application/json") == nil {
+		return r
+	}
+	if operation.RequestBody.Value.Content.Get(r.Header.Get("Content-Type")) != nil {
+		return r
+	}
+
+	validated := r.Clone(r.Context())
+	validated.Header.Set("Content-Type", "application/json")
+	return validated
+}
+
+// validationDetails flattens a validation error into one entry per problem.
+func validationDetails(err error) []api_errors.FieldError {
+	switch err := err.(type) {
+	case openapi3.MultiError:
+		var details []api_errors.FieldError
+		for _, e := range err {
+			details = append(details, validationDetails(e)...)
+		}
+		return details
+	case *openapi3filter.RequestError:
+		return requestErrorDetails(err)
+	}
+	return []api_errors.FieldError{{Message: err.Error()}}
+}
+
+func requestErrorDetails(err *openapi3filter.RequestError) []api_errors.FieldError {
+	if err.Parameter != nil {
+		detail := api_errors.FieldError{In: err.Parameter.In, Field: err.Parameter.Name, Message: err.Reason}
+		var schemaErr *openapi3.SchemaError
+		if errors.As(err.Err, &schemaErr) {
+			detail.Code = schemaErr.SchemaField
+			detail.Message = schemaErr.Reason
+		} else if detail.Message == "" && err.Err != nil {
+			detail.Message = err.Err.Error()
+		}
+		return []api_errors.FieldError{detail}
+	}
+
+	var causes []error
+	var multi openapi3.MultiError
+	if errors.As(err.Err, &multi) {
+		causes = multi
+	} else if err.Err != nil {
+		causes = []error{err.Err}
+	}
+	if len(causes) == 0 {
+		return []api_errors.FieldError{{In: "body", Message: err.Reason}}
+	}
+
+	details := make([]api_errors.FieldError, 0, len(causes))
+	for _, cause := range causes {
+		detail := api_errors.FieldError{In: "body", Message: cause.Error()}
+		var schemaErr *openapi3.SchemaError
+		if errors.As(cause, &schemaErr) {
+			detail.Field = strings.Join(schemaErr.JSONPointer(), ".")
+			detail.Code = schemaErr.SchemaField
+			detail.Message = schemaErr.Reason
+		} else if err.Reason != "" {
+			detail.Message = err.Reason + ": " + cause.Error()
+		}
+		details = append(details, detail)
+	}
+	return details
+}
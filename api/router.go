@@ -1,37 +1,156 @@
 package api
 
 import (
+	"avitoTest/api/handlers/audit_handler"
 	"avitoTest/api/handlers/bid_handler"
+	"avitoTest/api/handlers/cache_handler"
+	"avitoTest/api/handlers/category_handler"
 	"avitoTest/api/handlers/comment_handler"
+	"avitoTest/api/handlers/export_handler"
+	"avitoTest/api/handlers/health_handler"
+	"avitoTest/api/handlers/metrics_handler"
+	"avitoTest/api/handlers/notification_handler"
+	"avitoTest/api/handlers/openapi_handler"
 	"avitoTest/api/handlers/organization_handler"
 	"avitoTest/api/handlers/ping_handler"
+	"avitoTest/api/handlers/search_handler"
 	"avitoTest/api/handlers/tender_handler"
 	"avitoTest/api/handlers/user_handler"
+	"avitoTest/api/handlers/webhook_handler"
+	"avitoTest/api/middlewares"
+	"avitoTest/api/openapi"
+	"avitoTest/services/audit_service"
 	"avitoTest/services/bid_service"
+	"avitoTest/services/category_service"
 	"avitoTest/services/comment_service"
+	"avitoTest/services/idempotency_service"
+	"avitoTest/services/notification_service"
 	"avitoTest/services/organization_service"
+	"avitoTest/services/search_service"
 	"avitoTest/services/tender_service"
 	"avitoTest/services/user_service"
+	"avitoTest/services/webhook_service"
+	"avitoTest/shared"
+	"avitoTest/shared/auth"
+	"avitoTest/shared/config"
+	"avitoTest/shared/ratelimit"
+	"avitoTest/shared/requestid"
+	"net/http"
+	"time"
 
+	"github.com/getkin/kin-openapi/openapi3"
 	"github.com/gorilla/mux"
 )
 
+const (
+	defaultRequestTimeout = 30 * time.Second
+	defaultMaxBodySize    = 1 << 20
+)
+
+// routeTimeouts overrides the request timeout of routes that take longer than most.
+var routeTimeouts = map[string]time.Duration{
+	"/api/tenders/import":                          5 * time.Minute,
+	"/api/organizations/{org_id}/export/tenders":   10 * time.Minute,
+	"/api/organizations/{org_id}/export/bids":      10 * time.Minute,
+	"/api/organizations/{org_id}/export/decisions": 10 * time.Minute,
+}
+
+// routeBodyLimits overrides the body size limit of routes; the import handler limits the
+// size of import files itself.
+var routeBodyLimits = map[string]int64{
+	"/api/tenders/import": 0,
+}
+
+// routeRateLimitGroups overrides the rate limit group of routes; the probes of the
+// orchestrator and Prometheus are not limited.
+var routeRateLimitGroups = map[string]string{
+	"/api/ping":         "",
+	"/api/health/live":  "",
+	"/api/health/ready": "",
+	"/metrics":          "",
+}
+
+// Services are the services the API routes call. A route group whose service is left
+// nil must not be requested.
+type Services struct {
+	Organizations organization_service.OrganizationService
+	Users         user_service.UserService
+	Tenders       tender_service.TenderService
+	Bids          bid_service.BidService
+	Comments      comment_service.CommentService
+	Categories    category_service.CategoryService
+	Search        search_service.SearchService
+	Idempotency   idempotency_service.IdempotencyService
+	Audit         audit_service.AuditService
+	Webhooks      webhook_service.WebhookService
+	Notifications notification_service.NotificationService
+}
+
 // InitRoutes initializes all API routes.
-func InitRoutes(
-	router *mux.Router,
-	orgService organization_service.OrganizationService,
-	userService user_service.UserService,
-	tenderService tender_service.TenderService,
-	bidService bid_service.BidService,
-	commentService comment_service.CommentService) {
+func InitRoutes(router *mux.Router, services Services) {
+	doc, err := openapi.Load()
+	if err != nil {
+		shared.Logger.Fatalf("Failed to load the OpenAPI specification: %v", err)
+	}
+
+	// Trace every routed request, continuing the trace of the caller
+	router.Use(middlewares.TracingMiddleware)
+
+	// Count and time every routed request, including those rejected by the middlewares below
+	router.Use(middlewares.MetricsMiddleware)
+
+	// Bound the time and body size of requests before anything reads the body
+	router.Use(middlewares.TimeoutMiddleware(defaultRequestTimeout, routeTimeouts))
+	router.Use(middlewares.BodyLimitMiddleware(defaultMaxBodySize, routeBodyLimits))
+
+	// Replay the responses to retried POST requests instead of running them again
+	router.Use(middlewares.IdempotencyMiddleware(services.Idempotency))
+
+	// Reject requests that do not match the specification before they reach the handlers
+	validation, err := middlewares.RequestValidationMiddleware(doc)
+	if err != nil {
+		shared.Logger.Fatalf("Failed to build the request validation: %v", err)
+	}
+	router.Use(validation)
 
 	// Initialize individual route groups
 	initPingRoutes(router)
-	initOrganizationRoutes(router, orgService)
-	initUserRoutes(router, userService)
-	initTenderRoutes(router, tenderService, userService)
-	initBidRoutes(router, bidService, commentService)
-	initCommentRoutes(router, commentService)
+	initHealthRoutes(router)
+	initOrganizationRoutes(router, services.Organizations)
+	initUserRoutes(router, services.Users)
+	initNotificationRoutes(router, services.Notifications)
+	initTenderRoutes(router, services.Tenders, services.Users)
+	initBidRoutes(router, services.Bids, services.Comments)
+	initCommentRoutes(router, services.Comments)
+	initCategoryRoutes(router, services.Categories)
+	initSearchRoutes(router, services.Search)
+	initExportRoutes(router, services.Organizations, services.Tenders, services.Bids)
+	initAuditRoutes(router, services.Audit)
+	initWebhookRoutes(router, services.Webhooks)
+	initCacheRoutes(router)
+	initMetricsRoutes(router)
+	initDocsRoutes(router, doc)
+}
+
+// WithMiddlewares wraps the router in the middlewares every request passes through,
+// including requests that match no route, such as CORS preflight requests. The requests
+// of every caller are limited by limiter.
+func WithMiddlewares(router *mux.Router, conf *config.Config, limiter *ratelimit.Limiter) http.Handler {
+	return middlewares.Chain(router,
+		middlewares.RequestIDMiddleware,
+		middlewares.AccessLogMiddleware,
+		middlewares.RecoveryMiddleware,
+		middlewares.CORSMiddleware(middlewares.CORSConfig{
+			AllowedOrigins: conf.Server.CORSAllowedOrigins,
+			AllowedMethods: []string{"GET", "POST", "PUT", "PATCH", "DELETE"},
+			AllowedHeaders: []string{"Content-Type", requestid.Header, auth.APIKeyHeader, auth.UserHeader, middlewares.IdempotencyKeyHeader},
+			ExposedHeaders: []string{requestid.Header, "Content-Disposition", "Retry-After", middlewares.IdempotentReplayedHeader,
+				"X-RateLimit-Limit", "X-RateLimit-Remaining", "X-RateLimit-Reset"},
+			MaxAge: 10 * time.Minute,
+		}),
+		middlewares.AuthenticationMiddleware(auth.NewAuthenticator(conf.Auth.APIKeys), limiter),
+		middlewares.RateLimitMiddleware(limiter, router, routeRateLimitGroups),
+	)
 }
 
 // initPingRoutes sets up routes for server availability checks.
@@ -39,6 +158,12 @@ func initPingRoutes(router *mux.Router) {
 	router.HandleFunc("/api/ping", ping_handler.PingHandler).Methods("GET")
 }
 
+// initHealthRoutes sets up the routes the orchestrator checks the health of the service with.
+func initHealthRoutes(router *mux.Router) {
+	router.HandleFunc("/api/health/live", health_handler.LiveHandler).Methods("GET")
+	router.HandleFunc("/api/health/ready", health_handler.ReadyHandler).Methods("GET")
+}
+
 // initOrganizationRoutes sets up routes for organization-related operations.
 func initOrganizationRoutes(router *mux.Router, orgService organization_service.OrganizationService) {
 	orgHandler := organization_handler.NewOrganizationHandler(orgService)
@@ -67,11 +192,20 @@ func initUserRoutes(router *mux.Router, userService user_service.UserService) {
 	router.HandleFunc("/api/users/{user_id}/delete", userHandler.DeleteUser).Methods("DELETE")
 }
 
+// initNotificationRoutes sets up routes for the email notification preferences of users.
+func initNotificationRoutes(router *mux.Router, notificationService notification_service.NotificationService) {
+	notificationHandler := notification_handler.NewNotificationHandler(notificationService)
+
+	router.HandleFunc("/api/users/{user_id}/notifications/preferences", notificationHandler.GetPreferences).Methods("GET")
+	router.HandleFunc("/api/users/{user_id}/notifications/preferences", notificationHandler.UpdatePreferences).Methods("PUT")
+}
+
 // initTenderRoutes sets up routes for tender-related operations.
 func initTenderRoutes(router *mux.Router, tenderService tender_service.TenderService, userService user_service.UserService) {
 	tenderHandler := tender_handler.NewTenderHandler(tenderService, userService)
 
 	router.HandleFunc("/api/tenders/new", tenderHandler.CreateTender).Methods("POST")
+	router.HandleFunc("/api/tenders/import", tenderHandler.ImportTenders).Methods("POST")
 	router.HandleFunc("/api/tenders/", tenderHandler.GetTenders).Methods("GET")
 	router.HandleFunc("/api/tenders/{tenderId}", tenderHandler.GetTenderByID).Methods("GET")
 	router.HandleFunc("/api/tenders/my/{username}", tenderHandler.GetTendersByUsername).Methods("GET")
@@ -80,6 +214,8 @@ func initTenderRoutes(router *mux.Router, tenderService tender_service.TenderSer
 	router.HandleFunc("/api/tenders/{tenderId}/close", tenderHandler.CloseTender).Methods("POST")
 	router.HandleFunc("/api/tenders/{tenderId}/rollback/{version}", tenderHandler.RollbackTenderVersion).Methods("PUT")
 	router.HandleFunc("/api/tenders/{tenderId}/delete", tenderHandler.DeleteTender).Methods("DELETE")
+	router.HandleFunc("/api/tenders/{tenderId}/eligibility", tenderHandler.GetEligibilityRules).Methods("GET")
+	router.HandleFunc("/api/tenders/{tenderId}/eligibility", tenderHandler.SetEligibilityRules).Methods("PUT")
 }
 
 // initBidRoutes sets up routes for bid-related operations and reviews (comments).
@@ -96,6 +232,9 @@ func initBidRoutes(router *mux.Router, bidService bid_service.BidService, commen
 	router.HandleFunc("/api/bids/{bidId}/reject/", bidHandler.RejectBid).Methods("POST")
 	router.HandleFunc("/api/bids/{bidId}/rollback/{version}", bidHandler.RollbackBidVersion).Methods("PUT")
 	router.HandleFunc("/api/bids/{bidId}/delete", bidHandler.DeleteBid).Methods("DELETE")
+	router.HandleFunc("/api/bids/{bidId}/conflicts", bidHandler.GetBidConflicts).Methods("GET")
+	router.HandleFunc("/api/bids/{bidId}/conflicts/{userId}/new", bidHandler.DeclareConflict).Methods("POST")
+	router.HandleFunc("/api/tenders/{tenderId}/eligibility/check", bidHandler.CheckEligibility).Methods("GET")
 	router.HandleFunc("/api/bids/{tenderId}/reviews", commentHandler.GetReviews).Methods("GET")
 }
 
@@ -106,3 +245,70 @@ func initCommentRoutes(router *mux.Router, commentService comment_service.Commen
 	router.HandleFunc("/api/comments", commentHandler.CreateComment).Methods("POST")
 	router.HandleFunc("/api/comments/{commentId}", commentHandler.DeleteComment).Methods("DELETE")
 }
+
+// initCategoryRoutes sets up routes for managing the service category taxonomy.
+func initCategoryRoutes(router *mux.Router, categoryService category_service.CategoryService) {
+	categoryHandler := category_handler.NewCategoryHandler(categoryService)
+
+	router.HandleFunc("/api/categories/new", categoryHandler.CreateCategory).Methods("POST")
+	router.HandleFunc("/api/categories/", categoryHandler.GetCategories).Methods("GET")
+	router.HandleFunc("/api/categories/{categoryId}", categoryHandler.GetCategoryByID).Methods("GET")
+	router.HandleFunc("/api/categories/{categoryId}/edit", categoryHandler.UpdateCategory).Methods("PUT")
+	router.HandleFunc("/api/categories/{categoryId}/delete", categoryHandler.DeleteCategory).Methods("DELETE")
+}
+
+// initSearchRoutes sets up routes for full-text search over tenders and bids.
+func initSearchRoutes(router *mux.Router, searchService search_service.SearchService) {
+	searchHandler := search_handler.NewSearchHandler(searchService)
+
+	router.HandleFunc("/api/search/tenders", searchHandler.SearchTenders).Methods("GET")
+	router.HandleFunc("/api/search/bids", searchHandler.SearchBids).Methods("GET")
+}
+
+// initExportRoutes sets up routes for streaming exports of an organization's data.
+func initExportRoutes(router *mux.Router, orgService organization_service.OrganizationService, tenderService tender_service.TenderService, bidService bid_service.BidService) {
+	exportHandler := export_handler.NewExportHandler(orgService, tenderService, bidService)
+
+	router.HandleFunc("/api/organizations/{org_id}/export/tenders", exportHandler.ExportTenders).Methods("GET")
+	router.HandleFunc("/api/organizations/{org_id}/export/bids", exportHandler.ExportBids).Methods("GET")
+	router.HandleFunc("/api/organizations/{org_id}/export/decisions", exportHandler.ExportDecisions).Methods("GET")
+}
+
+// initAuditRoutes sets up routes for reading the audit log of changes.
+func initAuditRoutes(router *mux.Router, auditService audit_service.AuditService) {
+	auditHandler := audit_handler.NewAuditHandler(auditService)
+
+	router.HandleFunc("/api/audit", auditHandler.GetAuditEvents).Methods("GET")
+}
+
+// initWebhookRoutes sets up routes for managing the webhooks of an organization and
+// their deliveries.
+func initWebhookRoutes(router *mux.Router, webhookService webhook_service.WebhookService) {
+	webhookHandler := webhook_handler.NewWebhookHandler(webhookService)
+
+	router.HandleFunc("/api/organizations/{org_id}/webhooks/new", webhookHandler.CreateWebhook).Methods("POST")
+	router.HandleFunc("/api/organizations/{org_id}/webhooks", webhookHandler.GetWebhooks).Methods("GET")
+	router.HandleFunc("/api/organizations/{org_id}/webhooks/{webhook_id}", webhookHandler.GetWebhookByID).Methods("GET")
+	router.HandleFunc("/api/organizations/{org_id}/webhooks/{webhook_id}/edit", webhookHandler.UpdateWebhook).Methods("PATCH")
+	router.HandleFunc("/api/organizations/{org_id}/webhooks/{webhook_id}/delete", webhookHandler.DeleteWebhook).Methods("DELETE")
+	router.HandleFunc("/api/organizations/{org_id}/webhooks/{webhook_id}/deliveries", webhookHandler.GetDeliveries).Methods("GET")
+	router.HandleFunc("/api/organizations/{org_id}/webhooks/{webhook_id}/deliveries/{delivery_id}/redeliver", webhookHandler.Redeliver).Methods("POST")
+}
+
+// initCacheRoutes sets up routes for cache observability.
+func initCacheRoutes(router *mux.Router) {
+	router.HandleFunc("/api/cache/stats", cache_handler.CacheStatsHandler).Methods("GET")
+}
+
+// initMetricsRoutes sets up the route Prometheus scrapes the service metrics from.
+func initMetricsRoutes(router *mux.Router) {
+	router.Handle("/metrics", metrics_handler.MetricsHandler).Methods("GET")
+}
+
+// initDocsRoutes sets up routes for the API specification.
+func initDocsRoutes(router *mux.Router, doc *openapi3.T) {
+	openAPIHandler := openapi_handler.NewOpenAPIHandler(doc)
+
+	router.HandleFunc("/api/openapi.json", openAPIHandler.GetSpec).Methods("GET")
+	router.HandleFunc("/api/docs", openAPIHandler.SwaggerUI).Methods("GET")
+}
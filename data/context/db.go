@@ -3,11 +3,10 @@ package context
 import (
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
-
-	"avitoTest/data/entities"
 )
 
-// ConnectDB connects to PostgreSQL database and performs migrations
+// ConnectDB connects to PostgreSQL database. The schema is managed by the migrations
+// in data/migrations/sql, which are applied with the migrate command.
 func ConnectDB(dsn string) (*gorm.DB, error) {
 	// Opening a connection to the database
 	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{})
@@ -15,9 +14,8 @@ func ConnectDB(dsn string) (*gorm.DB, error) {
 		return nil, err
 	}
 
-	// Automatic creation of tables based on entities
-	err = db.AutoMigrate(&entities.User{}, &entities.Organization{}, &entities.OrganizationResponsible{}, &entities.Tender{}, &entities.Bid{})
-	if err != nil {
+	// Trace every query as a span of the request it is run for
+	if err := db.Use(TracingPlugin{}); err != nil {
 		return nil, err
 	}
 
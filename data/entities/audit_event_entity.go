@@ -0,0 +1,20 @@
+package entities
+
+import "time"
+
+// AuditEvent records a change made to an entity: who made it, in which request, and the
+// entity before and after the change as JSON. Events are never updated or deleted, and
+// outlive the entities they are about.
+type AuditEvent struct {
+	ID             int       `gorm:"primaryKey"`
+	EntityType     string    `gorm:"not null;size:50;index:idx_audit_events_entity"`
+	EntityID       int       `gorm:"not null;index:idx_audit_events_entity"`
+	OrganizationID *int      `gorm:"index"`
+	Action         string    `gorm:"not null;size:50"`
+	ActorUserID    *int      `gorm:"index"`
+	ActorClient    string    `gorm:"size:100"`
+	RequestID      string    `gorm:"size:128"`
+	Before         *string   `gorm:"type:jsonb"`
+	After          *string   `gorm:"type:jsonb"`
+	CreatedAt      time.Time `gorm:"not null;index"`
+}
@@ -0,0 +1,15 @@
+package entities
+
+import "time"
+
+// BidConflict represents a conflict of interest declared by a responsible for a Bid.
+// A user with a declared conflict cannot approve the bid and is excluded from its quorum.
+type BidConflict struct {
+	ID        int       `gorm:"primaryKey"`
+	BidID     int       `gorm:"not null;uniqueIndex:idx_bid_conflicts_bid_user"`
+	Bid       Bid       `gorm:"foreignKey:BidID;constraint:OnDelete:CASCADE;"`
+	UserID    int       `gorm:"not null;uniqueIndex:idx_bid_conflicts_bid_user"`
+	User      User      `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE;"`
+	Reason    string    `gorm:"size:255"`
+	CreatedAt time.Time `gorm:"autoCreateTime"`
+}
@@ -0,0 +1,14 @@
+package entities
+
+import "time"
+
+// BidDecision records an approval or a rejection of a Bid by a responsible of the bidding organization.
+type BidDecision struct {
+	ID        int       `gorm:"primaryKey"`
+	BidID     int       `gorm:"not null;index"`
+	Bid       Bid       `gorm:"foreignKey:BidID;constraint:OnDelete:CASCADE;"`
+	UserID    int       `gorm:"not null"`
+	User      User      `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE;"`
+	Decision  string    `gorm:"not null;size:50"`
+	CreatedAt time.Time `gorm:"autoCreateTime"`
+}
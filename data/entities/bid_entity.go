@@ -15,6 +15,7 @@ type Bid struct {
 	Creator        User         `gorm:"foreignKey:CreatorID;constraint:OnDelete:CASCADE;"`
 	ApprovalCount  int          `gorm:"not null;default:0"`
 	Versions       []BidVersion `gorm:"foreignKey:BidID;constraint:OnDelete:CASCADE;"`
+	CurrentVersion *BidVersion  `gorm:"foreignKey:BidID;constraint:-"`
 	Status         string       `gorm:"size:50"`
 	CreatedAt      time.Time    `gorm:"autoCreateTime"`
 }
@@ -22,10 +23,10 @@ type Bid struct {
 // BidVersion represents a version of a Bid.
 type BidVersion struct {
 	ID          int       `gorm:"primaryKey"`
-	BidID       int       `gorm:"not null"`
+	BidID       int       `gorm:"not null;index:idx_bid_versions_bid_id_version,priority:1"`
 	Bid         Bid       `gorm:"foreignKey:BidID"`
 	Name        string    `gorm:"not null;size:100"`
 	Description string    `gorm:"size:255"`
-	Version     int       `gorm:"not null"`
+	Version     int       `gorm:"not null;index:idx_bid_versions_bid_id_version,priority:2"`
 	UpdatedAt   time.Time `gorm:"autoUpdateTime"`
 }
@@ -0,0 +1,20 @@
+package entities
+
+import "time"
+
+// IdempotencyKey records a request made with an Idempotency-Key header and, once it has
+// completed, its response, which is replayed to retries of the request until ExpiresAt.
+// The key is unique per caller, named by Scope.
+type IdempotencyKey struct {
+	Scope       string    `gorm:"primaryKey;size:255"`
+	Key         string    `gorm:"primaryKey;size:255"`
+	Fingerprint string    `gorm:"not null;size:64"` // hash of the method, path and body of the request
+	Claim       string    `gorm:"not null;size:32"` // token of the request holding the key, which alone may complete or release it
+	Completed   bool      `gorm:"not null;default:false"`
+	Status      int       `gorm:"not null;default:0"`
+	Header      string    `gorm:"type:text"` // JSON of the headers set by the handler
+	Body        []byte    `gorm:"type:bytea"`
+	LockedUntil time.Time `gorm:"not null"` // a request still in progress after this time is abandoned
+	ExpiresAt   time.Time `gorm:"not null;index"`
+	CreatedAt   time.Time `gorm:"not null"`
+}
@@ -0,0 +1,32 @@
+package entities
+
+import "time"
+
+// NotificationPreference holds the address a User is emailed at and the events they opted
+// in to; EventTypes is a comma-separated list. Users without one receive no email.
+type NotificationPreference struct {
+	UserID     int       `gorm:"primaryKey"`
+	User       User      `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE;"`
+	Email      string    `gorm:"not null;size:254"`
+	EventTypes string    `gorm:"not null"`
+	UpdatedAt  time.Time `gorm:"autoUpdateTime"`
+}
+
+// Notification is an email in the outbox, rendered when the event happened and sent
+// in the background. Every attempt overwrites the error of the previous one.
+type Notification struct {
+	ID            int       `gorm:"primaryKey"`
+	UserID        int       `gorm:"not null;index"`
+	User          User      `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE;"`
+	EventType     string    `gorm:"not null;size:50"`
+	Recipient     string    `gorm:"not null;size:254"`
+	Subject       string    `gorm:"not null"`
+	Body          string    `gorm:"not null"`
+	Status        string    `gorm:"not null;size:20"`
+	Attempts      int       `gorm:"not null;default:0"`
+	NextAttemptAt time.Time `gorm:"not null"`
+	LastAttemptAt *time.Time
+	Error         string
+	SentAt        *time.Time
+	CreatedAt     time.Time `gorm:"not null"`
+}
@@ -16,6 +16,7 @@ type Organization struct {
 	Responsibles []User                     `gorm:"many2many:organization_responsibles;"`
 	Tenders      []Tender                   `gorm:"foreignKey:OrganizationID;constraint:OnDelete:CASCADE;"`
 	Comments     []Comment                  `gorm:"foreignKey:OrganizationID;constraint:OnDelete:CASCADE;"`
+	ServiceTypes []OrganizationServiceType  `gorm:"foreignKey:OrganizationID;constraint:OnDelete:CASCADE;"`
 }
 
 // OrganizationResponsible represents a link between an Organization and a User.
@@ -28,3 +29,10 @@ type OrganizationResponsible struct {
 	CreatedAt      time.Time    `gorm:"autoCreateTime"`
 	UpdatedAt      time.Time    `gorm:"autoUpdateTime"`
 }
+
+// OrganizationServiceType represents a service type provided by an Organization.
+type OrganizationServiceType struct {
+	ID             int    `gorm:"primaryKey"`
+	OrganizationID int    `gorm:"not null;index"`
+	ServiceType    string `gorm:"size:100;not null"`
+}
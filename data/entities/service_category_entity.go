@@ -0,0 +1,24 @@
+package entities
+
+import "time"
+
+// ServiceCategory represents a node of the service category taxonomy.
+// The Code is stored as the service type of tenders and organizations.
+type ServiceCategory struct {
+	ID        int                   `gorm:"primaryKey"`
+	Code      string                `gorm:"size:100;not null;uniqueIndex"`
+	ParentID  *int                  `gorm:"index"`
+	Parent    *ServiceCategory      `gorm:"foreignKey:ParentID;constraint:OnDelete:RESTRICT;"`
+	IsActive  bool                  `gorm:"not null"`
+	Names     []ServiceCategoryName `gorm:"foreignKey:CategoryID;constraint:OnDelete:CASCADE;"`
+	CreatedAt time.Time             `gorm:"autoCreateTime"`
+	UpdatedAt time.Time             `gorm:"autoUpdateTime"`
+}
+
+// ServiceCategoryName represents the localized name of a service category.
+type ServiceCategoryName struct {
+	ID         int    `gorm:"primaryKey"`
+	CategoryID int    `gorm:"not null;uniqueIndex:idx_service_category_names_category_language"`
+	Language   string `gorm:"size:10;not null;uniqueIndex:idx_service_category_names_category_language"`
+	Name       string `gorm:"size:255;not null"`
+}
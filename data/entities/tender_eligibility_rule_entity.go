@@ -0,0 +1,13 @@
+package entities
+
+import "time"
+
+// TenderEligibilityRule represents a restriction on which organizations may bid on a Tender.
+type TenderEligibilityRule struct {
+	ID        int       `gorm:"primaryKey"`
+	TenderID  int       `gorm:"not null;index"`
+	Tender    Tender    `gorm:"foreignKey:TenderID"`
+	RuleType  string    `gorm:"type:varchar(50);not null"`
+	Value     string    `gorm:"size:255;not null"`
+	CreatedAt time.Time `gorm:"autoCreateTime"`
+}
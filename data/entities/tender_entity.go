@@ -13,18 +13,22 @@ type Tender struct {
 	Creator        User            `gorm:"foreignKey:CreatorID;constraint:OnDelete:CASCADE;"`
 	Bids           []Bid           `gorm:"foreignKey:TenderID;constraint:OnDelete:CASCADE;"`
 	Versions       []TenderVersion `gorm:"foreignKey:TenderID;constraint:OnDelete:CASCADE;"`
+	CurrentVersion *TenderVersion  `gorm:"foreignKey:TenderID;constraint:-"`
 	Status         string          `gorm:"type:varchar(50);not null"`
 	ServiceType    string          `gorm:"size:100"`
-	CreatedAt      time.Time       `gorm:"autoCreateTime"`
+	Budget         *float64        `gorm:"type:numeric(15,2);index"`
+	CreatedAt      time.Time       `gorm:"autoCreateTime;index"`
+
+	EligibilityRules []TenderEligibilityRule `gorm:"foreignKey:TenderID;constraint:OnDelete:CASCADE;"`
 }
 
 // TenderVersion represents a version of a Tender.
 type TenderVersion struct {
 	ID          int       `gorm:"primaryKey"`
-	TenderID    int       `gorm:"not null"`
+	TenderID    int       `gorm:"not null;index:idx_tender_versions_tender_id_version,priority:1"`
 	Tender      Tender    `gorm:"foreignKey:TenderID"`
 	Name        string    `gorm:"not null;size:100"`
 	Description string    `gorm:"size:255"`
-	Version     int       `gorm:"not null"`
+	Version     int       `gorm:"not null;index:idx_tender_versions_tender_id_version,priority:2"`
 	UpdatedAt   time.Time `gorm:"autoUpdateTime"`
 }
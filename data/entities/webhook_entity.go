@@ -0,0 +1,39 @@
+package entities
+
+import "time"
+
+// Webhook is an endpoint of an Organization the events it subscribed to are delivered to.
+// EventTypes is a comma-separated list; Secret signs the payloads.
+type Webhook struct {
+	ID             int          `gorm:"primaryKey"`
+	OrganizationID int          `gorm:"not null;index"`
+	Organization   Organization `gorm:"foreignKey:OrganizationID;constraint:OnDelete:CASCADE;"`
+	URL            string       `gorm:"not null;size:2048"`
+	Secret         string       `gorm:"not null;size:100"`
+	EventTypes     string       `gorm:"not null"`
+	Active         bool         `gorm:"not null;default:true"`
+	CreatedAt      time.Time    `gorm:"autoCreateTime"`
+	UpdatedAt      time.Time    `gorm:"autoUpdateTime"`
+}
+
+// WebhookDelivery is an event queued for, or delivered to, a Webhook. Every attempt
+// overwrites the outcome of the previous one; a redelivery is a new delivery of the same
+// event.
+type WebhookDelivery struct {
+	ID             int       `gorm:"primaryKey"`
+	WebhookID      int       `gorm:"not null;index"`
+	Webhook        Webhook   `gorm:"foreignKey:WebhookID;constraint:OnDelete:CASCADE;"`
+	EventID        string    `gorm:"not null;size:64"`
+	EventType      string    `gorm:"not null;size:50"`
+	Payload        string    `gorm:"type:jsonb;not null"`
+	Status         string    `gorm:"not null;size:20"`
+	Attempts       int       `gorm:"not null;default:0"`
+	NextAttemptAt  time.Time `gorm:"not null"`
+	LastAttemptAt  *time.Time
+	ResponseStatus int
+	ResponseBody   string
+	Error          string
+	RedeliveryOf   *int
+	DeliveredAt    *time.Time
+	CreatedAt      time.Time `gorm:"not null"`
+}
@@ -1,8 +0,0 @@
-DROP TABLE IF EXISTS comments;
-DROP TABLE IF EXISTS bid_versions;
-DROP TABLE IF EXISTS bids;
-DROP TABLE IF EXISTS tender_versions;
-DROP TABLE IF EXISTS tenders;
-DROP TABLE IF EXISTS organization_responsibles;
-DROP TABLE IF EXISTS organizations;
-DROP TABLE IF EXISTS users;
@@ -1,76 +0,0 @@
-CREATE TABLE users (
-    id SERIAL PRIMARY KEY,
-    username VARCHAR(50) NOT NULL UNIQUE,
-    first_name VARCHAR(50),
-    last_name VARCHAR(50),
-    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
-    updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
-);
-
-CREATE TABLE organizations (
-    id SERIAL PRIMARY KEY,
-    name VARCHAR(100) NOT NULL,
-    description TEXT,
-    type VARCHAR(3) NOT NULL,
-    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
-    updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
-);
-
-CREATE TABLE organization_responsibles (
-    id SERIAL PRIMARY KEY,
-    organization_id INT NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
-    user_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
-    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
-    updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
-);
-
-CREATE TABLE tenders (
-    id SERIAL PRIMARY KEY,
-    organization_id INT NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
-    creator_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
-    status VARCHAR(50) NOT NULL,
-    service_type VARCHAR(100),
-    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
-);
-
-CREATE TABLE tender_versions (
-    id SERIAL PRIMARY KEY,
-    tender_id INT NOT NULL REFERENCES tenders(id) ON DELETE CASCADE,
-    name VARCHAR(100) NOT NULL,
-    description VARCHAR(255),
-    version INT NOT NULL,
-    updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
-);
-
-CREATE TABLE bids (
-    id SERIAL PRIMARY KEY,
-    tender_id INT NOT NULL REFERENCES tenders(id) ON DELETE CASCADE,
-    organization_id INT NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
-    creator_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
-    approval_count INT DEFAULT 0 NOT NULL,
-    status VARCHAR(50),
-    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
-);
-
-CREATE TABLE bid_versions (
-    id SERIAL PRIMARY KEY,
-    bid_id INT NOT NULL REFERENCES bids(id) ON DELETE CASCADE,
-    name VARCHAR(100) NOT NULL,
-    description VARCHAR(255),
-    version INT NOT NULL,
-    updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
-);
-
-CREATE TABLE comments (
-    id SERIAL PRIMARY KEY,
-    user_id INT NOT NULL REFERENCES users(id),
-    organization_id INT NOT NULL REFERENCES organizations(id),
-    company_name VARCHAR(100) NOT NULL,
-    tender_name VARCHAR(100) NOT NULL,
-    tender_description VARCHAR(255),
-    bid_description VARCHAR(255),
-    service_type VARCHAR(100),
-    content VARCHAR(500) NOT NULL,
-    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
-    updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
-);
@@ -0,0 +1,15 @@
+DROP TABLE IF EXISTS comments;
+DROP TABLE IF EXISTS bid_decisions;
+DROP TABLE IF EXISTS bid_conflicts;
+DROP TABLE IF EXISTS bid_versions;
+DROP TABLE IF EXISTS bids;
+DROP TABLE IF EXISTS tender_eligibility_rules;
+DROP TABLE IF EXISTS tender_versions;
+DROP TABLE IF EXISTS tenders;
+DROP TABLE IF EXISTS service_category_names;
+DROP TABLE IF EXISTS service_categories;
+DROP TABLE IF EXISTS organization_service_types;
+DROP TABLE IF EXISTS organization_responsibles;
+DROP TABLE IF EXISTS organizations;
+DROP TABLE IF EXISTS users;
+DROP TYPE IF EXISTS organization_type;
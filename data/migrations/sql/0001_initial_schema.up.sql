@@ -0,0 +1,168 @@
+-- The schema of the entities in data/entities. Statements are guarded with IF NOT EXISTS
+-- so databases created by the former AutoMigrate on startup can adopt the migrations.
+
+DO $$
+BEGIN
+    CREATE TYPE organization_type AS ENUM ('IE', 'LLC', 'JSC');
+EXCEPTION
+    WHEN duplicate_object THEN NULL;
+END
+$$;
+
+CREATE TABLE IF NOT EXISTS users (
+    id         bigserial PRIMARY KEY,
+    username   varchar(50) NOT NULL CONSTRAINT uni_users_username UNIQUE,
+    first_name varchar(50),
+    last_name  varchar(50),
+    created_at timestamptz,
+    updated_at timestamptz
+);
+
+CREATE TABLE IF NOT EXISTS organizations (
+    id          bigserial PRIMARY KEY,
+    name        varchar(100) NOT NULL,
+    description text,
+    type        organization_type,
+    created_at  timestamptz,
+    updated_at  timestamptz
+);
+
+CREATE TABLE IF NOT EXISTS organization_responsibles (
+    id              bigserial PRIMARY KEY,
+    organization_id bigint NOT NULL REFERENCES organizations (id) ON DELETE CASCADE,
+    user_id         bigint NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    created_at      timestamptz,
+    updated_at      timestamptz
+);
+CREATE INDEX IF NOT EXISTS idx_organization_responsibles_organization_id ON organization_responsibles (organization_id);
+CREATE INDEX IF NOT EXISTS idx_organization_responsibles_user_id ON organization_responsibles (user_id);
+
+CREATE TABLE IF NOT EXISTS organization_service_types (
+    id              bigserial PRIMARY KEY,
+    organization_id bigint NOT NULL REFERENCES organizations (id) ON DELETE CASCADE,
+    service_type    varchar(100) NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_organization_service_types_organization_id ON organization_service_types (organization_id);
+
+CREATE TABLE IF NOT EXISTS service_categories (
+    id         bigserial PRIMARY KEY,
+    code       varchar(100) NOT NULL,
+    parent_id  bigint REFERENCES service_categories (id) ON DELETE RESTRICT,
+    is_active  boolean NOT NULL,
+    created_at timestamptz,
+    updated_at timestamptz
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_service_categories_code ON service_categories (code);
+CREATE INDEX IF NOT EXISTS idx_service_categories_parent_id ON service_categories (parent_id);
+
+CREATE TABLE IF NOT EXISTS service_category_names (
+    id          bigserial PRIMARY KEY,
+    category_id bigint NOT NULL REFERENCES service_categories (id) ON DELETE CASCADE,
+    language    varchar(10) NOT NULL,
+    name        varchar(255) NOT NULL
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_service_category_names_category_language ON service_category_names (category_id, language);
+
+CREATE TABLE IF NOT EXISTS tenders (
+    id              bigserial PRIMARY KEY,
+    organization_id bigint NOT NULL REFERENCES organizations (id) ON DELETE CASCADE,
+    creator_id      bigint NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    status          varchar(50) NOT NULL,
+    service_type    varchar(100),
+    budget          numeric(15, 2),
+    created_at      timestamptz
+);
+CREATE INDEX IF NOT EXISTS idx_tenders_budget ON tenders (budget);
+CREATE INDEX IF NOT EXISTS idx_tenders_created_at ON tenders (created_at);
+
+-- The search columns are generated by PostgreSQL on every version insert
+CREATE TABLE IF NOT EXISTS tender_versions (
+    id          bigserial PRIMARY KEY,
+    tender_id   bigint NOT NULL REFERENCES tenders (id) ON DELETE CASCADE,
+    name        varchar(100) NOT NULL,
+    description varchar(255),
+    version     bigint NOT NULL,
+    updated_at  timestamptz,
+    search_ru   tsvector GENERATED ALWAYS AS (
+        setweight(to_tsvector('russian', coalesce(name, '')), 'A') ||
+        setweight(to_tsvector('russian', coalesce(description, '')), 'B')
+    ) STORED,
+    search_en   tsvector GENERATED ALWAYS AS (
+        setweight(to_tsvector('english', coalesce(name, '')), 'A') ||
+        setweight(to_tsvector('english', coalesce(description, '')), 'B')
+    ) STORED
+);
+CREATE INDEX IF NOT EXISTS idx_tender_versions_tender_id_version ON tender_versions (tender_id, version);
+CREATE INDEX IF NOT EXISTS idx_tender_versions_search_ru ON tender_versions USING GIN (search_ru);
+CREATE INDEX IF NOT EXISTS idx_tender_versions_search_en ON tender_versions USING GIN (search_en);
+
+CREATE TABLE IF NOT EXISTS tender_eligibility_rules (
+    id         bigserial PRIMARY KEY,
+    tender_id  bigint NOT NULL REFERENCES tenders (id) ON DELETE CASCADE,
+    rule_type  varchar(50) NOT NULL,
+    value      varchar(255) NOT NULL,
+    created_at timestamptz
+);
+CREATE INDEX IF NOT EXISTS idx_tender_eligibility_rules_tender_id ON tender_eligibility_rules (tender_id);
+
+CREATE TABLE IF NOT EXISTS bids (
+    id              bigserial PRIMARY KEY,
+    tender_id       bigint NOT NULL REFERENCES tenders (id) ON DELETE CASCADE,
+    organization_id bigint NOT NULL REFERENCES organizations (id) ON DELETE CASCADE,
+    creator_id      bigint NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    approval_count  bigint NOT NULL DEFAULT 0,
+    status          varchar(50),
+    created_at      timestamptz
+);
+
+CREATE TABLE IF NOT EXISTS bid_versions (
+    id          bigserial PRIMARY KEY,
+    bid_id      bigint NOT NULL REFERENCES bids (id) ON DELETE CASCADE,
+    name        varchar(100) NOT NULL,
+    description varchar(255),
+    version     bigint NOT NULL,
+    updated_at  timestamptz,
+    search_ru   tsvector GENERATED ALWAYS AS (
+        setweight(to_tsvector('russian', coalesce(name, '')), 'A') ||
+        setweight(to_tsvector('russian', coalesce(description, '')), 'B')
+    ) STORED,
+    search_en   tsvector GENERATED ALWAYS AS (
+        setweight(to_tsvector('english', coalesce(name, '')), 'A') ||
+        setweight(to_tsvector('english', coalesce(description, '')), 'B')
+    ) STORED
+);
+CREATE INDEX IF NOT EXISTS idx_bid_versions_bid_id_version ON bid_versions (bid_id, version);
+CREATE INDEX IF NOT EXISTS idx_bid_versions_search_ru ON bid_versions USING GIN (search_ru);
+CREATE INDEX IF NOT EXISTS idx_bid_versions_search_en ON bid_versions USING GIN (search_en);
+
+CREATE TABLE IF NOT EXISTS bid_conflicts (
+    id         bigserial PRIMARY KEY,
+    bid_id     bigint NOT NULL REFERENCES bids (id) ON DELETE CASCADE,
+    user_id    bigint NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    reason     varchar(255),
+    created_at timestamptz
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_bid_conflicts_bid_user ON bid_conflicts (bid_id, user_id);
+
+CREATE TABLE IF NOT EXISTS bid_decisions (
+    id         bigserial PRIMARY KEY,
+    bid_id     bigint NOT NULL REFERENCES bids (id) ON DELETE CASCADE,
+    user_id    bigint NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    decision   varchar(50) NOT NULL,
+    created_at timestamptz
+);
+CREATE INDEX IF NOT EXISTS idx_bid_decisions_bid_id ON bid_decisions (bid_id);
+
+CREATE TABLE IF NOT EXISTS comments (
+    id                 bigserial PRIMARY KEY,
+    user_id            bigint NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    organization_id    bigint NOT NULL REFERENCES organizations (id) ON DELETE CASCADE,
+    company_name       varchar(100) NOT NULL,
+    tender_name        varchar(100) NOT NULL,
+    tender_description varchar(255),
+    bid_description    varchar(255),
+    service_type       varchar(100),
+    content            varchar(500) NOT NULL,
+    created_at         timestamptz,
+    updated_at         timestamptz
+);
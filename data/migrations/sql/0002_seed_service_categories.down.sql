@@ -0,0 +1,3 @@
+DELETE FROM service_categories
+WHERE code IN ('Construction', 'IT Services', 'Consulting')
+  AND NOT EXISTS (SELECT 1 FROM service_categories child WHERE child.parent_id = service_categories.id);
@@ -0,0 +1,17 @@
+-- The initial service categories; the codes are the service types of tenders and organizations
+INSERT INTO service_categories (code, is_active, created_at, updated_at)
+VALUES ('Construction', true, now(), now()),
+       ('IT Services', true, now(), now()),
+       ('Consulting', true, now(), now())
+ON CONFLICT (code) DO NOTHING;
+
+INSERT INTO service_category_names (category_id, language, name)
+SELECT c.id, n.language, n.name
+FROM (VALUES ('Construction', 'ru', 'Строительство'),
+             ('Construction', 'en', 'Construction'),
+             ('IT Services', 'ru', 'ИТ-услуги'),
+             ('IT Services', 'en', 'IT Services'),
+             ('Consulting', 'ru', 'Консалтинг'),
+             ('Consulting', 'en', 'Consulting')) AS n (code, language, name)
+JOIN service_categories c ON c.code = n.code
+ON CONFLICT (category_id, language) DO NOTHING;
@@ -0,0 +1,17 @@
+-- Requests made with an Idempotency-Key header and the responses replayed to their retries
+CREATE TABLE idempotency_keys (
+    scope        varchar(255) NOT NULL,
+    key          varchar(255) NOT NULL,
+    fingerprint  varchar(64) NOT NULL,
+    claim        varchar(32) NOT NULL,
+    completed    boolean NOT NULL DEFAULT false,
+    status       bigint NOT NULL DEFAULT 0,
+    header       text,
+    body         bytea,
+    locked_until timestamptz NOT NULL,
+    expires_at   timestamptz NOT NULL,
+    created_at   timestamptz NOT NULL,
+    PRIMARY KEY (scope, key)
+);
+
+CREATE INDEX idx_idempotency_keys_expires_at ON idempotency_keys (expires_at);
@@ -0,0 +1,31 @@
+-- The append-only log of the changes made to tenders, bids, organizations, their
+-- responsibles, users and comments. It has no foreign keys so events outlive the
+-- entities they are about.
+CREATE TABLE audit_events (
+    id              bigserial PRIMARY KEY,
+    entity_type     varchar(50) NOT NULL,
+    entity_id       bigint NOT NULL,
+    organization_id bigint,
+    action          varchar(50) NOT NULL,
+    actor_user_id   bigint,
+    actor_client    varchar(100),
+    request_id      varchar(128),
+    before          jsonb,
+    after           jsonb,
+    created_at      timestamptz NOT NULL
+);
+
+CREATE INDEX idx_audit_events_entity ON audit_events (entity_type, entity_id);
+CREATE INDEX idx_audit_events_organization_id ON audit_events (organization_id);
+CREATE INDEX idx_audit_events_actor_user_id ON audit_events (actor_user_id);
+CREATE INDEX idx_audit_events_created_at ON audit_events (created_at);
+
+CREATE FUNCTION audit_events_append_only() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'audit_events is append-only';
+END
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER audit_events_append_only
+    BEFORE UPDATE OR DELETE OR TRUNCATE ON audit_events
+    FOR EACH STATEMENT EXECUTE FUNCTION audit_events_append_only();
@@ -0,0 +1,33 @@
+-- Webhook endpoints of organizations and the queue and history of the deliveries of
+-- events to them
+CREATE TABLE webhooks (
+    id              bigserial PRIMARY KEY,
+    organization_id bigint NOT NULL REFERENCES organizations (id) ON DELETE CASCADE,
+    url             varchar(2048) NOT NULL,
+    secret          varchar(100) NOT NULL,
+    event_types     text NOT NULL,
+    active          boolean NOT NULL DEFAULT true,
+    created_at      timestamptz,
+    updated_at      timestamptz
+);
+CREATE INDEX idx_webhooks_organization_id ON webhooks (organization_id);
+
+CREATE TABLE webhook_deliveries (
+    id              bigserial PRIMARY KEY,
+    webhook_id      bigint NOT NULL REFERENCES webhooks (id) ON DELETE CASCADE,
+    event_id        varchar(64) NOT NULL,
+    event_type      varchar(50) NOT NULL,
+    payload         jsonb NOT NULL,
+    status          varchar(20) NOT NULL,
+    attempts        bigint NOT NULL DEFAULT 0,
+    next_attempt_at timestamptz NOT NULL,
+    last_attempt_at timestamptz,
+    response_status bigint,
+    response_body   text,
+    error           text,
+    redelivery_of   bigint,
+    delivered_at    timestamptz,
+    created_at      timestamptz NOT NULL
+);
+CREATE INDEX idx_webhook_deliveries_webhook_id ON webhook_deliveries (webhook_id, created_at);
+CREATE INDEX idx_webhook_deliveries_pending ON webhook_deliveries (next_attempt_at) WHERE status = 'pending';
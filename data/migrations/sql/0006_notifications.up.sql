@@ -0,0 +1,25 @@
+-- Email notification preferences of users and the outbox of the emails sent to them
+CREATE TABLE notification_preferences (
+    user_id     bigint PRIMARY KEY REFERENCES users (id) ON DELETE CASCADE,
+    email       varchar(254) NOT NULL,
+    event_types text NOT NULL,
+    updated_at  timestamptz
+);
+
+CREATE TABLE notifications (
+    id              bigserial PRIMARY KEY,
+    user_id         bigint NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    event_type      varchar(50) NOT NULL,
+    recipient       varchar(254) NOT NULL,
+    subject         text NOT NULL,
+    body            text NOT NULL,
+    status          varchar(20) NOT NULL,
+    attempts        bigint NOT NULL DEFAULT 0,
+    next_attempt_at timestamptz NOT NULL,
+    last_attempt_at timestamptz,
+    error           text,
+    sent_at         timestamptz,
+    created_at      timestamptz NOT NULL
+);
+CREATE INDEX idx_notifications_user_id ON notifications (user_id, created_at);
+CREATE INDEX idx_notifications_pending ON notifications (next_attempt_at) WHERE status = 'pending';
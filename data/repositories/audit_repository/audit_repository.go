@@ -0,0 +1,13 @@
+package audit_repository
+
+import (
+	"avitoTest/data/entities"
+	"avitoTest/shared/filter"
+	"avitoTest/shared/pagination"
+	"context"
+)
+
+type AuditRepository interface {
+	Create(ctx context.Context, event *entities.AuditEvent) error
+	FindByFilters(ctx context.Context, f filter.Filter, params pagination.Params) ([]*entities.AuditEvent, int64, error)
+}
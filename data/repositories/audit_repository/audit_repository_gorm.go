@@ -0,0 +1,47 @@
+package audit_repository
+
+import (
+	"avitoTest/data/entities"
+	"avitoTest/data/repositories/repository_scopes"
+	"avitoTest/data/repositories/transaction"
+	"avitoTest/shared/constants"
+	"avitoTest/shared/filter"
+	"avitoTest/shared/pagination"
+	"context"
+
+	"gorm.io/gorm"
+)
+
+type auditRepositoryGorm struct {
+	db *gorm.DB
+}
+
+func NewAuditRepository(db *gorm.DB) AuditRepository {
+	return &auditRepositoryGorm{db: db}
+}
+
+func (r *auditRepositoryGorm) Create(ctx context.Context, event *entities.AuditEvent) error {
+	return transaction.DB(ctx, r.db).Create(event).Error
+}
+
+// auditSortColumns maps the audit event list sort keys to columns.
+var auditSortColumns = repository_scopes.SortColumns{
+	pagination.SortCreatedAt: "audit_events.created_at",
+}
+
+// auditFilterColumns maps the audit event filter fields to columns.
+var auditFilterColumns = repository_scopes.FilterColumns{
+	constants.FilterFieldEntityType:     "audit_events.entity_type",
+	constants.FilterFieldEntityID:       "audit_events.entity_id",
+	constants.FilterFieldAction:         "audit_events.action",
+	constants.FilterFieldOrganizationID: "audit_events.organization_id",
+	constants.FilterFieldUserID:         "audit_events.actor_user_id",
+	constants.FilterFieldRequestID:      "audit_events.request_id",
+	constants.FilterFieldCreatedAt:      "audit_events.created_at",
+}
+
+func (r *auditRepositoryGorm) FindByFilters(ctx context.Context, f filter.Filter, params pagination.Params) ([]*entities.AuditEvent, int64, error) {
+	query := transaction.DB(ctx, r.db).Model(&entities.AuditEvent{}).
+		Scopes(repository_scopes.Filter(f, auditFilterColumns))
+	return repository_scopes.FindPage[*entities.AuditEvent](query, params, auditSortColumns, "audit_events.id")
+}
@@ -0,0 +1,27 @@
+package audit_repository
+
+import (
+	"avitoTest/data/entities"
+	"avitoTest/shared/filter"
+	"avitoTest/shared/pagination"
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockAuditRepository struct {
+	mock.Mock
+}
+
+func (m *MockAuditRepository) Create(ctx context.Context, event *entities.AuditEvent) error {
+	args := m.Called(ctx, event)
+	return args.Error(0)
+}
+
+func (m *MockAuditRepository) FindByFilters(ctx context.Context, f filter.Filter, params pagination.Params) ([]*entities.AuditEvent, int64, error) {
+	args := m.Called(ctx, f, params)
+	if events, ok := args.Get(0).([]*entities.AuditEvent); ok {
+		return events, args.Get(1).(int64), args.Error(2)
+	}
+	return nil, 0, args.Error(2)
+}
@@ -2,6 +2,8 @@ package bid_repository
 
 import (
 	"avitoTest/data/entities"
+	"avitoTest/shared/filter"
+	"avitoTest/shared/pagination"
 	"context"
 )
 
@@ -9,11 +11,21 @@ type BidRepository interface {
 	Create(ctx context.Context, bid *entities.Bid) error
 	Update(ctx context.Context, bid *entities.Bid) error
 	FindByID(ctx context.Context, id int) (*entities.Bid, error)
-	FindByTenderID(ctx context.Context, tenderID int) ([]*entities.Bid, error)
-	FindByCreatorID(ctx context.Context, creatorID int) ([]*entities.Bid, error)
+	FindByTenderID(ctx context.Context, tenderID int, f filter.Filter, params pagination.Params) ([]*entities.Bid, int64, error)
+	FindByCreatorID(ctx context.Context, creatorID int, params pagination.Params) ([]*entities.Bid, int64, error)
+	StreamByTenderOrganizationID(ctx context.Context, orgID int, f filter.Filter, fn func(batch []*entities.Bid) error) error
 	FindByUsername(ctx context.Context, username string) ([]*entities.Bid, error)
 	FindLatestVersion(ctx context.Context, bidID int) (*entities.BidVersion, error)
 	FindVersionByNumber(ctx context.Context, bidID int, versionNumber int) (*entities.BidVersion, error)
 	CreateVersion(ctx context.Context, version *entities.BidVersion) error
 	Delete(ctx context.Context, bidID int) error
+
+	// Conflict of interest management
+	CreateConflict(ctx context.Context, conflict *entities.BidConflict) error
+	FindConflictsByBidID(ctx context.Context, bidID int) ([]*entities.BidConflict, error)
+
+	// Approval decisions
+	CreateDecision(ctx context.Context, decision *entities.BidDecision) error
+	HasDecision(ctx context.Context, bidID, userID int) (bool, error)
+	StreamDecisionsByTenderOrganizationID(ctx context.Context, orgID int, f filter.Filter, fn func(batch []*entities.BidDecision) error) error
 }
@@ -2,9 +2,17 @@ package bid_repository
 
 import (
 	"avitoTest/data/entities"
+	"avitoTest/data/repositories/repository_scopes"
+	"avitoTest/data/repositories/transaction"
+	"avitoTest/shared/constants"
+	"avitoTest/shared/errors/bid_errors"
+	"avitoTest/shared/filter"
+	"avitoTest/shared/pagination"
 	"context"
+	"errors"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type bidRepositoryGorm struct {
@@ -16,48 +24,80 @@ func NewBidRepository(db *gorm.DB) BidRepository {
 }
 
 func (r *bidRepositoryGorm) Create(ctx context.Context, bid *entities.Bid) error {
-	return r.db.WithContext(ctx).Create(bid).Error
+	return transaction.DB(ctx, r.db).Create(bid).Error
 }
 
 func (r *bidRepositoryGorm) Update(ctx context.Context, bid *entities.Bid) error {
-	return r.db.WithContext(ctx).Save(bid).Error
+	return transaction.DB(ctx, r.db).Omit(clause.Associations).Save(bid).Error
 }
 
 func (r *bidRepositoryGorm) FindByID(ctx context.Context, id int) (*entities.Bid, error) {
 	var bid entities.Bid
-	if err := r.db.WithContext(ctx).First(&bid, id).Error; err != nil {
+	if err := transaction.DB(ctx, r.db).First(&bid, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, bid_errors.ErrBidNotFound
+		}
 		return nil, err
 	}
 	return &bid, nil
 }
 
-func (r *bidRepositoryGorm) FindByTenderID(ctx context.Context, tenderID int) ([]*entities.Bid, error) {
-	var bids []*entities.Bid
-	if err := r.db.WithContext(ctx).Where("tender_id = ?", tenderID).Find(&bids).Error; err != nil {
-		return nil, err
-	}
-	return bids, nil
+// bidSortColumns maps the bid list sort keys to columns; the name is taken from the latest version.
+var bidSortColumns = repository_scopes.SortColumns{
+	pagination.SortCreatedAt: "bids.created_at",
+	pagination.SortName:      "(SELECT bv.name FROM bid_versions bv WHERE bv.bid_id = bids.id ORDER BY bv.version DESC LIMIT 1)",
+	pagination.SortStatus:    "bids.status",
 }
 
-func (r *bidRepositoryGorm) FindByCreatorID(ctx context.Context, creatorID int) ([]*entities.Bid, error) {
-	var bids []*entities.Bid
-	if err := r.db.WithContext(ctx).Where("creator_id = ?", creatorID).Find(&bids).Error; err != nil {
-		return nil, err
-	}
-	return bids, nil
+// preloadCurrentVersion loads the latest version of every bid in a single query.
+var preloadCurrentVersion = repository_scopes.PreloadCurrentVersion("CurrentVersion", "bid_versions", "bid_id")
+
+// bidFilterColumns maps the bid filter fields to columns.
+var bidFilterColumns = repository_scopes.FilterColumns{
+	constants.FilterFieldStatus:         "bids.status",
+	constants.FilterFieldOrganizationID: "bids.organization_id",
+	constants.FilterFieldCreatorID:      "bids.creator_id",
+	constants.FilterFieldCreatedAt:      "bids.created_at",
+	constants.FilterFieldName:           bidSortColumns[pagination.SortName],
+}
+
+func (r *bidRepositoryGorm) FindByTenderID(ctx context.Context, tenderID int, f filter.Filter, params pagination.Params) ([]*entities.Bid, int64, error) {
+	query := transaction.DB(ctx, r.db).Model(&entities.Bid{}).
+		Where("tender_id = ?", tenderID).
+		Scopes(repository_scopes.Filter(f, bidFilterColumns), preloadCurrentVersion)
+	return repository_scopes.FindPage[*entities.Bid](query, params, bidSortColumns, "bids.id")
+}
+
+func (r *bidRepositoryGorm) FindByCreatorID(ctx context.Context, creatorID int, params pagination.Params) ([]*entities.Bid, int64, error) {
+	query := transaction.DB(ctx, r.db).Model(&entities.Bid{}).
+		Where("creator_id = ?", creatorID).
+		Scopes(preloadCurrentVersion)
+	return repository_scopes.FindPage[*entities.Bid](query, params, bidSortColumns, "bids.id")
+}
+
+// tendersOfOrganization selects the IDs of the tenders of an organization.
+const tendersOfOrganization = "SELECT tenders.id FROM tenders WHERE tenders.organization_id = ?"
+
+// StreamByTenderOrganizationID passes the bids received by the tenders of an organization
+// matching the filter to fn in batches, each bid with its current version.
+func (r *bidRepositoryGorm) StreamByTenderOrganizationID(ctx context.Context, orgID int, f filter.Filter, fn func(batch []*entities.Bid) error) error {
+	query := transaction.DB(ctx, r.db).Model(&entities.Bid{}).
+		Where("bids.tender_id IN ("+tendersOfOrganization+")", orgID).
+		Scopes(repository_scopes.Filter(f, bidFilterColumns), preloadCurrentVersion)
+	return repository_scopes.StreamBatches(query, fn)
 }
 
 // FindByUsername finds all bids created by a user with the given username.
 func (r *bidRepositoryGorm) FindByUsername(ctx context.Context, username string) ([]*entities.Bid, error) {
 	// Step 1: Find the user by username
 	var user entities.User
-	if err := r.db.WithContext(ctx).Where("username = ?", username).First(&user).Error; err != nil {
+	if err := transaction.DB(ctx, r.db).Where("username = ?", username).First(&user).Error; err != nil {
 		return nil, err
 	}
 
 	// Step 2: Fetch bids created by the user
 	var bids []*entities.Bid
-	if err := r.db.WithContext(ctx).Where("creator_id = ?", user.ID).Find(&bids).Error; err != nil {
+	if err := transaction.DB(ctx, r.db).Where("creator_id = ?", user.ID).Find(&bids).Error; err != nil {
 		return nil, err
 	}
 
@@ -66,7 +106,10 @@ func (r *bidRepositoryGorm) FindByUsername(ctx context.Context, username string)
 
 func (r *bidRepositoryGorm) FindLatestVersion(ctx context.Context, bidID int) (*entities.BidVersion, error) {
 	var version entities.BidVersion
-	if err := r.db.WithContext(ctx).Where("bid_id = ?", bidID).Order("version DESC").First(&version).Error; err != nil {
+	if err := transaction.DB(ctx, r.db).Where("bid_id = ?", bidID).Order("version DESC").First(&version).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, bid_errors.ErrBidVersionNotFound
+		}
 		return nil, err
 	}
 	return &version, nil
@@ -74,16 +117,65 @@ func (r *bidRepositoryGorm) FindLatestVersion(ctx context.Context, bidID int) (*
 
 func (r *bidRepositoryGorm) FindVersionByNumber(ctx context.Context, bidID int, versionNumber int) (*entities.BidVersion, error) {
 	var version entities.BidVersion
-	if err := r.db.WithContext(ctx).Where("bid_id = ? AND version = ?", bidID, versionNumber).First(&version).Error; err != nil {
+	if err := transaction.DB(ctx, r.db).Where("bid_id = ? AND version = ?", bidID, versionNumber).First(&version).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, bid_errors.ErrBidVersionNotFound
+		}
 		return nil, err
 	}
 	return &version, nil
 }
 
 func (r *bidRepositoryGorm) CreateVersion(ctx context.Context, version *entities.BidVersion) error {
-	return r.db.WithContext(ctx).Create(version).Error
+	return transaction.DB(ctx, r.db).Create(version).Error
 }
 
 func (r *bidRepositoryGorm) Delete(ctx context.Context, bidID int) error {
-	return r.db.WithContext(ctx).Delete(&entities.Bid{}, bidID).Error
+	return transaction.DB(ctx, r.db).Delete(&entities.Bid{}, bidID).Error
+}
+
+// CreateConflict records a conflict of interest declared for a bid.
+func (r *bidRepositoryGorm) CreateConflict(ctx context.Context, conflict *entities.BidConflict) error {
+	return transaction.DB(ctx, r.db).Create(conflict).Error
+}
+
+// FindConflictsByBidID returns all conflicts of interest declared for a bid.
+func (r *bidRepositoryGorm) FindConflictsByBidID(ctx context.Context, bidID int) ([]*entities.BidConflict, error) {
+	var conflicts []*entities.BidConflict
+	if err := transaction.DB(ctx, r.db).Where("bid_id = ?", bidID).Order("created_at").Find(&conflicts).Error; err != nil {
+		return nil, err
+	}
+	return conflicts, nil
+}
+
+// CreateDecision records an approval or a rejection of a bid.
+func (r *bidRepositoryGorm) CreateDecision(ctx context.Context, decision *entities.BidDecision) error {
+	return transaction.DB(ctx, r.db).Create(decision).Error
+}
+
+// HasDecision checks whether a user has already approved or rejected a bid.
+func (r *bidRepositoryGorm) HasDecision(ctx context.Context, bidID, userID int) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&entities.BidDecision{}).
+		Where("bid_id = ? AND user_id = ?", bidID, userID).Count(&count).Error
+	return count > 0, err
+}
+
+// decisionFilterColumns maps the decision filter fields to columns.
+var decisionFilterColumns = repository_scopes.FilterColumns{
+	constants.FilterFieldDecision:  "bid_decisions.decision",
+	constants.FilterFieldBidID:     "bid_decisions.bid_id",
+	constants.FilterFieldTenderID:  "(SELECT bids.tender_id FROM bids WHERE bids.id = bid_decisions.bid_id)",
+	constants.FilterFieldUserID:    "bid_decisions.user_id",
+	constants.FilterFieldCreatedAt: "bid_decisions.created_at",
+}
+
+// StreamDecisionsByTenderOrganizationID passes the decisions made on the bids received by the
+// tenders of an organization matching the filter to fn in batches, each decision with its bid.
+func (r *bidRepositoryGorm) StreamDecisionsByTenderOrganizationID(ctx context.Context, orgID int, f filter.Filter, fn func(batch []*entities.BidDecision) error) error {
+	query := transaction.DB(ctx, r.db).Model(&entities.BidDecision{}).
+		Where("bid_decisions.bid_id IN (SELECT bids.id FROM bids WHERE bids.tender_id IN ("+tendersOfOrganization+"))", orgID).
+		Scopes(repository_scopes.Filter(f, decisionFilterColumns)).
+		Preload("Bid")
+	return repository_scopes.StreamBatches(query, fn)
 }
@@ -2,6 +2,8 @@ package bid_repository
 
 import (
 	"avitoTest/data/entities"
+	"avitoTest/shared/filter"
+	"avitoTest/shared/pagination"
 	"context"
 
 	"github.com/stretchr/testify/mock"
@@ -34,21 +36,34 @@ func (m *MockBidRepository) FindByID(ctx context.Context, id int) (*entities.Bid
 }
 
 // FindByTenderID mocks finding Bids by Tender ID.
-func (m *MockBidRepository) FindByTenderID(ctx context.Context, tenderID int) ([]*entities.Bid, error) {
-	args := m.Called(ctx, tenderID)
+func (m *MockBidRepository) FindByTenderID(ctx context.Context, tenderID int, f filter.Filter, params pagination.Params) ([]*entities.Bid, int64, error) {
+	args := m.Called(ctx, tenderID, f, params)
 	if bids, ok := args.Get(0).([]*entities.Bid); ok {
-		return bids, args.Error(1)
+		return bids, args.Get(1).(int64), args.Error(2)
 	}
-	return nil, args.Error(1)
+	return nil, 0, args.Error(2)
+}
+
+// StreamByTenderOrganizationID passes the mocked batches of bids to fn.
+func (m *MockBidRepository) StreamByTenderOrganizationID(ctx context.Context, orgID int, f filter.Filter, fn func(batch []*entities.Bid) error) error {
+	args := m.Called(ctx, orgID, f)
+	if batches, ok := args.Get(0).([][]*entities.Bid); ok {
+		for _, batch := range batches {
+			if err := fn(batch); err != nil {
+				return err
+			}
+		}
+	}
+	return args.Error(1)
 }
 
 // FindByCreatorID mocks finding Bids by the Creator ID.
-func (m *MockBidRepository) FindByCreatorID(ctx context.Context, creatorID int) ([]*entities.Bid, error) {
-	args := m.Called(ctx, creatorID)
+func (m *MockBidRepository) FindByCreatorID(ctx context.Context, creatorID int, params pagination.Params) ([]*entities.Bid, int64, error) {
+	args := m.Called(ctx, creatorID, params)
 	if bids, ok := args.Get(0).([]*entities.Bid); ok {
-		return bids, args.Error(1)
+		return bids, args.Get(1).(int64), args.Error(2)
 	}
-	return nil, args.Error(1)
+	return nil, 0, args.Error(2)
 }
 
 // FindLatestVersion mocks finding the latest version of a Bid.
@@ -89,3 +104,43 @@ func (m *MockBidRepository) Delete(ctx context.Context, bidID int) error {
 	args := m.Called(ctx, bidID)
 	return args.Error(0)
 }
+
+// CreateConflict mocks recording a conflict of interest for a Bid.
+func (m *MockBidRepository) CreateConflict(ctx context.Context, conflict *entities.BidConflict) error {
+	args := m.Called(ctx, conflict)
+	return args.Error(0)
+}
+
+// FindConflictsByBidID mocks finding the conflicts of interest declared for a Bid.
+func (m *MockBidRepository) FindConflictsByBidID(ctx context.Context, bidID int) ([]*entities.BidConflict, error) {
+	args := m.Called(ctx, bidID)
+	if conflicts, ok := args.Get(0).([]*entities.BidConflict); ok {
+		return conflicts, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+// CreateDecision mocks recording an approval or a rejection of a Bid.
+func (m *MockBidRepository) CreateDecision(ctx context.Context, decision *entities.BidDecision) error {
+	args := m.Called(ctx, decision)
+	return args.Error(0)
+}
+
+// HasDecision mocks checking whether a user has already decided on a Bid.
+func (m *MockBidRepository) HasDecision(ctx context.Context, bidID, userID int) (bool, error) {
+	args := m.Called(ctx, bidID, userID)
+	return args.Bool(0), args.Error(1)
+}
+
+// StreamDecisionsByTenderOrganizationID passes the mocked batches of decisions to fn.
+func (m *MockBidRepository) StreamDecisionsByTenderOrganizationID(ctx context.Context, orgID int, f filter.Filter, fn func(batch []*entities.BidDecision) error) error {
+	args := m.Called(ctx, orgID, f)
+	if batches, ok := args.Get(0).([][]*entities.BidDecision); ok {
+		for _, batch := range batches {
+			if err := fn(batch); err != nil {
+				return err
+			}
+		}
+	}
+	return args.Error(1)
+}
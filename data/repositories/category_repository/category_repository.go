@@ -0,0 +1,21 @@
+package category_repository
+
+import (
+	"avitoTest/data/entities"
+	"context"
+)
+
+type CategoryRepository interface {
+	Create(ctx context.Context, category *entities.ServiceCategory) error
+	Update(ctx context.Context, category *entities.ServiceCategory) error
+	Delete(ctx context.Context, id int) error
+	FindByID(ctx context.Context, id int) (*entities.ServiceCategory, error)
+	FindByCode(ctx context.Context, code string) (*entities.ServiceCategory, error)
+	GetAll(ctx context.Context, includeInactive bool) ([]*entities.ServiceCategory, error)
+
+	// Taxonomy helpers
+	CountChildren(ctx context.Context, id int) (int64, error)
+	FindSubtreeCodes(ctx context.Context, id int) ([]string, error)
+	IsActiveCode(ctx context.Context, code string) (bool, error)
+	IsCodeInUse(ctx context.Context, code string) (bool, error)
+}
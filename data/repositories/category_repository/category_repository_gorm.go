@@ -0,0 +1,124 @@
+package category_repository
+
+import (
+	"avitoTest/data/entities"
+	"avitoTest/data/repositories/transaction"
+	"avitoTest/shared/constants"
+	"avitoTest/shared/errors/category_errors"
+	"context"
+	"errors"
+
+	"gorm.io/gorm"
+)
+
+type categoryRepositoryGorm struct {
+	db *gorm.DB
+}
+
+func NewCategoryRepository(db *gorm.DB) CategoryRepository {
+	return &categoryRepositoryGorm{db: db}
+}
+
+// Create inserts a new service category together with its localized names.
+func (r *categoryRepositoryGorm) Create(ctx context.Context, category *entities.ServiceCategory) error {
+	return transaction.DB(ctx, r.db).Create(category).Error
+}
+
+// Update saves a service category and replaces its localized names.
+func (r *categoryRepositoryGorm) Update(ctx context.Context, category *entities.ServiceCategory) error {
+	return transaction.DB(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("category_id = ?", category.ID).Delete(&entities.ServiceCategoryName{}).Error; err != nil {
+			return err
+		}
+		for i := range category.Names {
+			category.Names[i].ID = 0
+			category.Names[i].CategoryID = category.ID
+		}
+		return tx.Omit("Parent").Save(category).Error
+	})
+}
+
+// Delete removes a service category by its ID.
+func (r *categoryRepositoryGorm) Delete(ctx context.Context, id int) error {
+	return transaction.DB(ctx, r.db).Delete(&entities.ServiceCategory{}, id).Error
+}
+
+// FindByID retrieves a service category with its localized names.
+func (r *categoryRepositoryGorm) FindByID(ctx context.Context, id int) (*entities.ServiceCategory, error) {
+	var category entities.ServiceCategory
+	if err := transaction.DB(ctx, r.db).Preload("Names").First(&category, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, category_errors.ErrCategoryNotFound
+		}
+		return nil, err
+	}
+	return &category, nil
+}
+
+// FindByCode retrieves a service category by its code.
+func (r *categoryRepositoryGorm) FindByCode(ctx context.Context, code string) (*entities.ServiceCategory, error) {
+	var category entities.ServiceCategory
+	if err := transaction.DB(ctx, r.db).Preload("Names").Where("code = ?", code).First(&category).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, category_errors.ErrCategoryNotFound
+		}
+		return nil, err
+	}
+	return &category, nil
+}
+
+// GetAll retrieves the service categories, optionally including inactive ones.
+func (r *categoryRepositoryGorm) GetAll(ctx context.Context, includeInactive bool) ([]*entities.ServiceCategory, error) {
+	var categories []*entities.ServiceCategory
+	query := transaction.DB(ctx, r.db).Preload("Names").Order("id")
+	if !includeInactive {
+		query = query.Where("is_active = ?", true)
+	}
+	if err := query.Find(&categories).Error; err != nil {
+		return nil, err
+	}
+	return categories, nil
+}
+
+// CountChildren returns the number of direct subcategories of a category.
+func (r *categoryRepositoryGorm) CountChildren(ctx context.Context, id int) (int64, error) {
+	var count int64
+	err := transaction.DB(ctx, r.db).Model(&entities.ServiceCategory{}).Where("parent_id = ?", id).Count(&count).Error
+	return count, err
+}
+
+// FindSubtreeCodes returns the codes of a category and all of its descendants.
+func (r *categoryRepositoryGorm) FindSubtreeCodes(ctx context.Context, id int) ([]string, error) {
+	var codes []string
+	err := transaction.DB(ctx, r.db).Raw(`
+		WITH RECURSIVE subtree AS (
+			SELECT id, code FROM service_categories WHERE id = ?
+			UNION ALL
+			SELECT c.id, c.code FROM service_categories c JOIN subtree s ON c.parent_id = s.id
+		)
+		SELECT code FROM subtree`, id).Scan(&codes).Error
+	if err != nil {
+		return nil, err
+	}
+	return codes, nil
+}
+
+// IsActiveCode checks whether an active service category with the given code exists.
+func (r *categoryRepositoryGorm) IsActiveCode(ctx context.Context, code string) (bool, error) {
+	var count int64
+	err := transaction.DB(ctx, r.db).Model(&entities.ServiceCategory{}).
+		Where("code = ? AND is_active = ?", code, true).Count(&count).Error
+	return count > 0, err
+}
+
+// IsCodeInUse checks whether a tender, an organization or an eligibility rule refers to
+// the service category with the given code.
+func (r *categoryRepositoryGorm) IsCodeInUse(ctx context.Context, code string) (bool, error) {
+	var inUse bool
+	err := transaction.DB(ctx, r.db).Raw(`
+		SELECT EXISTS (SELECT 1 FROM tenders WHERE service_type = @code)
+			OR EXISTS (SELECT 1 FROM organization_service_types WHERE service_type = @code)
+			OR EXISTS (SELECT 1 FROM tender_eligibility_rules WHERE rule_type = @rule AND value = @code)`,
+		map[string]interface{}{"code": code, "rule": constants.EligibilityServiceType}).Scan(&inUse).Error
+	return inUse, err
+}
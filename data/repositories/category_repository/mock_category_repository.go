@@ -0,0 +1,74 @@
+package category_repository
+
+import (
+	"avitoTest/data/entities"
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockCategoryRepository struct {
+	mock.Mock
+}
+
+func (m *MockCategoryRepository) Create(ctx context.Context, category *entities.ServiceCategory) error {
+	args := m.Called(ctx, category)
+	return args.Error(0)
+}
+
+func (m *MockCategoryRepository) Update(ctx context.Context, category *entities.ServiceCategory) error {
+	args := m.Called(ctx, category)
+	return args.Error(0)
+}
+
+func (m *MockCategoryRepository) Delete(ctx context.Context, id int) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockCategoryRepository) FindByID(ctx context.Context, id int) (*entities.ServiceCategory, error) {
+	args := m.Called(ctx, id)
+	if category, ok := args.Get(0).(*entities.ServiceCategory); ok {
+		return category, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockCategoryRepository) FindByCode(ctx context.Context, code string) (*entities.ServiceCategory, error) {
+	args := m.Called(ctx, code)
+	if category, ok := args.Get(0).(*entities.ServiceCategory); ok {
+		return category, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockCategoryRepository) GetAll(ctx context.Context, includeInactive bool) ([]*entities.ServiceCategory, error) {
+	args := m.Called(ctx, includeInactive)
+	if categories, ok := args.Get(0).([]*entities.ServiceCategory); ok {
+		return categories, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockCategoryRepository) CountChildren(ctx context.Context, id int) (int64, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockCategoryRepository) FindSubtreeCodes(ctx context.Context, id int) ([]string, error) {
+	args := m.Called(ctx, id)
+	if codes, ok := args.Get(0).([]string); ok {
+		return codes, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockCategoryRepository) IsActiveCode(ctx context.Context, code string) (bool, error) {
+	args := m.Called(ctx, code)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockCategoryRepository) IsCodeInUse(ctx context.Context, code string) (bool, error) {
+	args := m.Called(ctx, code)
+	return args.Bool(0), args.Error(1)
+}
@@ -2,11 +2,13 @@ package comment_repository
 
 import (
 	"avitoTest/data/entities"
+	"avitoTest/shared/pagination"
 	"context"
 )
 
 type CommentRepository interface {
 	Create(ctx context.Context, comment *entities.Comment) error
-	FindByFilters(ctx context.Context, authorUsername string, organizationID int) ([]*entities.Comment, error)
+	FindByID(ctx context.Context, id int) (*entities.Comment, error)
+	FindByFilters(ctx context.Context, authorUsername string, organizationID int, params pagination.Params) ([]*entities.Comment, int64, error)
 	Delete(ctx context.Context, id int) error
 }
@@ -2,11 +2,18 @@ package comment_repository
 
 import (
 	"avitoTest/data/entities"
+	"avitoTest/data/repositories/repository_scopes"
+	"avitoTest/data/repositories/transaction"
+	"avitoTest/shared/errors/domain_errors"
+	"avitoTest/shared/pagination"
 	"context"
+	"errors"
 
 	"gorm.io/gorm"
 )
 
+var ErrCommentNotFound = domain_errors.New(domain_errors.ErrNotFound, "comment not found")
+
 type commentRepositoryGorm struct {
 	db *gorm.DB
 }
@@ -16,25 +23,36 @@ func NewCommentRepository(db *gorm.DB) CommentRepository {
 }
 
 func (r *commentRepositoryGorm) Create(ctx context.Context, comment *entities.Comment) error {
-	return r.db.WithContext(ctx).Create(comment).Error
+	return transaction.DB(ctx, r.db).Create(comment).Error
 }
 
-func (r *commentRepositoryGorm) FindByFilters(ctx context.Context, authorUsername string, organizationID int) ([]*entities.Comment, error) {
-	var comments []*entities.Comment
+func (r *commentRepositoryGorm) FindByID(ctx context.Context, id int) (*entities.Comment, error) {
+	var comment entities.Comment
+	if err := transaction.DB(ctx, r.db).First(&comment, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrCommentNotFound
+		}
+		return nil, err
+	}
+	return &comment, nil
+}
 
-	query := r.db.WithContext(ctx).Where("user_id = (SELECT id FROM users WHERE username = ?)", authorUsername)
+var commentSortColumns = repository_scopes.SortColumns{
+	pagination.SortCreatedAt: "comments.created_at",
+	pagination.SortName:      "comments.tender_name",
+}
+
+func (r *commentRepositoryGorm) FindByFilters(ctx context.Context, authorUsername string, organizationID int, params pagination.Params) ([]*entities.Comment, int64, error) {
+	query := transaction.DB(ctx, r.db).Model(&entities.Comment{}).
+		Where("user_id = (SELECT id FROM users WHERE username = ?)", authorUsername)
 
 	if organizationID > 0 {
 		query = query.Where("organization_id = ?", organizationID)
 	}
 
-	if err := query.Find(&comments).Error; err != nil {
-		return nil, err
-	}
-
-	return comments, nil
+	return repository_scopes.FindPage[*entities.Comment](query, params, commentSortColumns, "comments.id")
 }
 
 func (r *commentRepositoryGorm) Delete(ctx context.Context, id int) error {
-	return r.db.WithContext(ctx).Delete(&entities.Comment{}, id).Error
+	return transaction.DB(ctx, r.db).Delete(&entities.Comment{}, id).Error
 }
@@ -2,6 +2,7 @@ package comment_repository
 
 import (
 	"avitoTest/data/entities"
+	"avitoTest/shared/pagination"
 	"context"
 
 	"github.com/stretchr/testify/mock"
@@ -16,14 +17,22 @@ func (m *MockCommentRepository) Create(ctx context.Context, comment *entities.Co
 	return args.Error(0)
 }
 
-func (m *MockCommentRepository) FindByFilters(ctx context.Context, authorUsername string, organizationID int) ([]*entities.Comment, error) {
-	args := m.Called(ctx, authorUsername, organizationID)
-	if comments, ok := args.Get(0).([]*entities.Comment); ok {
-		return comments, args.Error(1)
+func (m *MockCommentRepository) FindByID(ctx context.Context, id int) (*entities.Comment, error) {
+	args := m.Called(ctx, id)
+	if comment, ok := args.Get(0).(*entities.Comment); ok {
+		return comment, args.Error(1)
 	}
 	return nil, args.Error(1)
 }
 
+func (m *MockCommentRepository) FindByFilters(ctx context.Context, authorUsername string, organizationID int, params pagination.Params) ([]*entities.Comment, int64, error) {
+	args := m.Called(ctx, authorUsername, organizationID, params)
+	if comments, ok := args.Get(0).([]*entities.Comment); ok {
+		return comments, args.Get(1).(int64), args.Error(2)
+	}
+	return nil, 0, args.Error(2)
+}
+
 func (m *MockCommentRepository) Delete(ctx context.Context, id int) error {
 	args := m.Called(ctx, id)
 	return args.Error(0)
@@ -0,0 +1,20 @@
+package idempotency_repository
+
+import (
+	"avitoTest/data/entities"
+	"context"
+	"time"
+)
+
+type IdempotencyRepository interface {
+	// Claim stores record unless its key is already taken by a request that has not expired
+	// or, while in progress, been abandoned. It returns whether record was stored, and the
+	// record holding the key otherwise.
+	Claim(ctx context.Context, record *entities.IdempotencyKey) (*entities.IdempotencyKey, bool, error)
+	// Complete stores the response of the request holding claim on the key. It fails with
+	// ErrClaimLost when another request has taken the key over in the meantime.
+	Complete(ctx context.Context, scope, key, claim string, status int, header string, body []byte) error
+	// Release frees the key unless another request has taken it over.
+	Release(ctx context.Context, scope, key, claim string) error
+	DeleteExpired(ctx context.Context, now time.Time) (int64, error)
+}
@@ -0,0 +1,75 @@
+package idempotency_repository
+
+import (
+	"avitoTest/data/entities"
+	"avitoTest/shared/errors/idempotency_errors"
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type idempotencyRepositoryGorm struct {
+	db *gorm.DB
+}
+
+func NewIdempotencyRepository(db *gorm.DB) IdempotencyRepository {
+	return &idempotencyRepositoryGorm{db: db}
+}
+
+func (r *idempotencyRepositoryGorm) Claim(ctx context.Context, record *entities.IdempotencyKey) (*entities.IdempotencyKey, bool, error) {
+	db := r.db.WithContext(ctx)
+
+	result := db.Clauses(clause.OnConflict{DoNothing: true}).Create(record)
+	if result.Error != nil {
+		return nil, false, result.Error
+	}
+	if result.RowsAffected == 1 {
+		return nil, true, nil
+	}
+
+	// Take over a key whose response has expired or whose request was abandoned, such as
+	// by an instance that stopped while serving it
+	result = db.Model(&entities.IdempotencyKey{}).
+		Where("scope = ? AND key = ?", record.Scope, record.Key).
+		Where("expires_at <= ? OR (NOT completed AND locked_until <= ?)", record.CreatedAt, record.CreatedAt).
+		Select("*").
+		Updates(record)
+	if result.Error != nil {
+		return nil, false, result.Error
+	}
+	if result.RowsAffected == 1 {
+		return nil, true, nil
+	}
+
+	var existing entities.IdempotencyKey
+	if err := db.Where("scope = ? AND key = ?", record.Scope, record.Key).First(&existing).Error; err != nil {
+		return nil, false, err
+	}
+	return &existing, false, nil
+}
+
+func (r *idempotencyRepositoryGorm) Complete(ctx context.Context, scope, key, claim string, status int, header string, body []byte) error {
+	result := r.db.WithContext(ctx).Model(&entities.IdempotencyKey{}).
+		Where("scope = ? AND key = ? AND claim = ? AND NOT completed", scope, key, claim).
+		Updates(map[string]any{"completed": true, "status": status, "header": header, "body": body})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return idempotency_errors.ErrClaimLost
+	}
+	return nil
+}
+
+func (r *idempotencyRepositoryGorm) Release(ctx context.Context, scope, key, claim string) error {
+	return r.db.WithContext(ctx).
+		Where("scope = ? AND key = ? AND claim = ? AND NOT completed", scope, key, claim).
+		Delete(&entities.IdempotencyKey{}).Error
+}
+
+func (r *idempotencyRepositoryGorm) DeleteExpired(ctx context.Context, now time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).Where("expires_at <= ?", now).Delete(&entities.IdempotencyKey{})
+	return result.RowsAffected, result.Error
+}
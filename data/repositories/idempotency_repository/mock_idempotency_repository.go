@@ -0,0 +1,36 @@
+package idempotency_repository
+
+import (
+	"avitoTest/data/entities"
+	"context"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockIdempotencyRepository struct {
+	mock.Mock
+}
+
+func (m *MockIdempotencyRepository) Claim(ctx context.Context, record *entities.IdempotencyKey) (*entities.IdempotencyKey, bool, error) {
+	args := m.Called(ctx, record)
+	if existing, ok := args.Get(0).(*entities.IdempotencyKey); ok {
+		return existing, args.Bool(1), args.Error(2)
+	}
+	return nil, args.Bool(1), args.Error(2)
+}
+
+func (m *MockIdempotencyRepository) Complete(ctx context.Context, scope, key, claim string, status int, header string, body []byte) error {
+	args := m.Called(ctx, scope, key, claim, status, header, body)
+	return args.Error(0)
+}
+
+func (m *MockIdempotencyRepository) Release(ctx context.Context, scope, key, claim string) error {
+	args := m.Called(ctx, scope, key, claim)
+	return args.Error(0)
+}
+
+func (m *MockIdempotencyRepository) DeleteExpired(ctx context.Context, now time.Time) (int64, error) {
+	args := m.Called(ctx, now)
+	return args.Get(0).(int64), args.Error(1)
+}
@@ -0,0 +1,52 @@
+package notification_repository
+
+import (
+	"avitoTest/data/entities"
+	"context"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockNotificationRepository struct {
+	mock.Mock
+}
+
+func (m *MockNotificationRepository) FindPreferences(ctx context.Context, userID int) (*entities.NotificationPreference, error) {
+	args := m.Called(ctx, userID)
+	if preferences, ok := args.Get(0).(*entities.NotificationPreference); ok {
+		return preferences, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockNotificationRepository) SavePreferences(ctx context.Context, preferences *entities.NotificationPreference) error {
+	args := m.Called(ctx, preferences)
+	return args.Error(0)
+}
+
+func (m *MockNotificationRepository) FindOptedIn(ctx context.Context, userIDs []int, eventType string) ([]*entities.NotificationPreference, error) {
+	args := m.Called(ctx, userIDs, eventType)
+	if preferences, ok := args.Get(0).([]*entities.NotificationPreference); ok {
+		return preferences, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockNotificationRepository) CreateNotifications(ctx context.Context, notifications []*entities.Notification) error {
+	args := m.Called(ctx, notifications)
+	return args.Error(0)
+}
+
+func (m *MockNotificationRepository) UpdateNotification(ctx context.Context, notification *entities.Notification) error {
+	args := m.Called(ctx, notification)
+	return args.Error(0)
+}
+
+func (m *MockNotificationRepository) ClaimDue(ctx context.Context, now, leaseUntil time.Time, limit int) ([]*entities.Notification, error) {
+	args := m.Called(ctx, now, leaseUntil, limit)
+	if notifications, ok := args.Get(0).([]*entities.Notification); ok {
+		return notifications, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
@@ -0,0 +1,21 @@
+package notification_repository
+
+import (
+	"avitoTest/data/entities"
+	"context"
+	"time"
+)
+
+type NotificationRepository interface {
+	FindPreferences(ctx context.Context, userID int) (*entities.NotificationPreference, error)
+	SavePreferences(ctx context.Context, preferences *entities.NotificationPreference) error
+	// FindOptedIn returns the preferences, with their users, of the users who opted in to eventType.
+	FindOptedIn(ctx context.Context, userIDs []int, eventType string) ([]*entities.NotificationPreference, error)
+
+	// Outbox
+	CreateNotifications(ctx context.Context, notifications []*entities.Notification) error
+	UpdateNotification(ctx context.Context, notification *entities.Notification) error
+	// ClaimDue returns up to limit pending notifications due at now and postpones them to
+	// leaseUntil so no other instance claims them while they are being sent.
+	ClaimDue(ctx context.Context, now, leaseUntil time.Time, limit int) ([]*entities.Notification, error)
+}
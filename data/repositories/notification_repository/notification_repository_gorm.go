@@ -0,0 +1,76 @@
+package notification_repository
+
+import (
+	"avitoTest/data/entities"
+	"avitoTest/shared/constants"
+	"avitoTest/shared/errors/notification_errors"
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type notificationRepositoryGorm struct {
+	db *gorm.DB
+}
+
+func NewNotificationRepository(db *gorm.DB) NotificationRepository {
+	return &notificationRepositoryGorm{db: db}
+}
+
+func (r *notificationRepositoryGorm) FindPreferences(ctx context.Context, userID int) (*entities.NotificationPreference, error) {
+	var preferences entities.NotificationPreference
+	if err := r.db.WithContext(ctx).First(&preferences, "user_id = ?", userID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, notification_errors.ErrPreferencesNotFound
+		}
+		return nil, err
+	}
+	return &preferences, nil
+}
+
+func (r *notificationRepositoryGorm) SavePreferences(ctx context.Context, preferences *entities.NotificationPreference) error {
+	return r.db.WithContext(ctx).Omit(clause.Associations).Save(preferences).Error
+}
+
+func (r *notificationRepositoryGorm) FindOptedIn(ctx context.Context, userIDs []int, eventType string) ([]*entities.NotificationPreference, error) {
+	var preferences []*entities.NotificationPreference
+	err := r.db.WithContext(ctx).
+		Preload("User").
+		Where("user_id IN ?", userIDs).
+		Where("',' || event_types || ',' LIKE ?", "%,"+eventType+",%").
+		Order("user_id").
+		Find(&preferences).Error
+	return preferences, err
+}
+
+func (r *notificationRepositoryGorm) CreateNotifications(ctx context.Context, notifications []*entities.Notification) error {
+	return r.db.WithContext(ctx).Omit(clause.Associations).Create(notifications).Error
+}
+
+func (r *notificationRepositoryGorm) UpdateNotification(ctx context.Context, notification *entities.Notification) error {
+	return r.db.WithContext(ctx).Omit(clause.Associations).Save(notification).Error
+}
+
+// claimDue postpones the due pending notifications, skipping those another instance is
+// claiming at the same time.
+const claimDue = `
+UPDATE notifications SET next_attempt_at = ?
+WHERE id IN (
+    SELECT id FROM notifications
+    WHERE status = ? AND next_attempt_at <= ?
+    ORDER BY next_attempt_at
+    LIMIT ?
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING *`
+
+func (r *notificationRepositoryGorm) ClaimDue(ctx context.Context, now, leaseUntil time.Time, limit int) ([]*entities.Notification, error) {
+	var notifications []*entities.Notification
+	err := r.db.WithContext(ctx).
+		Raw(claimDue, leaseUntil, string(constants.NotificationPending), now, limit).
+		Scan(&notifications).Error
+	return notifications, err
+}
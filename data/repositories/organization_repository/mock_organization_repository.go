@@ -2,6 +2,7 @@ package organization_repository
 
 import (
 	"avitoTest/data/entities"
+	"avitoTest/shared/pagination"
 	"context"
 
 	"github.com/stretchr/testify/mock"
@@ -17,12 +18,12 @@ func (m *MockOrganizationRepository) Create(ctx context.Context, org *entities.O
 	return args.Error(0)
 }
 
-func (m *MockOrganizationRepository) GetAll(ctx context.Context) ([]entities.Organization, error) {
-	args := m.Called(ctx)
+func (m *MockOrganizationRepository) GetAll(ctx context.Context, params pagination.Params) ([]entities.Organization, int64, error) {
+	args := m.Called(ctx, params)
 	if orgs, ok := args.Get(0).([]entities.Organization); ok {
-		return orgs, args.Error(1)
+		return orgs, args.Get(1).(int64), args.Error(2)
 	}
-	return nil, args.Error(1)
+	return nil, 0, args.Error(2)
 }
 
 func (m *MockOrganizationRepository) FindByID(ctx context.Context, id int) (*entities.Organization, error) {
@@ -2,14 +2,18 @@ package organization_repository
 
 import (
 	"avitoTest/data/entities"
+	"avitoTest/data/repositories/repository_scopes"
+	"avitoTest/data/repositories/transaction"
+	"avitoTest/shared/errors/domain_errors"
+	"avitoTest/shared/pagination"
 	"context"
 	"errors"
 
 	"gorm.io/gorm"
 )
 
-var ErrOrganizationNotFound = errors.New("organization not found")
-var ErrResponsibleNotFound = errors.New("responsible not found")
+var ErrOrganizationNotFound = domain_errors.New(domain_errors.ErrNotFound, "organization not found")
+var ErrResponsibleNotFound = domain_errors.New(domain_errors.ErrNotFound, "responsible user not found")
 
 type OrganizationRepositoryGorm struct {
 	db *gorm.DB
@@ -20,20 +24,22 @@ func NewOrganizationRepository(db *gorm.DB) OrganizationRepository {
 }
 
 func (r *OrganizationRepositoryGorm) Create(ctx context.Context, org *entities.Organization) error {
-	return r.db.WithContext(ctx).Create(org).Error
+	return transaction.DB(ctx, r.db).Create(org).Error
 }
 
-func (r *OrganizationRepositoryGorm) GetAll(ctx context.Context) ([]entities.Organization, error) {
-	var organizations []entities.Organization
-	if err := r.db.WithContext(ctx).Find(&organizations).Error; err != nil {
-		return nil, err
-	}
-	return organizations, nil
+var organizationSortColumns = repository_scopes.SortColumns{
+	pagination.SortCreatedAt: "organizations.created_at",
+	pagination.SortName:      "organizations.name",
+}
+
+func (r *OrganizationRepositoryGorm) GetAll(ctx context.Context, params pagination.Params) ([]entities.Organization, int64, error) {
+	query := transaction.DB(ctx, r.db).Model(&entities.Organization{}).Preload("ServiceTypes")
+	return repository_scopes.FindPage[entities.Organization](query, params, organizationSortColumns, "organizations.id")
 }
 
 func (r *OrganizationRepositoryGorm) FindByID(ctx context.Context, id int) (*entities.Organization, error) {
 	var org entities.Organization
-	if err := r.db.WithContext(ctx).First(&org, id).Error; err != nil {
+	if err := transaction.DB(ctx, r.db).Preload("ServiceTypes").First(&org, id).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, ErrOrganizationNotFound
 		}
@@ -42,27 +48,36 @@ func (r *OrganizationRepositoryGorm) FindByID(ctx context.Context, id int) (*ent
 	return &org, nil
 }
 
+// Update saves the organization and replaces the service types it provides.
 func (r *OrganizationRepositoryGorm) Update(ctx context.Context, org *entities.Organization) error {
-	return r.db.WithContext(ctx).Save(org).Error
+	return transaction.DB(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("organization_id = ?", org.ID).Delete(&entities.OrganizationServiceType{}).Error; err != nil {
+			return err
+		}
+		for i := range org.ServiceTypes {
+			org.ServiceTypes[i].ID = 0
+		}
+		return tx.Save(org).Error
+	})
 }
 
 func (r *OrganizationRepositoryGorm) Delete(ctx context.Context, id int) error {
-	return r.db.WithContext(ctx).Delete(&entities.Organization{}, id).Error
+	return transaction.DB(ctx, r.db).Delete(&entities.Organization{}, id).Error
 }
 
 func (r *OrganizationRepositoryGorm) AddResponsible(ctx context.Context, orgResponsible *entities.OrganizationResponsible) error {
-	return r.db.WithContext(ctx).Create(orgResponsible).Error
+	return transaction.DB(ctx, r.db).Create(orgResponsible).Error
 }
 
 func (r *OrganizationRepositoryGorm) DeleteResponsible(ctx context.Context, orgID int, userID int) error {
-	return r.db.WithContext(ctx).
+	return transaction.DB(ctx, r.db).
 		Where("organization_id = ? AND user_id = ?", orgID, userID).
 		Delete(&entities.OrganizationResponsible{}).Error
 }
 
 func (r *OrganizationRepositoryGorm) GetResponsibles(ctx context.Context, orgID int) ([]entities.User, error) {
 	var responsibles []entities.User
-	err := r.db.WithContext(ctx).
+	err := transaction.DB(ctx, r.db).
 		Model(&entities.OrganizationResponsible{}).
 		Where("organization_id = ?", orgID).
 		Joins("JOIN users ON users.id = organization_responsibles.user_id").
@@ -79,7 +94,7 @@ func (r *OrganizationRepositoryGorm) GetResponsibles(ctx context.Context, orgID
 // GetResponsibleByID retrieves a responsible user by organization ID and user ID.
 func (r *OrganizationRepositoryGorm) GetResponsibleByID(ctx context.Context, orgID int, userID int) (*entities.User, error) {
 	var responsible entities.User
-	err := r.db.WithContext(ctx).
+	err := transaction.DB(ctx, r.db).
 		Model(&entities.OrganizationResponsible{}).
 		Where("organization_id = ? AND user_id = ?", orgID, userID).
 		Joins("JOIN users ON users.id = organization_responsibles.user_id").
@@ -88,7 +103,7 @@ func (r *OrganizationRepositoryGorm) GetResponsibleByID(ctx context.Context, org
 
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("responsible user not found")
+			return nil, ErrResponsibleNotFound
 		}
 		return nil, err
 	}
@@ -0,0 +1,22 @@
+package repository_scopes
+
+import (
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// PreloadCurrentVersion preloads the relation holding only the latest row of a
+// versions table, so a whole page of records gets its current versions in one
+// query instead of one query per record or the full history.
+func PreloadCurrentVersion(relation, versionsTable, foreignKey string) func(db *gorm.DB) *gorm.DB {
+	condition := fmt.Sprintf(
+		"%[1]s.version = (SELECT MAX(latest.version) FROM %[1]s latest WHERE latest.%[2]s = %[1]s.%[2]s)",
+		versionsTable, foreignKey,
+	)
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Preload(relation, func(db *gorm.DB) *gorm.DB {
+			return db.Where(condition)
+		})
+	}
+}
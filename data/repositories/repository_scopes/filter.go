@@ -0,0 +1,52 @@
+package repository_scopes
+
+import (
+	"avitoTest/shared/errors/filter_errors"
+	"avitoTest/shared/filter"
+	"fmt"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// FilterColumns maps the public filter fields of a list to SQL expressions.
+type FilterColumns map[string]string
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// Filter adds a WHERE condition for every condition of the filter. A field without
+// a column is reported as an error on the query.
+func Filter(f filter.Filter, columns FilterColumns) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		for _, condition := range f {
+			column, ok := columns[condition.Field]
+			if !ok {
+				db.AddError(fmt.Errorf("%w: %q", filter_errors.ErrUnknownField, condition.Field))
+				return db
+			}
+
+			switch condition.Operator {
+			case filter.OpEq:
+				db = db.Where(column+" = ?", condition.Value())
+			case filter.OpNe:
+				db = db.Where(column+" <> ?", condition.Value())
+			case filter.OpGt:
+				db = db.Where(column+" > ?", condition.Value())
+			case filter.OpGte:
+				db = db.Where(column+" >= ?", condition.Value())
+			case filter.OpLt:
+				db = db.Where(column+" < ?", condition.Value())
+			case filter.OpLte:
+				db = db.Where(column+" <= ?", condition.Value())
+			case filter.OpIn:
+				db = db.Where(column+" IN ?", condition.Values)
+			case filter.OpContains:
+				db = db.Where(column+" ILIKE ?", "%"+likeEscaper.Replace(fmt.Sprint(condition.Value()))+"%")
+			default:
+				db.AddError(fmt.Errorf("%w: %q", filter_errors.ErrUnsupportedOperator, condition.Operator))
+				return db
+			}
+		}
+		return db
+	}
+}
@@ -0,0 +1,54 @@
+package repository_scopes
+
+import (
+	"avitoTest/shared/pagination"
+
+	"gorm.io/gorm"
+)
+
+// SortColumns maps the public sort keys of a list to SQL expressions.
+type SortColumns map[string]string
+
+// Paginate applies the ordering, limit and offset of the page. Unknown sort keys
+// fall back to the creation time, and idColumn keeps the order stable between pages.
+func Paginate(params pagination.Params, columns SortColumns, idColumn string) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		column, ok := columns[params.Sort]
+		if !ok {
+			column = columns[pagination.SortCreatedAt]
+		}
+
+		direction := "DESC"
+		if params.Order == pagination.OrderAsc {
+			direction = "ASC"
+		}
+
+		limit := params.Limit
+		if limit <= 0 {
+			limit = pagination.DefaultLimit
+		}
+
+		return db.
+			Order(column + " " + direction).
+			Order(idColumn + " " + direction).
+			Limit(limit).
+			Offset(params.Offset)
+	}
+}
+
+// FindPage counts the rows matched by query and loads the requested page of them.
+// The query must have its model set.
+func FindPage[T any](query *gorm.DB, params pagination.Params, columns SortColumns, idColumn string) ([]T, int64, error) {
+	query = query.Session(&gorm.Session{})
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var items []T
+	if err := query.Scopes(Paginate(params, columns, idColumn)).Find(&items).Error; err != nil {
+		return nil, 0, err
+	}
+	return items, total, nil
+}
@@ -0,0 +1,16 @@
+package repository_scopes
+
+import "gorm.io/gorm"
+
+// StreamBatchSize is the number of rows loaded per query when streaming.
+const StreamBatchSize = 500
+
+// StreamBatches loads the rows matched by query in primary key order, StreamBatchSize
+// rows at a time, and passes every batch to fn before loading the next one. The batch
+// slice is reused, so fn must not keep it.
+func StreamBatches[T any](query *gorm.DB, fn func(batch []T) error) error {
+	var batch []T
+	return query.FindInBatches(&batch, StreamBatchSize, func(_ *gorm.DB, _ int) error {
+		return fn(batch)
+	}).Error
+}
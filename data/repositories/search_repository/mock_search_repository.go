@@ -0,0 +1,27 @@
+package search_repository
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockSearchRepository struct {
+	mock.Mock
+}
+
+func (m *MockSearchRepository) SearchTenders(ctx context.Context, params SearchParams) ([]*TenderSearchRow, error) {
+	args := m.Called(ctx, params)
+	if rows, ok := args.Get(0).([]*TenderSearchRow); ok {
+		return rows, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockSearchRepository) SearchBids(ctx context.Context, params SearchParams) ([]*BidSearchRow, error) {
+	args := m.Called(ctx, params)
+	if rows, ok := args.Get(0).([]*BidSearchRow); ok {
+		return rows, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
@@ -0,0 +1,44 @@
+package search_repository
+
+import (
+	"context"
+)
+
+// SearchParams describes a full-text search request.
+type SearchParams struct {
+	Query          string
+	Language       string
+	OrganizationID int
+	Limit          int
+}
+
+// TenderSearchRow is a tender whose latest version matches the search query.
+type TenderSearchRow struct {
+	TenderID       int
+	OrganizationID int
+	ServiceType    string
+	Status         string
+	Name           string
+	Description    string
+	Version        int
+	Rank           float64
+	Snippet        string
+}
+
+// BidSearchRow is a bid whose latest version matches the search query.
+type BidSearchRow struct {
+	BidID          int
+	TenderID       int
+	OrganizationID int
+	Status         string
+	Name           string
+	Description    string
+	Version        int
+	Rank           float64
+	Snippet        string
+}
+
+type SearchRepository interface {
+	SearchTenders(ctx context.Context, params SearchParams) ([]*TenderSearchRow, error)
+	SearchBids(ctx context.Context, params SearchParams) ([]*BidSearchRow, error)
+}
@@ -0,0 +1,101 @@
+package search_repository
+
+import (
+	"avitoTest/shared/constants"
+	"avitoTest/shared/errors/search_errors"
+	"context"
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// searchConfigs maps the supported languages to PostgreSQL text search configurations.
+var searchConfigs = map[string]string{
+	"ru": "russian",
+	"en": "english",
+}
+
+const headlineOptions = "StartSel=<b>, StopSel=</b>, MaxWords=35, MinWords=10, MaxFragments=2"
+
+// headlineText is the text of a version HTML-escaped, so that the only markup of the
+// snippet highlighted in it is the one added by ts_headline.
+const headlineText = `replace(replace(replace(replace(v.name || ' ' || coalesce(v.description, ''),
+	'&', '&amp;'), '<', '&lt;'), '>', '&gt;'), '"', '&quot;')`
+
+type searchRepositoryGorm struct {
+	db *gorm.DB
+}
+
+func NewSearchRepository(db *gorm.DB) SearchRepository {
+	return &searchRepositoryGorm{db: db}
+}
+
+// SearchTenders searches the latest version of each tender. Without an organization
+// only published tenders are searched, otherwise all tenders of that organization.
+func (r *searchRepositoryGorm) SearchTenders(ctx context.Context, params SearchParams) ([]*TenderSearchRow, error) {
+	config, ok := searchConfigs[params.Language]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", search_errors.ErrUnsupportedLanguage, params.Language)
+	}
+
+	query := fmt.Sprintf(`
+		SELECT t.id AS tender_id, t.organization_id, t.service_type, t.status,
+			v.name, v.description, v.version,
+			ts_rank(v.search_%[1]s, q.query) AS rank,
+			ts_headline('%[2]s', %[4]s, q.query, '%[3]s') AS snippet
+		FROM tenders t
+		JOIN LATERAL (
+			SELECT * FROM tender_versions tv WHERE tv.tender_id = t.id ORDER BY tv.version DESC LIMIT 1
+		) v ON TRUE
+		CROSS JOIN websearch_to_tsquery('%[2]s', @query) AS q(query)
+		WHERE v.search_%[1]s @@ q.query
+			AND ((@organization_id = 0 AND t.status = @published) OR t.organization_id = @organization_id)
+		ORDER BY rank DESC, t.id
+		LIMIT @limit`, params.Language, config, headlineOptions, headlineText)
+
+	var rows []*TenderSearchRow
+	err := r.db.WithContext(ctx).Raw(query, map[string]interface{}{
+		"query":           params.Query,
+		"organization_id": params.OrganizationID,
+		"published":       string(constants.TenderStatusPublished),
+		"limit":           params.Limit,
+	}).Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+// SearchBids searches the latest version of each bid submitted by the organization.
+func (r *searchRepositoryGorm) SearchBids(ctx context.Context, params SearchParams) ([]*BidSearchRow, error) {
+	config, ok := searchConfigs[params.Language]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", search_errors.ErrUnsupportedLanguage, params.Language)
+	}
+
+	query := fmt.Sprintf(`
+		SELECT b.id AS bid_id, b.tender_id, b.organization_id, b.status,
+			v.name, v.description, v.version,
+			ts_rank(v.search_%[1]s, q.query) AS rank,
+			ts_headline('%[2]s', %[4]s, q.query, '%[3]s') AS snippet
+		FROM bids b
+		JOIN LATERAL (
+			SELECT * FROM bid_versions bv WHERE bv.bid_id = b.id ORDER BY bv.version DESC LIMIT 1
+		) v ON TRUE
+		CROSS JOIN websearch_to_tsquery('%[2]s', @query) AS q(query)
+		WHERE v.search_%[1]s @@ q.query
+			AND b.organization_id = @organization_id
+		ORDER BY rank DESC, b.id
+		LIMIT @limit`, params.Language, config, headlineOptions, headlineText)
+
+	var rows []*BidSearchRow
+	err := r.db.WithContext(ctx).Raw(query, map[string]interface{}{
+		"query":           params.Query,
+		"organization_id": params.OrganizationID,
+		"limit":           params.Limit,
+	}).Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
@@ -2,6 +2,8 @@ package tender_repository
 
 import (
 	"avitoTest/data/entities"
+	"avitoTest/shared/filter"
+	"avitoTest/shared/pagination"
 	"context"
 
 	"github.com/stretchr/testify/mock"
@@ -30,20 +32,12 @@ func (m *MockTenderRepository) FindByID(ctx context.Context, id int) (*entities.
 	return nil, args.Error(1)
 }
 
-func (m *MockTenderRepository) GetAll(ctx context.Context) ([]*entities.Tender, error) {
-	args := m.Called(ctx)
+func (m *MockTenderRepository) GetAll(ctx context.Context, f filter.Filter, params pagination.Params) ([]*entities.Tender, int64, error) {
+	args := m.Called(ctx, f, params)
 	if tenders, ok := args.Get(0).([]*entities.Tender); ok {
-		return tenders, args.Error(1)
+		return tenders, args.Get(1).(int64), args.Error(2)
 	}
-	return nil, args.Error(1)
-}
-
-func (m *MockTenderRepository) GetAllByServiceType(ctx context.Context, serviceType string) ([]*entities.Tender, error) {
-	args := m.Called(ctx, serviceType)
-	if tenders, ok := args.Get(0).([]*entities.Tender); ok {
-		return tenders, args.Error(1)
-	}
-	return nil, args.Error(1)
+	return nil, 0, args.Error(2)
 }
 
 func (m *MockTenderRepository) CreateVersion(ctx context.Context, version *entities.TenderVersion) error {
@@ -75,12 +69,25 @@ func (m *MockTenderRepository) FindUserOrganizationResponsibility(ctx context.Co
 	return nil, args.Error(1)
 }
 
-func (m *MockTenderRepository) GetAllByCreatorID(ctx context.Context, creatorID int) ([]*entities.Tender, error) {
-	args := m.Called(ctx, creatorID)
+func (m *MockTenderRepository) GetAllByCreatorID(ctx context.Context, creatorID int, params pagination.Params) ([]*entities.Tender, int64, error) {
+	args := m.Called(ctx, creatorID, params)
 	if tenders, ok := args.Get(0).([]*entities.Tender); ok {
-		return tenders, args.Error(1)
+		return tenders, args.Get(1).(int64), args.Error(2)
 	}
-	return nil, args.Error(1)
+	return nil, 0, args.Error(2)
+}
+
+// StreamByOrganizationID passes the mocked batches of tenders to fn.
+func (m *MockTenderRepository) StreamByOrganizationID(ctx context.Context, orgID int, f filter.Filter, fn func(batch []*entities.Tender) error) error {
+	args := m.Called(ctx, orgID, f)
+	if batches, ok := args.Get(0).([][]*entities.Tender); ok {
+		for _, batch := range batches {
+			if err := fn(batch); err != nil {
+				return err
+			}
+		}
+	}
+	return args.Error(1)
 }
 
 func (m *MockTenderRepository) Delete(ctx context.Context, id int) error {
@@ -97,3 +104,16 @@ func (m *MockTenderRepository) PublishTender(ctx context.Context, tenderID int)
 	args := m.Called(ctx, tenderID)
 	return args.Error(0)
 }
+
+func (m *MockTenderRepository) FindEligibilityRules(ctx context.Context, tenderID int) ([]*entities.TenderEligibilityRule, error) {
+	args := m.Called(ctx, tenderID)
+	if rules, ok := args.Get(0).([]*entities.TenderEligibilityRule); ok {
+		return rules, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockTenderRepository) ReplaceEligibilityRules(ctx context.Context, tenderID int, rules []*entities.TenderEligibilityRule) error {
+	args := m.Called(ctx, tenderID, rules)
+	return args.Error(0)
+}
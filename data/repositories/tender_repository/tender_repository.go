@@ -4,6 +4,8 @@ package tender_repository
 
 import (
 	"avitoTest/data/entities"
+	"avitoTest/shared/filter"
+	"avitoTest/shared/pagination"
 	"context"
 )
 
@@ -12,9 +14,9 @@ type TenderRepository interface {
 	Create(ctx context.Context, tender *entities.Tender) error
 	Update(ctx context.Context, tender *entities.Tender) error
 	FindByID(ctx context.Context, id int) (*entities.Tender, error)
-	GetAll(ctx context.Context) ([]*entities.Tender, error)
-	GetAllByServiceType(ctx context.Context, serviceType string) ([]*entities.Tender, error)
-	GetAllByCreatorID(ctx context.Context, creatorID int) ([]*entities.Tender, error)
+	GetAll(ctx context.Context, f filter.Filter, params pagination.Params) ([]*entities.Tender, int64, error)
+	GetAllByCreatorID(ctx context.Context, creatorID int, params pagination.Params) ([]*entities.Tender, int64, error)
+	StreamByOrganizationID(ctx context.Context, orgID int, f filter.Filter, fn func(batch []*entities.Tender) error) error
 
 	// Tender Version Management
 	CreateVersion(ctx context.Context, version *entities.TenderVersion) error
@@ -26,6 +28,10 @@ type TenderRepository interface {
 	// User Responsibility Check
 	FindUserOrganizationResponsibility(ctx context.Context, userID, orgID int) (*entities.OrganizationResponsible, error)
 
+	// Eligibility Rules Management
+	FindEligibilityRules(ctx context.Context, tenderID int) ([]*entities.TenderEligibilityRule, error)
+	ReplaceEligibilityRules(ctx context.Context, tenderID int, rules []*entities.TenderEligibilityRule) error
+
 	PublishTender(ctx context.Context, tenderID int) error
 	CloseTender(ctx context.Context, tenderID int) error
 	Delete(ctx context.Context, id int) error
@@ -2,11 +2,17 @@ package tender_repository
 
 import (
 	"avitoTest/data/entities"
+	"avitoTest/data/repositories/repository_scopes"
+	"avitoTest/data/repositories/transaction"
 	"avitoTest/shared/constants"
+	"avitoTest/shared/errors/tendert_erorrs"
+	"avitoTest/shared/filter"
+	"avitoTest/shared/pagination"
 	"context"
 	"errors"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type tenderRepositoryGorm struct {
@@ -20,70 +26,84 @@ func NewTenderRepository(db *gorm.DB) TenderRepository {
 
 // Create adds a new tender to the database.
 func (r *tenderRepositoryGorm) Create(ctx context.Context, tender *entities.Tender) error {
-	return r.db.WithContext(ctx).Create(tender).Error
+	return transaction.DB(ctx, r.db).Create(tender).Error
 }
 
-// Update modifies an existing tender in the database.
+// Update modifies an existing tender in the database. Versions are never written back.
 func (r *tenderRepositoryGorm) Update(ctx context.Context, tender *entities.Tender) error {
-	return r.db.WithContext(ctx).Save(tender).Error
+	return transaction.DB(ctx, r.db).Omit(clause.Associations).Save(tender).Error
 }
 
-// FindByID retrieves a tender by its ID.
+// preloadCurrentVersion loads the latest version of every tender in a single query.
+var preloadCurrentVersion = repository_scopes.PreloadCurrentVersion("CurrentVersion", "tender_versions", "tender_id")
+
+// FindByID retrieves a tender by its ID together with its current version.
 func (r *tenderRepositoryGorm) FindByID(ctx context.Context, id int) (*entities.Tender, error) {
 	var tender entities.Tender
-	if err := r.db.WithContext(ctx).Preload("Versions").First(&tender, id).Error; err != nil {
+	if err := transaction.DB(ctx, r.db).Scopes(preloadCurrentVersion).First(&tender, id).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("tender not found")
+			return nil, tendert_erorrs.ErrTenderNotFound
 		}
 		return nil, err
 	}
 	return &tender, nil
 }
 
-// GetAll retrieves all tenders from the database.
-func (r *tenderRepositoryGorm) GetAll(ctx context.Context) ([]*entities.Tender, error) {
-	var tenders []*entities.Tender
-	if err := r.db.WithContext(ctx).Preload("Versions").Find(&tenders).Error; err != nil {
-		return nil, err
-	}
-	return tenders, nil
+// tenderSortColumns maps the tender list sort keys to columns; the name is taken from the latest version.
+var tenderSortColumns = repository_scopes.SortColumns{
+	pagination.SortCreatedAt: "tenders.created_at",
+	pagination.SortName:      "(SELECT tv.name FROM tender_versions tv WHERE tv.tender_id = tenders.id ORDER BY tv.version DESC LIMIT 1)",
+	pagination.SortStatus:    "tenders.status",
 }
 
-// GetAllByServiceType retrieves all tenders filtered by service type.
-func (r *tenderRepositoryGorm) GetAllByServiceType(ctx context.Context, serviceType string) ([]*entities.Tender, error) {
-	var tenders []*entities.Tender
-	if err := r.db.WithContext(ctx).
-		Where("service_type = ?", serviceType).Preload("Versions").Find(&tenders).Error; err != nil {
-		return nil, err
-	}
-	return tenders, nil
+// tenderFilterColumns maps the tender filter fields to columns; the name is taken from the latest version.
+var tenderFilterColumns = repository_scopes.FilterColumns{
+	constants.FilterFieldStatus:         "tenders.status",
+	constants.FilterFieldOrganizationID: "tenders.organization_id",
+	constants.FilterFieldCreatorID:      "tenders.creator_id",
+	constants.FilterFieldCreatedAt:      "tenders.created_at",
+	constants.FilterFieldBudget:         "tenders.budget",
+	constants.FilterFieldCategory:       "tenders.service_type",
+	constants.FilterFieldName:           tenderSortColumns[pagination.SortName],
 }
 
-// GetAllByCreatorID retrieves all tenders created by a specific user ID.
-func (r *tenderRepositoryGorm) GetAllByCreatorID(ctx context.Context, creatorID int) ([]*entities.Tender, error) {
-	var tenders []*entities.Tender
-	if err := r.db.WithContext(ctx).
+// GetAll retrieves a page of tenders matching the filter with their current versions and the total number of matching tenders.
+func (r *tenderRepositoryGorm) GetAll(ctx context.Context, f filter.Filter, params pagination.Params) ([]*entities.Tender, int64, error) {
+	query := transaction.DB(ctx, r.db).Model(&entities.Tender{}).
+		Scopes(repository_scopes.Filter(f, tenderFilterColumns), preloadCurrentVersion)
+	return repository_scopes.FindPage[*entities.Tender](query, params, tenderSortColumns, "tenders.id")
+}
+
+// StreamByOrganizationID passes the tenders of an organization matching the filter to fn in batches,
+// each tender with its current version.
+func (r *tenderRepositoryGorm) StreamByOrganizationID(ctx context.Context, orgID int, f filter.Filter, fn func(batch []*entities.Tender) error) error {
+	query := transaction.DB(ctx, r.db).Model(&entities.Tender{}).
+		Where("tenders.organization_id = ?", orgID).
+		Scopes(repository_scopes.Filter(f, tenderFilterColumns), preloadCurrentVersion)
+	return repository_scopes.StreamBatches(query, fn)
+}
+
+// GetAllByCreatorID retrieves a page of tenders created by a specific user ID with their current versions.
+func (r *tenderRepositoryGorm) GetAllByCreatorID(ctx context.Context, creatorID int, params pagination.Params) ([]*entities.Tender, int64, error) {
+	query := transaction.DB(ctx, r.db).Model(&entities.Tender{}).
 		Where("creator_id = ?", creatorID).
-		Preload("Versions").
-		Find(&tenders).Error; err != nil {
-		return nil, err
-	}
-	return tenders, nil
+		Scopes(preloadCurrentVersion)
+	return repository_scopes.FindPage[*entities.Tender](query, params, tenderSortColumns, "tenders.id")
 }
 
 // CreateVersion adds a new version of a tender to the database.
 func (r *tenderRepositoryGorm) CreateVersion(ctx context.Context, version *entities.TenderVersion) error {
-	return r.db.WithContext(ctx).Create(version).Error
+	return transaction.DB(ctx, r.db).Create(version).Error
 }
 
 // FindVersionByNumber retrieves a specific version of a tender by tender ID and version number.
 func (r *tenderRepositoryGorm) FindVersionByNumber(ctx context.Context, tenderID int, versionNumber int) (*entities.TenderVersion, error) {
 	var version entities.TenderVersion
-	if err := r.db.WithContext(ctx).
+	if err := transaction.DB(ctx, r.db).
 		Where("tender_id = ? AND version = ?", tenderID, versionNumber).
 		First(&version).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("tender version not found")
+			return nil, tendert_erorrs.ErrTenderVersionNotFound
 		}
 		return nil, err
 	}
@@ -93,12 +113,12 @@ func (r *tenderRepositoryGorm) FindVersionByNumber(ctx context.Context, tenderID
 // FindLatestVersion retrieves the latest version of a tender.
 func (r *tenderRepositoryGorm) FindLatestVersion(ctx context.Context, tenderID int) (*entities.TenderVersion, error) {
 	var version entities.TenderVersion
-	if err := r.db.WithContext(ctx).
+	if err := transaction.DB(ctx, r.db).
 		Where("tender_id = ?", tenderID).
 		Order("version DESC").
 		First(&version).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("latest tender version not found")
+			return nil, tendert_erorrs.ErrTenderVersionNotFound
 		}
 		return nil, err
 	}
@@ -108,7 +128,7 @@ func (r *tenderRepositoryGorm) FindLatestVersion(ctx context.Context, tenderID i
 // FindUserOrganizationResponsibility checks if a user is a responsible person for a given organization.
 func (r *tenderRepositoryGorm) FindUserOrganizationResponsibility(ctx context.Context, userID, orgID int) (*entities.OrganizationResponsible, error) {
 	var responsible entities.OrganizationResponsible
-	if err := r.db.WithContext(ctx).
+	if err := transaction.DB(ctx, r.db).
 		Where("user_id = ? AND organization_id = ?", userID, orgID).
 		First(&responsible).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
@@ -119,12 +139,37 @@ func (r *tenderRepositoryGorm) FindUserOrganizationResponsibility(ctx context.Co
 	return &responsible, nil
 }
 
+// FindEligibilityRules retrieves the eligibility rules declared for a tender.
+func (r *tenderRepositoryGorm) FindEligibilityRules(ctx context.Context, tenderID int) ([]*entities.TenderEligibilityRule, error) {
+	var rules []*entities.TenderEligibilityRule
+	if err := transaction.DB(ctx, r.db).
+		Where("tender_id = ?", tenderID).
+		Order("id").
+		Find(&rules).Error; err != nil {
+		return nil, err
+	}
+	return rules, nil
+}
+
+// ReplaceEligibilityRules replaces all eligibility rules of a tender in a single transaction.
+func (r *tenderRepositoryGorm) ReplaceEligibilityRules(ctx context.Context, tenderID int, rules []*entities.TenderEligibilityRule) error {
+	return transaction.DB(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("tender_id = ?", tenderID).Delete(&entities.TenderEligibilityRule{}).Error; err != nil {
+			return err
+		}
+		if len(rules) == 0 {
+			return nil
+		}
+		return tx.Create(&rules).Error
+	})
+}
+
 // PublishTender updates the status of the tender to "PUBLISHED" without creating a new version.
 func (r *tenderRepositoryGorm) PublishTender(ctx context.Context, tenderID int) error {
 	var tender entities.Tender
-	if err := r.db.WithContext(ctx).First(&tender, tenderID).Error; err != nil {
+	if err := transaction.DB(ctx, r.db).First(&tender, tenderID).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return errors.New("tender not found")
+			return tendert_erorrs.ErrTenderNotFound
 		}
 		return err
 	}
@@ -132,7 +177,7 @@ func (r *tenderRepositoryGorm) PublishTender(ctx context.Context, tenderID int)
 	// Update the status to "PUBLISHED"
 	tender.Status = string(constants.TenderStatusPublished)
 
-	if err := r.db.WithContext(ctx).Save(&tender).Error; err != nil {
+	if err := transaction.DB(ctx, r.db).Save(&tender).Error; err != nil {
 		return err
 	}
 
@@ -142,9 +187,9 @@ func (r *tenderRepositoryGorm) PublishTender(ctx context.Context, tenderID int)
 // CloseTender updates the status of the tender to "CLOSED" without creating a new version.
 func (r *tenderRepositoryGorm) CloseTender(ctx context.Context, tenderID int) error {
 	var tender entities.Tender
-	if err := r.db.WithContext(ctx).First(&tender, tenderID).Error; err != nil {
+	if err := transaction.DB(ctx, r.db).First(&tender, tenderID).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return errors.New("tender not found")
+			return tendert_erorrs.ErrTenderNotFound
 		}
 		return err
 	}
@@ -156,7 +201,7 @@ func (r *tenderRepositoryGorm) CloseTender(ctx context.Context, tenderID int) er
 
 	tender.Status = string(constants.TenderStatusClosed)
 
-	if err := r.db.WithContext(ctx).Save(&tender).Error; err != nil {
+	if err := transaction.DB(ctx, r.db).Save(&tender).Error; err != nil {
 		return err
 	}
 
@@ -165,5 +210,5 @@ func (r *tenderRepositoryGorm) CloseTender(ctx context.Context, tenderID int) er
 
 // Delete removes a tender by its ID from the database.
 func (r *tenderRepositoryGorm) Delete(ctx context.Context, id int) error {
-	return r.db.WithContext(ctx).Delete(&entities.Tender{}, id).Error
+	return transaction.DB(ctx, r.db).Delete(&entities.Tender{}, id).Error
 }
@@ -0,0 +1,20 @@
+package transaction
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// MockManager is a mock implementation of Manager; fn runs unless the mocked call returns an error.
+type MockManager struct {
+	mock.Mock
+}
+
+func (m *MockManager) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	args := m.Called(ctx)
+	if err := args.Error(0); err != nil {
+		return err
+	}
+	return fn(ctx)
+}
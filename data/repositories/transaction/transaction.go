@@ -0,0 +1,24 @@
+package transaction
+
+import "context"
+
+// Manager runs functions in a database transaction. Repositories pick the
+// transaction up from the context passed to fn through DB.
+type Manager interface {
+	// WithinTransaction commits when fn returns nil and rolls back otherwise.
+	// Nested calls run in a savepoint of the surrounding transaction.
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+type txKey struct{}
+
+// AfterCommit runs fn once the transaction of ctx has committed, or right away
+// when ctx carries no transaction. Functions registered in a transaction that
+// rolls back are dropped.
+func AfterCommit(ctx context.Context, fn func()) {
+	if state, ok := ctx.Value(txKey{}).(*txState); ok && !state.root().done {
+		state.afterCommit = append(state.afterCommit, fn)
+		return
+	}
+	fn()
+}
@@ -0,0 +1,72 @@
+package transaction
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+)
+
+// txState is the transaction carried by a context.
+type txState struct {
+	db          *gorm.DB
+	parent      *txState
+	afterCommit []func()
+	// done is set once the outermost transaction has ended, so contexts kept
+	// past it, such as in after-commit functions, go back to the database
+	done bool
+}
+
+type gormManager struct {
+	db *gorm.DB
+}
+
+// NewManager creates a GORM-based transaction manager.
+func NewManager(db *gorm.DB) Manager {
+	return &gormManager{db: db}
+}
+
+func (m *gormManager) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	parent, nested := ctx.Value(txKey{}).(*txState)
+	db := m.db
+	if nested {
+		db = parent.db
+	}
+
+	state := &txState{parent: parent}
+	err := db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		state.db = tx
+		return fn(context.WithValue(ctx, txKey{}, state))
+	})
+	if !nested {
+		state.done = true
+	}
+	if err != nil {
+		return err
+	}
+
+	// A savepoint is only final once the outermost transaction commits
+	if nested {
+		parent.afterCommit = append(parent.afterCommit, state.afterCommit...)
+		return nil
+	}
+	for _, callback := range state.afterCommit {
+		callback()
+	}
+	return nil
+}
+
+// root returns the state of the outermost transaction.
+func (s *txState) root() *txState {
+	for s.parent != nil {
+		s = s.parent
+	}
+	return s
+}
+
+// DB returns the transaction carried by ctx, or db when there is none, bound to ctx.
+func DB(ctx context.Context, db *gorm.DB) *gorm.DB {
+	if state, ok := ctx.Value(txKey{}).(*txState); ok && !state.root().done {
+		return state.db.WithContext(ctx)
+	}
+	return db.WithContext(ctx)
+}
@@ -2,6 +2,7 @@ package user_repository
 
 import (
 	"avitoTest/data/entities"
+	"avitoTest/shared/pagination"
 	"context"
 
 	"github.com/stretchr/testify/mock"
@@ -17,12 +18,12 @@ func (m *MockUserRepository) Create(ctx context.Context, user *entities.User) er
 	return args.Error(0)
 }
 
-func (m *MockUserRepository) GetAll(ctx context.Context) ([]entities.User, error) {
-	args := m.Called(ctx)
+func (m *MockUserRepository) GetAll(ctx context.Context, params pagination.Params) ([]entities.User, int64, error) {
+	args := m.Called(ctx, params)
 	if users, ok := args.Get(0).([]entities.User); ok {
-		return users, args.Error(1)
+		return users, args.Get(1).(int64), args.Error(2)
 	}
-	return nil, args.Error(1)
+	return nil, 0, args.Error(2)
 }
 
 func (m *MockUserRepository) FindByID(ctx context.Context, id int) (*entities.User, error) {
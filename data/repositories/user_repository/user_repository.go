@@ -2,12 +2,13 @@ package user_repository
 
 import (
 	"avitoTest/data/entities"
+	"avitoTest/shared/pagination"
 	"context"
 )
 
 type UserRepository interface {
 	Create(ctx context.Context, user *entities.User) error
-	GetAll(ctx context.Context) ([]entities.User, error)
+	GetAll(ctx context.Context, params pagination.Params) ([]entities.User, int64, error)
 	FindByID(ctx context.Context, id int) (*entities.User, error)
 	FindByUsername(ctx context.Context, username string) (*entities.User, error)
 	Update(ctx context.Context, user *entities.User) error
@@ -2,13 +2,17 @@ package user_repository
 
 import (
 	"avitoTest/data/entities"
+	"avitoTest/data/repositories/repository_scopes"
+	"avitoTest/data/repositories/transaction"
+	"avitoTest/shared/errors/domain_errors"
+	"avitoTest/shared/pagination"
 	"context"
 	"errors"
 
 	"gorm.io/gorm"
 )
 
-var ErrUserNotFound = errors.New("user not found")
+var ErrUserNotFound = domain_errors.New(domain_errors.ErrNotFound, "user not found")
 
 type UserRepositoryGorm struct {
 	db *gorm.DB
@@ -19,20 +23,22 @@ func NewUserRepository(db *gorm.DB) UserRepository {
 }
 
 func (r *UserRepositoryGorm) Create(ctx context.Context, user *entities.User) error {
-	return r.db.WithContext(ctx).Create(user).Error
+	return transaction.DB(ctx, r.db).Create(user).Error
 }
 
-func (r *UserRepositoryGorm) GetAll(ctx context.Context) ([]entities.User, error) {
-	var users []entities.User
-	if err := r.db.WithContext(ctx).Find(&users).Error; err != nil {
-		return nil, err
-	}
-	return users, nil
+var userSortColumns = repository_scopes.SortColumns{
+	pagination.SortCreatedAt: "users.created_at",
+	pagination.SortName:      "users.username",
+}
+
+func (r *UserRepositoryGorm) GetAll(ctx context.Context, params pagination.Params) ([]entities.User, int64, error) {
+	query := transaction.DB(ctx, r.db).Model(&entities.User{})
+	return repository_scopes.FindPage[entities.User](query, params, userSortColumns, "users.id")
 }
 
 func (r *UserRepositoryGorm) FindByID(ctx context.Context, id int) (*entities.User, error) {
 	var user entities.User
-	if err := r.db.WithContext(ctx).First(&user, id).Error; err != nil {
+	if err := transaction.DB(ctx, r.db).First(&user, id).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, ErrUserNotFound
 		}
@@ -44,16 +50,19 @@ func (r *UserRepositoryGorm) FindByID(ctx context.Context, id int) (*entities.Us
 func (r *UserRepositoryGorm) FindByUsername(ctx context.Context, username string) (*entities.User, error) {
 	var user entities.User
 	// Пример поиска в базе данных с использованием GORM
-	if err := r.db.Where("username = ?", username).First(&user).Error; err != nil {
-		return nil, ErrUserNotFound
+	if err := transaction.DB(ctx, r.db).Where("username = ?", username).First(&user).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, err
 	}
 	return &user, nil
 }
 
 func (r *UserRepositoryGorm) Update(ctx context.Context, user *entities.User) error {
-	return r.db.WithContext(ctx).Save(user).Error
+	return transaction.DB(ctx, r.db).Save(user).Error
 }
 
 func (r *UserRepositoryGorm) Delete(ctx context.Context, id int) error {
-	return r.db.WithContext(ctx).Delete(&entities.User{}, id).Error
+	return transaction.DB(ctx, r.db).Delete(&entities.User{}, id).Error
 }
@@ -0,0 +1,88 @@
+package webhook_repository
+
+import (
+	"avitoTest/data/entities"
+	"avitoTest/shared/filter"
+	"avitoTest/shared/pagination"
+	"context"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockWebhookRepository struct {
+	mock.Mock
+}
+
+func (m *MockWebhookRepository) Create(ctx context.Context, webhook *entities.Webhook) error {
+	args := m.Called(ctx, webhook)
+	return args.Error(0)
+}
+
+func (m *MockWebhookRepository) Update(ctx context.Context, webhook *entities.Webhook) error {
+	args := m.Called(ctx, webhook)
+	return args.Error(0)
+}
+
+func (m *MockWebhookRepository) Delete(ctx context.Context, id int) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockWebhookRepository) FindByID(ctx context.Context, id int) (*entities.Webhook, error) {
+	args := m.Called(ctx, id)
+	if webhook, ok := args.Get(0).(*entities.Webhook); ok {
+		return webhook, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockWebhookRepository) FindByOrganizationID(ctx context.Context, orgID int) ([]*entities.Webhook, error) {
+	args := m.Called(ctx, orgID)
+	if webhooks, ok := args.Get(0).([]*entities.Webhook); ok {
+		return webhooks, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockWebhookRepository) FindSubscribed(ctx context.Context, orgIDs []int, eventType string) ([]*entities.Webhook, error) {
+	args := m.Called(ctx, orgIDs, eventType)
+	if webhooks, ok := args.Get(0).([]*entities.Webhook); ok {
+		return webhooks, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockWebhookRepository) CreateDeliveries(ctx context.Context, deliveries []*entities.WebhookDelivery) error {
+	args := m.Called(ctx, deliveries)
+	return args.Error(0)
+}
+
+func (m *MockWebhookRepository) UpdateDelivery(ctx context.Context, delivery *entities.WebhookDelivery) error {
+	args := m.Called(ctx, delivery)
+	return args.Error(0)
+}
+
+func (m *MockWebhookRepository) FindDeliveryByID(ctx context.Context, id int) (*entities.WebhookDelivery, error) {
+	args := m.Called(ctx, id)
+	if delivery, ok := args.Get(0).(*entities.WebhookDelivery); ok {
+		return delivery, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockWebhookRepository) FindDeliveries(ctx context.Context, webhookID int, f filter.Filter, params pagination.Params) ([]*entities.WebhookDelivery, int64, error) {
+	args := m.Called(ctx, webhookID, f, params)
+	if deliveries, ok := args.Get(0).([]*entities.WebhookDelivery); ok {
+		return deliveries, args.Get(1).(int64), args.Error(2)
+	}
+	return nil, 0, args.Error(2)
+}
+
+func (m *MockWebhookRepository) ClaimDue(ctx context.Context, now, leaseUntil time.Time, limit int) ([]*entities.WebhookDelivery, error) {
+	args := m.Called(ctx, now, leaseUntil, limit)
+	if deliveries, ok := args.Get(0).([]*entities.WebhookDelivery); ok {
+		return deliveries, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
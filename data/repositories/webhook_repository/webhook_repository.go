@@ -0,0 +1,29 @@
+package webhook_repository
+
+import (
+	"avitoTest/data/entities"
+	"avitoTest/shared/filter"
+	"avitoTest/shared/pagination"
+	"context"
+	"time"
+)
+
+type WebhookRepository interface {
+	Create(ctx context.Context, webhook *entities.Webhook) error
+	Update(ctx context.Context, webhook *entities.Webhook) error
+	Delete(ctx context.Context, id int) error
+	FindByID(ctx context.Context, id int) (*entities.Webhook, error)
+	FindByOrganizationID(ctx context.Context, orgID int) ([]*entities.Webhook, error)
+	// FindSubscribed returns the active webhooks of the organizations subscribed to eventType.
+	FindSubscribed(ctx context.Context, orgIDs []int, eventType string) ([]*entities.Webhook, error)
+
+	// Deliveries
+	CreateDeliveries(ctx context.Context, deliveries []*entities.WebhookDelivery) error
+	UpdateDelivery(ctx context.Context, delivery *entities.WebhookDelivery) error
+	FindDeliveryByID(ctx context.Context, id int) (*entities.WebhookDelivery, error)
+	FindDeliveries(ctx context.Context, webhookID int, f filter.Filter, params pagination.Params) ([]*entities.WebhookDelivery, int64, error)
+	// ClaimDue returns up to limit pending deliveries due at now to active webhooks, with
+	// their webhooks, and postpones them to leaseUntil so no other instance claims them
+	// while they are being sent.
+	ClaimDue(ctx context.Context, now, leaseUntil time.Time, limit int) ([]*entities.WebhookDelivery, error)
+}
@@ -0,0 +1,148 @@
+package webhook_repository
+
+import (
+	"avitoTest/data/entities"
+	"avitoTest/data/repositories/repository_scopes"
+	"avitoTest/shared/constants"
+	"avitoTest/shared/errors/webhook_errors"
+	"avitoTest/shared/filter"
+	"avitoTest/shared/pagination"
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type webhookRepositoryGorm struct {
+	db *gorm.DB
+}
+
+func NewWebhookRepository(db *gorm.DB) WebhookRepository {
+	return &webhookRepositoryGorm{db: db}
+}
+
+func (r *webhookRepositoryGorm) Create(ctx context.Context, webhook *entities.Webhook) error {
+	return r.db.WithContext(ctx).Omit(clause.Associations).Create(webhook).Error
+}
+
+func (r *webhookRepositoryGorm) Update(ctx context.Context, webhook *entities.Webhook) error {
+	return r.db.WithContext(ctx).Omit(clause.Associations).Save(webhook).Error
+}
+
+func (r *webhookRepositoryGorm) Delete(ctx context.Context, id int) error {
+	return r.db.WithContext(ctx).Delete(&entities.Webhook{}, id).Error
+}
+
+func (r *webhookRepositoryGorm) FindByID(ctx context.Context, id int) (*entities.Webhook, error) {
+	var webhook entities.Webhook
+	if err := r.db.WithContext(ctx).First(&webhook, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, webhook_errors.ErrWebhookNotFound
+		}
+		return nil, err
+	}
+	return &webhook, nil
+}
+
+func (r *webhookRepositoryGorm) FindByOrganizationID(ctx context.Context, orgID int) ([]*entities.Webhook, error) {
+	var webhooks []*entities.Webhook
+	err := r.db.WithContext(ctx).
+		Where("organization_id = ?", orgID).
+		Order("id").
+		Find(&webhooks).Error
+	return webhooks, err
+}
+
+func (r *webhookRepositoryGorm) FindSubscribed(ctx context.Context, orgIDs []int, eventType string) ([]*entities.Webhook, error) {
+	var webhooks []*entities.Webhook
+	err := r.db.WithContext(ctx).
+		Where("organization_id IN ? AND active", orgIDs).
+		Where("',' || event_types || ',' LIKE ?", "%,"+eventType+",%").
+		Order("id").
+		Find(&webhooks).Error
+	return webhooks, err
+}
+
+func (r *webhookRepositoryGorm) CreateDeliveries(ctx context.Context, deliveries []*entities.WebhookDelivery) error {
+	return r.db.WithContext(ctx).Omit(clause.Associations).Create(deliveries).Error
+}
+
+func (r *webhookRepositoryGorm) UpdateDelivery(ctx context.Context, delivery *entities.WebhookDelivery) error {
+	return r.db.WithContext(ctx).Omit(clause.Associations).Save(delivery).Error
+}
+
+func (r *webhookRepositoryGorm) FindDeliveryByID(ctx context.Context, id int) (*entities.WebhookDelivery, error) {
+	var delivery entities.WebhookDelivery
+	if err := r.db.WithContext(ctx).First(&delivery, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, webhook_errors.ErrDeliveryNotFound
+		}
+		return nil, err
+	}
+	return &delivery, nil
+}
+
+// deliverySortColumns maps the delivery list sort keys to columns.
+var deliverySortColumns = repository_scopes.SortColumns{
+	pagination.SortCreatedAt: "webhook_deliveries.created_at",
+	pagination.SortStatus:    "webhook_deliveries.status",
+}
+
+// deliveryFilterColumns maps the delivery filter fields to columns.
+var deliveryFilterColumns = repository_scopes.FilterColumns{
+	constants.FilterFieldStatus:    "webhook_deliveries.status",
+	constants.FilterFieldEventType: "webhook_deliveries.event_type",
+	constants.FilterFieldCreatedAt: "webhook_deliveries.created_at",
+}
+
+func (r *webhookRepositoryGorm) FindDeliveries(ctx context.Context, webhookID int, f filter.Filter, params pagination.Params) ([]*entities.WebhookDelivery, int64, error) {
+	query := r.db.WithContext(ctx).Model(&entities.WebhookDelivery{}).
+		Where("webhook_id = ?", webhookID).
+		Scopes(repository_scopes.Filter(f, deliveryFilterColumns))
+	return repository_scopes.FindPage[*entities.WebhookDelivery](query, params, deliverySortColumns, "webhook_deliveries.id")
+}
+
+// claimDue postpones the due pending deliveries to active webhooks, skipping those another
+// instance is claiming at the same time.
+const claimDue = `
+UPDATE webhook_deliveries SET next_attempt_at = ?
+WHERE id IN (
+    SELECT d.id FROM webhook_deliveries d
+    JOIN webhooks w ON w.id = d.webhook_id
+    WHERE d.status = ? AND d.next_attempt_at <= ? AND w.active
+    ORDER BY d.next_attempt_at
+    LIMIT ?
+    FOR UPDATE OF d SKIP LOCKED
+)
+RETURNING *`
+
+func (r *webhookRepositoryGorm) ClaimDue(ctx context.Context, now, leaseUntil time.Time, limit int) ([]*entities.WebhookDelivery, error) {
+	db := r.db.WithContext(ctx)
+
+	var deliveries []*entities.WebhookDelivery
+	if err := db.Raw(claimDue, leaseUntil, string(constants.WebhookDeliveryPending), now, limit).Scan(&deliveries).Error; err != nil {
+		return nil, err
+	}
+	if len(deliveries) == 0 {
+		return nil, nil
+	}
+
+	webhookIDs := make([]int, 0, len(deliveries))
+	for _, delivery := range deliveries {
+		webhookIDs = append(webhookIDs, delivery.WebhookID)
+	}
+	var webhooks []entities.Webhook
+	if err := db.Find(&webhooks, webhookIDs).Error; err != nil {
+		return nil, err
+	}
+	byID := make(map[int]entities.Webhook, len(webhooks))
+	for _, webhook := range webhooks {
+		byID[webhook.ID] = webhook
+	}
+	for _, delivery := range deliveries {
+		delivery.Webhook = byID[delivery.WebhookID]
+	}
+	return deliveries, nil
+}
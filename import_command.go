@@ -0,0 +1,68 @@
+package main
+
+import (
+	"avitoTest/services/tender_service"
+	"avitoTest/services/tender_service/tender_models"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// importTendersCommand is the name of the CLI command importing tenders from a file.
+const importTendersCommand = "import-tenders"
+
+// runImportTenders imports the tenders of a CSV or JSON file, prints the report as JSON to
+// stdout and returns the exit code: 0 when every row is valid, 1 when some rows failed and
+// 2 when the import could not run.
+func runImportTenders(args []string, tenderService tender_service.TenderService, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet(importTendersCommand, flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	path := flags.String("file", "", "path to the CSV or JSON file to import")
+	format := flags.String("format", "", "file format: csv or json (default: from the file extension)")
+	mode := flags.String("mode", tender_models.ImportModeAtomic, "import mode: atomic or per_row")
+	dryRun := flags.Bool("dry-run", false, "validate the rows without importing them")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if *path == "" {
+		fmt.Fprintln(stderr, "-file is required")
+		flags.Usage()
+		return 2
+	}
+	if *format == "" {
+		*format = strings.TrimPrefix(strings.ToLower(filepath.Ext(*path)), ".")
+	}
+
+	file, err := os.Open(*path)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 2
+	}
+	defer file.Close()
+
+	rows, err := tender_service.ParseTenderImport(file, *format)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 2
+	}
+
+	report, err := tenderService.ImportTenders(context.Background(), rows, tender_models.TenderImportOptions{Mode: *mode, DryRun: *dryRun})
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 2
+	}
+
+	encoder := json.NewEncoder(stdout)
+	encoder.SetIndent("", "  ")
+	encoder.Encode(report)
+
+	if report.Failed > 0 {
+		return 1
+	}
+	return 0
+}
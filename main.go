@@ -2,19 +2,47 @@ package main
 
 import (
 	"avitoTest/api"
-	"avitoTest/data/context"
+	dbcontext "avitoTest/data/context"
+	"avitoTest/data/migrations"
+	"avitoTest/data/repositories/audit_repository"
 	"avitoTest/data/repositories/bid_repository"
+	"avitoTest/data/repositories/category_repository"
 	"avitoTest/data/repositories/comment_repository"
+	"avitoTest/data/repositories/idempotency_repository"
+	"avitoTest/data/repositories/notification_repository"
 	"avitoTest/data/repositories/organization_repository"
+	"avitoTest/data/repositories/search_repository"
 	"avitoTest/data/repositories/tender_repository"
+	"avitoTest/data/repositories/transaction"
 	"avitoTest/data/repositories/user_repository"
+	"avitoTest/data/repositories/webhook_repository"
+	"avitoTest/services/audit_service"
 	"avitoTest/services/bid_service"
+	"avitoTest/services/category_service"
 	"avitoTest/services/comment_service"
+	"avitoTest/services/idempotency_service"
+	"avitoTest/services/notification_service"
 	"avitoTest/services/organization_service"
+	"avitoTest/services/search_service"
 	"avitoTest/services/tender_service"
 	"avitoTest/services/user_service"
+	"avitoTest/services/webhook_service"
 	"avitoTest/shared"
+	"avitoTest/shared/cache"
+	"avitoTest/shared/config"
+	"avitoTest/shared/health"
+	"avitoTest/shared/metrics"
+	"avitoTest/shared/ratelimit"
+	"avitoTest/shared/scheduler"
+	"avitoTest/shared/tracing"
+	"context"
+	"errors"
+	"flag"
+	"net"
 	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
 
 	"github.com/gorilla/mux"
 	"gorm.io/gorm"
@@ -22,49 +50,151 @@ import (
 
 func main() {
 	// Step 1: Load configuration
-	conf := loadConfiguration()
+	conf, args := loadConfiguration(os.Args[1:])
 
 	// Step 2: Initialize logger
 	initLogger(conf)
 
-	// Step 3: Connect to the database
+	// Managing the schema needs neither the services nor the spans
+	if len(args) > 0 && args[0] == migrateCommand {
+		os.Exit(runMigrate(args[1:], conf, os.Stdout, os.Stderr))
+	}
+
+	// Step 3: Start sending spans
+	shutdownTracing := initTracing(conf)
+
+	// Step 4: Connect to the database
 	db := connectToDatabase(conf)
-	defer closeDatabaseConnection(db)
+	migrator := checkMigrations(db, conf)
+
+	// Step 5: Initialize services and the background jobs they need
+	jobs := scheduler.New()
+	orgService, userService, tenderService, bidService, commentService, categoryService, searchService, idempotencyService, auditService, webhookService, notificationService := initializeServices(db, conf, jobs)
+
+	// Commands run against the same services instead of starting the server
+	if len(args) > 0 && args[0] == importTendersCommand {
+		code := runImportTenders(args[1:], tenderService, os.Stdout, os.Stderr)
+		closeDatabaseConnection(db)
+		shutdownTracing()
+		os.Exit(code)
+	}
+
+	// Step 6: Limit the rate of the requests of every caller
+	limiter := newRateLimiter(conf, jobs)
 
-	// Step 4: Initialize services
-	orgService, userService, tenderService, bidService, commentService := initializeServices(db)
+	// Step 7: Start the background jobs and report the health of the dependencies
+	startScheduler(jobs)
+	registerHealthChecks(db, migrator, jobs)
 
-	// Step 5: Setup the router with all the routes
-	router := setupRouter(orgService, userService, tenderService, bidService, commentService)
+	// Step 8: Setup the router with all the routes
+	handler := setupRouter(conf, limiter, api.Services{
+		Organizations: orgService,
+		Users:         userService,
+		Tenders:       tenderService,
+		Bids:          bidService,
+		Comments:      commentService,
+		Categories:    categoryService,
+		Search:        searchService,
+		Idempotency:   idempotencyService,
+		Audit:         auditService,
+		Webhooks:      webhookService,
+		Notifications: notificationService,
+	})
 
-	// Step 6: Start the server
-	startServer(conf.ServerAddress, router)
+	// Step 9: Serve requests until the process is told to stop and drain them
+	code := serve(conf.Server, handler, jobs)
+
+	// Step 10: Release the database and flush the spans once nothing uses them
+	closeDatabaseConnection(db)
+	shutdownTracing()
+	os.Exit(code)
 }
 
-// loadConfiguration loads the application configuration from environment variables.
-func loadConfiguration() *shared.Config {
-	conf := shared.LoadConfig()
-	shared.Logger.Infof("Configuration loaded: %+v", conf)
-	return conf
+// loadConfiguration loads the application configuration from the configuration file, the
+// environment and the command line, and returns the arguments left after the flags.
+func loadConfiguration(args []string) (*config.Config, []string) {
+	conf, args, err := config.Load(args, os.Stderr)
+	if errors.Is(err, flag.ErrHelp) {
+		os.Exit(0)
+	}
+	if err != nil {
+		shared.Logger.Fatal(err)
+	}
+	shared.Logger.Infof("Configuration loaded: %s", conf)
+	return conf, args
 }
 
 // initLogger initializes the logger with the loaded configuration.
-func initLogger(conf *shared.Config) {
-	shared.InitLogger(conf)
+func initLogger(conf *config.Config) {
+	shared.InitLogger(conf.Log)
 	shared.Logger.Infof("Logger initialized")
 }
 
+// initTracing sets up the exporter the spans are sent with and returns the function
+// flushing the spans not sent yet.
+func initTracing(conf *config.Config) func() {
+	shutdown, err := tracing.Init(conf.Tracing.Exporter)
+	if err != nil {
+		shared.Logger.Fatalf("Failed to initialize tracing: %v", err)
+	}
+	shared.Logger.Infof("Tracing initialized with the %s exporter", conf.Tracing.Exporter)
+
+	return func() {
+		if err := shutdown(); err != nil {
+			shared.Logger.Errorf("Error while flushing spans: %v", err)
+		}
+	}
+}
+
 // connectToDatabase connects to the database using the configuration and returns the DB connection.
-func connectToDatabase(conf *shared.Config) *gorm.DB {
+func connectToDatabase(conf *config.Config) *gorm.DB {
 	shared.Logger.Info("Connecting to the database")
-	db, err := context.ConnectDB(conf.PostgresConn)
+	db, err := dbcontext.ConnectDB(conf.Database.ConnectionString())
 	if err != nil {
 		shared.Logger.Fatalf("Failed to connect to the database: %v", err)
 	}
 	shared.Logger.Info("Database connected successfully")
+
+	sqlDB, err := db.DB()
+	if err != nil {
+		shared.Logger.Fatalf("Failed to get sql.DB: %v", err)
+	}
+	sqlDB.SetMaxOpenConns(conf.Database.MaxOpenConns)
+	sqlDB.SetMaxIdleConns(conf.Database.MaxIdleConns)
+	sqlDB.SetConnMaxLifetime(conf.Database.ConnMaxLifetime)
+	sqlDB.SetConnMaxIdleTime(conf.Database.ConnMaxIdleTime)
+
+	// Expose the connection pool statistics with the other metrics
+	metrics.RegisterDB(sqlDB, "postgres")
 	return db
 }
 
+// checkMigrations applies the pending migrations when configured to, and stops the
+// process if the schema is still not up to date, since the queries would fail.
+func checkMigrations(db *gorm.DB, conf *config.Config) *migrations.Migrator {
+	sqlDB, err := db.DB()
+	if err != nil {
+		shared.Logger.Fatalf("Failed to get sql.DB: %v", err)
+	}
+	migrator, err := migrations.New(sqlDB, migrations.Files)
+	if err != nil {
+		shared.Logger.Fatalf("Failed to load the migrations: %v", err)
+	}
+
+	ctx := context.Background()
+	if conf.Database.MigrateOnStart {
+		applied, err := migrator.Up(ctx)
+		if err != nil {
+			shared.Logger.Fatalf("Failed to migrate the database: %v", err)
+		}
+		shared.Logger.Infof("Applied %d migrations", len(applied))
+	}
+	if err := migrator.Check(ctx); err != nil {
+		shared.Logger.Fatalf("%v; run `migrate up` first", err)
+	}
+	return migrator
+}
+
 // closeDatabaseConnection gracefully closes the database connection.
 func closeDatabaseConnection(db *gorm.DB) {
 	sqlDB, err := db.DB() // Get *sql.DB to close connection
@@ -78,12 +208,18 @@ func closeDatabaseConnection(db *gorm.DB) {
 }
 
 // initializeServices initializes the necessary repositories and services.
-func initializeServices(db *gorm.DB) (
+func initializeServices(db *gorm.DB, conf *config.Config, jobs *scheduler.Scheduler) (
 	organization_service.OrganizationService,
 	user_service.UserService,
 	tender_service.TenderService,
 	bid_service.BidService,
-	comment_service.CommentService) {
+	comment_service.CommentService,
+	category_service.CategoryService,
+	search_service.SearchService,
+	idempotency_service.IdempotencyService,
+	audit_service.AuditService,
+	webhook_service.WebhookService,
+	notification_service.NotificationService) {
 
 	shared.Logger.Info("Initializing repositories and services")
 
@@ -93,38 +229,198 @@ func initializeServices(db *gorm.DB) (
 	tenderRepo := tender_repository.NewTenderRepository(db)
 	bidRepo := bid_repository.NewBidRepository(db)
 	commentRepo := comment_repository.NewCommentRepository(db)
+	categoryRepo := category_repository.NewCategoryRepository(db)
+	searchRepo := search_repository.NewSearchRepository(db)
+	idempotencyRepo := idempotency_repository.NewIdempotencyRepository(db)
+	auditRepo := audit_repository.NewAuditRepository(db)
+	webhookRepo := webhook_repository.NewWebhookRepository(db)
+	notificationRepo := notification_repository.NewNotificationRepository(db)
+	transactions := transaction.NewManager(db)
 
 	// Step 2: Initialize services
-	orgService := organization_service.NewOrganizationService(orgRepo, userRepo)
+	orgService := organization_service.NewOrganizationService(orgRepo, userRepo, categoryRepo)
 	userService := user_service.NewUserService(userRepo)
-	tenderService := tender_service.NewTenderService(tenderRepo, userRepo)
+	tenderService := tender_service.NewTenderService(tenderRepo, userRepo, categoryRepo, transactions)
 	bidService := bid_service.NewBidService(bidRepo, orgRepo, userRepo, tenderRepo)
 	commentService := comment_service.NewCommentService(commentRepo)
+	categoryService := category_service.NewCategoryService(categoryRepo)
+	searchService := search_service.NewSearchService(searchRepo, orgRepo)
+	idempotencyService := idempotency_service.NewIdempotencyService(idempotencyRepo, conf.Idempotency.TTL, conf.Idempotency.LockTimeout)
+	jobs.Add(scheduler.Job{Name: "idempotency_purge", Interval: conf.Scheduler.IdempotencyPurgeInterval, Run: func(ctx context.Context) error {
+		_, err := idempotencyService.PurgeExpired(ctx)
+		return err
+	}})
+
+	// Queue the tender and bid events for the webhooks subscribed to them and send them
+	webhookService := webhook_service.NewWebhookService(webhookRepo, orgRepo,
+		webhook_service.NewHTTPClient(conf.Webhooks.Timeout, conf.Webhooks.AllowPrivateNetworks),
+		webhook_service.RetryPolicy{MaxAttempts: conf.Webhooks.MaxAttempts, Backoff: conf.Webhooks.Backoff, MaxBackoff: conf.Webhooks.MaxBackoff})
+	webhook_service.SubscribeToEvents(webhookService, tenderService, bidService)
+	jobs.Add(scheduler.Job{Name: "webhook_delivery", Interval: conf.Scheduler.WebhookDeliveryInterval, Run: func(ctx context.Context) error {
+		_, err := webhookService.DeliverDue(ctx)
+		return err
+	}})
+
+	// Put the emails of the events users opted in to in the outbox and send them
+	notifications := conf.Notifications
+	notificationService := notification_service.NewNotificationService(notificationRepo, userRepo, orgRepo, bidRepo,
+		notification_service.NewSMTPMailer(notification_service.SMTPConfig{
+			Host:     notifications.SMTP.Host,
+			Port:     notifications.SMTP.Port,
+			Username: notifications.SMTP.Username,
+			Password: notifications.SMTP.Password,
+			From:     notifications.From,
+			StartTLS: notifications.SMTP.StartTLS,
+			Timeout:  notifications.SMTP.Timeout,
+		}),
+		notification_service.RetryPolicy{MaxAttempts: notifications.MaxAttempts, Backoff: notifications.Backoff, MaxBackoff: notifications.MaxBackoff})
+	if notifications.Enabled {
+		notification_service.SubscribeToEvents(notificationService, tenderService, bidService)
+		jobs.Add(scheduler.Job{Name: "notification_send", Interval: conf.Scheduler.NotificationSendInterval, Run: func(ctx context.Context) error {
+			_, err := notificationService.SendDue(ctx)
+			return err
+		}})
+	}
+
+	// Record the changes made through the services, reading the snapshots past the cache
+	auditService := audit_service.NewAuditService(auditRepo, orgRepo)
+	orgService = organization_service.NewAuditedOrganizationService(orgService, auditService, transactions)
+	userService = user_service.NewAuditedUserService(userService, auditService, transactions)
+	tenderService = tender_service.NewAuditedTenderService(tenderService, auditService, transactions)
+	bidService = bid_service.NewAuditedBidService(bidService, tenderService, auditService, transactions)
+	commentService = comment_service.NewAuditedCommentService(commentService, auditService, transactions)
+
+	// Step 3: Put a read-through cache in front of hot reads
+	backend := cache.NewLRU(conf.Cache.Size)
+	jobs.Add(scheduler.Job{Name: "cache_purge", Interval: conf.Scheduler.CachePurgeInterval, Run: func(ctx context.Context) error {
+		backend.PurgeExpired(ctx)
+		return nil
+	}})
+	orgService = organization_service.NewCachedOrganizationService(orgService, cache.NewNamespace(backend, "organizations"), conf.Cache.TTL)
+	tenderService = tender_service.NewCachedTenderService(tenderService, cache.NewNamespace(backend, "tenders"), conf.Cache.TTL)
+
+	// Step 4: Trace the calls to the services, including those answered from the cache
+	tenderService = tender_service.NewTracedTenderService(tenderService)
+	bidService = bid_service.NewTracedBidService(bidService)
+
+	return orgService, userService, tenderService, bidService, commentService, categoryService, searchService, idempotencyService, auditService, webhookService, notificationService
+}
+
+// startScheduler starts running the background jobs.
+func startScheduler(jobs *scheduler.Scheduler) {
+	jobs.Start()
+	shared.Logger.Info("Background jobs started")
+}
 
-	return orgService, userService, tenderService, bidService, commentService
+// registerHealthChecks registers the checks of the components the readiness of the
+// service depends on.
+func registerHealthChecks(db *gorm.DB, migrator *migrations.Migrator, jobs *scheduler.Scheduler) {
+	health.Register("database", func(ctx context.Context) (any, error) {
+		sqlDB, err := db.DB()
+		if err != nil {
+			return nil, err
+		}
+		stats := sqlDB.Stats()
+		details := map[string]int{"open_connections": stats.OpenConnections, "in_use": stats.InUse, "idle": stats.Idle}
+		return details, sqlDB.PingContext(ctx)
+	})
+	health.Register("migrations", func(ctx context.Context) (any, error) {
+		return nil, migrator.Check(ctx)
+	})
+	health.Register("scheduler", jobs.Check)
 }
 
-// setupRouter sets up the HTTP router with the necessary routes.
-func setupRouter(
-	orgService organization_service.OrganizationService,
-	userService user_service.UserService,
-	tenderService tender_service.TenderService,
-	bidService bid_service.BidService,
-	commentService comment_service.CommentService) *mux.Router {
+// newRateLimiter creates the limiter of the requests of every caller, keeping the buckets
+// in memory. Without rate limiting, it has no policies and allows every request.
+func newRateLimiter(conf *config.Config, jobs *scheduler.Scheduler) *ratelimit.Limiter {
+	store := ratelimit.NewMemoryStore()
+	if !conf.RateLimit.Enabled {
+		return ratelimit.NewLimiter(store, nil)
+	}
+
+	jobs.Add(scheduler.Job{Name: "rate_limit_purge", Interval: conf.Scheduler.RateLimitPurgeInterval, Run: func(ctx context.Context) error {
+		store.PurgeIdle(ctx)
+		return nil
+	}})
+	return ratelimit.NewLimiter(store, map[string]ratelimit.Policy{
+		ratelimit.GroupReads:  ratelimit.PerMinute(conf.RateLimit.ReadsPerMinute, conf.RateLimit.ReadsBurst),
+		ratelimit.GroupWrites: ratelimit.PerMinute(conf.RateLimit.WritesPerMinute, conf.RateLimit.WritesBurst),
+		ratelimit.GroupAuth:   ratelimit.PerMinute(conf.RateLimit.AuthPerMinute, conf.RateLimit.AuthBurst),
+	})
+}
+
+// setupRouter sets up the HTTP router with the necessary routes and middlewares.
+func setupRouter(conf *config.Config, limiter *ratelimit.Limiter, services api.Services) http.Handler {
 
 	shared.Logger.Info("Initializing routes")
 	router := mux.NewRouter()
 
 	// Step 1: Initialize routes for various services
-	api.InitRoutes(router, orgService, userService, tenderService, bidService, commentService)
+	api.InitRoutes(router, services)
 
-	return router
+	// Step 2: Wrap the routes in the middlewares every request passes through
+	return api.WithMiddlewares(router, conf, limiter)
+}
+
+// newServer creates the HTTP server with the limits from the configuration. Requests run
+// with baseCtx, so cancelling it stops the queries of requests still running.
+func newServer(conf config.ServerConfig, handler http.Handler, baseCtx context.Context) *http.Server {
+	return &http.Server{
+		Addr:              conf.Address,
+		Handler:           handler,
+		ReadHeaderTimeout: conf.ReadHeaderTimeout,
+		ReadTimeout:       conf.ReadTimeout,
+		WriteTimeout:      conf.WriteTimeout,
+		IdleTimeout:       conf.IdleTimeout,
+		MaxHeaderBytes:    conf.MaxHeaderBytes,
+		BaseContext:       func(net.Listener) context.Context { return baseCtx },
+	}
 }
 
-// startServer starts the HTTP server with the specified address and router.
-func startServer(address string, router *mux.Router) {
-	shared.Logger.Infof("Starting server on %s...", address)
-	if err := http.ListenAndServe(address, router); err != nil {
-		shared.Logger.Fatalf("Failed to start server: %v", err)
+// serve serves requests until SIGINT or SIGTERM, then stops accepting connections and
+// waits for the requests in progress and the background jobs to finish, at most
+// conf.ShutdownTimeout. Requests still running after that are cancelled. It returns the
+// exit code of the process.
+func serve(conf config.ServerConfig, handler http.Handler, jobs *scheduler.Scheduler) int {
+	baseCtx, cancelRequests := context.WithCancel(context.Background())
+	defer cancelRequests()
+	server := newServer(conf, handler, baseCtx)
+
+	signals, stopSignals := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stopSignals()
+
+	failed := make(chan error, 1)
+	go func() {
+		shared.Logger.Infof("Starting server on %s...", conf.Address)
+		if err := server.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+			failed <- err
+		}
+	}()
+
+	code := 0
+	select {
+	case <-signals.Done():
+		shared.Logger.Info("Shutting down: draining requests in progress and background jobs")
+	case err := <-failed:
+		shared.Logger.Errorf("Server failed: %v", err)
+		code = 1
+	}
+	// A second signal terminates the process right away
+	stopSignals()
+
+	ctx, cancel := context.WithTimeout(context.Background(), conf.ShutdownTimeout)
+	defer cancel()
+
+	if err := server.Shutdown(ctx); err != nil {
+		shared.Logger.Errorf("Requests still in progress after %s were aborted: %v", conf.ShutdownTimeout, err)
+		cancelRequests()
+		server.Close()
+		code = 1
+	}
+	if err := jobs.Stop(ctx); err != nil {
+		shared.Logger.Errorf("Background jobs still running after %s were abandoned: %v", conf.ShutdownTimeout, err)
+		code = 1
 	}
+	shared.Logger.Info("Server stopped")
+	return code
 }
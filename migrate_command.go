@@ -0,0 +1,137 @@
+package main
+
+import (
+	"avitoTest/data/migrations"
+	"avitoTest/shared/config"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"text/tabwriter"
+	"time"
+)
+
+// migrateCommand is the name of the CLI command managing the database schema.
+const migrateCommand = "migrate"
+
+const migrateUsage = `usage: migrate <command> [flags]
+
+commands:
+  up             apply every pending migration
+  down           revert the last applied migrations (-steps, default 1)
+  status         list the migrations and whether they are applied (-json)
+  create NAME    add empty up and down files for a new migration (-dir)`
+
+// runMigrate runs a migrate subcommand and returns the exit code: 0 on success, 1 when
+// the migrations failed and 2 when the command could not run.
+func runMigrate(args []string, conf *config.Config, stdout, stderr io.Writer) int {
+	if len(args) == 0 {
+		fmt.Fprintln(stderr, migrateUsage)
+		return 2
+	}
+
+	flags := flag.NewFlagSet(migrateCommand+" "+args[0], flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	steps := flags.Int("steps", 1, "number of migrations to revert")
+	asJSON := flags.Bool("json", false, "print the status as JSON")
+	dir := flags.String("dir", migrations.Dir, "directory the migration files are created in")
+	if err := flags.Parse(args[1:]); err != nil {
+		return 2
+	}
+
+	// Creating a migration only writes files, so it does not need the database
+	if args[0] == "create" {
+		if flags.NArg() != 1 {
+			fmt.Fprintln(stderr, "create takes the name of the migration")
+			return 2
+		}
+		up, down, err := migrations.Create(*dir, flags.Arg(0))
+		if err != nil {
+			fmt.Fprintln(stderr, err)
+			return 2
+		}
+		fmt.Fprintf(stdout, "Created %s\nCreated %s\n", up, down)
+		return 0
+	}
+
+	db := connectToDatabase(conf)
+	defer closeDatabaseConnection(db)
+	sqlDB, err := db.DB()
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 2
+	}
+	migrator, err := migrations.New(sqlDB, migrations.Files)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 2
+	}
+
+	ctx := context.Background()
+	switch args[0] {
+	case "up":
+		applied, err := migrator.Up(ctx)
+		printMigrations(stdout, "Applied", applied)
+		if err != nil {
+			fmt.Fprintln(stderr, err)
+			return 1
+		}
+	case "down":
+		if *steps < 1 {
+			fmt.Fprintln(stderr, "-steps must be at least 1")
+			return 2
+		}
+		reverted, err := migrator.Down(ctx, *steps)
+		printMigrations(stdout, "Reverted", reverted)
+		if err != nil {
+			fmt.Fprintln(stderr, err)
+			return 1
+		}
+	case "status":
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			fmt.Fprintln(stderr, err)
+			return 1
+		}
+		printStatus(stdout, statuses, *asJSON)
+	default:
+		fmt.Fprintf(stderr, "unknown migrate command %q\n%s\n", args[0], migrateUsage)
+		return 2
+	}
+	return 0
+}
+
+// printMigrations prints one line per migration, or a note that there was none.
+func printMigrations(w io.Writer, verb string, list []migrations.Migration) {
+	if len(list) == 0 {
+		fmt.Fprintln(w, "Nothing to do")
+	}
+	for _, migration := range list {
+		fmt.Fprintf(w, "%s %04d_%s\n", verb, migration.Version, migration.Name)
+	}
+}
+
+// printStatus prints the migration statuses as a table or as JSON.
+func printStatus(w io.Writer, statuses []migrations.Status, asJSON bool) {
+	if asJSON {
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		encoder.Encode(statuses)
+		return
+	}
+
+	table := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(table, "VERSION\tNAME\tAPPLIED AT")
+	for _, status := range statuses {
+		appliedAt := "pending"
+		if status.AppliedAt != nil {
+			appliedAt = status.AppliedAt.Format(time.RFC3339)
+		}
+		if status.Unknown {
+			appliedAt += " (unknown to this build)"
+		}
+		fmt.Fprintf(table, "%04d\t%s\t%s\n", status.Version, status.Name, appliedAt)
+	}
+	table.Flush()
+}
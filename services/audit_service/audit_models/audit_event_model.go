@@ -0,0 +1,22 @@
+package audit_models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// AuditEventModel is a recorded change. Before and After are the snapshots of the
+// entity, absent when it did not exist before or after the change.
+type AuditEventModel struct {
+	ID             int             `json:"id"`
+	EntityType     string          `json:"entity_type"`
+	EntityID       int             `json:"entity_id"`
+	OrganizationID *int            `json:"organization_id,omitempty"`
+	Action         string          `json:"action"`
+	ActorUserID    *int            `json:"actor_user_id,omitempty"`
+	ActorClient    string          `json:"actor_client,omitempty"`
+	RequestID      string          `json:"request_id,omitempty"`
+	Before         json.RawMessage `json:"before,omitempty"`
+	After          json.RawMessage `json:"after,omitempty"`
+	CreatedAt      time.Time       `json:"created_at"`
+}
@@ -0,0 +1,42 @@
+package audit_models
+
+import (
+	"avitoTest/shared/constants"
+	"avitoTest/shared/filter"
+)
+
+// AuditFilterSchema lists the fields audit events can be filtered by. userId is the
+// user who made the change.
+var AuditFilterSchema = filter.Schema{
+	constants.FilterFieldEntityType: {
+		Type:      filter.String,
+		Operators: filter.Equality,
+		Values: []string{
+			string(constants.AuditEntityTender),
+			string(constants.AuditEntityBid),
+			string(constants.AuditEntityOrganization),
+			string(constants.AuditEntityResponsible),
+			string(constants.AuditEntityUser),
+			string(constants.AuditEntityComment),
+		},
+	},
+	constants.FilterFieldAction: {
+		Type:      filter.String,
+		Operators: filter.Equality,
+		Values: []string{
+			string(constants.AuditActionCreated),
+			string(constants.AuditActionUpdated),
+			string(constants.AuditActionDeleted),
+			string(constants.AuditActionPublished),
+			string(constants.AuditActionClosed),
+			string(constants.AuditActionApproved),
+			string(constants.AuditActionRejected),
+			string(constants.AuditActionRolledBack),
+		},
+	},
+	constants.FilterFieldEntityID:       {Type: filter.Int, Operators: filter.Equality},
+	constants.FilterFieldOrganizationID: {Type: filter.Int, Operators: filter.Equality},
+	constants.FilterFieldUserID:         {Type: filter.Int, Operators: filter.Equality},
+	constants.FilterFieldRequestID:      {Type: filter.String, Operators: []filter.Operator{filter.OpEq}},
+	constants.FilterFieldCreatedAt:      {Type: filter.Time, Operators: filter.Comparison},
+}
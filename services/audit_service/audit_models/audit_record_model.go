@@ -0,0 +1,17 @@
+package audit_models
+
+import "avitoTest/shared/constants"
+
+// AuditRecordModel describes a change to record. OrganizationID is the organization the
+// entity belongs to, 0 for none. ActorUserID names the acting user when the request was
+// made on behalf of one without authenticating as them. Before and After are encoded as
+// JSON; nil means the entity did not exist.
+type AuditRecordModel struct {
+	EntityType     constants.AuditEntityType
+	EntityID       int
+	OrganizationID int
+	Action         constants.AuditAction
+	ActorUserID    int
+	Before         any
+	After          any
+}
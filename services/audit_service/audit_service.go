@@ -0,0 +1,149 @@
+package audit_service
+
+import (
+	"avitoTest/data/entities"
+	"avitoTest/data/repositories/audit_repository"
+	"avitoTest/data/repositories/organization_repository"
+	"avitoTest/services/audit_service/audit_models"
+	"avitoTest/shared/auth"
+	"avitoTest/shared/constants"
+	"avitoTest/shared/errors/audit_errors"
+	"avitoTest/shared/errors/domain_errors"
+	"avitoTest/shared/filter"
+	"avitoTest/shared/pagination"
+	"avitoTest/shared/requestid"
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+type auditService struct {
+	auditRepo audit_repository.AuditRepository
+	orgRepo   organization_repository.OrganizationRepository
+}
+
+func NewAuditService(auditRepo audit_repository.AuditRepository, orgRepo organization_repository.OrganizationRepository) AuditService {
+	return &auditService{auditRepo: auditRepo, orgRepo: orgRepo}
+}
+
+// Actor returns the user a change is made by: the user authenticated in ctx, or else
+// fallback, the user the change itself names (such as the creator of a new tender).
+func Actor(ctx context.Context, fallback int) int {
+	if userID := auth.FromContext(ctx).UserID; userID != 0 {
+		return userID
+	}
+	return fallback
+}
+
+// Record appends an event for the change, attributing it to the caller and the request
+// of ctx.
+func (s *auditService) Record(ctx context.Context, record audit_models.AuditRecordModel) error {
+	principal := auth.FromContext(ctx)
+
+	event := &entities.AuditEvent{
+		EntityType:  string(record.EntityType),
+		EntityID:    record.EntityID,
+		Action:      string(record.Action),
+		ActorClient: principal.Client,
+		RequestID:   requestid.FromContext(ctx),
+		CreatedAt:   time.Now(),
+	}
+	if record.OrganizationID != 0 {
+		event.OrganizationID = &record.OrganizationID
+	}
+	if principal.UserID != 0 {
+		event.ActorUserID = &principal.UserID
+	} else if record.ActorUserID != 0 {
+		event.ActorUserID = &record.ActorUserID
+	}
+
+	var err error
+	if event.Before, err = snapshot(record.Before); err != nil {
+		return err
+	}
+	if event.After, err = snapshot(record.After); err != nil {
+		return err
+	}
+
+	return s.auditRepo.Create(ctx, event)
+}
+
+// GetEvents returns a page of the events matching the filter, newest first unless params
+// say otherwise. API clients acting on their own behalf read the whole log; users read the
+// log of an organization they are responsible for, which the filter must select.
+func (s *auditService) GetEvents(ctx context.Context, f filter.Filter, params pagination.Params) (*pagination.Page[*audit_models.AuditEventModel], error) {
+	if err := s.authorize(ctx, f); err != nil {
+		return nil, err
+	}
+
+	events, total, err := s.auditRepo.FindByFilters(ctx, f, params)
+	if err != nil {
+		return nil, err
+	}
+
+	models := make([]*audit_models.AuditEventModel, 0, len(events))
+	for _, event := range events {
+		models = append(models, toAuditEventModel(event))
+	}
+	return pagination.NewPage(models, total, params), nil
+}
+
+func (s *auditService) authorize(ctx context.Context, f filter.Filter) error {
+	principal := auth.FromContext(ctx)
+	if principal.UserID == 0 {
+		if principal.Client == "" {
+			return audit_errors.ErrAuthenticationRequired
+		}
+		return nil
+	}
+
+	conditions := f.Fields(constants.FilterFieldOrganizationID)
+	if len(conditions) != 1 || conditions[0].Operator != filter.OpEq {
+		return audit_errors.ErrOrganizationRequired
+	}
+	orgID, _ := conditions[0].Value().(int)
+
+	if _, err := s.orgRepo.GetResponsibleByID(ctx, orgID, principal.UserID); err != nil {
+		if errors.Is(err, domain_errors.ErrNotFound) {
+			return audit_errors.ErrNotOrganizationAdmin
+		}
+		return err
+	}
+	return nil
+}
+
+// snapshot encodes the state of an entity, nil when there is none, including when it is
+// a nil pointer.
+func snapshot(state any) (*string, error) {
+	data, err := json.Marshal(state)
+	if err != nil {
+		return nil, err
+	}
+	encoded := string(data)
+	if encoded == "null" {
+		return nil, nil
+	}
+	return &encoded, nil
+}
+
+func toAuditEventModel(event *entities.AuditEvent) *audit_models.AuditEventModel {
+	model := &audit_models.AuditEventModel{
+		ID:             event.ID,
+		EntityType:     event.EntityType,
+		EntityID:       event.EntityID,
+		OrganizationID: event.OrganizationID,
+		Action:         event.Action,
+		ActorUserID:    event.ActorUserID,
+		ActorClient:    event.ActorClient,
+		RequestID:      event.RequestID,
+		CreatedAt:      event.CreatedAt,
+	}
+	if event.Before != nil {
+		model.Before = json.RawMessage(*event.Before)
+	}
+	if event.After != nil {
+		model.After = json.RawMessage(*event.After)
+	}
+	return model
+}
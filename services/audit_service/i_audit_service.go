@@ -0,0 +1,13 @@
+package audit_service
+
+import (
+	"avitoTest/services/audit_service/audit_models"
+	"avitoTest/shared/filter"
+	"avitoTest/shared/pagination"
+	"context"
+)
+
+type AuditService interface {
+	Record(ctx context.Context, record audit_models.AuditRecordModel) error
+	GetEvents(ctx context.Context, f filter.Filter, params pagination.Params) (*pagination.Page[*audit_models.AuditEventModel], error)
+}
@@ -0,0 +1,27 @@
+package audit_service
+
+import (
+	"avitoTest/services/audit_service/audit_models"
+	"avitoTest/shared/filter"
+	"avitoTest/shared/pagination"
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockAuditService struct {
+	mock.Mock
+}
+
+func (m *MockAuditService) Record(ctx context.Context, record audit_models.AuditRecordModel) error {
+	args := m.Called(ctx, record)
+	return args.Error(0)
+}
+
+func (m *MockAuditService) GetEvents(ctx context.Context, f filter.Filter, params pagination.Params) (*pagination.Page[*audit_models.AuditEventModel], error) {
+	args := m.Called(ctx, f, params)
+	if page, ok := args.Get(0).(*pagination.Page[*audit_models.AuditEventModel]); ok {
+		return page, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
@@ -0,0 +1,179 @@
+package bid_service
+
+import (
+	"avitoTest/data/repositories/transaction"
+	"avitoTest/services/audit_service"
+	"avitoTest/services/audit_service/audit_models"
+	"avitoTest/services/bid_service/bid_models"
+	"avitoTest/services/tender_service"
+	"avitoTest/services/tender_service/tender_models"
+	"avitoTest/shared/constants"
+	"context"
+	"fmt"
+)
+
+// auditedBidService records an audit event for every change made through the wrapped
+// service.
+type auditedBidService struct {
+	BidService
+	tenders      tender_service.TenderService
+	audit        audit_service.AuditService
+	transactions transaction.Manager
+}
+
+// NewAuditedBidService wraps service so its successful changes are recorded in the audit
+// log, with the bid before and after each change. An approval closing the tender of the
+// bid is recorded as a change to the tender too, read through tenders. Each change is
+// recorded in its own transaction, so failing to record it fails the change.
+func NewAuditedBidService(service BidService, tenders tender_service.TenderService, audit audit_service.AuditService, transactions transaction.Manager) BidService {
+	return &auditedBidService{BidService: service, tenders: tenders, audit: audit, transactions: transactions}
+}
+
+func (s *auditedBidService) CreateBid(ctx context.Context, bid bid_models.BidCreateModel) (*bid_models.BidModel, error) {
+	var created *bid_models.BidModel
+	err := s.transactions.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		if created, err = s.BidService.CreateBid(ctx, bid); err != nil {
+			return err
+		}
+		return s.record(ctx, constants.AuditActionCreated, created.ID, audit_service.Actor(ctx, bid.CreatorID), nil, created)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return created, nil
+}
+
+func (s *auditedBidService) UpdateBid(ctx context.Context, bid bid_models.BidUpdateModel) (*bid_models.BidModel, error) {
+	var updated *bid_models.BidModel
+	err := s.transactions.WithinTransaction(ctx, func(ctx context.Context) error {
+		before := s.snapshot(ctx, bid.ID)
+		var err error
+		if updated, err = s.BidService.UpdateBid(ctx, bid); err != nil {
+			return err
+		}
+		return s.record(ctx, constants.AuditActionUpdated, bid.ID, audit_service.Actor(ctx, 0), before, updated)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+func (s *auditedBidService) ApproveBid(ctx context.Context, bidID, approverID int) error {
+	return s.transactions.WithinTransaction(ctx, func(ctx context.Context) error {
+		before := s.snapshot(ctx, bidID)
+		var tenderBefore *tender_models.TenderModel
+		if before != nil {
+			tenderBefore = s.tenderSnapshot(ctx, before.TenderID)
+		}
+		if err := s.BidService.ApproveBid(ctx, bidID, approverID); err != nil {
+			return err
+		}
+		after := s.snapshot(ctx, bidID)
+		if err := s.record(ctx, constants.AuditActionApproved, bidID, approverID, before, after); err != nil {
+			return err
+		}
+
+		// The approval reaching the quorum closed the tender
+		if tenderBefore != nil && after != nil && after.Status == "APPROVED" {
+			return s.recordTenderClosed(ctx, approverID, tenderBefore, s.tenderSnapshot(ctx, tenderBefore.ID))
+		}
+		return nil
+	})
+}
+
+func (s *auditedBidService) RejectBid(ctx context.Context, bidID, rejecterID int) error {
+	return s.transactions.WithinTransaction(ctx, func(ctx context.Context) error {
+		before := s.snapshot(ctx, bidID)
+		if err := s.BidService.RejectBid(ctx, bidID, rejecterID); err != nil {
+			return err
+		}
+		return s.record(ctx, constants.AuditActionRejected, bidID, rejecterID, before, s.snapshot(ctx, bidID))
+	})
+}
+
+func (s *auditedBidService) RollbackBidVersion(ctx context.Context, bidID int, version int) (*bid_models.BidModel, error) {
+	var rolledBack *bid_models.BidModel
+	err := s.transactions.WithinTransaction(ctx, func(ctx context.Context) error {
+		before := s.snapshot(ctx, bidID)
+		var err error
+		if rolledBack, err = s.BidService.RollbackBidVersion(ctx, bidID, version); err != nil {
+			return err
+		}
+		return s.record(ctx, constants.AuditActionRolledBack, bidID, audit_service.Actor(ctx, 0), before, rolledBack)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return rolledBack, nil
+}
+
+func (s *auditedBidService) DeleteBid(ctx context.Context, bidID int) error {
+	return s.transactions.WithinTransaction(ctx, func(ctx context.Context) error {
+		before := s.snapshot(ctx, bidID)
+		if err := s.BidService.DeleteBid(ctx, bidID); err != nil {
+			return err
+		}
+		return s.record(ctx, constants.AuditActionDeleted, bidID, audit_service.Actor(ctx, 0), before, nil)
+	})
+}
+
+// snapshot returns the bid as it is now, or nil if it cannot be read.
+func (s *auditedBidService) snapshot(ctx context.Context, bidID int) *bid_models.BidModel {
+	bid, err := s.BidService.GetBidByID(ctx, bidID)
+	if err != nil {
+		return nil
+	}
+	return bid
+}
+
+// tenderSnapshot returns the tender as it is now, or nil if it cannot be read.
+func (s *auditedBidService) tenderSnapshot(ctx context.Context, tenderID int) *tender_models.TenderModel {
+	tender, err := s.tenders.GetTenderByID(ctx, tenderID)
+	if err != nil {
+		return nil
+	}
+	return tender
+}
+
+// recordTenderClosed appends the event of the tender closed by the approval of one of
+// its bids.
+func (s *auditedBidService) recordTenderClosed(ctx context.Context, approverID int, before, after *tender_models.TenderModel) error {
+	record := audit_models.AuditRecordModel{
+		EntityType:     constants.AuditEntityTender,
+		EntityID:       before.ID,
+		OrganizationID: before.OrganizationID,
+		Action:         constants.AuditActionClosed,
+		ActorUserID:    approverID,
+		Before:         before,
+		After:          after,
+	}
+	if err := s.audit.Record(ctx, record); err != nil {
+		return fmt.Errorf("audit: recording %s of %s %d: %w", record.Action, record.EntityType, record.EntityID, err)
+	}
+	return nil
+}
+
+// record appends the event of a change to a bid. actorID names the user who made the
+// change, the approver or rejecter of a decision.
+func (s *auditedBidService) record(ctx context.Context, action constants.AuditAction, bidID, actorID int, before, after *bid_models.BidModel) error {
+	record := audit_models.AuditRecordModel{
+		EntityType:  constants.AuditEntityBid,
+		EntityID:    bidID,
+		Action:      action,
+		ActorUserID: actorID,
+		Before:      before,
+		After:       after,
+	}
+	if after != nil {
+		record.OrganizationID = after.OrganizationID
+	} else if before != nil {
+		record.OrganizationID = before.OrganizationID
+	}
+
+	if err := s.audit.Record(ctx, record); err != nil {
+		return fmt.Errorf("audit: recording %s of %s %d: %w", record.Action, record.EntityType, record.EntityID, err)
+	}
+	return nil
+}
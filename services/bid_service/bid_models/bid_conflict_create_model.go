@@ -0,0 +1,7 @@
+package bid_models
+
+type BidConflictCreateModel struct {
+	BidID  int    `json:"bid_id" validate:"required"`
+	UserID int    `json:"user_id" validate:"required"`
+	Reason string `json:"reason" validate:"max=255"`
+}
@@ -0,0 +1,11 @@
+package bid_models
+
+import "time"
+
+type BidConflictModel struct {
+	ID        int       `json:"id"`
+	BidID     int       `json:"bid_id"`
+	UserID    int       `json:"user_id"`
+	Reason    string    `json:"reason"`
+	CreatedAt time.Time `json:"created_at"`
+}
@@ -1,10 +1,10 @@
 package bid_models
 
 type BidCreateModel struct {
-	Name           string `json:"name" validate:"required"`
-	Description    string `json:"description" validate:"required"`
-	TenderID       int    `json:"tender_id" validate:"required"`
-	OrganizationID int    `json:"organization_id" validate:"required"`
-	CreatorID      int    `json:"creator_id" validate:"required"`
-	Status         string `json:"status" validate:"required,oneof=CREATED PUBLISHED CANCELED"`
+	Name           string `json:"name" validate:"required,max=100"`
+	Description    string `json:"description" validate:"max=255"`
+	TenderID       int    `json:"tenderId" validate:"required,gt=0"`
+	OrganizationID int    `json:"organizationId" validate:"required,gt=0"`
+	CreatorID      int    `json:"creatorId" validate:"required"`
+	Status         string `json:"status" validate:"omitempty,bid_status"`
 }
@@ -0,0 +1,22 @@
+package bid_models
+
+import (
+	"avitoTest/shared/constants"
+	"avitoTest/shared/filter"
+)
+
+// DecisionFilterSchema lists the fields bid decisions can be filtered by.
+var DecisionFilterSchema = filter.Schema{
+	constants.FilterFieldDecision: {
+		Type:      filter.String,
+		Operators: filter.Equality,
+		Values: []string{
+			string(constants.BidDecisionApproved),
+			string(constants.BidDecisionRejected),
+		},
+	},
+	constants.FilterFieldBidID:     {Type: filter.Int, Operators: filter.Equality},
+	constants.FilterFieldTenderID:  {Type: filter.Int, Operators: filter.Equality},
+	constants.FilterFieldUserID:    {Type: filter.Int, Operators: filter.Equality},
+	constants.FilterFieldCreatedAt: {Type: filter.Time, Operators: filter.Comparison},
+}
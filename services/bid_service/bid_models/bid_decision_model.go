@@ -0,0 +1,12 @@
+package bid_models
+
+import "time"
+
+type BidDecisionModel struct {
+	ID        int       `json:"id"`
+	BidID     int       `json:"bid_id"`
+	TenderID  int       `json:"tender_id"`
+	UserID    int       `json:"user_id"`
+	Decision  string    `json:"decision"`
+	CreatedAt time.Time `json:"created_at"`
+}
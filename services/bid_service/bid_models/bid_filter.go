@@ -0,0 +1,25 @@
+package bid_models
+
+import (
+	"avitoTest/shared/constants"
+	"avitoTest/shared/filter"
+)
+
+// BidFilterSchema lists the fields bid lists can be filtered by.
+var BidFilterSchema = filter.Schema{
+	constants.FilterFieldStatus: {
+		Type:      filter.String,
+		Operators: filter.Equality,
+		Values: []string{
+			string(constants.BidStatusCreated),
+			string(constants.BidStatusPublished),
+			string(constants.BidStatusClosed),
+			string(constants.BidStatusRejected),
+			string(constants.BidStatusApproved),
+		},
+	},
+	constants.FilterFieldOrganizationID: {Type: filter.Int, Operators: filter.Equality},
+	constants.FilterFieldCreatorID:      {Type: filter.Int, Operators: filter.Equality},
+	constants.FilterFieldCreatedAt:      {Type: filter.Time, Operators: filter.Comparison},
+	constants.FilterFieldName:           {Type: filter.String, Operators: []filter.Operator{filter.OpEq, filter.OpContains}},
+}
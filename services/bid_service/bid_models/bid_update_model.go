@@ -2,6 +2,6 @@ package bid_models
 
 type BidUpdateModel struct {
 	ID          int    `json:"id" validate:"required"`
-	Name        string `json:"name"`
-	Description string `json:"description"`
+	Name        string `json:"name" validate:"max=100"`
+	Description string `json:"description" validate:"max=255"`
 }
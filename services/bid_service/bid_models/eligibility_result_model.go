@@ -0,0 +1,17 @@
+package bid_models
+
+// FailedEligibilityRule describes a tender eligibility rule the organization does not satisfy.
+type FailedEligibilityRule struct {
+	RuleType string `json:"rule_type"`
+	Expected string `json:"expected"`
+	Actual   string `json:"actual"`
+	Message  string `json:"message"`
+}
+
+// EligibilityResultModel is the outcome of checking an organization against a tender's eligibility rules.
+type EligibilityResultModel struct {
+	TenderID       int                     `json:"tender_id"`
+	OrganizationID int                     `json:"organization_id"`
+	Eligible       bool                    `json:"eligible"`
+	FailedRules    []FailedEligibilityRule `json:"failed_rules"`
+}
@@ -5,11 +5,20 @@ import (
 	"avitoTest/data/repositories/bid_repository"
 	"avitoTest/data/repositories/organization_repository"
 	"avitoTest/data/repositories/tender_repository"
+	"avitoTest/data/repositories/transaction"
 	"avitoTest/data/repositories/user_repository"
 	"avitoTest/services/bid_service/bid_models"
 	"avitoTest/shared"
+	"avitoTest/shared/auth"
+	"avitoTest/shared/constants"
+	"avitoTest/shared/errors/bid_errors"
+	"avitoTest/shared/errors/tendert_erorrs"
+	"avitoTest/shared/events"
+	"avitoTest/shared/filter"
+	"avitoTest/shared/metrics"
+	"avitoTest/shared/pagination"
+	"avitoTest/shared/validation"
 	"context"
-	"errors"
 	"time"
 )
 
@@ -17,13 +26,18 @@ type BidService interface {
 	CreateBid(ctx context.Context, bid bid_models.BidCreateModel) (*bid_models.BidModel, error)
 	UpdateBid(ctx context.Context, bid bid_models.BidUpdateModel) (*bid_models.BidModel, error)
 	GetBidByID(ctx context.Context, bidID int) (*bid_models.BidModel, error)
-	GetBidsByTenderID(ctx context.Context, tenderID int) ([]*bid_models.BidModel, error)
-	GetBidsByUserID(ctx context.Context, userID int) ([]*bid_models.BidModel, error)
-	GetBidsByUsername(ctx context.Context, username string) ([]*bid_models.BidModel, error)
+	GetBidsByTenderID(ctx context.Context, tenderID int, f filter.Filter, params pagination.Params) (*pagination.Page[*bid_models.BidModel], error)
+	GetBidsByUserID(ctx context.Context, userID int, params pagination.Params) (*pagination.Page[*bid_models.BidModel], error)
+	GetBidsByUsername(ctx context.Context, username string, params pagination.Params) (*pagination.Page[*bid_models.BidModel], error)
 	ApproveBid(ctx context.Context, bidID, approverID int) error
 	RejectBid(ctx context.Context, bidID, rejecterID int) error
 	RollbackBidVersion(ctx context.Context, bidID int, version int) (*bid_models.BidModel, error)
 	DeleteBid(ctx context.Context, bidID int) error
+	DeclareConflict(ctx context.Context, conflict bid_models.BidConflictCreateModel) (*bid_models.BidConflictModel, error)
+	GetBidConflicts(ctx context.Context, bidID int) ([]*bid_models.BidConflictModel, error)
+	CheckEligibility(ctx context.Context, tenderID, organizationID int) (*bid_models.EligibilityResultModel, error)
+	ExportBids(ctx context.Context, orgID int, f filter.Filter, write func(*bid_models.BidModel) error) error
+	ExportDecisions(ctx context.Context, orgID int, f filter.Filter, write func(*bid_models.BidDecisionModel) error) error
 }
 
 type bidService struct {
@@ -31,6 +45,8 @@ type bidService struct {
 	orgRepo    organization_repository.OrganizationRepository
 	userRepo   user_repository.UserRepository
 	tenderRepo tender_repository.TenderRepository
+	conflicts  *conflictChecker
+	validate   *validation.Validator
 }
 
 func NewBidService(
@@ -43,24 +59,37 @@ func NewBidService(
 		orgRepo:    orgRepo,
 		userRepo:   userRepo,
 		tenderRepo: tenderRepo,
+		conflicts:  newConflictChecker(bidRepo, tenderRepo),
+		validate:   validation.New(nil),
 	}
 }
 
 // CreateBid creates a new bid and its initial version
 func (s *bidService) CreateBid(ctx context.Context, bid bid_models.BidCreateModel) (*bid_models.BidModel, error) {
-	// Validate required fields (example validation, customize as needed)
-	if bid.Name == "" {
-		return nil, errors.New("name is required")
+	if err := s.validate.Struct(ctx, bid); err != nil {
+		return nil, err
 	}
 
 	// Log the TenderID for additional debugging
 	shared.Logger.Infof("Creating bid with TenderID: %d", bid.TenderID)
 
-	if bid.TenderID <= 0 {
-		return nil, errors.New("invalid tender ID")
+	tender, err := s.tenderRepo.FindByID(ctx, bid.TenderID)
+	if err != nil {
+		return nil, err
 	}
-	if bid.OrganizationID <= 0 {
-		return nil, errors.New("invalid organization ID")
+
+	// Block bids that would put the bidder on both sides of the tender
+	if err := s.conflicts.checkSubmission(ctx, tender, bid.OrganizationID, bid.CreatorID); err != nil {
+		return nil, err
+	}
+
+	// Make sure the organization satisfies the eligibility rules declared by the tender owner
+	failedRules, err := s.evaluateEligibility(ctx, tender.ID, bid.OrganizationID)
+	if err != nil {
+		return nil, err
+	}
+	if len(failedRules) > 0 {
+		return nil, &EligibilityError{FailedRules: failedRules}
 	}
 
 	entity := &entities.Bid{
@@ -87,6 +116,7 @@ func (s *bidService) CreateBid(ctx context.Context, bid bid_models.BidCreateMode
 	if err := s.bidRepo.CreateVersion(ctx, version); err != nil {
 		return nil, err
 	}
+	publish(ctx, metrics.BidCreated, events.BidEvent{BidID: entity.ID, TenderID: entity.TenderID, Action: events.ActionCreated})
 
 	return &bid_models.BidModel{
 		ID:             entity.ID,
@@ -103,6 +133,10 @@ func (s *bidService) CreateBid(ctx context.Context, bid bid_models.BidCreateMode
 
 // UpdateBid updates the bid and increments its version
 func (s *bidService) UpdateBid(ctx context.Context, bid bid_models.BidUpdateModel) (*bid_models.BidModel, error) {
+	if err := s.validate.Struct(ctx, bid); err != nil {
+		return nil, err
+	}
+
 	entity, err := s.bidRepo.FindByID(ctx, bid.ID)
 	if err != nil {
 		return nil, err
@@ -170,100 +204,51 @@ func (s *bidService) GetBidByID(ctx context.Context, bidID int) (*bid_models.Bid
 	}, nil
 }
 
-// GetBidsByTenderID retrieves all bids for a specific tender
-func (s *bidService) GetBidsByTenderID(ctx context.Context, tenderID int) ([]*bid_models.BidModel, error) {
-	entities, err := s.bidRepo.FindByTenderID(ctx, tenderID)
+// GetBidsByTenderID retrieves a page of bids for a specific tender matching the filter
+func (s *bidService) GetBidsByTenderID(ctx context.Context, tenderID int, f filter.Filter, params pagination.Params) (*pagination.Page[*bid_models.BidModel], error) {
+	entities, total, err := s.bidRepo.FindByTenderID(ctx, tenderID, f, params)
 	if err != nil {
 		return nil, err
 	}
 
-	var bids []*bid_models.BidModel
-	for _, entity := range entities {
-		latestVersion, err := s.bidRepo.FindLatestVersion(ctx, entity.ID)
-		if err != nil {
-			return nil, err
-		}
-
-		bidModel := &bid_models.BidModel{
-			ID:             entity.ID,
-			Name:           latestVersion.Name,
-			Description:    latestVersion.Description,
-			Status:         entity.Status,
-			TenderID:       entity.TenderID,
-			OrganizationID: entity.OrganizationID,
-			CreatedAt:      entity.CreatedAt,
-			Version:        latestVersion.Version,
-		}
-		bids = append(bids, bidModel)
+	bids, err := toBidModels(entities)
+	if err != nil {
+		return nil, err
 	}
-	return bids, nil
+	return pagination.NewPage(bids, total, params), nil
 }
 
-// GetBidsByUserID retrieves all bids created by a specific user
-func (s *bidService) GetBidsByUserID(ctx context.Context, userID int) ([]*bid_models.BidModel, error) {
-	entities, err := s.bidRepo.FindByCreatorID(ctx, userID)
+// GetBidsByUserID retrieves a page of bids created by a specific user
+func (s *bidService) GetBidsByUserID(ctx context.Context, userID int, params pagination.Params) (*pagination.Page[*bid_models.BidModel], error) {
+	entities, total, err := s.bidRepo.FindByCreatorID(ctx, userID, params)
 	if err != nil {
 		return nil, err
 	}
 
-	var bids []*bid_models.BidModel
-	for _, entity := range entities {
-		latestVersion, err := s.bidRepo.FindLatestVersion(ctx, entity.ID)
-		if err != nil {
-			return nil, err
-		}
-
-		bidModel := &bid_models.BidModel{
-			ID:             entity.ID,
-			Name:           latestVersion.Name,
-			Description:    latestVersion.Description,
-			Status:         entity.Status,
-			TenderID:       entity.TenderID,
-			OrganizationID: entity.OrganizationID,
-			CreatedAt:      entity.CreatedAt,
-			Version:        latestVersion.Version,
-		}
-		bids = append(bids, bidModel)
+	bids, err := toBidModels(entities)
+	if err != nil {
+		return nil, err
 	}
-	return bids, nil
+	return pagination.NewPage(bids, total, params), nil
 }
 
-func (s *bidService) GetBidsByUsername(ctx context.Context, username string) ([]*bid_models.BidModel, error) {
+func (s *bidService) GetBidsByUsername(ctx context.Context, username string, params pagination.Params) (*pagination.Page[*bid_models.BidModel], error) {
 	user, err := s.userRepo.FindByUsername(ctx, username)
 	if err != nil {
-		if errors.Is(err, user_repository.ErrUserNotFound) {
-			return nil, errors.New("user not found")
-		}
 		return nil, err
 	}
 
-	bids, err := s.bidRepo.FindByCreatorID(ctx, user.ID)
+	bids, total, err := s.bidRepo.FindByCreatorID(ctx, user.ID, params)
 	if err != nil {
 		return nil, err
 	}
 
-	var bidModels []*bid_models.BidModel
-	for _, bid := range bids {
-		version, err := s.bidRepo.FindLatestVersion(ctx, bid.ID)
-		if err != nil {
-			return nil, err
-		}
-
-		bidModel := &bid_models.BidModel{
-			ID:             bid.ID,
-			TenderID:       bid.TenderID,
-			OrganizationID: bid.OrganizationID,
-			CreatorID:      bid.CreatorID,
-			Status:         bid.Status,
-			CreatedAt:      bid.CreatedAt,
-			Name:           version.Name,
-			Description:    version.Description,
-		}
-
-		bidModels = append(bidModels, bidModel)
+	bidModels, err := toBidModels(bids)
+	if err != nil {
+		return nil, err
 	}
 
-	return bidModels, nil
+	return pagination.NewPage(bidModels, total, params), nil
 }
 
 func (s *bidService) ApproveBid(ctx context.Context, bidID, approverID int) error {
@@ -273,12 +258,9 @@ func (s *bidService) ApproveBid(ctx context.Context, bidID, approverID int) erro
 		return err
 	}
 
-	// Log the organization ID to see if it's 0
-	shared.Logger.Infof("Bid organization ID: %d", bid.OrganizationID)
-
 	// Validate that the organization exists
 	if bid.OrganizationID <= 0 {
-		return errors.New("invalid organization ID")
+		return bid_errors.ErrInvalidOrganizationID
 	}
 
 	if err := s.validateOrganizationExists(ctx, bid.OrganizationID); err != nil {
@@ -291,7 +273,26 @@ func (s *bidService) ApproveBid(ctx context.Context, bidID, approverID int) erro
 		return err
 	}
 	if !isResponsible {
-		return errors.New("user is not responsible for the organization")
+		return bid_errors.ErrNotResponsible
+	}
+
+	tender, err := s.tenderRepo.FindByID(ctx, bid.TenderID)
+	if err != nil {
+		return err
+	}
+
+	// Make sure the approver has no conflict of interest with this bid
+	if err := s.conflicts.checkApproval(ctx, bid, tender, approverID); err != nil {
+		return err
+	}
+
+	// Every responsible approves a bid at most once
+	decided, err := s.bidRepo.HasDecision(ctx, bid.ID, approverID)
+	if err != nil {
+		return err
+	}
+	if decided {
+		return bid_errors.ErrAlreadyDecided
 	}
 
 	// Get the responsibles for this organization
@@ -301,11 +302,24 @@ func (s *bidService) ApproveBid(ctx context.Context, bidID, approverID int) erro
 	}
 
 	if bid.Status == "REJECTED" {
-		return errors.New("bid already REJECTED")
+		return bid_errors.ErrBidAlreadyRejected
+	}
+	// An approved bid has already closed its tender
+	if bid.Status == "APPROVED" {
+		return bid_errors.ErrBidAlreadyApproved
+	}
+
+	// Responsibles who declared a conflict of interest do not count towards the quorum
+	approvers, err := s.conflicts.eligibleApprovers(ctx, bid.ID, responsibles)
+	if err != nil {
+		return err
+	}
+	if len(approvers) == 0 {
+		return bid_errors.ErrNoEligibleApprovers
 	}
 
 	// Calculate the quorum and increment approval count
-	quorum := min(3, len(responsibles))
+	quorum := min(3, len(approvers))
 	bid.ApprovalCount++
 
 	// Update bid status if quorum is met
@@ -319,7 +333,7 @@ func (s *bidService) ApproveBid(ctx context.Context, bidID, approverID int) erro
 			return err
 		}
 	} else {
-		shared.Logger.Infof("Approval count: %d, Quorum: %d\n", bid.ApprovalCount, quorum)
+		shared.Logger.Debugf("Approval count: %d, Quorum: %d", bid.ApprovalCount, quorum)
 	}
 
 	// Update the bid in the repository
@@ -327,6 +341,18 @@ func (s *bidService) ApproveBid(ctx context.Context, bidID, approverID int) erro
 		return err
 	}
 
+	if err := s.recordDecision(ctx, bid.ID, approverID, constants.BidDecisionApproved); err != nil {
+		return err
+	}
+
+	// Announce the approval only once it has been saved
+	if bid.Status == "APPROVED" {
+		transaction.AfterCommit(ctx, func() {
+			metrics.Tenders.WithLabelValues(events.ActionClosed).Inc()
+			events.Publish(ctx, events.TenderEvent{TenderID: bid.TenderID, Action: events.ActionClosed})
+		})
+		publish(ctx, metrics.BidApproved, events.BidEvent{BidID: bid.ID, TenderID: bid.TenderID, Action: events.ActionApproved})
+	}
 	return nil
 }
 
@@ -343,7 +369,7 @@ func (s *bidService) RejectBid(ctx context.Context, bidID, rejecterID int) error
 		return err
 	}
 	if !isResponsible {
-		return errors.New("user is not responsible for the organization")
+		return bid_errors.ErrNotResponsible
 	}
 
 	//If there is at least one deviation, the status changes to "REJECTED"
@@ -354,9 +380,32 @@ func (s *bidService) RejectBid(ctx context.Context, bidID, rejecterID int) error
 		return err
 	}
 
+	if err := s.recordDecision(ctx, bid.ID, rejecterID, constants.BidDecisionRejected); err != nil {
+		return err
+	}
+	publish(ctx, metrics.BidRejected, events.BidEvent{BidID: bid.ID, TenderID: bid.TenderID, Action: events.ActionRejected})
 	return nil
 }
 
+// recordDecision keeps the approval or rejection of a bid, so nobody decides on it twice
+// and the decision export can list it
+func (s *bidService) recordDecision(ctx context.Context, bidID, userID int, decision constants.BidDecision) error {
+	return s.bidRepo.CreateDecision(ctx, &entities.BidDecision{
+		BidID:     bidID,
+		UserID:    userID,
+		Decision:  string(decision),
+		CreatedAt: time.Now(),
+	})
+}
+
+// publish announces and counts a bid change once the surrounding transaction, if any, has committed
+func publish(ctx context.Context, result string, event events.BidEvent) {
+	transaction.AfterCommit(ctx, func() {
+		metrics.Bids.WithLabelValues(result).Inc()
+		events.Publish(ctx, event)
+	})
+}
+
 // RollbackBidVersion rolls back the bid to a specific version
 func (s *bidService) RollbackBidVersion(ctx context.Context, bidID int, versionNumber int) (*bid_models.BidModel, error) {
 	entity, err := s.bidRepo.FindByID(ctx, bidID)
@@ -366,7 +415,7 @@ func (s *bidService) RollbackBidVersion(ctx context.Context, bidID int, versionN
 
 	tenderVersion, err := s.bidRepo.FindVersionByNumber(ctx, bidID, versionNumber)
 	if err != nil {
-		return nil, errors.New("bid version not found")
+		return nil, err
 	}
 
 	// Create a new version with the rolled-back details
@@ -405,11 +454,94 @@ func (s *bidService) DeleteBid(ctx context.Context, bidID int) error {
 	return s.bidRepo.Delete(ctx, bidID)
 }
 
+// DeclareConflict records a conflict of interest declared by a responsible of the bidding organization.
+// The responsible can no longer approve the bid and is excluded from its quorum.
+func (s *bidService) DeclareConflict(ctx context.Context, conflict bid_models.BidConflictCreateModel) (*bid_models.BidConflictModel, error) {
+	if err := s.validate.Struct(ctx, conflict); err != nil {
+		return nil, err
+	}
+
+	// Only the responsible themselves may declare their conflict of interest
+	principal := auth.FromContext(ctx)
+	if principal.UserID == 0 {
+		return nil, bid_errors.ErrDeclarantRequired
+	}
+	if principal.UserID != conflict.UserID {
+		return nil, bid_errors.ErrNotDeclarant
+	}
+
+	bid, err := s.bidRepo.FindByID(ctx, conflict.BidID)
+	if err != nil {
+		return nil, err
+	}
+
+	isResponsible, err := s.isUserResponsibleForOrganization(ctx, bid.OrganizationID, conflict.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if !isResponsible {
+		return nil, bid_errors.ErrNotResponsible
+	}
+
+	declared, err := s.conflicts.hasDeclaredConflict(ctx, bid.ID, conflict.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if declared {
+		return nil, bid_errors.ErrConflictAlreadyDeclared
+	}
+
+	entity := &entities.BidConflict{
+		BidID:     bid.ID,
+		UserID:    conflict.UserID,
+		Reason:    conflict.Reason,
+		CreatedAt: time.Now(),
+	}
+
+	if err := s.bidRepo.CreateConflict(ctx, entity); err != nil {
+		return nil, err
+	}
+
+	shared.Logger.Infof("User %d declared a conflict of interest for bid %d", conflict.UserID, bid.ID)
+
+	return &bid_models.BidConflictModel{
+		ID:        entity.ID,
+		BidID:     entity.BidID,
+		UserID:    entity.UserID,
+		Reason:    entity.Reason,
+		CreatedAt: entity.CreatedAt,
+	}, nil
+}
+
+// GetBidConflicts retrieves all conflicts of interest declared for a bid
+func (s *bidService) GetBidConflicts(ctx context.Context, bidID int) ([]*bid_models.BidConflictModel, error) {
+	if _, err := s.bidRepo.FindByID(ctx, bidID); err != nil {
+		return nil, err
+	}
+
+	conflicts, err := s.bidRepo.FindConflictsByBidID(ctx, bidID)
+	if err != nil {
+		return nil, err
+	}
+
+	var models []*bid_models.BidConflictModel
+	for _, conflict := range conflicts {
+		models = append(models, &bid_models.BidConflictModel{
+			ID:        conflict.ID,
+			BidID:     conflict.BidID,
+			UserID:    conflict.UserID,
+			Reason:    conflict.Reason,
+			CreatedAt: conflict.CreatedAt,
+		})
+	}
+	return models, nil
+}
+
 // validateTenderExists checks whether a tender exists before updating its status.
 func (s *bidService) validateOrganizationExists(ctx context.Context, organizationID int) error {
 	organization, _ := s.bidRepo.FindByID(ctx, organizationID)
 	if organization == nil {
-		return errors.New("organization not found")
+		return organization_repository.ErrOrganizationNotFound
 	}
 	return nil
 }
@@ -418,7 +550,7 @@ func (s *bidService) validateOrganizationExists(ctx context.Context, organizatio
 func (s *bidService) validateTenderExists(ctx context.Context, tenderID int) error {
 	tender, _ := s.tenderRepo.FindByID(ctx, tenderID)
 	if tender == nil {
-		return errors.New("tender not found")
+		return tendert_erorrs.ErrTenderNotFound
 	}
 	return nil
 }
@@ -445,3 +577,62 @@ func min(a, b int) int {
 	}
 	return b
 }
+
+// ExportBids passes every bid received by the tenders of an organization that matches the filter
+// to write, reading the bids in batches
+func (s *bidService) ExportBids(ctx context.Context, orgID int, f filter.Filter, write func(*bid_models.BidModel) error) error {
+	return s.bidRepo.StreamByTenderOrganizationID(ctx, orgID, f, func(batch []*entities.Bid) error {
+		bids, err := toBidModels(batch)
+		if err != nil {
+			return err
+		}
+		for _, bid := range bids {
+			if err := write(bid); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// ExportDecisions passes every approval and rejection of the bids received by the tenders of an
+// organization that matches the filter to write, reading the decisions in batches
+func (s *bidService) ExportDecisions(ctx context.Context, orgID int, f filter.Filter, write func(*bid_models.BidDecisionModel) error) error {
+	return s.bidRepo.StreamDecisionsByTenderOrganizationID(ctx, orgID, f, func(batch []*entities.BidDecision) error {
+		for _, decision := range batch {
+			if err := write(&bid_models.BidDecisionModel{
+				ID:        decision.ID,
+				BidID:     decision.BidID,
+				TenderID:  decision.Bid.TenderID,
+				UserID:    decision.UserID,
+				Decision:  decision.Decision,
+				CreatedAt: decision.CreatedAt,
+			}); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// toBidModels builds the bid models of a page of bids loaded with their current versions
+func toBidModels(bids []*entities.Bid) ([]*bid_models.BidModel, error) {
+	models := make([]*bid_models.BidModel, 0, len(bids))
+	for _, bid := range bids {
+		if bid.CurrentVersion == nil {
+			return nil, bid_errors.ErrBidVersionNotFound
+		}
+		models = append(models, &bid_models.BidModel{
+			ID:             bid.ID,
+			Name:           bid.CurrentVersion.Name,
+			Description:    bid.CurrentVersion.Description,
+			TenderID:       bid.TenderID,
+			OrganizationID: bid.OrganizationID,
+			CreatorID:      bid.CreatorID,
+			Status:         bid.Status,
+			CreatedAt:      bid.CreatedAt,
+			Version:        bid.CurrentVersion.Version,
+		})
+	}
+	return models, nil
+}
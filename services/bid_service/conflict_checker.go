@@ -0,0 +1,106 @@
+package bid_service
+
+import (
+	"avitoTest/data/entities"
+	"avitoTest/data/repositories/bid_repository"
+	"avitoTest/data/repositories/tender_repository"
+	"avitoTest/shared"
+	"avitoTest/shared/errors/bid_errors"
+	"context"
+)
+
+// conflictChecker detects conflicts of interest between bidders, approvers and the
+// organization that owns the tender, using OrganizationResponsible links.
+type conflictChecker struct {
+	bidRepo    bid_repository.BidRepository
+	tenderRepo tender_repository.TenderRepository
+}
+
+func newConflictChecker(bidRepo bid_repository.BidRepository, tenderRepo tender_repository.TenderRepository) *conflictChecker {
+	return &conflictChecker{
+		bidRepo:    bidRepo,
+		tenderRepo: tenderRepo,
+	}
+}
+
+// checkSubmission blocks bids from the tender's own organization and bids created
+// by a responsible of the tender's organization on behalf of another organization.
+func (c *conflictChecker) checkSubmission(ctx context.Context, tender *entities.Tender, organizationID, creatorID int) error {
+	if tender.OrganizationID == organizationID {
+		shared.Logger.Warnf("Conflict of interest: organization %d bids on its own tender %d", organizationID, tender.ID)
+		return bid_errors.ErrSelfBid
+	}
+
+	responsible, err := c.tenderRepo.FindUserOrganizationResponsibility(ctx, creatorID, tender.OrganizationID)
+	if err != nil {
+		return err
+	}
+	if responsible != nil {
+		shared.Logger.Warnf("Conflict of interest: user %d is responsible for organization %d that owns tender %d", creatorID, tender.OrganizationID, tender.ID)
+		return bid_errors.ErrCreatorConflict
+	}
+
+	return nil
+}
+
+// checkApproval blocks approvals by the bid creator, by responsibles of the tender's
+// organization and by users who declared a conflict of interest for the bid.
+func (c *conflictChecker) checkApproval(ctx context.Context, bid *entities.Bid, tender *entities.Tender, approverID int) error {
+	if bid.CreatorID == approverID {
+		return bid_errors.ErrApproverConflict
+	}
+
+	responsible, err := c.tenderRepo.FindUserOrganizationResponsibility(ctx, approverID, tender.OrganizationID)
+	if err != nil {
+		return err
+	}
+	if responsible != nil {
+		return bid_errors.ErrApproverConflict
+	}
+
+	declared, err := c.hasDeclaredConflict(ctx, bid.ID, approverID)
+	if err != nil {
+		return err
+	}
+	if declared {
+		return bid_errors.ErrApproverConflict
+	}
+
+	return nil
+}
+
+// eligibleApprovers filters out responsibles who declared a conflict of interest for the bid.
+func (c *conflictChecker) eligibleApprovers(ctx context.Context, bidID int, responsibles []entities.User) ([]entities.User, error) {
+	conflicts, err := c.bidRepo.FindConflictsByBidID(ctx, bidID)
+	if err != nil {
+		return nil, err
+	}
+
+	excluded := make(map[int]bool, len(conflicts))
+	for _, conflict := range conflicts {
+		excluded[conflict.UserID] = true
+	}
+
+	var eligible []entities.User
+	for _, responsible := range responsibles {
+		if !excluded[responsible.ID] {
+			eligible = append(eligible, responsible)
+		}
+	}
+	return eligible, nil
+}
+
+// hasDeclaredConflict checks whether the user already declared a conflict of interest for the bid.
+func (c *conflictChecker) hasDeclaredConflict(ctx context.Context, bidID, userID int) (bool, error) {
+	conflicts, err := c.bidRepo.FindConflictsByBidID(ctx, bidID)
+	if err != nil {
+		return false, err
+	}
+
+	for _, conflict := range conflicts {
+		if conflict.UserID == userID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,149 @@
+package bid_service
+
+import (
+	"avitoTest/data/entities"
+	"avitoTest/services/bid_service/bid_models"
+	"avitoTest/shared/constants"
+	"avitoTest/shared/errors/bid_errors"
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// EligibilityError is returned when a bidding organization fails the tender's eligibility rules.
+type EligibilityError struct {
+	FailedRules []bid_models.FailedEligibilityRule
+}
+
+func (e *EligibilityError) Error() string {
+	messages := make([]string, 0, len(e.FailedRules))
+	for _, rule := range e.FailedRules {
+		messages = append(messages, rule.Message)
+	}
+	return fmt.Sprintf("%s: %s", bid_errors.ErrNotEligible, strings.Join(messages, "; "))
+}
+
+func (e *EligibilityError) Unwrap() error {
+	return bid_errors.ErrNotEligible
+}
+
+// CheckEligibility evaluates an organization against the eligibility rules of a tender.
+func (s *bidService) CheckEligibility(ctx context.Context, tenderID, organizationID int) (*bid_models.EligibilityResultModel, error) {
+	if _, err := s.tenderRepo.FindByID(ctx, tenderID); err != nil {
+		return nil, err
+	}
+
+	failed, err := s.evaluateEligibility(ctx, tenderID, organizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &bid_models.EligibilityResultModel{
+		TenderID:       tenderID,
+		OrganizationID: organizationID,
+		Eligible:       len(failed) == 0,
+		FailedRules:    failed,
+	}, nil
+}
+
+// evaluateEligibility returns the eligibility rules of the tender the organization does not satisfy.
+func (s *bidService) evaluateEligibility(ctx context.Context, tenderID, organizationID int) ([]bid_models.FailedEligibilityRule, error) {
+	rules, err := s.tenderRepo.FindEligibilityRules(ctx, tenderID)
+	if err != nil {
+		return nil, err
+	}
+	if len(rules) == 0 {
+		return []bid_models.FailedEligibilityRule{}, nil
+	}
+
+	organization, err := s.orgRepo.FindByID(ctx, organizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	return evaluateEligibilityRules(organization, rules, time.Now()), nil
+}
+
+// evaluateEligibilityRules checks the organization against every rule and collects the failed ones.
+func evaluateEligibilityRules(organization *entities.Organization, rules []*entities.TenderEligibilityRule, now time.Time) []bid_models.FailedEligibilityRule {
+	failed := []bid_models.FailedEligibilityRule{}
+
+	for _, rule := range rules {
+		switch constants.EligibilityRuleType(rule.RuleType) {
+		case constants.EligibilityOrganizationType:
+			if !containsValue(strings.Split(rule.Value, ","), string(organization.Type)) {
+				failed = append(failed, bid_models.FailedEligibilityRule{
+					RuleType: rule.RuleType,
+					Expected: rule.Value,
+					Actual:   string(organization.Type),
+					Message:  fmt.Sprintf("organization type must be one of %s", rule.Value),
+				})
+			}
+		case constants.EligibilityMinRegistrationMonths:
+			months, err := strconv.Atoi(rule.Value)
+			if err != nil || months <= 0 {
+				failed = append(failed, invalidRule(rule, "months must be a positive number"))
+				continue
+			}
+			if organization.CreatedAt.AddDate(0, months, 0).After(now) {
+				failed = append(failed, bid_models.FailedEligibilityRule{
+					RuleType: rule.RuleType,
+					Expected: rule.Value,
+					Actual:   strconv.Itoa(monthsBetween(organization.CreatedAt, now)),
+					Message:  fmt.Sprintf("organization must be registered for at least %s months", rule.Value),
+				})
+			}
+		case constants.EligibilityServiceType:
+			provided := make([]string, 0, len(organization.ServiceTypes))
+			for _, serviceType := range organization.ServiceTypes {
+				provided = append(provided, serviceType.ServiceType)
+			}
+			if !containsValue(provided, rule.Value) {
+				failed = append(failed, bid_models.FailedEligibilityRule{
+					RuleType: rule.RuleType,
+					Expected: rule.Value,
+					Actual:   strings.Join(provided, ","),
+					Message:  fmt.Sprintf("organization must provide %s", rule.Value),
+				})
+			}
+		default:
+			// A rule that cannot be checked is not satisfied
+			failed = append(failed, invalidRule(rule, "unknown rule type"))
+		}
+	}
+
+	return failed
+}
+
+// invalidRule reports a rule that cannot be evaluated as failed.
+func invalidRule(rule *entities.TenderEligibilityRule, reason string) bid_models.FailedEligibilityRule {
+	return bid_models.FailedEligibilityRule{
+		RuleType: rule.RuleType,
+		Expected: rule.Value,
+		Message:  fmt.Sprintf("invalid %s rule: %s", rule.RuleType, reason),
+	}
+}
+
+// containsValue checks whether the trimmed values contain the target.
+func containsValue(values []string, target string) bool {
+	for _, value := range values {
+		if strings.TrimSpace(value) == target {
+			return true
+		}
+	}
+	return false
+}
+
+// monthsBetween returns the number of full months elapsed between two dates.
+func monthsBetween(from, to time.Time) int {
+	months := (to.Year()-from.Year())*12 + int(to.Month()-from.Month())
+	if to.Day() < from.Day() {
+		months--
+	}
+	if months < 0 {
+		return 0
+	}
+	return months
+}
@@ -2,6 +2,8 @@ package bid_service
 
 import (
 	"avitoTest/services/bid_service/bid_models"
+	"avitoTest/shared/filter"
+	"avitoTest/shared/pagination"
 	"context"
 
 	"github.com/stretchr/testify/mock"
@@ -27,19 +29,19 @@ func (m *MockBidService) GetBidByID(ctx context.Context, bidID int) (*bid_models
 	return args.Get(0).(*bid_models.BidModel), args.Error(1)
 }
 
-func (m *MockBidService) GetBidsByTenderID(ctx context.Context, tenderID int) ([]*bid_models.BidModel, error) {
-	args := m.Called(ctx, tenderID)
-	return args.Get(0).([]*bid_models.BidModel), args.Error(1)
+func (m *MockBidService) GetBidsByTenderID(ctx context.Context, tenderID int, f filter.Filter, params pagination.Params) (*pagination.Page[*bid_models.BidModel], error) {
+	args := m.Called(ctx, tenderID, f, params)
+	return args.Get(0).(*pagination.Page[*bid_models.BidModel]), args.Error(1)
 }
 
-func (m *MockBidService) GetBidsByUserID(ctx context.Context, userID int) ([]*bid_models.BidModel, error) {
-	args := m.Called(ctx, userID)
-	return args.Get(0).([]*bid_models.BidModel), args.Error(1)
+func (m *MockBidService) GetBidsByUserID(ctx context.Context, userID int, params pagination.Params) (*pagination.Page[*bid_models.BidModel], error) {
+	args := m.Called(ctx, userID, params)
+	return args.Get(0).(*pagination.Page[*bid_models.BidModel]), args.Error(1)
 }
 
-func (m *MockBidService) GetBidsByUsername(ctx context.Context, username string) ([]*bid_models.BidModel, error) {
-	args := m.Called(ctx, username)
-	return args.Get(0).([]*bid_models.BidModel), args.Error(1)
+func (m *MockBidService) GetBidsByUsername(ctx context.Context, username string, params pagination.Params) (*pagination.Page[*bid_models.BidModel], error) {
+	args := m.Called(ctx, username, params)
+	return args.Get(0).(*pagination.Page[*bid_models.BidModel]), args.Error(1)
 }
 
 func (m *MockBidService) ApproveBid(ctx context.Context, bidID, approverID int) error {
@@ -61,3 +63,51 @@ func (m *MockBidService) DeleteBid(ctx context.Context, bidID int) error {
 	args := m.Called(ctx, bidID)
 	return args.Error(0)
 }
+
+func (m *MockBidService) DeclareConflict(ctx context.Context, conflict bid_models.BidConflictCreateModel) (*bid_models.BidConflictModel, error) {
+	args := m.Called(ctx, conflict)
+	if model, ok := args.Get(0).(*bid_models.BidConflictModel); ok {
+		return model, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockBidService) GetBidConflicts(ctx context.Context, bidID int) ([]*bid_models.BidConflictModel, error) {
+	args := m.Called(ctx, bidID)
+	if models, ok := args.Get(0).([]*bid_models.BidConflictModel); ok {
+		return models, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockBidService) CheckEligibility(ctx context.Context, tenderID, organizationID int) (*bid_models.EligibilityResultModel, error) {
+	args := m.Called(ctx, tenderID, organizationID)
+	if result, ok := args.Get(0).(*bid_models.EligibilityResultModel); ok {
+		return result, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockBidService) ExportBids(ctx context.Context, orgID int, f filter.Filter, write func(*bid_models.BidModel) error) error {
+	args := m.Called(ctx, orgID, f)
+	if bids, ok := args.Get(0).([]*bid_models.BidModel); ok {
+		for _, bid := range bids {
+			if err := write(bid); err != nil {
+				return err
+			}
+		}
+	}
+	return args.Error(1)
+}
+
+func (m *MockBidService) ExportDecisions(ctx context.Context, orgID int, f filter.Filter, write func(*bid_models.BidDecisionModel) error) error {
+	args := m.Called(ctx, orgID, f)
+	if decisions, ok := args.Get(0).([]*bid_models.BidDecisionModel); ok {
+		for _, decision := range decisions {
+			if err := write(decision); err != nil {
+				return err
+			}
+		}
+	}
+	return args.Error(1)
+}
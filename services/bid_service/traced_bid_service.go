@@ -0,0 +1,110 @@
+package bid_service
+
+import (
+	"avitoTest/services/bid_service/bid_models"
+	"avitoTest/shared/filter"
+	"avitoTest/shared/pagination"
+	"avitoTest/shared/tracing"
+	"context"
+)
+
+// tracedBidService starts a span for every call to the wrapped service.
+type tracedBidService struct {
+	BidService
+}
+
+// NewTracedBidService wraps service so each of its methods is traced as a span named
+// after the method, with the queries it runs as children.
+func NewTracedBidService(service BidService) BidService {
+	return &tracedBidService{BidService: service}
+}
+
+func (s *tracedBidService) CreateBid(ctx context.Context, bid bid_models.BidCreateModel) (created *bid_models.BidModel, err error) {
+	ctx, span := tracing.Start(ctx, "BidService.CreateBid")
+	defer func() { tracing.End(span, err) }()
+	return s.BidService.CreateBid(ctx, bid)
+}
+
+func (s *tracedBidService) UpdateBid(ctx context.Context, bid bid_models.BidUpdateModel) (updated *bid_models.BidModel, err error) {
+	ctx, span := tracing.Start(ctx, "BidService.UpdateBid")
+	defer func() { tracing.End(span, err) }()
+	return s.BidService.UpdateBid(ctx, bid)
+}
+
+func (s *tracedBidService) GetBidByID(ctx context.Context, bidID int) (bid *bid_models.BidModel, err error) {
+	ctx, span := tracing.Start(ctx, "BidService.GetBidByID")
+	defer func() { tracing.End(span, err) }()
+	return s.BidService.GetBidByID(ctx, bidID)
+}
+
+func (s *tracedBidService) GetBidsByTenderID(ctx context.Context, tenderID int, f filter.Filter, params pagination.Params) (page *pagination.Page[*bid_models.BidModel], err error) {
+	ctx, span := tracing.Start(ctx, "BidService.GetBidsByTenderID")
+	defer func() { tracing.End(span, err) }()
+	return s.BidService.GetBidsByTenderID(ctx, tenderID, f, params)
+}
+
+func (s *tracedBidService) GetBidsByUserID(ctx context.Context, userID int, params pagination.Params) (page *pagination.Page[*bid_models.BidModel], err error) {
+	ctx, span := tracing.Start(ctx, "BidService.GetBidsByUserID")
+	defer func() { tracing.End(span, err) }()
+	return s.BidService.GetBidsByUserID(ctx, userID, params)
+}
+
+func (s *tracedBidService) GetBidsByUsername(ctx context.Context, username string, params pagination.Params) (page *pagination.Page[*bid_models.BidModel], err error) {
+	ctx, span := tracing.Start(ctx, "BidService.GetBidsByUsername")
+	defer func() { tracing.End(span, err) }()
+	return s.BidService.GetBidsByUsername(ctx, username, params)
+}
+
+func (s *tracedBidService) ApproveBid(ctx context.Context, bidID, approverID int) (err error) {
+	ctx, span := tracing.Start(ctx, "BidService.ApproveBid")
+	defer func() { tracing.End(span, err) }()
+	return s.BidService.ApproveBid(ctx, bidID, approverID)
+}
+
+func (s *tracedBidService) RejectBid(ctx context.Context, bidID, rejecterID int) (err error) {
+	ctx, span := tracing.Start(ctx, "BidService.RejectBid")
+	defer func() { tracing.End(span, err) }()
+	return s.BidService.RejectBid(ctx, bidID, rejecterID)
+}
+
+func (s *tracedBidService) RollbackBidVersion(ctx context.Context, bidID int, version int) (bid *bid_models.BidModel, err error) {
+	ctx, span := tracing.Start(ctx, "BidService.RollbackBidVersion")
+	defer func() { tracing.End(span, err) }()
+	return s.BidService.RollbackBidVersion(ctx, bidID, version)
+}
+
+func (s *tracedBidService) DeleteBid(ctx context.Context, bidID int) (err error) {
+	ctx, span := tracing.Start(ctx, "BidService.DeleteBid")
+	defer func() { tracing.End(span, err) }()
+	return s.BidService.DeleteBid(ctx, bidID)
+}
+
+func (s *tracedBidService) DeclareConflict(ctx context.Context, conflict bid_models.BidConflictCreateModel) (declared *bid_models.BidConflictModel, err error) {
+	ctx, span := tracing.Start(ctx, "BidService.DeclareConflict")
+	defer func() { tracing.End(span, err) }()
+	return s.BidService.DeclareConflict(ctx, conflict)
+}
+
+func (s *tracedBidService) GetBidConflicts(ctx context.Context, bidID int) (conflicts []*bid_models.BidConflictModel, err error) {
+	ctx, span := tracing.Start(ctx, "BidService.GetBidConflicts")
+	defer func() { tracing.End(span, err) }()
+	return s.BidService.GetBidConflicts(ctx, bidID)
+}
+
+func (s *tracedBidService) CheckEligibility(ctx context.Context, tenderID, organizationID int) (result *bid_models.EligibilityResultModel, err error) {
+	ctx, span := tracing.Start(ctx, "BidService.CheckEligibility")
+	defer func() { tracing.End(span, err) }()
+	return s.BidService.CheckEligibility(ctx, tenderID, organizationID)
+}
+
+func (s *tracedBidService) ExportBids(ctx context.Context, orgID int, f filter.Filter, write func(*bid_models.BidModel) error) (err error) {
+	ctx, span := tracing.Start(ctx, "BidService.ExportBids")
+	defer func() { tracing.End(span, err) }()
+	return s.BidService.ExportBids(ctx, orgID, f, write)
+}
+
+func (s *tracedBidService) ExportDecisions(ctx context.Context, orgID int, f filter.Filter, write func(*bid_models.BidDecisionModel) error) (err error) {
+	ctx, span := tracing.Start(ctx, "BidService.ExportDecisions")
+	defer func() { tracing.End(span, err) }()
+	return s.BidService.ExportDecisions(ctx, orgID, f, write)
+}
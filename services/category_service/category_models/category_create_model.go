@@ -0,0 +1,10 @@
+package category_models
+
+// CategoryCreateModel represents the data needed to create a service category.
+// A category is active unless IsActive is explicitly set to false.
+type CategoryCreateModel struct {
+	Code     string            `json:"code" validate:"required,max=100"`
+	ParentID *int              `json:"parent_id"`
+	IsActive *bool             `json:"is_active"`
+	Names    map[string]string `json:"names" validate:"required,min=1,dive,keys,required,max=10,endkeys,required,max=255"`
+}
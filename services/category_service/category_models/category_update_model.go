@@ -0,0 +1,10 @@
+package category_models
+
+// CategoryUpdateModel represents the data needed to update a service category.
+// The code is immutable because it is stored as the service type of tenders and organizations.
+type CategoryUpdateModel struct {
+	ID       int               `json:"id" validate:"required"`
+	ParentID *int              `json:"parent_id"`
+	IsActive bool              `json:"is_active"`
+	Names    map[string]string `json:"names" validate:"required,min=1,dive,keys,required,max=10,endkeys,required,max=255"`
+}
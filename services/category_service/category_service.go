@@ -0,0 +1,212 @@
+package category_service
+
+import (
+	"avitoTest/data/entities"
+	"avitoTest/data/repositories/category_repository"
+	"avitoTest/services/category_service/category_models"
+	"avitoTest/shared/errors/category_errors"
+	"avitoTest/shared/events"
+	"avitoTest/shared/validation"
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// DefaultLanguage is used when the requested language has no localized name.
+const DefaultLanguage = "ru"
+
+type categoryService struct {
+	categoryRepo category_repository.CategoryRepository
+	validate     *validation.Validator
+}
+
+// NewCategoryService creates a new instance of CategoryService.
+func NewCategoryService(categoryRepo category_repository.CategoryRepository) CategoryService {
+	return &categoryService{
+		categoryRepo: categoryRepo,
+		validate:     validation.New(nil),
+	}
+}
+
+// CreateCategory creates a new service category.
+func (s *categoryService) CreateCategory(ctx context.Context, model category_models.CategoryCreateModel) (*category_models.CategoryModel, error) {
+	if err := s.validate.Struct(ctx, model); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.categoryRepo.FindByCode(ctx, model.Code); err == nil {
+		return nil, category_errors.ErrCategoryCodeTaken
+	} else if !errors.Is(err, category_errors.ErrCategoryNotFound) {
+		return nil, err
+	}
+
+	if model.ParentID != nil {
+		if _, err := s.categoryRepo.FindByID(ctx, *model.ParentID); err != nil {
+			if errors.Is(err, category_errors.ErrCategoryNotFound) {
+				return nil, category_errors.ErrInvalidParent
+			}
+			return nil, err
+		}
+	}
+
+	entity := &entities.ServiceCategory{
+		Code:     model.Code,
+		ParentID: model.ParentID,
+		IsActive: model.IsActive == nil || *model.IsActive,
+		Names:    buildNames(model.Names),
+	}
+
+	if err := s.categoryRepo.Create(ctx, entity); err != nil {
+		return nil, err
+	}
+
+	events.Publish(ctx, events.CategoryEvent{CategoryID: entity.ID, Action: events.ActionCreated})
+	return toCategoryModel(entity, DefaultLanguage), nil
+}
+
+// UpdateCategory updates the parent, active flag and localized names of a service category.
+func (s *categoryService) UpdateCategory(ctx context.Context, model category_models.CategoryUpdateModel) (*category_models.CategoryModel, error) {
+	if err := s.validate.Struct(ctx, model); err != nil {
+		return nil, err
+	}
+
+	entity, err := s.categoryRepo.FindByID(ctx, model.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if model.ParentID != nil {
+		if err := s.checkParent(ctx, model.ID, *model.ParentID); err != nil {
+			return nil, err
+		}
+	}
+
+	entity.ParentID = model.ParentID
+	entity.IsActive = model.IsActive
+	entity.Names = buildNames(model.Names)
+
+	if err := s.categoryRepo.Update(ctx, entity); err != nil {
+		return nil, err
+	}
+
+	events.Publish(ctx, events.CategoryEvent{CategoryID: entity.ID, Action: events.ActionUpdated})
+	return toCategoryModel(entity, DefaultLanguage), nil
+}
+
+// DeleteCategory deletes a service category that has no subcategories and that no tender,
+// organization or eligibility rule refers to. A category in use can only be deactivated.
+func (s *categoryService) DeleteCategory(ctx context.Context, id int) error {
+	entity, err := s.categoryRepo.FindByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	children, err := s.categoryRepo.CountChildren(ctx, id)
+	if err != nil {
+		return err
+	}
+	if children > 0 {
+		return category_errors.ErrCategoryHasChildren
+	}
+
+	inUse, err := s.categoryRepo.IsCodeInUse(ctx, entity.Code)
+	if err != nil {
+		return err
+	}
+	if inUse {
+		return category_errors.ErrCategoryInUse
+	}
+
+	if err := s.categoryRepo.Delete(ctx, id); err != nil {
+		return err
+	}
+
+	events.Publish(ctx, events.CategoryEvent{CategoryID: id, Action: events.ActionDeleted})
+	return nil
+}
+
+// GetCategoryByID retrieves a service category with its name in the requested language.
+func (s *categoryService) GetCategoryByID(ctx context.Context, id int, lang string) (*category_models.CategoryModel, error) {
+	entity, err := s.categoryRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return toCategoryModel(entity, lang), nil
+}
+
+// GetCategories retrieves the service categories with names in the requested language.
+func (s *categoryService) GetCategories(ctx context.Context, lang string, includeInactive bool) ([]*category_models.CategoryModel, error) {
+	categories, err := s.categoryRepo.GetAll(ctx, includeInactive)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*category_models.CategoryModel, 0, len(categories))
+	for _, category := range categories {
+		result = append(result, toCategoryModel(category, lang))
+	}
+	return result, nil
+}
+
+// checkParent ensures the parent exists and is not the category itself or one of its descendants.
+func (s *categoryService) checkParent(ctx context.Context, id, parentID int) error {
+	for current := parentID; ; {
+		if current == id {
+			return fmt.Errorf("%w: category cannot be nested under itself", category_errors.ErrInvalidParent)
+		}
+		parent, err := s.categoryRepo.FindByID(ctx, current)
+		if err != nil {
+			if errors.Is(err, category_errors.ErrCategoryNotFound) {
+				return category_errors.ErrInvalidParent
+			}
+			return err
+		}
+		if parent.ParentID == nil {
+			return nil
+		}
+		current = *parent.ParentID
+	}
+}
+
+// buildNames converts localized names to entities in a stable order.
+func buildNames(names map[string]string) []entities.ServiceCategoryName {
+	languages := make([]string, 0, len(names))
+	for language := range names {
+		languages = append(languages, language)
+	}
+	sort.Strings(languages)
+
+	result := make([]entities.ServiceCategoryName, 0, len(names))
+	for _, language := range languages {
+		result = append(result, entities.ServiceCategoryName{Language: language, Name: names[language]})
+	}
+	return result
+}
+
+// toCategoryModel converts a service category entity to a model, resolving its name for lang.
+func toCategoryModel(entity *entities.ServiceCategory, lang string) *category_models.CategoryModel {
+	names := make(map[string]string, len(entity.Names))
+	for _, name := range entity.Names {
+		names[name.Language] = name.Name
+	}
+
+	name, ok := names[lang]
+	if !ok {
+		name, ok = names[DefaultLanguage]
+	}
+	if !ok {
+		name = entity.Code
+	}
+
+	return &category_models.CategoryModel{
+		ID:        entity.ID,
+		Code:      entity.Code,
+		ParentID:  entity.ParentID,
+		IsActive:  entity.IsActive,
+		Name:      name,
+		Names:     names,
+		CreatedAt: entity.CreatedAt,
+		UpdatedAt: entity.UpdatedAt,
+	}
+}
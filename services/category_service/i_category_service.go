@@ -0,0 +1,14 @@
+package category_service
+
+import (
+	"avitoTest/services/category_service/category_models"
+	"context"
+)
+
+type CategoryService interface {
+	CreateCategory(ctx context.Context, model category_models.CategoryCreateModel) (*category_models.CategoryModel, error)
+	UpdateCategory(ctx context.Context, model category_models.CategoryUpdateModel) (*category_models.CategoryModel, error)
+	DeleteCategory(ctx context.Context, id int) error
+	GetCategoryByID(ctx context.Context, id int, lang string) (*category_models.CategoryModel, error)
+	GetCategories(ctx context.Context, lang string, includeInactive bool) ([]*category_models.CategoryModel, error)
+}
@@ -0,0 +1,49 @@
+package category_service
+
+import (
+	"avitoTest/services/category_service/category_models"
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockCategoryService struct {
+	mock.Mock
+}
+
+func (m *MockCategoryService) CreateCategory(ctx context.Context, model category_models.CategoryCreateModel) (*category_models.CategoryModel, error) {
+	args := m.Called(ctx, model)
+	if category, ok := args.Get(0).(*category_models.CategoryModel); ok {
+		return category, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockCategoryService) UpdateCategory(ctx context.Context, model category_models.CategoryUpdateModel) (*category_models.CategoryModel, error) {
+	args := m.Called(ctx, model)
+	if category, ok := args.Get(0).(*category_models.CategoryModel); ok {
+		return category, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockCategoryService) DeleteCategory(ctx context.Context, id int) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockCategoryService) GetCategoryByID(ctx context.Context, id int, lang string) (*category_models.CategoryModel, error) {
+	args := m.Called(ctx, id, lang)
+	if category, ok := args.Get(0).(*category_models.CategoryModel); ok {
+		return category, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockCategoryService) GetCategories(ctx context.Context, lang string, includeInactive bool) ([]*category_models.CategoryModel, error) {
+	args := m.Called(ctx, lang, includeInactive)
+	if categories, ok := args.Get(0).([]*category_models.CategoryModel); ok {
+		return categories, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
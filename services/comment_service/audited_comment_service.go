@@ -0,0 +1,75 @@
+package comment_service
+
+import (
+	"avitoTest/data/repositories/transaction"
+	"avitoTest/services/audit_service"
+	"avitoTest/services/audit_service/audit_models"
+	"avitoTest/services/comment_service/comment_models"
+	"avitoTest/shared/constants"
+	"context"
+	"fmt"
+)
+
+// auditedCommentService records an audit event for every change made through the wrapped
+// service.
+type auditedCommentService struct {
+	CommentService
+	audit        audit_service.AuditService
+	transactions transaction.Manager
+}
+
+// NewAuditedCommentService wraps service so the comments it creates and deletes are
+// recorded in the audit log. Each change is recorded in its own transaction, so failing
+// to record it fails the change.
+func NewAuditedCommentService(service CommentService, audit audit_service.AuditService, transactions transaction.Manager) CommentService {
+	return &auditedCommentService{CommentService: service, audit: audit, transactions: transactions}
+}
+
+func (s *auditedCommentService) CreateComment(ctx context.Context, model comment_models.CommentCreateModel) (*comment_models.CommentModel, error) {
+	var created *comment_models.CommentModel
+	err := s.transactions.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		if created, err = s.CommentService.CreateComment(ctx, model); err != nil {
+			return err
+		}
+		return s.record(ctx, constants.AuditActionCreated, created.ID, nil, created)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return created, nil
+}
+
+func (s *auditedCommentService) DeleteComment(ctx context.Context, id int) error {
+	return s.transactions.WithinTransaction(ctx, func(ctx context.Context) error {
+		before, _ := s.CommentService.GetCommentByID(ctx, id)
+		if err := s.CommentService.DeleteComment(ctx, id); err != nil {
+			return err
+		}
+		// Deleting a comment that does not exist changes nothing
+		if before == nil {
+			return nil
+		}
+		return s.record(ctx, constants.AuditActionDeleted, id, before, nil)
+	})
+}
+
+func (s *auditedCommentService) record(ctx context.Context, action constants.AuditAction, commentID int, before, after *comment_models.CommentModel) error {
+	record := audit_models.AuditRecordModel{
+		EntityType: constants.AuditEntityComment,
+		EntityID:   commentID,
+		Action:     action,
+		Before:     before,
+		After:      after,
+	}
+	if after != nil {
+		record.OrganizationID = after.OrganizationID
+	} else if before != nil {
+		record.OrganizationID = before.OrganizationID
+	}
+
+	if err := s.audit.Record(ctx, record); err != nil {
+		return fmt.Errorf("audit: recording %s of %s %d: %w", record.Action, record.EntityType, record.EntityID, err)
+	}
+	return nil
+}
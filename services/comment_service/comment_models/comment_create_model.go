@@ -2,7 +2,7 @@ package comment_models
 
 // CommentCreateModel represents the data needed to create a comment
 type CommentCreateModel struct {
-	UserID            int    `json:"user_id"`
+	UserID            int    `json:"user_id" validate:"required"`
 	OrganizationID    int    `json:"organization_id"`
 	CompanyName       string `json:"company_name"`
 	TenderName        string `json:"tender_name"`
@@ -4,23 +4,27 @@ import (
 	"avitoTest/data/entities"
 	"avitoTest/data/repositories/comment_repository"
 	"avitoTest/services/comment_service/comment_models"
+	"avitoTest/shared/pagination"
+	"avitoTest/shared/validation"
 	"context"
-	"errors"
 	"time"
 )
 
 type commentService struct {
 	commentRepo comment_repository.CommentRepository
+	validate    *validation.Validator
 }
 
 func NewCommentService(commentRepo comment_repository.CommentRepository) CommentService {
-	return &commentService{commentRepo: commentRepo}
+	return &commentService{
+		commentRepo: commentRepo,
+		validate:    validation.New(nil),
+	}
 }
 
 func (s *commentService) CreateComment(ctx context.Context, model comment_models.CommentCreateModel) (*comment_models.CommentModel, error) {
-	// Проверяем, что UserID не равен 0
-	if model.UserID == 0 {
-		return nil, errors.New("user ID is required")
+	if err := s.validate.Struct(ctx, model); err != nil {
+		return nil, err
 	}
 
 	comment := &entities.Comment{
@@ -39,46 +43,48 @@ func (s *commentService) CreateComment(ctx context.Context, model comment_models
 		return nil, err
 	}
 
-	return &comment_models.CommentModel{
-		ID:                comment.ID,
-		UserID:            comment.UserID,
-		OrganizationID:    comment.OrganizationID,
-		CompanyName:       comment.CompanyName,
-		TenderName:        comment.TenderName,
-		TenderDescription: comment.TenderDescription,
-		BidDescription:    comment.BidDescription,
-		ServiceType:       comment.ServiceType,
-		Content:           comment.Content,
-		CreatedAt:         comment.CreatedAt.Format(time.RFC3339),
-	}, nil
+	return toCommentModel(comment), nil
+}
+
+// GetCommentByID returns a comment by its ID.
+func (s *commentService) GetCommentByID(ctx context.Context, id int) (*comment_models.CommentModel, error) {
+	comment, err := s.commentRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return toCommentModel(comment), nil
 }
 
-// GetCommentsByFilters returns comments by authorUsername and organizationID (if specified)
-func (s *commentService) GetCommentsByFilters(ctx context.Context, authorUsername string, organizationID int) ([]*comment_models.CommentModel, error) {
-	comments, err := s.commentRepo.FindByFilters(ctx, authorUsername, organizationID)
+// GetCommentsByFilters returns a page of comments by authorUsername and organizationID (if specified)
+func (s *commentService) GetCommentsByFilters(ctx context.Context, authorUsername string, organizationID int, params pagination.Params) (*pagination.Page[*comment_models.CommentModel], error) {
+	comments, total, err := s.commentRepo.FindByFilters(ctx, authorUsername, organizationID, params)
 	if err != nil {
 		return nil, err
 	}
 
 	var commentModels []*comment_models.CommentModel
 	for _, comment := range comments {
-		commentModels = append(commentModels, &comment_models.CommentModel{
-			ID:                comment.ID,
-			UserID:            comment.UserID,
-			OrganizationID:    comment.OrganizationID,
-			CompanyName:       comment.CompanyName,
-			TenderName:        comment.TenderName,
-			TenderDescription: comment.TenderDescription,
-			BidDescription:    comment.BidDescription,
-			ServiceType:       comment.ServiceType,
-			Content:           comment.Content,
-			CreatedAt:         comment.CreatedAt.Format(time.RFC3339),
-		})
+		commentModels = append(commentModels, toCommentModel(comment))
 	}
 
-	return commentModels, nil
+	return pagination.NewPage(commentModels, total, params), nil
 }
 
 func (s *commentService) DeleteComment(ctx context.Context, id int) error {
 	return s.commentRepo.Delete(ctx, id)
 }
+
+func toCommentModel(comment *entities.Comment) *comment_models.CommentModel {
+	return &comment_models.CommentModel{
+		ID:                comment.ID,
+		UserID:            comment.UserID,
+		OrganizationID:    comment.OrganizationID,
+		CompanyName:       comment.CompanyName,
+		TenderName:        comment.TenderName,
+		TenderDescription: comment.TenderDescription,
+		BidDescription:    comment.BidDescription,
+		ServiceType:       comment.ServiceType,
+		Content:           comment.Content,
+		CreatedAt:         comment.CreatedAt.Format(time.RFC3339),
+	}
+}
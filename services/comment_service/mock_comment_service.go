@@ -2,6 +2,7 @@ package comment_service
 
 import (
 	"avitoTest/services/comment_service/comment_models"
+	"avitoTest/shared/pagination"
 	"context"
 
 	"github.com/stretchr/testify/mock"
@@ -19,9 +20,17 @@ func (m *MockCommentService) CreateComment(ctx context.Context, model comment_mo
 	return nil, args.Error(1)
 }
 
-func (m *MockCommentService) GetCommentsByFilters(ctx context.Context, authorUsername string, organizationID int) ([]*comment_models.CommentModel, error) {
-	args := m.Called(ctx, authorUsername, organizationID)
-	if comments, ok := args.Get(0).([]*comment_models.CommentModel); ok {
+func (m *MockCommentService) GetCommentByID(ctx context.Context, id int) (*comment_models.CommentModel, error) {
+	args := m.Called(ctx, id)
+	if comment, ok := args.Get(0).(*comment_models.CommentModel); ok {
+		return comment, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockCommentService) GetCommentsByFilters(ctx context.Context, authorUsername string, organizationID int, params pagination.Params) (*pagination.Page[*comment_models.CommentModel], error) {
+	args := m.Called(ctx, authorUsername, organizationID, params)
+	if comments, ok := args.Get(0).(*pagination.Page[*comment_models.CommentModel]); ok {
 		return comments, args.Error(1)
 	}
 	return nil, args.Error(1)
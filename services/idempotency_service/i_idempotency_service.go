@@ -0,0 +1,16 @@
+package idempotency_service
+
+import (
+	"avitoTest/services/idempotency_service/idempotency_models"
+	"context"
+)
+
+type IdempotencyService interface {
+	// Begin claims key for a request of the caller named by scope. It returns the stored
+	// response if the request has already completed, and otherwise the claim the request
+	// should run with and pass to Complete or Release.
+	Begin(ctx context.Context, scope, key, fingerprint string) (*idempotency_models.StoredResponse, string, error)
+	Complete(ctx context.Context, scope, key, claim string, response idempotency_models.StoredResponse) error
+	Release(ctx context.Context, scope, key, claim string) error
+	PurgeExpired(ctx context.Context) (int64, error)
+}
@@ -0,0 +1,11 @@
+package idempotency_models
+
+import "net/http"
+
+// StoredResponse is the response to a request made with an Idempotency-Key, replayed
+// to its retries. Header only holds the headers set by the handler.
+type StoredResponse struct {
+	Status int
+	Header http.Header
+	Body   []byte
+}
@@ -0,0 +1,102 @@
+package idempotency_service
+
+import (
+	"avitoTest/data/entities"
+	"avitoTest/data/repositories/idempotency_repository"
+	"avitoTest/services/idempotency_service/idempotency_models"
+	"avitoTest/shared/errors/idempotency_errors"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// maxKeyLength limits the length of the keys accepted from clients.
+const maxKeyLength = 255
+
+type idempotencyService struct {
+	repo        idempotency_repository.IdempotencyRepository
+	ttl         time.Duration
+	lockTimeout time.Duration
+}
+
+// NewIdempotencyService creates a new instance of IdempotencyService. Responses are
+// replayed for ttl; a request still in progress after lockTimeout is considered abandoned
+// and may be retried.
+func NewIdempotencyService(repo idempotency_repository.IdempotencyRepository, ttl, lockTimeout time.Duration) IdempotencyService {
+	return &idempotencyService{repo: repo, ttl: ttl, lockTimeout: lockTimeout}
+}
+
+// Begin claims the key for the request, or returns the response of the request it was
+// used for. Reusing a key for a different request, or while its request is in progress,
+// is an error.
+func (s *idempotencyService) Begin(ctx context.Context, scope, key, fingerprint string) (*idempotency_models.StoredResponse, string, error) {
+	if key == "" || len(key) > maxKeyLength {
+		return nil, "", idempotency_errors.ErrInvalidKey
+	}
+
+	now := time.Now()
+	claim := newClaim()
+	existing, claimed, err := s.repo.Claim(ctx, &entities.IdempotencyKey{
+		Scope:       scope,
+		Key:         key,
+		Fingerprint: fingerprint,
+		Claim:       claim,
+		LockedUntil: now.Add(s.lockTimeout),
+		ExpiresAt:   now.Add(s.ttl),
+		CreatedAt:   now,
+	})
+	if err != nil {
+		return nil, "", err
+	}
+	if claimed {
+		return nil, claim, nil
+	}
+
+	if existing.Fingerprint != fingerprint {
+		return nil, "", idempotency_errors.ErrKeyReused
+	}
+	if !existing.Completed {
+		return nil, "", idempotency_errors.ErrRequestInProgress
+	}
+
+	response := &idempotency_models.StoredResponse{Status: existing.Status, Body: existing.Body}
+	if existing.Header != "" {
+		if err := json.Unmarshal([]byte(existing.Header), &response.Header); err != nil {
+			return nil, "", fmt.Errorf("failed to decode the stored headers of %q: %w", key, err)
+		}
+	}
+	return response, "", nil
+}
+
+// Complete stores the response of the request the key was claimed for. A request that ran
+// past the lock timeout may have lost the key to a retry, whose response is kept instead.
+func (s *idempotencyService) Complete(ctx context.Context, scope, key, claim string, response idempotency_models.StoredResponse) error {
+	var header []byte
+	if len(response.Header) > 0 {
+		var err error
+		if header, err = json.Marshal(response.Header); err != nil {
+			return err
+		}
+	}
+	return s.repo.Complete(ctx, scope, key, claim, response.Status, string(header), response.Body)
+}
+
+// Release frees the key of a request that failed, so it can be retried.
+func (s *idempotencyService) Release(ctx context.Context, scope, key, claim string) error {
+	return s.repo.Release(ctx, scope, key, claim)
+}
+
+// newClaim generates a random token identifying a claim of a key.
+func newClaim() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// PurgeExpired deletes the keys whose responses are no longer replayed.
+func (s *idempotencyService) PurgeExpired(ctx context.Context) (int64, error) {
+	return s.repo.DeleteExpired(ctx, time.Now())
+}
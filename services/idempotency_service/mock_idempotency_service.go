@@ -0,0 +1,35 @@
+package idempotency_service
+
+import (
+	"avitoTest/services/idempotency_service/idempotency_models"
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockIdempotencyService struct {
+	mock.Mock
+}
+
+func (m *MockIdempotencyService) Begin(ctx context.Context, scope, key, fingerprint string) (*idempotency_models.StoredResponse, string, error) {
+	args := m.Called(ctx, scope, key, fingerprint)
+	if response, ok := args.Get(0).(*idempotency_models.StoredResponse); ok {
+		return response, args.String(1), args.Error(2)
+	}
+	return nil, args.String(1), args.Error(2)
+}
+
+func (m *MockIdempotencyService) Complete(ctx context.Context, scope, key, claim string, response idempotency_models.StoredResponse) error {
+	args := m.Called(ctx, scope, key, claim, response)
+	return args.Error(0)
+}
+
+func (m *MockIdempotencyService) Release(ctx context.Context, scope, key, claim string) error {
+	args := m.Called(ctx, scope, key, claim)
+	return args.Error(0)
+}
+
+func (m *MockIdempotencyService) PurgeExpired(ctx context.Context) (int64, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(int64), args.Error(1)
+}
@@ -0,0 +1,17 @@
+package notification_service
+
+import (
+	"avitoTest/services/notification_service/notification_models"
+	"context"
+)
+
+type NotificationService interface {
+	GetPreferences(ctx context.Context, userID int) (*notification_models.NotificationPreferencesModel, error)
+	UpdatePreferences(ctx context.Context, model notification_models.NotificationPreferencesUpdateModel) (*notification_models.NotificationPreferencesModel, error)
+
+	// Notify renders the emails of an event to the users concerned who opted in to it
+	// and puts them in the outbox.
+	Notify(ctx context.Context, event notification_models.NotificationEventModel) error
+	// SendDue sends the emails in the outbox that are due and returns how many were attempted.
+	SendDue(ctx context.Context) (int, error)
+}
@@ -0,0 +1,149 @@
+package notification_service
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"mime"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Email is a plain text email to a single recipient.
+type Email struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// Mailer sends emails.
+type Mailer interface {
+	Send(ctx context.Context, email Email) error
+}
+
+// SMTPConfig configures the server emails are sent through. From is the sender, such as
+// "Tenders <noreply@example.com>". Without StartTLS the connection is not encrypted,
+// which is only meant for local stand-ins such as Mailpit.
+type SMTPConfig struct {
+	Host     string
+	Port     int
+	Username string
+	Password string
+	From     string
+	StartTLS bool
+	Timeout  time.Duration
+}
+
+var errStartTLSUnsupported = errors.New("the SMTP server does not support STARTTLS")
+
+type smtpMailer struct {
+	conf SMTPConfig
+}
+
+// NewSMTPMailer creates a Mailer sending emails through an SMTP server.
+func NewSMTPMailer(conf SMTPConfig) Mailer {
+	return &smtpMailer{conf: conf}
+}
+
+func (m *smtpMailer) Send(ctx context.Context, email Email) error {
+	from, err := mail.ParseAddress(m.conf.From)
+	if err != nil {
+		return fmt.Errorf("invalid sender: %w", err)
+	}
+	message, err := buildMessage(from, email, time.Now())
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, m.conf.Timeout)
+	defer cancel()
+
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", net.JoinHostPort(m.conf.Host, strconv.Itoa(m.conf.Port)))
+	if err != nil {
+		return err
+	}
+	deadline, _ := ctx.Deadline()
+	conn.SetDeadline(deadline)
+
+	client, err := smtp.NewClient(conn, m.conf.Host)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	defer client.Close()
+
+	if m.conf.StartTLS {
+		if ok, _ := client.Extension("STARTTLS"); !ok {
+			return errStartTLSUnsupported
+		}
+		if err := client.StartTLS(&tls.Config{ServerName: m.conf.Host}); err != nil {
+			return err
+		}
+	}
+	if m.conf.Username != "" {
+		if err := client.Auth(smtp.PlainAuth("", m.conf.Username, m.conf.Password, m.conf.Host)); err != nil {
+			return err
+		}
+	}
+
+	if err := client.Mail(from.Address); err != nil {
+		return err
+	}
+	if err := client.Rcpt(email.To); err != nil {
+		return err
+	}
+	w, err := client.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(message); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return client.Quit()
+}
+
+// buildMessage formats an email as a MIME message with a quoted-printable UTF-8 body.
+func buildMessage(from *mail.Address, email Email, date time.Time) ([]byte, error) {
+	to, err := mail.ParseAddress(email.To)
+	if err != nil {
+		return nil, fmt.Errorf("invalid recipient: %w", err)
+	}
+
+	var msg bytes.Buffer
+	header := func(name, value string) {
+		msg.WriteString(name + ": " + value + "\r\n")
+	}
+	header("From", from.String())
+	header("To", to.String())
+	header("Subject", mime.QEncoding.Encode("utf-8", email.Subject))
+	header("Date", date.Format(time.RFC1123Z))
+	header("Message-ID", "<"+randomHex(16)+"@"+domain(from.Address)+">")
+	header("MIME-Version", "1.0")
+	header("Content-Type", "text/plain; charset=utf-8")
+	header("Content-Transfer-Encoding", "quoted-printable")
+	msg.WriteString("\r\n")
+
+	body := quotedprintable.NewWriter(&msg)
+	if _, err := body.Write([]byte(email.Body)); err != nil {
+		return nil, err
+	}
+	if err := body.Close(); err != nil {
+		return nil, err
+	}
+	return msg.Bytes(), nil
+}
+
+func domain(address string) string {
+	return address[strings.LastIndex(address, "@")+1:]
+}
@@ -0,0 +1,38 @@
+package notification_service
+
+import (
+	"avitoTest/services/notification_service/notification_models"
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockNotificationService struct {
+	mock.Mock
+}
+
+func (m *MockNotificationService) GetPreferences(ctx context.Context, userID int) (*notification_models.NotificationPreferencesModel, error) {
+	args := m.Called(ctx, userID)
+	if preferences, ok := args.Get(0).(*notification_models.NotificationPreferencesModel); ok {
+		return preferences, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockNotificationService) UpdatePreferences(ctx context.Context, model notification_models.NotificationPreferencesUpdateModel) (*notification_models.NotificationPreferencesModel, error) {
+	args := m.Called(ctx, model)
+	if preferences, ok := args.Get(0).(*notification_models.NotificationPreferencesModel); ok {
+		return preferences, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockNotificationService) Notify(ctx context.Context, event notification_models.NotificationEventModel) error {
+	args := m.Called(ctx, event)
+	return args.Error(0)
+}
+
+func (m *MockNotificationService) SendDue(ctx context.Context) (int, error) {
+	args := m.Called(ctx)
+	return args.Int(0), args.Error(1)
+}
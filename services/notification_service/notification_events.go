@@ -0,0 +1,73 @@
+package notification_service
+
+import (
+	"avitoTest/services/bid_service"
+	"avitoTest/services/notification_service/notification_models"
+	"avitoTest/services/tender_service"
+	"avitoTest/shared"
+	"avitoTest/shared/constants"
+	"avitoTest/shared/events"
+	"context"
+)
+
+var tenderNotifications = map[string]constants.NotificationEventType{
+	events.ActionPublished: constants.NotificationTenderPublished,
+	events.ActionClosed:    constants.NotificationTenderClosed,
+}
+
+var bidNotifications = map[string]constants.NotificationEventType{
+	events.ActionCreated:  constants.NotificationBidCreated,
+	events.ActionApproved: constants.NotificationBidApproved,
+	events.ActionRejected: constants.NotificationBidRejected,
+}
+
+// SubscribeToEvents emails the users concerned by the tender and bid events they can opt
+// in to and returns a function unsubscribing from them. Only the outbox is written while
+// the event is published; the emails are sent by SendDue.
+func SubscribeToEvents(service NotificationService, tenders tender_service.TenderService, bids bid_service.BidService) func() {
+	unsubscribeTenders := events.Subscribe(events.TopicTender, func(ctx context.Context, event events.Event) {
+		tenderEvent := event.(events.TenderEvent)
+		eventType, ok := tenderNotifications[tenderEvent.Action]
+		if !ok {
+			return
+		}
+
+		tender, err := tenders.GetTenderByID(ctx, tenderEvent.TenderID)
+		if err != nil {
+			shared.Logger.Warnf("Failed to load tender %d for notifications: %v", tenderEvent.TenderID, err)
+			return
+		}
+		notify(ctx, service, notification_models.NotificationEventModel{Type: eventType, Tender: tender})
+	})
+
+	unsubscribeBids := events.Subscribe(events.TopicBid, func(ctx context.Context, event events.Event) {
+		bidEvent := event.(events.BidEvent)
+		eventType, ok := bidNotifications[bidEvent.Action]
+		if !ok {
+			return
+		}
+
+		bid, err := bids.GetBidByID(ctx, bidEvent.BidID)
+		if err != nil {
+			shared.Logger.Warnf("Failed to load bid %d for notifications: %v", bidEvent.BidID, err)
+			return
+		}
+		tender, err := tenders.GetTenderByID(ctx, bidEvent.TenderID)
+		if err != nil {
+			shared.Logger.Warnf("Failed to load tender %d for notifications: %v", bidEvent.TenderID, err)
+			return
+		}
+		notify(ctx, service, notification_models.NotificationEventModel{Type: eventType, Tender: tender, Bid: bid})
+	})
+
+	return func() {
+		unsubscribeTenders()
+		unsubscribeBids()
+	}
+}
+
+func notify(ctx context.Context, service NotificationService, event notification_models.NotificationEventModel) {
+	if err := service.Notify(ctx, event); err != nil {
+		shared.Logger.Warnf("Failed to queue %s notifications: %v", event.Type, err)
+	}
+}
@@ -0,0 +1,15 @@
+package notification_models
+
+import (
+	"avitoTest/services/bid_service/bid_models"
+	"avitoTest/services/tender_service/tender_models"
+	"avitoTest/shared/constants"
+)
+
+// NotificationEventModel is an event to email the users concerned about. Bid is only set
+// for bid events.
+type NotificationEventModel struct {
+	Type   constants.NotificationEventType
+	Tender *tender_models.TenderModel
+	Bid    *bid_models.BidModel
+}
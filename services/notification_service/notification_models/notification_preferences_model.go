@@ -0,0 +1,20 @@
+package notification_models
+
+import "time"
+
+// NotificationPreferencesModel is the address a user is emailed at and the events they
+// opted in to. UpdatedAt is not set until the user saves their preferences.
+type NotificationPreferencesModel struct {
+	UserID     int        `json:"user_id"`
+	Email      string     `json:"email"`
+	EventTypes []string   `json:"event_types"`
+	UpdatedAt  *time.Time `json:"updated_at,omitempty"`
+}
+
+// NotificationPreferencesUpdateModel replaces the preferences of a user. An email is
+// required to opt in to any event; no event types opts out of every email.
+type NotificationPreferencesUpdateModel struct {
+	UserID     int      `json:"user_id" validate:"required"`
+	Email      string   `json:"email" validate:"omitempty,email,max=254"`
+	EventTypes []string `json:"event_types" validate:"dive,notification_event"`
+}
@@ -0,0 +1,78 @@
+package notification_service
+
+import (
+	"avitoTest/data/entities"
+	"avitoTest/shared"
+	"avitoTest/shared/constants"
+	"avitoTest/shared/metrics"
+	"context"
+	"time"
+)
+
+const (
+	// sendBatchSize is the number of emails claimed by one SendDue call.
+	sendBatchSize = 20
+	// claimLease is how long claimed emails are kept from other instances. SendDue stops
+	// sending halfway through it, leaving the rest to be claimed again.
+	claimLease = 10 * time.Minute
+)
+
+// SendDue sends the pending emails that are due. An email that fails to send is retried
+// with exponential backoff until the retry policy gives up on it.
+func (s *notificationService) SendDue(ctx context.Context) (int, error) {
+	now := time.Now()
+	notifications, err := s.notificationRepo.ClaimDue(ctx, now, now.Add(claimLease), sendBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	stopAt := now.Add(claimLease / 2)
+	attempted := 0
+	for _, notification := range notifications {
+		if ctx.Err() != nil || time.Now().After(stopAt) {
+			break
+		}
+		s.attempt(ctx, notification)
+		attempted++
+		if err := s.notificationRepo.UpdateNotification(ctx, notification); err != nil {
+			shared.Logger.Errorf("Failed to save notification %d: %v", notification.ID, err)
+		}
+	}
+	return attempted, nil
+}
+
+// attempt sends an email once and records the outcome on it.
+func (s *notificationService) attempt(ctx context.Context, notification *entities.Notification) {
+	now := time.Now()
+	notification.Attempts++
+	notification.LastAttemptAt = &now
+
+	err := s.mailer.Send(ctx, Email{To: notification.Recipient, Subject: notification.Subject, Body: notification.Body})
+	if err == nil {
+		notification.Status = string(constants.NotificationSent)
+		notification.Error = ""
+		notification.SentAt = &now
+		metrics.NotificationEmails.WithLabelValues(metrics.NotificationSent).Inc()
+		return
+	}
+
+	notification.Error = err.Error()
+	if notification.Attempts >= s.retry.MaxAttempts {
+		notification.Status = string(constants.NotificationFailed)
+		metrics.NotificationEmails.WithLabelValues(metrics.NotificationFailed).Inc()
+		shared.Logger.Warnf("Giving up on notification %d to user %d after %d attempts: %s",
+			notification.ID, notification.UserID, notification.Attempts, notification.Error)
+		return
+	}
+	notification.NextAttemptAt = now.Add(s.backoff(notification.Attempts))
+	metrics.NotificationEmails.WithLabelValues(metrics.NotificationRetried).Inc()
+}
+
+// backoff returns the delay before the attempt following the given number of failed ones.
+func (s *notificationService) backoff(attempts int) time.Duration {
+	delay := s.retry.Backoff
+	for i := 1; i < attempts && delay < s.retry.MaxBackoff; i++ {
+		delay *= 2
+	}
+	return min(delay, s.retry.MaxBackoff)
+}
@@ -0,0 +1,247 @@
+package notification_service
+
+import (
+	"avitoTest/data/entities"
+	"avitoTest/data/repositories/bid_repository"
+	"avitoTest/data/repositories/notification_repository"
+	"avitoTest/data/repositories/organization_repository"
+	"avitoTest/data/repositories/user_repository"
+	"avitoTest/services/notification_service/notification_models"
+	"avitoTest/shared/auth"
+	"avitoTest/shared/constants"
+	"avitoTest/shared/errors/notification_errors"
+	"avitoTest/shared/pagination"
+	"avitoTest/shared/validation"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"slices"
+	"strings"
+	"time"
+)
+
+// RetryPolicy decides when emails that failed to send are retried: Backoff after the
+// first failed attempt, doubling with every further one up to MaxBackoff, until
+// MaxAttempts attempts have failed.
+type RetryPolicy struct {
+	MaxAttempts int
+	Backoff     time.Duration
+	MaxBackoff  time.Duration
+}
+
+type notificationService struct {
+	notificationRepo notification_repository.NotificationRepository
+	userRepo         user_repository.UserRepository
+	orgRepo          organization_repository.OrganizationRepository
+	bidRepo          bid_repository.BidRepository
+	mailer           Mailer
+	retry            RetryPolicy
+	validate         *validation.Validator
+}
+
+// NewNotificationService creates a new instance of NotificationService sending emails with mailer.
+func NewNotificationService(
+	notificationRepo notification_repository.NotificationRepository,
+	userRepo user_repository.UserRepository,
+	orgRepo organization_repository.OrganizationRepository,
+	bidRepo bid_repository.BidRepository,
+	mailer Mailer,
+	retry RetryPolicy) NotificationService {
+	return &notificationService{
+		notificationRepo: notificationRepo,
+		userRepo:         userRepo,
+		orgRepo:          orgRepo,
+		bidRepo:          bidRepo,
+		mailer:           mailer,
+		retry:            retry,
+		validate:         validation.New(nil),
+	}
+}
+
+// GetPreferences returns the notification preferences of a user; a user who never saved
+// any has opted in to nothing.
+func (s *notificationService) GetPreferences(ctx context.Context, userID int) (*notification_models.NotificationPreferencesModel, error) {
+	if err := s.authorize(ctx, userID); err != nil {
+		return nil, err
+	}
+	if _, err := s.userRepo.FindByID(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	preferences, err := s.notificationRepo.FindPreferences(ctx, userID)
+	if errors.Is(err, notification_errors.ErrPreferencesNotFound) {
+		return &notification_models.NotificationPreferencesModel{UserID: userID, EventTypes: []string{}}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return toPreferencesModel(preferences), nil
+}
+
+// UpdatePreferences replaces the notification preferences of a user.
+func (s *notificationService) UpdatePreferences(ctx context.Context, model notification_models.NotificationPreferencesUpdateModel) (*notification_models.NotificationPreferencesModel, error) {
+	if err := s.validate.Struct(ctx, model); err != nil {
+		return nil, err
+	}
+	if len(model.EventTypes) > 0 && model.Email == "" {
+		return nil, validation.NewFieldError("email", "required", notification_errors.ErrEmailRequired)
+	}
+	if err := s.authorize(ctx, model.UserID); err != nil {
+		return nil, err
+	}
+	if _, err := s.userRepo.FindByID(ctx, model.UserID); err != nil {
+		return nil, err
+	}
+
+	preferences := &entities.NotificationPreference{
+		UserID:     model.UserID,
+		Email:      model.Email,
+		EventTypes: strings.Join(slices.Compact(slices.Sorted(slices.Values(model.EventTypes))), ","),
+		UpdatedAt:  time.Now(),
+	}
+	if err := s.notificationRepo.SavePreferences(ctx, preferences); err != nil {
+		return nil, err
+	}
+	return toPreferencesModel(preferences), nil
+}
+
+// Notify puts the emails of an event to the users concerned who opted in to it in the
+// outbox; SendDue sends them. Users are not emailed about their own actions.
+func (s *notificationService) Notify(ctx context.Context, event notification_models.NotificationEventModel) error {
+	recipients, err := s.recipients(ctx, event)
+	if err != nil {
+		return err
+	}
+	actor := auth.FromContext(ctx).UserID
+	recipients = slices.DeleteFunc(slices.Compact(slices.Sorted(slices.Values(recipients))), func(userID int) bool {
+		return userID == actor
+	})
+	if len(recipients) == 0 {
+		return nil
+	}
+
+	preferences, err := s.notificationRepo.FindOptedIn(ctx, recipients, string(event.Type))
+	if err != nil || len(preferences) == 0 {
+		return err
+	}
+
+	now := time.Now()
+	notifications := make([]*entities.Notification, 0, len(preferences))
+	for _, preference := range preferences {
+		subject, body, err := render(string(event.Type), templateData{
+			Name:   displayName(preference.User),
+			Event:  string(event.Type),
+			Tender: event.Tender,
+			Bid:    event.Bid,
+		})
+		if err != nil {
+			return err
+		}
+		notifications = append(notifications, &entities.Notification{
+			UserID:        preference.UserID,
+			EventType:     string(event.Type),
+			Recipient:     preference.Email,
+			Subject:       subject,
+			Body:          body,
+			Status:        string(constants.NotificationPending),
+			NextAttemptAt: now,
+			CreatedAt:     now,
+		})
+	}
+	return s.notificationRepo.CreateNotifications(ctx, notifications)
+}
+
+// recipients returns the users concerned by an event: the responsibles of the tender's
+// organization for tender events, and the bidders too when the tender is closed; the
+// responsibles approving a new bid; the creator of an approved or rejected bid.
+func (s *notificationService) recipients(ctx context.Context, event notification_models.NotificationEventModel) ([]int, error) {
+	switch event.Type {
+	case constants.NotificationTenderPublished:
+		return s.responsibles(ctx, event.Tender.OrganizationID)
+	case constants.NotificationTenderClosed:
+		responsibles, err := s.responsibles(ctx, event.Tender.OrganizationID)
+		if err != nil {
+			return nil, err
+		}
+		bidders, err := s.bidders(ctx, event.Tender.ID)
+		return append(responsibles, bidders...), err
+	case constants.NotificationBidCreated:
+		responsibles, err := s.responsibles(ctx, event.Bid.OrganizationID)
+		return slices.DeleteFunc(responsibles, func(userID int) bool { return userID == event.Bid.CreatorID }), err
+	case constants.NotificationBidApproved, constants.NotificationBidRejected:
+		return []int{event.Bid.CreatorID}, nil
+	}
+	return nil, nil
+}
+
+func (s *notificationService) responsibles(ctx context.Context, orgID int) ([]int, error) {
+	users, err := s.orgRepo.GetResponsibles(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	userIDs := make([]int, 0, len(users))
+	for _, user := range users {
+		userIDs = append(userIDs, user.ID)
+	}
+	return userIDs, nil
+}
+
+// bidders returns the creators of the bids on a tender.
+func (s *notificationService) bidders(ctx context.Context, tenderID int) ([]int, error) {
+	var userIDs []int
+	params := pagination.Params{Limit: pagination.MaxLimit, Sort: pagination.SortCreatedAt, Order: pagination.OrderAsc}
+	for {
+		bids, total, err := s.bidRepo.FindByTenderID(ctx, tenderID, nil, params)
+		if err != nil {
+			return nil, err
+		}
+		for _, bid := range bids {
+			userIDs = append(userIDs, bid.CreatorID)
+		}
+		params.Offset += len(bids)
+		if len(bids) == 0 || int64(params.Offset) >= total {
+			return userIDs, nil
+		}
+	}
+}
+
+// authorize lets API clients acting on their own behalf manage the preferences of every
+// user, and users their own.
+func (s *notificationService) authorize(ctx context.Context, userID int) error {
+	principal := auth.FromContext(ctx)
+	switch {
+	case principal.UserID == 0 && principal.Client == "":
+		return notification_errors.ErrAuthenticationRequired
+	case principal.UserID != 0 && principal.UserID != userID:
+		return notification_errors.ErrNotOwnPreferences
+	}
+	return nil
+}
+
+func displayName(user entities.User) string {
+	if user.FirstName != "" {
+		return user.FirstName
+	}
+	return user.Username
+}
+
+// randomHex returns n random bytes in hex.
+func randomHex(n int) string {
+	b := make([]byte, n)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+func toPreferencesModel(preferences *entities.NotificationPreference) *notification_models.NotificationPreferencesModel {
+	eventTypes := []string{}
+	if preferences.EventTypes != "" {
+		eventTypes = strings.Split(preferences.EventTypes, ",")
+	}
+	return &notification_models.NotificationPreferencesModel{
+		UserID:     preferences.UserID,
+		Email:      preferences.Email,
+		EventTypes: eventTypes,
+		UpdatedAt:  &preferences.UpdatedAt,
+	}
+}
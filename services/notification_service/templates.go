@@ -0,0 +1,65 @@
+package notification_service
+
+import (
+	"avitoTest/services/bid_service/bid_models"
+	"avitoTest/services/tender_service/tender_models"
+	"avitoTest/shared/constants"
+	"embed"
+	"fmt"
+	"strings"
+	"text/template"
+)
+
+//go:embed templates/*.tmpl
+var templateFiles embed.FS
+
+// templateFuncs are the functions the templates may call.
+var templateFuncs = template.FuncMap{
+	// amount formats an optional sum such as the budget of a tender
+	"amount": func(sum *float64) string {
+		return fmt.Sprintf("%.2f", *sum)
+	},
+}
+
+// templates holds the template of every event, defining its "subject" and "body".
+var templates = map[string]*template.Template{}
+
+func init() {
+	for _, eventType := range []constants.NotificationEventType{
+		constants.NotificationTenderPublished,
+		constants.NotificationTenderClosed,
+		constants.NotificationBidCreated,
+		constants.NotificationBidApproved,
+		constants.NotificationBidRejected,
+	} {
+		name := string(eventType)
+		templates[name] = template.Must(template.New(name).Option("missingkey=error").Funcs(templateFuncs).
+			ParseFS(templateFiles, "templates/footer.tmpl", "templates/"+name+".tmpl"))
+	}
+}
+
+// templateData is what the templates are rendered with.
+type templateData struct {
+	Name   string // how to address the recipient
+	Event  string
+	Tender *tender_models.TenderModel
+	Bid    *bid_models.BidModel
+}
+
+// render returns the subject and the body of the email of an event.
+func render(eventType string, data templateData) (string, string, error) {
+	tmpl, ok := templates[eventType]
+	if !ok {
+		return "", "", fmt.Errorf("no email template for %s", eventType)
+	}
+
+	var subject, body strings.Builder
+	if err := tmpl.ExecuteTemplate(&subject, "subject", data); err != nil {
+		return "", "", err
+	}
+	if err := tmpl.ExecuteTemplate(&body, "body", data); err != nil {
+		return "", "", err
+	}
+	// A subject is a single header line
+	return strings.Join(strings.Fields(subject.String()), " "), body.String(), nil
+}
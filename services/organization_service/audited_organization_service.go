@@ -0,0 +1,103 @@
+package organization_service
+
+import (
+	"avitoTest/data/repositories/transaction"
+	"avitoTest/services/audit_service"
+	"avitoTest/services/audit_service/audit_models"
+	"avitoTest/services/organization_service/organization_models"
+	"avitoTest/shared/constants"
+	"context"
+	"fmt"
+)
+
+// auditedOrganizationService records an audit event for every change made through the
+// wrapped service.
+type auditedOrganizationService struct {
+	OrganizationService
+	audit        audit_service.AuditService
+	transactions transaction.Manager
+}
+
+// NewAuditedOrganizationService wraps service so its successful changes to organizations
+// and their responsibles are recorded in the audit log. Each change is recorded in its own
+// transaction, so failing to record it fails the change.
+func NewAuditedOrganizationService(service OrganizationService, audit audit_service.AuditService, transactions transaction.Manager) OrganizationService {
+	return &auditedOrganizationService{OrganizationService: service, audit: audit, transactions: transactions}
+}
+
+func (s *auditedOrganizationService) CreateOrganization(ctx context.Context, org organization_models.OrganizationCreateModel) (*organization_models.OrganizationModel, error) {
+	var created *organization_models.OrganizationModel
+	err := s.transactions.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		if created, err = s.OrganizationService.CreateOrganization(ctx, org); err != nil {
+			return err
+		}
+		return s.record(ctx, constants.AuditEntityOrganization, constants.AuditActionCreated, created.ID, created.ID, nil, created)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return created, nil
+}
+
+func (s *auditedOrganizationService) UpdateOrganization(ctx context.Context, org organization_models.OrganizationUpdateModel) (*organization_models.OrganizationModel, error) {
+	var updated *organization_models.OrganizationModel
+	err := s.transactions.WithinTransaction(ctx, func(ctx context.Context) error {
+		before, _ := s.OrganizationService.GetOrganizationByID(ctx, org.ID)
+		var err error
+		if updated, err = s.OrganizationService.UpdateOrganization(ctx, org); err != nil {
+			return err
+		}
+		return s.record(ctx, constants.AuditEntityOrganization, constants.AuditActionUpdated, org.ID, org.ID, before, updated)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+func (s *auditedOrganizationService) DeleteOrganization(ctx context.Context, id int) error {
+	return s.transactions.WithinTransaction(ctx, func(ctx context.Context) error {
+		before, _ := s.OrganizationService.GetOrganizationByID(ctx, id)
+		if err := s.OrganizationService.DeleteOrganization(ctx, id); err != nil {
+			return err
+		}
+		return s.record(ctx, constants.AuditEntityOrganization, constants.AuditActionDeleted, id, id, before, nil)
+	})
+}
+
+// AddResponsible records the user made responsible as a created responsible of the organization.
+func (s *auditedOrganizationService) AddResponsible(ctx context.Context, orgID int, userID int) error {
+	return s.transactions.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.OrganizationService.AddResponsible(ctx, orgID, userID); err != nil {
+			return err
+		}
+		after, _ := s.OrganizationService.GetResponsibleByID(ctx, orgID, userID)
+		return s.record(ctx, constants.AuditEntityResponsible, constants.AuditActionCreated, userID, orgID, nil, after)
+	})
+}
+
+func (s *auditedOrganizationService) DeleteResponsible(ctx context.Context, orgID int, userID int) error {
+	return s.transactions.WithinTransaction(ctx, func(ctx context.Context) error {
+		before, _ := s.OrganizationService.GetResponsibleByID(ctx, orgID, userID)
+		if err := s.OrganizationService.DeleteResponsible(ctx, orgID, userID); err != nil {
+			return err
+		}
+		return s.record(ctx, constants.AuditEntityResponsible, constants.AuditActionDeleted, userID, orgID, before, nil)
+	})
+}
+
+func (s *auditedOrganizationService) record(ctx context.Context, entityType constants.AuditEntityType, action constants.AuditAction, entityID, orgID int, before, after any) error {
+	record := audit_models.AuditRecordModel{
+		EntityType:     entityType,
+		EntityID:       entityID,
+		OrganizationID: orgID,
+		Action:         action,
+		Before:         before,
+		After:          after,
+	}
+	if err := s.audit.Record(ctx, record); err != nil {
+		return fmt.Errorf("audit: recording %s of %s %d: %w", record.Action, record.EntityType, record.EntityID, err)
+	}
+	return nil
+}
@@ -0,0 +1,48 @@
+package organization_service
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"avitoTest/services/organization_service/organization_models"
+	"avitoTest/shared/cache"
+	"avitoTest/shared/events"
+)
+
+const organizationKeyPrefix = "organization:"
+
+// cachedOrganizationService serves organization reads from a cache and drops the
+// cached entry whenever an organization event reports a change.
+type cachedOrganizationService struct {
+	OrganizationService
+	cache cache.Cache
+	ttl   time.Duration
+}
+
+// NewCachedOrganizationService wraps service with a read-through cache for single
+// organizations. Writes go to service unchanged.
+func NewCachedOrganizationService(service OrganizationService, c cache.Cache, ttl time.Duration) OrganizationService {
+	s := &cachedOrganizationService{OrganizationService: service, cache: c, ttl: ttl}
+	events.Subscribe(events.TopicOrganization, s.invalidate)
+	return s
+}
+
+// GetOrganizationByID returns the cached organization or loads it from the wrapped service.
+func (s *cachedOrganizationService) GetOrganizationByID(ctx context.Context, id int) (*organization_models.OrganizationModel, error) {
+	return cache.GetOrLoad(ctx, s.cache, organizationKey(id), s.ttl, func() (*organization_models.OrganizationModel, error) {
+		return s.OrganizationService.GetOrganizationByID(ctx, id)
+	})
+}
+
+func (s *cachedOrganizationService) invalidate(ctx context.Context, event events.Event) {
+	orgEvent, ok := event.(events.OrganizationEvent)
+	if !ok {
+		return
+	}
+	cache.Invalidate(ctx, s.cache, []string{organizationKey(orgEvent.OrganizationID)})
+}
+
+func organizationKey(id int) string {
+	return organizationKeyPrefix + strconv.Itoa(id)
+}
@@ -3,6 +3,7 @@ package organization_service
 import (
 	"avitoTest/services/organization_service/organization_models"
 	"avitoTest/services/user_service/user_models"
+	"avitoTest/shared/pagination"
 	"context"
 
 	"github.com/stretchr/testify/mock"
@@ -23,9 +24,9 @@ func (m *MockOrganizationService) UpdateOrganization(ctx context.Context, org or
 	return args.Get(0).(*organization_models.OrganizationModel), args.Error(1)
 }
 
-func (m *MockOrganizationService) GetOrganizations(ctx context.Context) ([]*organization_models.OrganizationModel, error) {
-	args := m.Called(ctx)
-	return args.Get(0).([]*organization_models.OrganizationModel), args.Error(1)
+func (m *MockOrganizationService) GetOrganizations(ctx context.Context, params pagination.Params) (*pagination.Page[*organization_models.OrganizationModel], error) {
+	args := m.Called(ctx, params)
+	return args.Get(0).(*pagination.Page[*organization_models.OrganizationModel]), args.Error(1)
 }
 
 func (m *MockOrganizationService) GetOrganizationByID(ctx context.Context, id int) (*organization_models.OrganizationModel, error) {
@@ -2,8 +2,9 @@ package organization_models
 
 // OrganizationUpdateModel - model for updating an existing organization.
 type OrganizationUpdateModel struct {
-	ID          int    `json:"id" validate:"required"`
-	Name        string `json:"name" validate:"required,min=3,max=100"`
-	Description string `json:"description" validate:"max=255"`
-	Type        string `json:"type" validate:"required,oneof=IE LLC JSC"`
+	ID           int      `json:"id" validate:"required"`
+	Name         string   `json:"name" validate:"required,min=3,max=100"`
+	Description  string   `json:"description" validate:"max=255"`
+	Type         string   `json:"type" validate:"required,org_type"`
+	ServiceTypes []string `json:"service_types" validate:"dive,required,max=100,service_type"`
 }
@@ -2,65 +2,73 @@ package organization_service
 
 import (
 	"context"
-	"errors"
 	"time"
 
 	"avitoTest/data/entities"
+	"avitoTest/data/repositories/category_repository"
 	"avitoTest/data/repositories/organization_repository"
+	"avitoTest/data/repositories/transaction"
 	"avitoTest/data/repositories/user_repository"
 	"avitoTest/services/organization_service/organization_models"
 	"avitoTest/services/user_service/user_models"
 	"avitoTest/shared/constants"
-
-	"github.com/go-playground/validator/v10"
+	"avitoTest/shared/events"
+	"avitoTest/shared/pagination"
+	"avitoTest/shared/validation"
 )
 
 type organizationService struct {
-	orgRepo  organization_repository.OrganizationRepository
-	userRepo user_repository.UserRepository
-	validate *validator.Validate
+	orgRepo      organization_repository.OrganizationRepository
+	userRepo     user_repository.UserRepository
+	categoryRepo category_repository.CategoryRepository
+	validate     *validation.Validator
 }
 
 // NewOrganizationService creates a new instance of OrganizationService.
-func NewOrganizationService(orgRepo organization_repository.OrganizationRepository, userRepo user_repository.UserRepository) OrganizationService {
+func NewOrganizationService(orgRepo organization_repository.OrganizationRepository, userRepo user_repository.UserRepository, categoryRepo category_repository.CategoryRepository) OrganizationService {
 	return &organizationService{
-		orgRepo:  orgRepo,
-		userRepo: userRepo,
-		validate: validator.New(),
+		orgRepo:      orgRepo,
+		userRepo:     userRepo,
+		categoryRepo: categoryRepo,
+		validate:     validation.New(categoryRepo),
 	}
 }
 
 // CreateOrganization creates a new organization.
 func (s *organizationService) CreateOrganization(ctx context.Context, org organization_models.OrganizationCreateModel) (*organization_models.OrganizationModel, error) {
-	if err := s.validate.Struct(org); err != nil {
+	if err := s.validate.Struct(ctx, org); err != nil {
 		return nil, err
 	}
 
 	entity := &entities.Organization{
-		Name:        org.Name,
-		Description: org.Description,
-		Type:        constants.OrganizationType(org.Type),
-		CreatedAt:   time.Now(),
-		UpdatedAt:   time.Now(),
+		Name:         org.Name,
+		Description:  org.Description,
+		Type:         constants.OrganizationType(org.Type),
+		ServiceTypes: buildServiceTypes(org.ServiceTypes),
+		CreatedAt:    time.Now(),
+		UpdatedAt:    time.Now(),
 	}
 
 	if err := s.orgRepo.Create(ctx, entity); err != nil {
 		return nil, err
 	}
 
+	publish(ctx, events.OrganizationEvent{OrganizationID: entity.ID, Action: events.ActionCreated})
+
 	return &organization_models.OrganizationModel{
-		ID:          entity.ID,
-		Name:        entity.Name,
-		Description: entity.Description,
-		Type:        string(entity.Type),
-		CreatedAt:   entity.CreatedAt,
-		UpdatedAt:   entity.UpdatedAt,
+		ID:           entity.ID,
+		Name:         entity.Name,
+		Description:  entity.Description,
+		Type:         string(entity.Type),
+		ServiceTypes: serviceTypeNames(entity.ServiceTypes),
+		CreatedAt:    entity.CreatedAt,
+		UpdatedAt:    entity.UpdatedAt,
 	}, nil
 }
 
 // UpdateOrganization updates an existing organization.
 func (s *organizationService) UpdateOrganization(ctx context.Context, org organization_models.OrganizationUpdateModel) (*organization_models.OrganizationModel, error) {
-	if err := s.validate.Struct(org); err != nil {
+	if err := s.validate.Struct(ctx, org); err != nil {
 		return nil, err
 	}
 
@@ -74,23 +82,31 @@ func (s *organizationService) UpdateOrganization(ctx context.Context, org organi
 	entity.Type = constants.OrganizationType(org.Type)
 	entity.UpdatedAt = time.Now()
 
+	// Service types are only replaced when they are provided
+	if org.ServiceTypes != nil {
+		entity.ServiceTypes = buildServiceTypes(org.ServiceTypes)
+	}
+
 	if err := s.orgRepo.Update(ctx, entity); err != nil {
 		return nil, err
 	}
 
+	publish(ctx, events.OrganizationEvent{OrganizationID: entity.ID, Action: events.ActionUpdated})
+
 	return &organization_models.OrganizationModel{
-		ID:          entity.ID,
-		Name:        entity.Name,
-		Description: entity.Description,
-		Type:        string(entity.Type),
-		CreatedAt:   entity.CreatedAt,
-		UpdatedAt:   entity.UpdatedAt,
+		ID:           entity.ID,
+		Name:         entity.Name,
+		Description:  entity.Description,
+		Type:         string(entity.Type),
+		ServiceTypes: serviceTypeNames(entity.ServiceTypes),
+		CreatedAt:    entity.CreatedAt,
+		UpdatedAt:    entity.UpdatedAt,
 	}, nil
 }
 
-// GetOrganizations retrieves all organizations.
-func (s *organizationService) GetOrganizations(ctx context.Context) ([]*organization_models.OrganizationModel, error) {
-	entities, err := s.orgRepo.GetAll(ctx)
+// GetOrganizations retrieves a page of organizations.
+func (s *organizationService) GetOrganizations(ctx context.Context, params pagination.Params) (*pagination.Page[*organization_models.OrganizationModel], error) {
+	entities, total, err := s.orgRepo.GetAll(ctx, params)
 	if err != nil {
 		return nil, err
 	}
@@ -98,36 +114,35 @@ func (s *organizationService) GetOrganizations(ctx context.Context) ([]*organiza
 	var organizations []*organization_models.OrganizationModel
 	for _, entity := range entities {
 		orgModel := &organization_models.OrganizationModel{
-			ID:          entity.ID,
-			Name:        entity.Name,
-			Description: entity.Description,
-			Type:        string(entity.Type),
-			CreatedAt:   entity.CreatedAt,
-			UpdatedAt:   entity.UpdatedAt,
+			ID:           entity.ID,
+			Name:         entity.Name,
+			Description:  entity.Description,
+			Type:         string(entity.Type),
+			ServiceTypes: serviceTypeNames(entity.ServiceTypes),
+			CreatedAt:    entity.CreatedAt,
+			UpdatedAt:    entity.UpdatedAt,
 		}
 		organizations = append(organizations, orgModel)
 	}
 
-	return organizations, nil
+	return pagination.NewPage(organizations, total, params), nil
 }
 
 // GetOrganizationByID retrieves an organization by its ID.
 func (s *organizationService) GetOrganizationByID(ctx context.Context, id int) (*organization_models.OrganizationModel, error) {
 	entity, err := s.orgRepo.FindByID(ctx, id)
 	if err != nil {
-		if errors.Is(err, organization_repository.ErrOrganizationNotFound) {
-			return nil, errors.New("organization not found")
-		}
 		return nil, err
 	}
 
 	return &organization_models.OrganizationModel{
-		ID:          entity.ID,
-		Name:        entity.Name,
-		Description: entity.Description,
-		Type:        string(entity.Type),
-		CreatedAt:   entity.CreatedAt,
-		UpdatedAt:   entity.UpdatedAt,
+		ID:           entity.ID,
+		Name:         entity.Name,
+		Description:  entity.Description,
+		Type:         string(entity.Type),
+		ServiceTypes: serviceTypeNames(entity.ServiceTypes),
+		CreatedAt:    entity.CreatedAt,
+		UpdatedAt:    entity.UpdatedAt,
 	}, nil
 }
 
@@ -135,9 +150,6 @@ func (s *organizationService) GetOrganizationByID(ctx context.Context, id int) (
 func (s *organizationService) DeleteOrganization(ctx context.Context, id int) error {
 	entity, err := s.orgRepo.FindByID(ctx, id)
 	if err != nil {
-		if errors.Is(err, organization_repository.ErrOrganizationNotFound) {
-			return errors.New("organization not found")
-		}
 		return err
 	}
 
@@ -145,6 +157,7 @@ func (s *organizationService) DeleteOrganization(ctx context.Context, id int) er
 		return err
 	}
 
+	publish(ctx, events.OrganizationEvent{OrganizationID: entity.ID, Action: events.ActionDeleted})
 	return nil
 }
 
@@ -153,12 +166,12 @@ func (s *organizationService) AddResponsible(ctx context.Context, orgID int, use
 	// Validate that both organization and user exist
 	org, err := s.orgRepo.FindByID(ctx, orgID)
 	if err != nil {
-		return errors.New("organization not found")
+		return err
 	}
 
 	user, err := s.userRepo.FindByID(ctx, userID)
 	if err != nil {
-		return errors.New("user not found")
+		return err
 	}
 
 	// Create OrganizationResponsible entity
@@ -178,12 +191,12 @@ func (s *organizationService) DeleteResponsible(ctx context.Context, orgID int,
 	// Validate that both organization and user exist
 	_, err := s.orgRepo.FindByID(ctx, orgID)
 	if err != nil {
-		return errors.New("organization not found")
+		return err
 	}
 
 	_, err = s.userRepo.FindByID(ctx, userID)
 	if err != nil {
-		return errors.New("user not found")
+		return err
 	}
 
 	// Delete responsible
@@ -217,15 +230,12 @@ func (s *organizationService) GetResponsibleByID(ctx context.Context, orgID int,
 	// Validate that the organization exists
 	_, err := s.orgRepo.FindByID(ctx, orgID)
 	if err != nil {
-		return nil, errors.New("organization not found")
+		return nil, err
 	}
 
 	// Fetch the responsible user for the organization by user ID
 	responsible, err := s.orgRepo.GetResponsibleByID(ctx, orgID, userID)
 	if err != nil {
-		if errors.Is(err, organization_repository.ErrResponsibleNotFound) {
-			return nil, errors.New("responsible user not found for the organization")
-		}
 		return nil, err
 	}
 
@@ -237,3 +247,28 @@ func (s *organizationService) GetResponsibleByID(ctx context.Context, orgID int,
 		LastName:  responsible.LastName,
 	}, nil
 }
+
+// buildServiceTypes converts the validated service types of an organization to entities.
+func buildServiceTypes(serviceTypes []string) []entities.OrganizationServiceType {
+	result := make([]entities.OrganizationServiceType, 0, len(serviceTypes))
+	for _, serviceType := range serviceTypes {
+		result = append(result, entities.OrganizationServiceType{ServiceType: serviceType})
+	}
+	return result
+}
+
+// serviceTypeNames returns the names of the service types provided by an organization.
+func serviceTypeNames(serviceTypes []entities.OrganizationServiceType) []string {
+	names := make([]string, 0, len(serviceTypes))
+	for _, serviceType := range serviceTypes {
+		names = append(names, serviceType.ServiceType)
+	}
+	return names
+}
+
+// publish announces an organization change once the surrounding transaction, if any, has committed
+func publish(ctx context.Context, event events.OrganizationEvent) {
+	transaction.AfterCommit(ctx, func() {
+		events.Publish(ctx, event)
+	})
+}
@@ -0,0 +1,11 @@
+package search_service
+
+import (
+	"avitoTest/services/search_service/search_models"
+	"context"
+)
+
+type SearchService interface {
+	SearchTenders(ctx context.Context, query search_models.SearchQueryModel) ([]*search_models.TenderSearchResultModel, error)
+	SearchBids(ctx context.Context, query search_models.SearchQueryModel) ([]*search_models.BidSearchResultModel, error)
+}
@@ -0,0 +1,28 @@
+package search_service
+
+import (
+	"avitoTest/services/search_service/search_models"
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockSearchService struct {
+	mock.Mock
+}
+
+func (m *MockSearchService) SearchTenders(ctx context.Context, query search_models.SearchQueryModel) ([]*search_models.TenderSearchResultModel, error) {
+	args := m.Called(ctx, query)
+	if results, ok := args.Get(0).([]*search_models.TenderSearchResultModel); ok {
+		return results, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockSearchService) SearchBids(ctx context.Context, query search_models.SearchQueryModel) ([]*search_models.BidSearchResultModel, error) {
+	args := m.Called(ctx, query)
+	if results, ok := args.Get(0).([]*search_models.BidSearchResultModel); ok {
+		return results, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
@@ -0,0 +1,9 @@
+package search_models
+
+// SearchQueryModel represents a full-text search request
+type SearchQueryModel struct {
+	Query          string `json:"q"`
+	Language       string `json:"lang"`
+	OrganizationID int    `json:"organization_id"`
+	Limit          int    `json:"limit"`
+}
@@ -0,0 +1,27 @@
+package search_models
+
+// TenderSearchResultModel represents a tender found by full-text search
+type TenderSearchResultModel struct {
+	TenderID       int     `json:"tender_id"`
+	OrganizationID int     `json:"organization_id"`
+	Name           string  `json:"name"`
+	Description    string  `json:"description"`
+	ServiceType    string  `json:"service_type"`
+	Status         string  `json:"status"`
+	Version        int     `json:"version"`
+	Rank           float64 `json:"rank"`
+	Snippet        string  `json:"snippet"`
+}
+
+// BidSearchResultModel represents a bid found by full-text search
+type BidSearchResultModel struct {
+	BidID          int     `json:"bid_id"`
+	TenderID       int     `json:"tender_id"`
+	OrganizationID int     `json:"organization_id"`
+	Name           string  `json:"name"`
+	Description    string  `json:"description"`
+	Status         string  `json:"status"`
+	Version        int     `json:"version"`
+	Rank           float64 `json:"rank"`
+	Snippet        string  `json:"snippet"`
+}
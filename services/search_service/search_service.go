@@ -0,0 +1,158 @@
+package search_service
+
+import (
+	"avitoTest/data/repositories/organization_repository"
+	"avitoTest/data/repositories/search_repository"
+	"avitoTest/services/search_service/search_models"
+	"avitoTest/shared/auth"
+	"avitoTest/shared/errors/search_errors"
+	"context"
+	"strings"
+)
+
+const (
+	defaultLanguage = "ru"
+	defaultLimit    = 20
+	maxLimit        = 100
+)
+
+var supportedLanguages = map[string]bool{
+	"ru": true,
+	"en": true,
+}
+
+type searchService struct {
+	searchRepo search_repository.SearchRepository
+	orgRepo    organization_repository.OrganizationRepository
+}
+
+// NewSearchService creates a new instance of SearchService.
+func NewSearchService(searchRepo search_repository.SearchRepository, orgRepo organization_repository.OrganizationRepository) SearchService {
+	return &searchService{searchRepo: searchRepo, orgRepo: orgRepo}
+}
+
+// SearchTenders searches the latest versions of tenders, ranked by relevance. Everyone
+// may search the published tenders; all tenders of an organization only its responsibles.
+func (s *searchService) SearchTenders(ctx context.Context, query search_models.SearchQueryModel) ([]*search_models.TenderSearchResultModel, error) {
+	params, err := buildSearchParams(query)
+	if err != nil {
+		return nil, err
+	}
+	if params.OrganizationID > 0 {
+		if err := s.authorize(ctx, params.OrganizationID); err != nil {
+			return nil, err
+		}
+	}
+
+	rows, err := s.searchRepo.SearchTenders(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]*search_models.TenderSearchResultModel, 0, len(rows))
+	for _, row := range rows {
+		results = append(results, &search_models.TenderSearchResultModel{
+			TenderID:       row.TenderID,
+			OrganizationID: row.OrganizationID,
+			Name:           row.Name,
+			Description:    row.Description,
+			ServiceType:    row.ServiceType,
+			Status:         row.Status,
+			Version:        row.Version,
+			Rank:           row.Rank,
+			Snippet:        row.Snippet,
+		})
+	}
+	return results, nil
+}
+
+// SearchBids searches the latest versions of the bids submitted by an organization,
+// which only its responsibles may search.
+func (s *searchService) SearchBids(ctx context.Context, query search_models.SearchQueryModel) ([]*search_models.BidSearchResultModel, error) {
+	if query.OrganizationID <= 0 {
+		return nil, search_errors.ErrOrganizationRequired
+	}
+
+	params, err := buildSearchParams(query)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.authorize(ctx, params.OrganizationID); err != nil {
+		return nil, err
+	}
+
+	rows, err := s.searchRepo.SearchBids(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]*search_models.BidSearchResultModel, 0, len(rows))
+	for _, row := range rows {
+		results = append(results, &search_models.BidSearchResultModel{
+			BidID:          row.BidID,
+			TenderID:       row.TenderID,
+			OrganizationID: row.OrganizationID,
+			Name:           row.Name,
+			Description:    row.Description,
+			Status:         row.Status,
+			Version:        row.Version,
+			Rank:           row.Rank,
+			Snippet:        row.Snippet,
+		})
+	}
+	return results, nil
+}
+
+// authorize lets the responsibles of an organization and API clients acting on their
+// own behalf search what only the organization may see.
+func (s *searchService) authorize(ctx context.Context, orgID int) error {
+	principal := auth.FromContext(ctx)
+	if principal.UserID == 0 {
+		if principal.Anonymous() {
+			return search_errors.ErrAuthenticationRequired
+		}
+		return nil
+	}
+
+	responsibles, err := s.orgRepo.GetResponsibles(ctx, orgID)
+	if err != nil {
+		return err
+	}
+	for _, responsible := range responsibles {
+		if responsible.ID == principal.UserID {
+			return nil
+		}
+	}
+	return search_errors.ErrNotResponsible
+}
+
+// buildSearchParams validates the search request and applies defaults.
+func buildSearchParams(query search_models.SearchQueryModel) (search_repository.SearchParams, error) {
+	text := strings.TrimSpace(query.Query)
+	if text == "" {
+		return search_repository.SearchParams{}, search_errors.ErrEmptyQuery
+	}
+
+	language := query.Language
+	if language == "" {
+		language = defaultLanguage
+	}
+	if !supportedLanguages[language] {
+		return search_repository.SearchParams{}, search_errors.ErrUnsupportedLanguage
+	}
+
+	limit := query.Limit
+	if limit <= 0 {
+		limit = defaultLimit
+	}
+	if limit > maxLimit {
+		limit = maxLimit
+	}
+
+	return search_repository.SearchParams{
+		Query:          text,
+		Language:       language,
+		OrganizationID: query.OrganizationID,
+		Limit:          limit,
+	}, nil
+}
@@ -0,0 +1,201 @@
+package tender_service
+
+import (
+	"avitoTest/data/repositories/transaction"
+	"avitoTest/services/audit_service"
+	"avitoTest/services/audit_service/audit_models"
+	"avitoTest/services/tender_service/tender_models"
+	"avitoTest/shared/constants"
+	"context"
+	"fmt"
+)
+
+// auditedTenderService records an audit event for every change made through the
+// wrapped service.
+type auditedTenderService struct {
+	TenderService
+	audit        audit_service.AuditService
+	transactions transaction.Manager
+}
+
+// NewAuditedTenderService wraps service so its successful changes are recorded in the
+// audit log, with the tender before and after each change. Each change is recorded in its
+// own transaction, so failing to record it fails the change.
+func NewAuditedTenderService(service TenderService, audit audit_service.AuditService, transactions transaction.Manager) TenderService {
+	return &auditedTenderService{TenderService: service, audit: audit, transactions: transactions}
+}
+
+func (s *auditedTenderService) CreateTender(ctx context.Context, tender tender_models.TenderCreateModel) (*tender_models.TenderModel, error) {
+	var created *tender_models.TenderModel
+	err := s.transactions.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		if created, err = s.TenderService.CreateTender(ctx, tender); err != nil {
+			return err
+		}
+		return s.record(ctx, constants.AuditActionCreated, created.ID, audit_service.Actor(ctx, tender.CreatorID), nil, created)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return created, nil
+}
+
+func (s *auditedTenderService) UpdateTender(ctx context.Context, tender tender_models.TenderUpdateModel) (*tender_models.TenderModel, error) {
+	var updated *tender_models.TenderModel
+	err := s.transactions.WithinTransaction(ctx, func(ctx context.Context) error {
+		before := s.snapshot(ctx, tender.ID)
+		var err error
+		if updated, err = s.TenderService.UpdateTender(ctx, tender); err != nil {
+			return err
+		}
+		return s.record(ctx, constants.AuditActionUpdated, tender.ID, audit_service.Actor(ctx, 0), before, updated)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+func (s *auditedTenderService) PublishTender(ctx context.Context, tenderID int) error {
+	return s.transactions.WithinTransaction(ctx, func(ctx context.Context) error {
+		before := s.snapshot(ctx, tenderID)
+		if err := s.TenderService.PublishTender(ctx, tenderID); err != nil {
+			return err
+		}
+		return s.record(ctx, constants.AuditActionPublished, tenderID, audit_service.Actor(ctx, 0), before, s.snapshot(ctx, tenderID))
+	})
+}
+
+func (s *auditedTenderService) CloseTender(ctx context.Context, tenderID int) error {
+	return s.transactions.WithinTransaction(ctx, func(ctx context.Context) error {
+		before := s.snapshot(ctx, tenderID)
+		if err := s.TenderService.CloseTender(ctx, tenderID); err != nil {
+			return err
+		}
+		return s.record(ctx, constants.AuditActionClosed, tenderID, audit_service.Actor(ctx, 0), before, s.snapshot(ctx, tenderID))
+	})
+}
+
+func (s *auditedTenderService) RollbackTenderVersion(ctx context.Context, tenderID int, version int) (*tender_models.TenderModel, error) {
+	var rolledBack *tender_models.TenderModel
+	err := s.transactions.WithinTransaction(ctx, func(ctx context.Context) error {
+		before := s.snapshot(ctx, tenderID)
+		var err error
+		if rolledBack, err = s.TenderService.RollbackTenderVersion(ctx, tenderID, version); err != nil {
+			return err
+		}
+		return s.record(ctx, constants.AuditActionRolledBack, tenderID, audit_service.Actor(ctx, 0), before, rolledBack)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return rolledBack, nil
+}
+
+func (s *auditedTenderService) DeleteTender(ctx context.Context, tenderID int) error {
+	return s.transactions.WithinTransaction(ctx, func(ctx context.Context) error {
+		before := s.snapshot(ctx, tenderID)
+		if err := s.TenderService.DeleteTender(ctx, tenderID); err != nil {
+			return err
+		}
+		return s.record(ctx, constants.AuditActionDeleted, tenderID, audit_service.Actor(ctx, 0), before, nil)
+	})
+}
+
+// SetEligibilityRules records the replaced rules as an update of the tender.
+func (s *auditedTenderService) SetEligibilityRules(ctx context.Context, tenderID int, rules []tender_models.EligibilityRuleModel) ([]*tender_models.EligibilityRuleModel, error) {
+	var set []*tender_models.EligibilityRuleModel
+	err := s.transactions.WithinTransaction(ctx, func(ctx context.Context) error {
+		before, _ := s.TenderService.GetEligibilityRules(ctx, tenderID)
+		var err error
+		if set, err = s.TenderService.SetEligibilityRules(ctx, tenderID, rules); err != nil {
+			return err
+		}
+
+		tender := s.snapshot(ctx, tenderID)
+		return s.recordEvent(ctx, audit_models.AuditRecordModel{
+			EntityType:     constants.AuditEntityTender,
+			EntityID:       tenderID,
+			OrganizationID: organizationOf(tender, nil),
+			Action:         constants.AuditActionUpdated,
+			ActorUserID:    audit_service.Actor(ctx, 0),
+			Before:         eligibilityRulesState{EligibilityRules: before},
+			After:          eligibilityRulesState{EligibilityRules: set},
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return set, nil
+}
+
+// ImportTenders records every tender an import created.
+func (s *auditedTenderService) ImportTenders(ctx context.Context, rows []tender_models.TenderImportRow, options tender_models.TenderImportOptions) (*tender_models.TenderImportReport, error) {
+	var report *tender_models.TenderImportReport
+	err := s.transactions.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		if report, err = s.TenderService.ImportTenders(ctx, rows, options); err != nil || report.DryRun {
+			return err
+		}
+
+		for _, row := range report.Rows {
+			if row.Status != tender_models.ImportRowCreated || row.TenderID == nil {
+				continue
+			}
+			if err := s.record(ctx, constants.AuditActionCreated, *row.TenderID, audit_service.Actor(ctx, row.CreatorID), nil, s.snapshot(ctx, *row.TenderID)); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+// eligibilityRulesState is the snapshot of the eligibility rules of a tender.
+type eligibilityRulesState struct {
+	EligibilityRules []*tender_models.EligibilityRuleModel `json:"eligibility_rules"`
+}
+
+// snapshot returns the tender as it is now, or nil if it cannot be read.
+func (s *auditedTenderService) snapshot(ctx context.Context, tenderID int) *tender_models.TenderModel {
+	tender, err := s.TenderService.GetTenderByID(ctx, tenderID)
+	if err != nil {
+		return nil
+	}
+	return tender
+}
+
+// record appends the event of a change to a tender. actorID names the user who made the
+// change.
+func (s *auditedTenderService) record(ctx context.Context, action constants.AuditAction, tenderID, actorID int, before, after *tender_models.TenderModel) error {
+	return s.recordEvent(ctx, audit_models.AuditRecordModel{
+		EntityType:     constants.AuditEntityTender,
+		EntityID:       tenderID,
+		OrganizationID: organizationOf(before, after),
+		Action:         action,
+		ActorUserID:    actorID,
+		Before:         before,
+		After:          after,
+	})
+}
+
+func (s *auditedTenderService) recordEvent(ctx context.Context, record audit_models.AuditRecordModel) error {
+	if err := s.audit.Record(ctx, record); err != nil {
+		return fmt.Errorf("audit: recording %s of %s %d: %w", record.Action, record.EntityType, record.EntityID, err)
+	}
+	return nil
+}
+
+// organizationOf returns the organization of the tender, taken from whichever snapshot exists.
+func organizationOf(before, after *tender_models.TenderModel) int {
+	if after != nil {
+		return after.OrganizationID
+	}
+	if before != nil {
+		return before.OrganizationID
+	}
+	return 0
+}
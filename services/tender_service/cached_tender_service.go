@@ -0,0 +1,91 @@
+package tender_service
+
+import (
+	"avitoTest/services/tender_service/tender_models"
+	"avitoTest/shared"
+	"avitoTest/shared/cache"
+	"avitoTest/shared/events"
+	"avitoTest/shared/filter"
+	"avitoTest/shared/pagination"
+	"context"
+	"encoding/json"
+	"strconv"
+	"time"
+)
+
+const (
+	tenderKeyPrefix = "tender:"
+	listKeyPrefix   = "list:"
+)
+
+// cachedTenderService serves tender reads from a cache and drops the cached
+// entries whenever a tender, organization or category event reports a change.
+type cachedTenderService struct {
+	TenderService
+	cache cache.Cache
+	ttl   time.Duration
+}
+
+// NewCachedTenderService wraps service with a read-through cache for single tenders and
+// tender lists. Writes go to service unchanged.
+func NewCachedTenderService(service TenderService, c cache.Cache, ttl time.Duration) TenderService {
+	s := &cachedTenderService{TenderService: service, cache: c, ttl: ttl}
+	events.Subscribe(events.TopicTender, s.invalidate)
+	events.Subscribe(events.TopicOrganization, s.invalidateOrganization)
+	events.Subscribe(events.TopicCategory, s.invalidateLists)
+	return s
+}
+
+// GetTenderByID returns the cached tender or loads it from the wrapped service.
+func (s *cachedTenderService) GetTenderByID(ctx context.Context, id int) (*tender_models.TenderModel, error) {
+	return cache.GetOrLoad(ctx, s.cache, tenderKey(id), s.ttl, func() (*tender_models.TenderModel, error) {
+		return s.TenderService.GetTenderByID(ctx, id)
+	})
+}
+
+// GetAllTenders returns the cached page or loads it from the wrapped service.
+func (s *cachedTenderService) GetAllTenders(ctx context.Context, f filter.Filter, params pagination.Params) (*pagination.Page[*tender_models.TenderModel], error) {
+	load := func() (*pagination.Page[*tender_models.TenderModel], error) {
+		return s.TenderService.GetAllTenders(ctx, f, params)
+	}
+
+	key, err := json.Marshal(struct {
+		Filter filter.Filter
+		Params pagination.Params
+	}{f, params})
+	if err != nil {
+		shared.Logger.Warnf("cache: failed to build tender list key: %v", err)
+		return load()
+	}
+	return cache.GetOrLoad(ctx, s.cache, listKeyPrefix+string(key), s.ttl, load)
+}
+
+// invalidate drops the changed tender and every cached list, since any change can
+// move a tender in or out of a filtered page.
+func (s *cachedTenderService) invalidate(ctx context.Context, event events.Event) {
+	tenderEvent, ok := event.(events.TenderEvent)
+	if !ok {
+		return
+	}
+	cache.Invalidate(ctx, s.cache, []string{tenderKey(tenderEvent.TenderID)}, listKeyPrefix)
+}
+
+// invalidateOrganization drops every cached tender and list when an organization is
+// deleted, since its tenders are deleted with it without tender events of their own.
+func (s *cachedTenderService) invalidateOrganization(ctx context.Context, event events.Event) {
+	orgEvent, ok := event.(events.OrganizationEvent)
+	if !ok || orgEvent.Action != events.ActionDeleted {
+		return
+	}
+	cache.Invalidate(ctx, s.cache, nil, tenderKeyPrefix, listKeyPrefix)
+}
+
+// invalidateLists drops every cached list when a category changes, since a category
+// filter matches the tenders of all its subcategories.
+func (s *cachedTenderService) invalidateLists(ctx context.Context, event events.Event) {
+	cache.Invalidate(ctx, s.cache, nil, listKeyPrefix)
+}
+
+func tenderKey(id int) string {
+	return tenderKeyPrefix + strconv.Itoa(id)
+}
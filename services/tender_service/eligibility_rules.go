@@ -0,0 +1,107 @@
+package tender_service
+
+import (
+	"avitoTest/data/entities"
+	"avitoTest/services/tender_service/tender_models"
+	"avitoTest/shared/constants"
+	"avitoTest/shared/errors/category_errors"
+	"avitoTest/shared/errors/tendert_erorrs"
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SetEligibilityRules replaces the eligibility rules declared for a tender.
+func (s *tenderService) SetEligibilityRules(ctx context.Context, tenderID int, rules []tender_models.EligibilityRuleModel) ([]*tender_models.EligibilityRuleModel, error) {
+	if _, err := s.tenderRepo.FindByID(ctx, tenderID); err != nil {
+		return nil, err
+	}
+
+	ruleEntities, err := s.buildEligibilityRules(ctx, tenderID, rules)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.tenderRepo.ReplaceEligibilityRules(ctx, tenderID, ruleEntities); err != nil {
+		return nil, err
+	}
+
+	return toEligibilityRuleModels(ruleEntities), nil
+}
+
+// GetEligibilityRules retrieves the eligibility rules declared for a tender.
+func (s *tenderService) GetEligibilityRules(ctx context.Context, tenderID int) ([]*tender_models.EligibilityRuleModel, error) {
+	if _, err := s.tenderRepo.FindByID(ctx, tenderID); err != nil {
+		return nil, err
+	}
+
+	rules, err := s.tenderRepo.FindEligibilityRules(ctx, tenderID)
+	if err != nil {
+		return nil, err
+	}
+
+	return toEligibilityRuleModels(rules), nil
+}
+
+// buildEligibilityRules validates eligibility rules and converts them to entities.
+func (s *tenderService) buildEligibilityRules(ctx context.Context, tenderID int, rules []tender_models.EligibilityRuleModel) ([]*entities.TenderEligibilityRule, error) {
+	result := make([]*entities.TenderEligibilityRule, 0, len(rules))
+	for _, rule := range rules {
+		if err := s.validateEligibilityRule(ctx, rule); err != nil {
+			return nil, err
+		}
+		result = append(result, &entities.TenderEligibilityRule{
+			TenderID:  tenderID,
+			RuleType:  string(rule.RuleType),
+			Value:     strings.TrimSpace(rule.Value),
+			CreatedAt: time.Now(),
+		})
+	}
+	return result, nil
+}
+
+// validateEligibilityRule checks that the rule type is known and its value is well-formed.
+func (s *tenderService) validateEligibilityRule(ctx context.Context, rule tender_models.EligibilityRuleModel) error {
+	value := strings.TrimSpace(rule.Value)
+
+	switch rule.RuleType {
+	case constants.EligibilityOrganizationType:
+		for _, orgType := range strings.Split(value, ",") {
+			switch constants.OrganizationType(strings.TrimSpace(orgType)) {
+			case constants.IE, constants.LLC, constants.JSC:
+			default:
+				return fmt.Errorf("%w: unknown organization type %q", tendert_erorrs.ErrInvalidEligibilityRule, orgType)
+			}
+		}
+	case constants.EligibilityMinRegistrationMonths:
+		months, err := strconv.Atoi(value)
+		if err != nil || months <= 0 {
+			return fmt.Errorf("%w: registration months must be a positive number", tendert_erorrs.ErrInvalidEligibilityRule)
+		}
+	case constants.EligibilityServiceType:
+		if err := s.validateServiceType(ctx, value); err != nil {
+			if errors.Is(err, category_errors.ErrInvalidServiceType) {
+				return fmt.Errorf("%w: invalid service type %q", tendert_erorrs.ErrInvalidEligibilityRule, value)
+			}
+			return err
+		}
+	default:
+		return fmt.Errorf("%w: unknown rule type %q", tendert_erorrs.ErrInvalidEligibilityRule, rule.RuleType)
+	}
+
+	return nil
+}
+
+func toEligibilityRuleModels(rules []*entities.TenderEligibilityRule) []*tender_models.EligibilityRuleModel {
+	models := make([]*tender_models.EligibilityRuleModel, 0, len(rules))
+	for _, rule := range rules {
+		models = append(models, &tender_models.EligibilityRuleModel{
+			RuleType: constants.EligibilityRuleType(rule.RuleType),
+			Value:    rule.Value,
+		})
+	}
+	return models
+}
@@ -4,12 +4,14 @@ package tender_service
 
 import (
 	"avitoTest/services/tender_service/tender_models"
+	"avitoTest/shared/filter"
+	"avitoTest/shared/pagination"
 	"context"
 )
 
 type TenderService interface {
-	GetAllTenders(ctx context.Context, serviceTypeFilter string) ([]*tender_models.TenderModel, error)
-	GetTendersByUsername(ctx context.Context, username string) ([]*tender_models.TenderModel, error)
+	GetAllTenders(ctx context.Context, f filter.Filter, params pagination.Params) (*pagination.Page[*tender_models.TenderModel], error)
+	GetTendersByUsername(ctx context.Context, username string, params pagination.Params) (*pagination.Page[*tender_models.TenderModel], error)
 	GetTenderByID(ctx context.Context, id int) (*tender_models.TenderModel, error)
 	CreateTender(ctx context.Context, tender tender_models.TenderCreateModel) (*tender_models.TenderModel, error)
 	UpdateTender(ctx context.Context, tender tender_models.TenderUpdateModel) (*tender_models.TenderModel, error)
@@ -17,4 +19,8 @@ type TenderService interface {
 	CloseTender(ctx context.Context, tenderID int) error
 	RollbackTenderVersion(ctx context.Context, tenderID int, version int) (*tender_models.TenderModel, error)
 	DeleteTender(ctx context.Context, tenderID int) error
+	SetEligibilityRules(ctx context.Context, tenderID int, rules []tender_models.EligibilityRuleModel) ([]*tender_models.EligibilityRuleModel, error)
+	GetEligibilityRules(ctx context.Context, tenderID int) ([]*tender_models.EligibilityRuleModel, error)
+	ExportTenders(ctx context.Context, orgID int, f filter.Filter, write func(*tender_models.TenderModel) error) error
+	ImportTenders(ctx context.Context, rows []tender_models.TenderImportRow, options tender_models.TenderImportOptions) (*tender_models.TenderImportReport, error)
 }
@@ -2,6 +2,8 @@ package tender_service
 
 import (
 	"avitoTest/services/tender_service/tender_models"
+	"avitoTest/shared/filter"
+	"avitoTest/shared/pagination"
 	"context"
 
 	"github.com/stretchr/testify/mock"
@@ -42,9 +44,9 @@ func (m *MockTenderService) RollbackTenderVersion(ctx context.Context, tenderID
 	return args.Get(0).(*tender_models.TenderModel), args.Error(1)
 }
 
-func (m *MockTenderService) GetAllTenders(ctx context.Context, serviceTypeFilter string) ([]*tender_models.TenderModel, error) {
-	args := m.Called(ctx, serviceTypeFilter)
-	return args.Get(0).([]*tender_models.TenderModel), args.Error(1)
+func (m *MockTenderService) GetAllTenders(ctx context.Context, f filter.Filter, params pagination.Params) (*pagination.Page[*tender_models.TenderModel], error) {
+	args := m.Called(ctx, f, params)
+	return args.Get(0).(*pagination.Page[*tender_models.TenderModel]), args.Error(1)
 }
 
 func (m *MockTenderService) DeleteTender(ctx context.Context, tenderID int) error {
@@ -52,7 +54,43 @@ func (m *MockTenderService) DeleteTender(ctx context.Context, tenderID int) erro
 	return args.Error(0)
 }
 
-func (m *MockTenderService) GetTendersByUsername(ctx context.Context, username string) ([]*tender_models.TenderModel, error) {
-	args := m.Called(ctx, username)
-	return args.Get(0).([]*tender_models.TenderModel), args.Error(1)
+func (m *MockTenderService) GetTendersByUsername(ctx context.Context, username string, params pagination.Params) (*pagination.Page[*tender_models.TenderModel], error) {
+	args := m.Called(ctx, username, params)
+	return args.Get(0).(*pagination.Page[*tender_models.TenderModel]), args.Error(1)
+}
+
+func (m *MockTenderService) SetEligibilityRules(ctx context.Context, tenderID int, rules []tender_models.EligibilityRuleModel) ([]*tender_models.EligibilityRuleModel, error) {
+	args := m.Called(ctx, tenderID, rules)
+	if models, ok := args.Get(0).([]*tender_models.EligibilityRuleModel); ok {
+		return models, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockTenderService) GetEligibilityRules(ctx context.Context, tenderID int) ([]*tender_models.EligibilityRuleModel, error) {
+	args := m.Called(ctx, tenderID)
+	if models, ok := args.Get(0).([]*tender_models.EligibilityRuleModel); ok {
+		return models, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockTenderService) ExportTenders(ctx context.Context, orgID int, f filter.Filter, write func(*tender_models.TenderModel) error) error {
+	args := m.Called(ctx, orgID, f)
+	if tenders, ok := args.Get(0).([]*tender_models.TenderModel); ok {
+		for _, tender := range tenders {
+			if err := write(tender); err != nil {
+				return err
+			}
+		}
+	}
+	return args.Error(1)
+}
+
+func (m *MockTenderService) ImportTenders(ctx context.Context, rows []tender_models.TenderImportRow, options tender_models.TenderImportOptions) (*tender_models.TenderImportReport, error) {
+	args := m.Called(ctx, rows, options)
+	if report, ok := args.Get(0).(*tender_models.TenderImportReport); ok {
+		return report, args.Error(1)
+	}
+	return nil, args.Error(1)
 }
@@ -0,0 +1,147 @@
+package tender_service
+
+import (
+	"avitoTest/data/repositories/user_repository"
+	"avitoTest/services/tender_service/tender_models"
+	"avitoTest/shared"
+	"avitoTest/shared/constants"
+	"avitoTest/shared/errors/domain_errors"
+	"avitoTest/shared/errors/tendert_erorrs"
+	"avitoTest/shared/validation"
+	"context"
+	"errors"
+	"fmt"
+)
+
+// errImportRolledBack rolls back the import transaction of a dry run or a failed atomic import.
+var errImportRolledBack = errors.New("import rolled back")
+
+// ImportTenders creates the tenders of rows through CreateTender, so every row passes the same
+// checks as a tender created through the API. Each row runs in its own transaction; in atomic
+// mode and in dry runs the rows share an outer transaction that is only committed when every
+// row is valid and the import is not a dry run.
+func (s *tenderService) ImportTenders(ctx context.Context, rows []tender_models.TenderImportRow, options tender_models.TenderImportOptions) (*tender_models.TenderImportReport, error) {
+	if options.Mode == "" {
+		options.Mode = tender_models.ImportModeAtomic
+	}
+	if options.Mode != tender_models.ImportModeAtomic && options.Mode != tender_models.ImportModePerRow {
+		return nil, fmt.Errorf("%w: %q, allowed: %s, %s", tendert_erorrs.ErrInvalidImportMode, options.Mode, tender_models.ImportModeAtomic, tender_models.ImportModePerRow)
+	}
+
+	report := &tender_models.TenderImportReport{
+		Mode:   options.Mode,
+		DryRun: options.DryRun,
+		Total:  len(rows),
+		Rows:   make([]tender_models.TenderImportRowResult, 0, len(rows)),
+	}
+
+	importRows := func(ctx context.Context) error {
+		for _, row := range rows {
+			result := s.importRow(ctx, row)
+			if result.Status == tender_models.ImportRowFailed {
+				report.Failed++
+			}
+			report.Rows = append(report.Rows, result)
+		}
+		if options.DryRun || (options.Mode == tender_models.ImportModeAtomic && report.Failed > 0) {
+			return errImportRolledBack
+		}
+		return nil
+	}
+
+	var err error
+	if options.Mode == tender_models.ImportModeAtomic || options.DryRun {
+		err = s.transactions.WithinTransaction(ctx, importRows)
+	} else {
+		err = importRows(ctx)
+	}
+	if err != nil && !errors.Is(err, errImportRolledBack) {
+		return nil, err
+	}
+
+	// Rows created in a rolled back transaction were only validated
+	for i := range report.Rows {
+		result := &report.Rows[i]
+		if result.Status != tender_models.ImportRowCreated {
+			continue
+		}
+		if err != nil {
+			result.Status = tender_models.ImportRowValid
+			result.TenderID = nil
+			continue
+		}
+		report.Imported++
+	}
+	return report, nil
+}
+
+// importRow creates the tender of a single row in its own transaction
+func (s *tenderService) importRow(ctx context.Context, row tender_models.TenderImportRow) tender_models.TenderImportRowResult {
+	result := tender_models.TenderImportRowResult{Row: row.Row}
+	fail := func(messages ...string) tender_models.TenderImportRowResult {
+		result.Status = tender_models.ImportRowFailed
+		result.Errors = append(result.Errors, messages...)
+		return result
+	}
+
+	if len(row.Errors) > 0 {
+		return fail(row.Errors...)
+	}
+
+	user, err := s.userRepo.FindByUsername(ctx, row.CreatorUsername)
+	if errors.Is(err, user_repository.ErrUserNotFound) {
+		return fail(fmt.Sprintf("creator_username: user %q not found", row.CreatorUsername))
+	}
+	if err != nil {
+		return fail(importRowError(row, err))
+	}
+
+	status := constants.TenderStatus(row.Status)
+	if status == "" {
+		status = constants.TenderStatusCreated
+	}
+
+	var tender *tender_models.TenderModel
+	err = s.transactions.WithinTransaction(ctx, func(ctx context.Context) error {
+		tender, err = s.CreateTender(ctx, tender_models.TenderCreateModel{
+			Name:             row.Name,
+			Description:      row.Description,
+			ServiceType:      row.ServiceType,
+			Budget:           row.Budget,
+			OrganizationID:   row.OrganizationID,
+			CreatorID:        user.ID,
+			Status:           status,
+			EligibilityRules: row.EligibilityRules,
+		})
+		return err
+	})
+	var validationErr *validation.Error
+	if errors.As(err, &validationErr) {
+		// Report every invalid field of the row
+		messages := make([]string, 0, len(validationErr.Fields))
+		for _, field := range validationErr.Fields {
+			messages = append(messages, field.Message)
+		}
+		return fail(messages...)
+	}
+	if err != nil {
+		return fail(importRowError(row, err))
+	}
+
+	result.Status = tender_models.ImportRowCreated
+	result.TenderID = &tender.ID
+	result.CreatorID = user.ID
+	return result
+}
+
+// importRowError describes err for the import report. Validation, not found and conflict errors,
+// and a creator not responsible for the organization, are about the row and shown; anything else
+// is logged and reported as an internal error.
+func importRowError(row tender_models.TenderImportRow, err error) string {
+	switch domain_errors.Kind(err) {
+	case domain_errors.ErrValidation, domain_errors.ErrNotFound, domain_errors.ErrConflict, domain_errors.ErrForbidden:
+		return err.Error()
+	}
+	shared.Logger.Errorf("Error importing tender row %d: %v", row.Row, err)
+	return "internal error"
+}
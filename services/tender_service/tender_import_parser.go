@@ -0,0 +1,162 @@
+package tender_service
+
+import (
+	"avitoTest/services/tender_service/tender_models"
+	"avitoTest/shared/errors/tendert_erorrs"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// MaxImportRows is the largest number of tenders accepted in one import.
+const MaxImportRows = 5000
+
+// Columns of a CSV import file.
+const (
+	importColumnName            = "name"
+	importColumnDescription     = "description"
+	importColumnServiceType     = "service_type"
+	importColumnBudget          = "budget"
+	importColumnStatus          = "status"
+	importColumnOrganizationID  = "organization_id"
+	importColumnCreatorUsername = "creator_username"
+)
+
+var importColumns = map[string]bool{
+	importColumnName:            true,
+	importColumnDescription:     false,
+	importColumnServiceType:     true,
+	importColumnBudget:          false,
+	importColumnStatus:          false,
+	importColumnOrganizationID:  true,
+	importColumnCreatorUsername: true,
+}
+
+// ParseTenderImport reads the tenders of an import file. A file that cannot be read as a
+// whole is an error; problems with single rows are recorded on the rows, so they can be
+// reported together with the validation results.
+func ParseTenderImport(r io.Reader, format string) ([]tender_models.TenderImportRow, error) {
+	switch strings.ToLower(format) {
+	case tender_models.ImportFormatCSV:
+		return parseCSVImport(r)
+	case tender_models.ImportFormatJSON:
+		return parseJSONImport(r)
+	}
+	return nil, fmt.Errorf("%w: %q, allowed: %s, %s", tendert_erorrs.ErrInvalidImportFormat, format, tender_models.ImportFormatCSV, tender_models.ImportFormatJSON)
+}
+
+// parseCSVImport reads a CSV file with a header row naming the columns.
+func parseCSVImport(r io.Reader) ([]tender_models.TenderImportRow, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, fmt.Errorf("%w: the file is empty", tendert_erorrs.ErrInvalidImportFile)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", tendert_erorrs.ErrInvalidImportFile, err)
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		// Spreadsheets often save CSV with a byte order mark
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		if _, ok := importColumns[name]; !ok {
+			return nil, fmt.Errorf("%w: unknown column %q", tendert_erorrs.ErrInvalidImportFile, name)
+		}
+		columns[name] = i
+	}
+	for name, required := range importColumns {
+		if _, ok := columns[name]; required && !ok {
+			return nil, fmt.Errorf("%w: missing column %q", tendert_erorrs.ErrInvalidImportFile, name)
+		}
+	}
+
+	var rows []tender_models.TenderImportRow
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+
+		row := tender_models.TenderImportRow{Row: len(rows) + 1}
+		if errors.Is(err, csv.ErrFieldCount) {
+			row.Errors = append(row.Errors, fmt.Sprintf("expected %d fields, got %d", len(header), len(record)))
+		} else if err != nil {
+			return nil, fmt.Errorf("%w: %v", tendert_erorrs.ErrInvalidImportFile, err)
+		} else {
+			readCSVRow(&row, record, columns)
+		}
+
+		rows = append(rows, row)
+		if len(rows) > MaxImportRows {
+			return nil, fmt.Errorf("%w: more than %d rows", tendert_erorrs.ErrInvalidImportFile, MaxImportRows)
+		}
+	}
+	return rows, nil
+}
+
+func readCSVRow(row *tender_models.TenderImportRow, record []string, columns map[string]int) {
+	value := func(name string) string {
+		if i, ok := columns[name]; ok {
+			return strings.TrimSpace(record[i])
+		}
+		return ""
+	}
+
+	row.Name = value(importColumnName)
+	row.Description = value(importColumnDescription)
+	row.ServiceType = value(importColumnServiceType)
+	row.Status = value(importColumnStatus)
+	row.CreatorUsername = value(importColumnCreatorUsername)
+
+	if organizationID, err := strconv.Atoi(value(importColumnOrganizationID)); err != nil {
+		row.Errors = append(row.Errors, importColumnOrganizationID+": must be an integer")
+	} else {
+		row.OrganizationID = organizationID
+	}
+
+	if budget := value(importColumnBudget); budget != "" {
+		if parsed, err := strconv.ParseFloat(budget, 64); err != nil {
+			row.Errors = append(row.Errors, importColumnBudget+": must be a number")
+		} else {
+			row.Budget = &parsed
+		}
+	}
+}
+
+// parseJSONImport reads a JSON array of tenders one element at a time.
+func parseJSONImport(r io.Reader) ([]tender_models.TenderImportRow, error) {
+	decoder := json.NewDecoder(r)
+	if token, err := decoder.Token(); err != nil || token != json.Delim('[') {
+		return nil, fmt.Errorf("%w: expected a JSON array", tendert_erorrs.ErrInvalidImportFile)
+	}
+
+	var rows []tender_models.TenderImportRow
+	for decoder.More() {
+		var row tender_models.TenderImportRow
+		if err := decoder.Decode(&row); err != nil {
+			var typeErr *json.UnmarshalTypeError
+			if !errors.As(err, &typeErr) {
+				return nil, fmt.Errorf("%w: %v", tendert_erorrs.ErrInvalidImportFile, err)
+			}
+			row.Errors = append(row.Errors, typeErr.Field+": must be "+typeErr.Type.String())
+		}
+		row.Row = len(rows) + 1
+
+		rows = append(rows, row)
+		if len(rows) > MaxImportRows {
+			return nil, fmt.Errorf("%w: more than %d rows", tendert_erorrs.ErrInvalidImportFile, MaxImportRows)
+		}
+	}
+
+	if _, err := decoder.Token(); err != nil {
+		return nil, fmt.Errorf("%w: %v", tendert_erorrs.ErrInvalidImportFile, err)
+	}
+	return rows, nil
+}
@@ -0,0 +1,9 @@
+package tender_models
+
+import "avitoTest/shared/constants"
+
+// EligibilityRuleModel describes a restriction on which organizations may bid on a tender.
+type EligibilityRuleModel struct {
+	RuleType constants.EligibilityRuleType `json:"rule_type" validate:"required"`
+	Value    string                        `json:"value" validate:"max=255"`
+}
@@ -3,10 +3,13 @@ package tender_models
 import "avitoTest/shared/constants"
 
 type TenderCreateModel struct {
-	Name           string                 `json:"name"`
-	Description    string                 `json:"description"`
-	ServiceType    string                 `json:"service_type"`
-	OrganizationID int                    `json:"organization_id"`
-	CreatorID      int                    `json:"creator_id"`
-	Status         constants.TenderStatus `json:"status"`
+	Name           string                 `json:"name" validate:"required,max=100"`
+	Description    string                 `json:"description" validate:"max=255"`
+	ServiceType    string                 `json:"service_type" validate:"required,max=100,service_type"`
+	Budget         *float64               `json:"budget"`
+	OrganizationID int                    `json:"organization_id" validate:"required,gt=0"`
+	CreatorID      int                    `json:"creator_id" validate:"required"`
+	Status         constants.TenderStatus `json:"status" validate:"required,tender_status"`
+
+	EligibilityRules []EligibilityRuleModel `json:"eligibility_rules" validate:"dive"`
 }
@@ -0,0 +1,26 @@
+package tender_models
+
+import (
+	"avitoTest/shared/constants"
+	"avitoTest/shared/filter"
+)
+
+// TenderFilterSchema lists the fields tender lists can be filtered by.
+// The category filter also matches tenders of all subcategories.
+var TenderFilterSchema = filter.Schema{
+	constants.FilterFieldStatus: {
+		Type:      filter.String,
+		Operators: filter.Equality,
+		Values: []string{
+			string(constants.TenderStatusCreated),
+			string(constants.TenderStatusPublished),
+			string(constants.TenderStatusClosed),
+		},
+	},
+	constants.FilterFieldOrganizationID: {Type: filter.Int, Operators: filter.Equality},
+	constants.FilterFieldCreatorID:      {Type: filter.Int, Operators: filter.Equality},
+	constants.FilterFieldCreatedAt:      {Type: filter.Time, Operators: filter.Comparison},
+	constants.FilterFieldBudget:         {Type: filter.Number, Operators: filter.Comparison},
+	constants.FilterFieldCategory:       {Type: filter.String, Operators: []filter.Operator{filter.OpEq, filter.OpIn}},
+	constants.FilterFieldName:           {Type: filter.String, Operators: []filter.Operator{filter.OpEq, filter.OpContains}},
+}
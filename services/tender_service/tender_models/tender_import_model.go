@@ -0,0 +1,59 @@
+package tender_models
+
+// Import file formats.
+const (
+	ImportFormatCSV  = "csv"
+	ImportFormatJSON = "json"
+)
+
+// Import modes: atomic imports every row or none, per_row imports the valid rows.
+const (
+	ImportModeAtomic = "atomic"
+	ImportModePerRow = "per_row"
+)
+
+// Statuses of a row in an import report.
+const (
+	ImportRowCreated = "created"
+	ImportRowValid   = "valid"
+	ImportRowFailed  = "failed"
+)
+
+// TenderImportRow is a tender read from an import file. Row is its 1-based position
+// among the records of the file; Errors holds the problems found while reading it.
+type TenderImportRow struct {
+	Row              int                    `json:"-"`
+	Name             string                 `json:"name"`
+	Description      string                 `json:"description"`
+	ServiceType      string                 `json:"service_type"`
+	Budget           *float64               `json:"budget"`
+	Status           string                 `json:"status"`
+	OrganizationID   int                    `json:"organization_id"`
+	CreatorUsername  string                 `json:"creator_username"`
+	EligibilityRules []EligibilityRuleModel `json:"eligibility_rules"`
+	Errors           []string               `json:"-"`
+}
+
+type TenderImportOptions struct {
+	Mode   string
+	DryRun bool
+}
+
+type TenderImportReport struct {
+	Mode     string                  `json:"mode"`
+	DryRun   bool                    `json:"dry_run"`
+	Total    int                     `json:"total"`
+	Imported int                     `json:"imported"`
+	Failed   int                     `json:"failed"`
+	Rows     []TenderImportRowResult `json:"rows"`
+}
+
+type TenderImportRowResult struct {
+	Row      int      `json:"row"`
+	Status   string   `json:"status"`
+	TenderID *int     `json:"tender_id,omitempty"`
+	Errors   []string `json:"errors,omitempty"`
+
+	// CreatorID is the user the tender was created by, kept for the audit log.
+	CreatorID int `json:"-"`
+}
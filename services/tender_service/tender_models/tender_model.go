@@ -11,6 +11,7 @@ type TenderModel struct {
 	Name           string                 `json:"name"`
 	Description    string                 `json:"description"`
 	ServiceType    string                 `json:"service_type"`
+	Budget         *float64               `json:"budget,omitempty"`
 	Status         constants.TenderStatus `json:"status"`
 	CreatedAt      time.Time              `json:"created_at"`
 	Version        int                    `json:"version"`
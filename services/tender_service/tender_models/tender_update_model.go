@@ -1,7 +1,10 @@
 package tender_models
 
 type TenderUpdateModel struct {
-	ID          int    `json:"id"`
-	Name        string `json:"name"`
-	Description string `json:"description"`
+	ID          int    `json:"id" validate:"required"`
+	Name        string `json:"name" validate:"max=100"`
+	Description string `json:"description" validate:"max=255"`
+
+	// Budget replaces the tender budget when set
+	Budget *float64 `json:"budget"`
 }
@@ -4,70 +4,62 @@ package tender_service
 
 import (
 	"avitoTest/data/entities"
+	"avitoTest/data/repositories/category_repository"
 	"avitoTest/data/repositories/tender_repository"
+	"avitoTest/data/repositories/transaction"
 	"avitoTest/data/repositories/user_repository"
 	"avitoTest/services/tender_service/tender_models"
 	"avitoTest/shared"
 	"avitoTest/shared/constants"
+	"avitoTest/shared/errors/category_errors"
 	"avitoTest/shared/errors/tendert_erorrs"
-	"avitoTest/shared/validators"
+	"avitoTest/shared/events"
+	"avitoTest/shared/filter"
+	"avitoTest/shared/metrics"
+	"avitoTest/shared/pagination"
+	"avitoTest/shared/validation"
 	"context"
 	"errors"
 	"time"
 )
 
 type tenderService struct {
-	tenderRepo tender_repository.TenderRepository
-	userRepo   user_repository.UserRepository
+	tenderRepo   tender_repository.TenderRepository
+	userRepo     user_repository.UserRepository
+	categoryRepo category_repository.CategoryRepository
+	transactions transaction.Manager
+	validate     *validation.Validator
 }
 
-func NewTenderService(tenderRepo tender_repository.TenderRepository, userRepo user_repository.UserRepository) TenderService {
+func NewTenderService(tenderRepo tender_repository.TenderRepository, userRepo user_repository.UserRepository, categoryRepo category_repository.CategoryRepository, transactions transaction.Manager) TenderService {
 	return &tenderService{
-		tenderRepo: tenderRepo,
-		userRepo:   userRepo,
+		tenderRepo:   tenderRepo,
+		userRepo:     userRepo,
+		categoryRepo: categoryRepo,
+		transactions: transactions,
+		validate:     validation.New(categoryRepo),
 	}
 }
 
-// GetAllTenders retrieves all tenders
-func (s *tenderService) GetAllTenders(ctx context.Context, serviceTypeFilter string) ([]*tender_models.TenderModel, error) {
-	var entities []*entities.Tender
-	var err error
-
-	// Если есть фильтр по типу услуг, используем его
-	if serviceTypeFilter != "" {
-		if !validators.IsValidServiceType(serviceTypeFilter) {
-			return nil, errors.New("invalid service type")
-		}
-		entities, err = s.tenderRepo.GetAllByServiceType(ctx, serviceTypeFilter)
-	} else {
-		entities, err = s.tenderRepo.GetAll(ctx)
+// GetAllTenders retrieves a page of tenders matching the filter
+func (s *tenderService) GetAllTenders(ctx context.Context, f filter.Filter, params pagination.Params) (*pagination.Page[*tender_models.TenderModel], error) {
+	// Фильтр по категории услуг включает все её подкатегории
+	f, err := s.expandCategories(ctx, f)
+	if err != nil {
+		return nil, err
 	}
 
+	entities, total, err := s.tenderRepo.GetAll(ctx, f, params)
 	if err != nil {
 		return nil, err
 	}
 
-	var tenders []*tender_models.TenderModel
-	for _, entity := range entities {
-		// Find the latest version of each tender
-		latestVersion, err := s.tenderRepo.FindLatestVersion(ctx, entity.ID)
-		if err != nil {
-			return nil, err
-		}
-
-		tenderModel := &tender_models.TenderModel{
-			ID:             entity.ID,
-			OrganizationID: entity.OrganizationID,
-			Name:           latestVersion.Name,
-			Description:    latestVersion.Description,
-			Status:         constants.TenderStatus(entity.Status),
-			CreatedAt:      entity.CreatedAt,
-			Version:        latestVersion.Version,
-		}
-		tenders = append(tenders, tenderModel)
+	tenders, err := toTenderModels(entities)
+	if err != nil {
+		return nil, err
 	}
 
-	return tenders, nil
+	return pagination.NewPage(tenders, total, params), nil
 }
 
 // GetTenderByID retrieves a specific tender by ID
@@ -80,86 +72,58 @@ func (s *tenderService) GetTenderByID(ctx context.Context, id int) (*tender_mode
 		return nil, err
 	}
 
-	// Find the latest version of the tender
-	latestVersion, err := s.tenderRepo.FindLatestVersion(ctx, id)
-	if err != nil {
-		return nil, err
-	}
-
-	return &tender_models.TenderModel{
-		ID:             entity.ID,
-		OrganizationID: entity.OrganizationID,
-		Name:           latestVersion.Name,
-		Description:    latestVersion.Description,
-		Status:         constants.TenderStatus(entity.Status),
-		CreatedAt:      entity.CreatedAt,
-		Version:        latestVersion.Version,
-	}, nil
+	return toTenderModel(entity)
 }
 
 // Method to get tenders created by a specific user by username
-func (s *tenderService) GetTendersByUsername(ctx context.Context, username string) ([]*tender_models.TenderModel, error) {
+func (s *tenderService) GetTendersByUsername(ctx context.Context, username string, params pagination.Params) (*pagination.Page[*tender_models.TenderModel], error) {
 	user, err := s.userRepo.FindByUsername(ctx, username)
 	if err != nil {
-		if errors.Is(err, user_repository.ErrUserNotFound) {
-			return nil, errors.New("user not found")
-		}
 		return nil, err
 	}
 
-	tenders, err := s.tenderRepo.GetAllByCreatorID(ctx, user.ID)
+	tenders, total, err := s.tenderRepo.GetAllByCreatorID(ctx, user.ID, params)
 	if err != nil {
 		return nil, err
 	}
 
-	var tenderModels []*tender_models.TenderModel
-	for _, tender := range tenders {
-		latestVersion, err := s.tenderRepo.FindLatestVersion(ctx, tender.ID)
-		if err != nil {
-			return nil, err
-		}
-		tenderModel := &tender_models.TenderModel{
-			ID:             tender.ID,
-			OrganizationID: tender.OrganizationID,
-			Name:           latestVersion.Name,
-			Description:    latestVersion.Description,
-			ServiceType:    tender.ServiceType,
-			Status:         constants.TenderStatus(tender.Status),
-			CreatedAt:      tender.CreatedAt,
-			Version:        latestVersion.Version,
-		}
-		tenderModels = append(tenderModels, tenderModel)
+	tenderModels, err := toTenderModels(tenders)
+	if err != nil {
+		return nil, err
 	}
 
-	return tenderModels, nil
+	return pagination.NewPage(tenderModels, total, params), nil
 }
 
 // CreateTender создает новый тендер и проверяет статус
 func (s *tenderService) CreateTender(ctx context.Context, tender tender_models.TenderCreateModel) (*tender_models.TenderModel, error) {
+	if err := s.validate.Struct(ctx, tender); err != nil {
+		shared.Logger.Errorf("Invalid tender: %v", err)
+		return nil, err
+	}
+
 	// Check if the user is responsible for the organization
 	if !s.isUserResponsibleForOrganization(ctx, tender.CreatorID, tender.OrganizationID) {
 		shared.Logger.Warnf("Unauthorized access: User %d is not responsible for organization %d", tender.CreatorID, tender.OrganizationID)
 		return nil, tendert_erorrs.ErrUnauthorized
 	}
 
-	// Validate the OrganizationID
-	if tender.OrganizationID <= 0 {
-		shared.Logger.Errorf("Invalid Organization ID: %d", tender.OrganizationID)
-		return nil, errors.New("invalid organization ID")
-	}
-
-	// Validate the ServiceType using predefined constants
-	if tender.ServiceType != string(constants.ServiceTypeConstruction) &&
-		tender.ServiceType != string(constants.ServiceTypeIT) &&
-		tender.ServiceType != string(constants.ServiceTypeConsulting) {
-		shared.Logger.Errorf("Invalid service type: %s", tender.ServiceType)
-		return nil, errors.New("invalid service type")
+	if err := validateBudget(tender.Budget); err != nil {
+		shared.Logger.Errorf("Invalid budget: %v", *tender.Budget)
+		return nil, err
 	}
 
-	// Check for valid tender status
+	// New tenders are either drafts or published at once
 	if tender.Status != constants.TenderStatusCreated && tender.Status != constants.TenderStatusPublished {
 		shared.Logger.Errorf("Invalid tender status: %s", tender.Status)
-		return nil, tendert_erorrs.ErrInvalidStatus
+		return nil, validation.NewFieldError("status", "oneof", tendert_erorrs.ErrInvalidStatus)
+	}
+
+	// Validate the eligibility rules before anything is stored
+	rules, err := s.buildEligibilityRules(ctx, 0, tender.EligibilityRules)
+	if err != nil {
+		shared.Logger.Errorf("Invalid eligibility rules: %v", err)
+		return nil, err
 	}
 
 	// Create the Tender entity
@@ -168,6 +132,7 @@ func (s *tenderService) CreateTender(ctx context.Context, tender tender_models.T
 		CreatorID:      tender.CreatorID,
 		Status:         string(tender.Status),
 		ServiceType:    tender.ServiceType,
+		Budget:         tender.Budget,
 		CreatedAt:      time.Now(),
 	}
 
@@ -197,10 +162,23 @@ func (s *tenderService) CreateTender(ctx context.Context, tender tender_models.T
 		return nil, err
 	}
 
+	if len(rules) > 0 {
+		for _, rule := range rules {
+			rule.TenderID = entity.ID
+		}
+		if err := s.tenderRepo.ReplaceEligibilityRules(ctx, entity.ID, rules); err != nil {
+			shared.Logger.Errorf("Error creating tender eligibility rules: %v", err)
+			return nil, err
+		}
+	}
+
+	publish(ctx, events.TenderEvent{TenderID: entity.ID, Action: events.ActionCreated})
+
 	// Return the created tender model
 	return &tender_models.TenderModel{
 		ID:             entity.ID,
 		OrganizationID: entity.OrganizationID,
+		Budget:         entity.Budget,
 		Name:           version.Name,
 		Description:    version.Description,
 		ServiceType:    entity.ServiceType,
@@ -212,6 +190,10 @@ func (s *tenderService) CreateTender(ctx context.Context, tender tender_models.T
 
 // UpdateTender updates an existing tender and creates a new version
 func (s *tenderService) UpdateTender(ctx context.Context, tender tender_models.TenderUpdateModel) (*tender_models.TenderModel, error) {
+	if err := s.validate.Struct(ctx, tender); err != nil {
+		return nil, err
+	}
+
 	entity, err := s.tenderRepo.FindByID(ctx, tender.ID)
 	if err != nil {
 		if errors.Is(err, tendert_erorrs.ErrTenderNotFound) {
@@ -220,6 +202,16 @@ func (s *tenderService) UpdateTender(ctx context.Context, tender tender_models.T
 		return nil, err
 	}
 
+	if tender.Budget != nil {
+		if err := validateBudget(tender.Budget); err != nil {
+			return nil, err
+		}
+		entity.Budget = tender.Budget
+		if err := s.tenderRepo.Update(ctx, entity); err != nil {
+			return nil, err
+		}
+	}
+
 	// Find the latest version of the tender
 	latestVersion, err := s.tenderRepo.FindLatestVersion(ctx, tender.ID)
 	if err != nil {
@@ -242,9 +234,12 @@ func (s *tenderService) UpdateTender(ctx context.Context, tender tender_models.T
 		return nil, err
 	}
 
+	publish(ctx, events.TenderEvent{TenderID: entity.ID, Action: events.ActionUpdated})
+
 	return &tender_models.TenderModel{
 		ID:             entity.ID,
 		OrganizationID: entity.OrganizationID,
+		Budget:         entity.Budget,
 		Name:           version.Name,
 		Description:    version.Description,
 		ServiceType:    entity.ServiceType,
@@ -268,6 +263,7 @@ func (s *tenderService) PublishTender(ctx context.Context, tenderID int) error {
 		return err
 	}
 
+	publish(ctx, events.TenderEvent{TenderID: entity.ID, Action: events.ActionPublished})
 	return nil
 }
 
@@ -292,6 +288,7 @@ func (s *tenderService) CloseTender(ctx context.Context, tenderID int) error {
 		return err
 	}
 
+	publish(ctx, events.TenderEvent{TenderID: entity.ID, Action: events.ActionClosed})
 	return nil
 }
 
@@ -327,9 +324,12 @@ func (s *tenderService) RollbackTenderVersion(ctx context.Context, tenderID int,
 		return nil, err
 	}
 
+	publish(ctx, events.TenderEvent{TenderID: entity.ID, Action: events.ActionRolledBack})
+
 	return &tender_models.TenderModel{
 		ID:             entity.ID,
 		OrganizationID: entity.OrganizationID,
+		Budget:         entity.Budget,
 		Name:           rollbackVersion.Name,
 		Description:    rollbackVersion.Description,
 		ServiceType:    entity.ServiceType,
@@ -363,5 +363,124 @@ func (s *tenderService) DeleteTender(ctx context.Context, tenderID int) error {
 		return err
 	}
 
+	publish(ctx, events.TenderEvent{TenderID: tenderID, Action: events.ActionDeleted})
+	return nil
+}
+
+// ExportTenders passes every tender of an organization that matches the filter to write,
+// reading the tenders in batches
+func (s *tenderService) ExportTenders(ctx context.Context, orgID int, f filter.Filter, write func(*tender_models.TenderModel) error) error {
+	f, err := s.expandCategories(ctx, f)
+	if err != nil {
+		return err
+	}
+
+	return s.tenderRepo.StreamByOrganizationID(ctx, orgID, f, func(batch []*entities.Tender) error {
+		tenders, err := toTenderModels(batch)
+		if err != nil {
+			return err
+		}
+		for _, tender := range tenders {
+			if err := write(tender); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// publish announces and counts a tender change once the surrounding transaction, if any, has committed
+func publish(ctx context.Context, event events.TenderEvent) {
+	transaction.AfterCommit(ctx, func() {
+		metrics.Tenders.WithLabelValues(event.Action).Inc()
+		events.Publish(ctx, event)
+	})
+}
+
+// validateServiceType checks that the service type is the code of an active service category
+func (s *tenderService) validateServiceType(ctx context.Context, serviceType string) error {
+	active, err := s.categoryRepo.IsActiveCode(ctx, serviceType)
+	if err != nil {
+		return err
+	}
+	if !active {
+		return category_errors.ErrInvalidServiceType
+	}
 	return nil
 }
+
+// expandCategories replaces the category conditions of the filter with the codes
+// of the requested categories and all of their subcategories
+func (s *tenderService) expandCategories(ctx context.Context, f filter.Filter) (filter.Filter, error) {
+	conditions := f.Fields(constants.FilterFieldCategory)
+	if len(conditions) == 0 {
+		return f, nil
+	}
+
+	expanded := f.Without(constants.FilterFieldCategory)
+	for _, condition := range conditions {
+		var codes []any
+		for _, value := range condition.Values {
+			category, err := s.categoryRepo.FindByCode(ctx, value.(string))
+			if err != nil {
+				if errors.Is(err, category_errors.ErrCategoryNotFound) {
+					return nil, category_errors.ErrInvalidServiceType
+				}
+				return nil, err
+			}
+			subtree, err := s.categoryRepo.FindSubtreeCodes(ctx, category.ID)
+			if err != nil {
+				return nil, err
+			}
+			for _, code := range subtree {
+				codes = append(codes, code)
+			}
+		}
+		expanded = append(expanded, filter.Condition{
+			Field:    constants.FilterFieldCategory,
+			Operator: filter.OpIn,
+			Values:   codes,
+		})
+	}
+	return expanded, nil
+}
+
+// validateBudget checks that an optional budget is not negative
+func validateBudget(budget *float64) error {
+	if budget != nil && *budget < 0 {
+		return validation.NewFieldError("budget", "gte", tendert_erorrs.ErrInvalidBudget)
+	}
+	return nil
+}
+
+// toTenderModel builds the tender model from a tender loaded with its current version
+func toTenderModel(entity *entities.Tender) (*tender_models.TenderModel, error) {
+	if entity.CurrentVersion == nil {
+		return nil, tendert_erorrs.ErrTenderVersionNotFound
+	}
+
+	return &tender_models.TenderModel{
+		ID:             entity.ID,
+		OrganizationID: entity.OrganizationID,
+		Budget:         entity.Budget,
+		Name:           entity.CurrentVersion.Name,
+		Description:    entity.CurrentVersion.Description,
+		ServiceType:    entity.ServiceType,
+		Status:         constants.TenderStatus(entity.Status),
+		CreatedAt:      entity.CreatedAt,
+		Version:        entity.CurrentVersion.Version,
+	}, nil
+}
+
+// toTenderModels builds the tender models of a page of tenders
+func toTenderModels(tenders []*entities.Tender) ([]*tender_models.TenderModel, error) {
+	models := make([]*tender_models.TenderModel, 0, len(tenders))
+	for _, tender := range tenders {
+		model, err := toTenderModel(tender)
+		if err != nil {
+			return nil, err
+		}
+		models = append(models, model)
+	}
+	return models, nil
+}
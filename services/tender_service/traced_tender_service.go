@@ -0,0 +1,98 @@
+package tender_service
+
+import (
+	"avitoTest/services/tender_service/tender_models"
+	"avitoTest/shared/filter"
+	"avitoTest/shared/pagination"
+	"avitoTest/shared/tracing"
+	"context"
+)
+
+// tracedTenderService starts a span for every call to the wrapped service.
+type tracedTenderService struct {
+	TenderService
+}
+
+// NewTracedTenderService wraps service so each of its methods is traced as a span named
+// after the method, with the queries it runs as children.
+func NewTracedTenderService(service TenderService) TenderService {
+	return &tracedTenderService{TenderService: service}
+}
+
+func (s *tracedTenderService) GetAllTenders(ctx context.Context, f filter.Filter, params pagination.Params) (page *pagination.Page[*tender_models.TenderModel], err error) {
+	ctx, span := tracing.Start(ctx, "TenderService.GetAllTenders")
+	defer func() { tracing.End(span, err) }()
+	return s.TenderService.GetAllTenders(ctx, f, params)
+}
+
+func (s *tracedTenderService) GetTendersByUsername(ctx context.Context, username string, params pagination.Params) (page *pagination.Page[*tender_models.TenderModel], err error) {
+	ctx, span := tracing.Start(ctx, "TenderService.GetTendersByUsername")
+	defer func() { tracing.End(span, err) }()
+	return s.TenderService.GetTendersByUsername(ctx, username, params)
+}
+
+func (s *tracedTenderService) GetTenderByID(ctx context.Context, id int) (tender *tender_models.TenderModel, err error) {
+	ctx, span := tracing.Start(ctx, "TenderService.GetTenderByID")
+	defer func() { tracing.End(span, err) }()
+	return s.TenderService.GetTenderByID(ctx, id)
+}
+
+func (s *tracedTenderService) CreateTender(ctx context.Context, tender tender_models.TenderCreateModel) (created *tender_models.TenderModel, err error) {
+	ctx, span := tracing.Start(ctx, "TenderService.CreateTender")
+	defer func() { tracing.End(span, err) }()
+	return s.TenderService.CreateTender(ctx, tender)
+}
+
+func (s *tracedTenderService) UpdateTender(ctx context.Context, tender tender_models.TenderUpdateModel) (updated *tender_models.TenderModel, err error) {
+	ctx, span := tracing.Start(ctx, "TenderService.UpdateTender")
+	defer func() { tracing.End(span, err) }()
+	return s.TenderService.UpdateTender(ctx, tender)
+}
+
+func (s *tracedTenderService) PublishTender(ctx context.Context, tenderID int) (err error) {
+	ctx, span := tracing.Start(ctx, "TenderService.PublishTender")
+	defer func() { tracing.End(span, err) }()
+	return s.TenderService.PublishTender(ctx, tenderID)
+}
+
+func (s *tracedTenderService) CloseTender(ctx context.Context, tenderID int) (err error) {
+	ctx, span := tracing.Start(ctx, "TenderService.CloseTender")
+	defer func() { tracing.End(span, err) }()
+	return s.TenderService.CloseTender(ctx, tenderID)
+}
+
+func (s *tracedTenderService) RollbackTenderVersion(ctx context.Context, tenderID int, version int) (tender *tender_models.TenderModel, err error) {
+	ctx, span := tracing.Start(ctx, "TenderService.RollbackTenderVersion")
+	defer func() { tracing.End(span, err) }()
+	return s.TenderService.RollbackTenderVersion(ctx, tenderID, version)
+}
+
+func (s *tracedTenderService) DeleteTender(ctx context.Context, tenderID int) (err error) {
+	ctx, span := tracing.Start(ctx, "TenderService.DeleteTender")
+	defer func() { tracing.End(span, err) }()
+	return s.TenderService.DeleteTender(ctx, tenderID)
+}
+
+func (s *tracedTenderService) SetEligibilityRules(ctx context.Context, tenderID int, rules []tender_models.EligibilityRuleModel) (set []*tender_models.EligibilityRuleModel, err error) {
+	ctx, span := tracing.Start(ctx, "TenderService.SetEligibilityRules")
+	defer func() { tracing.End(span, err) }()
+	return s.TenderService.SetEligibilityRules(ctx, tenderID, rules)
+}
+
+func (s *tracedTenderService) GetEligibilityRules(ctx context.Context, tenderID int) (rules []*tender_models.EligibilityRuleModel, err error) {
+	ctx, span := tracing.Start(ctx, "TenderService.GetEligibilityRules")
+	defer func() { tracing.End(span, err) }()
+	return s.TenderService.GetEligibilityRules(ctx, tenderID)
+}
+
+func (s *tracedTenderService) ExportTenders(ctx context.Context, orgID int, f filter.Filter, write func(*tender_models.TenderModel) error) (err error) {
+	ctx, span := tracing.Start(ctx, "TenderService.ExportTenders")
+	defer func() { tracing.End(span, err) }()
+	return s.TenderService.ExportTenders(ctx, orgID, f, write)
+}
+
+func (s *tracedTenderService) ImportTenders(ctx context.Context, rows []tender_models.TenderImportRow, options tender_models.TenderImportOptions) (report *tender_models.TenderImportReport, err error) {
+	ctx, span := tracing.Start(ctx, "TenderService.ImportTenders")
+	defer func() { tracing.End(span, err) }()
+	return s.TenderService.ImportTenders(ctx, rows, options)
+}
@@ -0,0 +1,81 @@
+package user_service
+
+import (
+	"avitoTest/data/repositories/transaction"
+	"avitoTest/services/audit_service"
+	"avitoTest/services/audit_service/audit_models"
+	user_models "avitoTest/services/user_service/user_models"
+	"avitoTest/shared/constants"
+	"context"
+	"fmt"
+)
+
+// auditedUserService records an audit event for every change made through the wrapped
+// service.
+type auditedUserService struct {
+	UserService
+	audit        audit_service.AuditService
+	transactions transaction.Manager
+}
+
+// NewAuditedUserService wraps service so its successful changes are recorded in the audit
+// log, with the user before and after each change. Each change is recorded in its own
+// transaction, so failing to record it fails the change.
+func NewAuditedUserService(service UserService, audit audit_service.AuditService, transactions transaction.Manager) UserService {
+	return &auditedUserService{UserService: service, audit: audit, transactions: transactions}
+}
+
+func (s *auditedUserService) CreateUser(ctx context.Context, user user_models.UserCreateModel) (*user_models.UserModel, error) {
+	var created *user_models.UserModel
+	err := s.transactions.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		if created, err = s.UserService.CreateUser(ctx, user); err != nil {
+			return err
+		}
+		return s.record(ctx, constants.AuditActionCreated, created.ID, nil, created)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return created, nil
+}
+
+func (s *auditedUserService) UpdateUser(ctx context.Context, user user_models.UserUpdateModel) (*user_models.UserModel, error) {
+	var updated *user_models.UserModel
+	err := s.transactions.WithinTransaction(ctx, func(ctx context.Context) error {
+		before, _ := s.UserService.GetUserByID(ctx, user.ID)
+		var err error
+		if updated, err = s.UserService.UpdateUser(ctx, user); err != nil {
+			return err
+		}
+		return s.record(ctx, constants.AuditActionUpdated, user.ID, before, updated)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+func (s *auditedUserService) DeleteUser(ctx context.Context, id int) error {
+	return s.transactions.WithinTransaction(ctx, func(ctx context.Context) error {
+		before, _ := s.UserService.GetUserByID(ctx, id)
+		if err := s.UserService.DeleteUser(ctx, id); err != nil {
+			return err
+		}
+		return s.record(ctx, constants.AuditActionDeleted, id, before, nil)
+	})
+}
+
+func (s *auditedUserService) record(ctx context.Context, action constants.AuditAction, userID int, before, after *user_models.UserModel) error {
+	record := audit_models.AuditRecordModel{
+		EntityType: constants.AuditEntityUser,
+		EntityID:   userID,
+		Action:     action,
+		Before:     before,
+		After:      after,
+	}
+	if err := s.audit.Record(ctx, record); err != nil {
+		return fmt.Errorf("audit: recording %s of %s %d: %w", record.Action, record.EntityType, record.EntityID, err)
+	}
+	return nil
+}
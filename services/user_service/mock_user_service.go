@@ -2,6 +2,7 @@ package user_service
 
 import (
 	user_models "avitoTest/services/user_service/user_models"
+	"avitoTest/shared/pagination"
 	"context"
 
 	"github.com/stretchr/testify/mock"
@@ -22,9 +23,9 @@ func (m *MockUserService) UpdateUser(ctx context.Context, user user_models.UserU
 	return args.Get(0).(*user_models.UserModel), args.Error(1)
 }
 
-func (m *MockUserService) GetUsers(ctx context.Context) ([]*user_models.UserModel, error) {
-	args := m.Called(ctx)
-	return args.Get(0).([]*user_models.UserModel), args.Error(1)
+func (m *MockUserService) GetUsers(ctx context.Context, params pagination.Params) (*pagination.Page[*user_models.UserModel], error) {
+	args := m.Called(ctx, params)
+	return args.Get(0).(*pagination.Page[*user_models.UserModel]), args.Error(1)
 }
 
 func (m *MockUserService) GetUserByID(ctx context.Context, id int) (*user_models.UserModel, error) {
@@ -2,30 +2,29 @@ package user_service
 
 import (
 	"context"
-	"errors"
 	"time"
 
 	"avitoTest/data/entities"
 	"avitoTest/data/repositories/user_repository"
 	user_models "avitoTest/services/user_service/user_models"
-
-	"github.com/go-playground/validator/v10"
+	"avitoTest/shared/pagination"
+	"avitoTest/shared/validation"
 )
 
 type userService struct {
 	repo     user_repository.UserRepository
-	validate *validator.Validate
+	validate *validation.Validator
 }
 
 func NewUserService(repo user_repository.UserRepository) UserService {
 	return &userService{
 		repo:     repo,
-		validate: validator.New(),
+		validate: validation.New(nil),
 	}
 }
 
 func (s *userService) CreateUser(ctx context.Context, user user_models.UserCreateModel) (*user_models.UserModel, error) {
-	if err := s.validate.Struct(user); err != nil {
+	if err := s.validate.Struct(ctx, user); err != nil {
 		return nil, err
 	}
 
@@ -52,7 +51,7 @@ func (s *userService) CreateUser(ctx context.Context, user user_models.UserCreat
 }
 
 func (s *userService) UpdateUser(ctx context.Context, user user_models.UserUpdateModel) (*user_models.UserModel, error) {
-	if err := s.validate.Struct(user); err != nil {
+	if err := s.validate.Struct(ctx, user); err != nil {
 		return nil, err
 	}
 
@@ -80,8 +79,8 @@ func (s *userService) UpdateUser(ctx context.Context, user user_models.UserUpdat
 	}, nil
 }
 
-func (s *userService) GetUsers(ctx context.Context) ([]*user_models.UserModel, error) {
-	entities, err := s.repo.GetAll(ctx)
+func (s *userService) GetUsers(ctx context.Context, params pagination.Params) (*pagination.Page[*user_models.UserModel], error) {
+	entities, total, err := s.repo.GetAll(ctx, params)
 	if err != nil {
 		return nil, err
 	}
@@ -99,7 +98,7 @@ func (s *userService) GetUsers(ctx context.Context) ([]*user_models.UserModel, e
 		users = append(users, userModel)
 	}
 
-	return users, nil
+	return pagination.NewPage(users, total, params), nil
 }
 
 func (s *userService) GetUserByID(ctx context.Context, id int) (*user_models.UserModel, error) {
@@ -137,9 +136,6 @@ func (s *userService) GetUserByUsername(ctx context.Context, username string) (*
 func (s *userService) DeleteUser(ctx context.Context, id int) error {
 	entity, err := s.repo.FindByID(ctx, id)
 	if err != nil {
-		if errors.Is(err, user_repository.ErrUserNotFound) {
-			return errors.New("user not found")
-		}
 		return err
 	}
 
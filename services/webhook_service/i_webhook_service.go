@@ -0,0 +1,23 @@
+package webhook_service
+
+import (
+	"avitoTest/services/webhook_service/webhook_models"
+	"avitoTest/shared/filter"
+	"avitoTest/shared/pagination"
+	"context"
+)
+
+type WebhookService interface {
+	CreateWebhook(ctx context.Context, model webhook_models.WebhookCreateModel) (*webhook_models.WebhookModel, error)
+	UpdateWebhook(ctx context.Context, model webhook_models.WebhookUpdateModel) (*webhook_models.WebhookModel, error)
+	DeleteWebhook(ctx context.Context, orgID, webhookID int) error
+	GetWebhooks(ctx context.Context, orgID int) ([]*webhook_models.WebhookModel, error)
+	GetWebhookByID(ctx context.Context, orgID, webhookID int) (*webhook_models.WebhookModel, error)
+	GetDeliveries(ctx context.Context, orgID, webhookID int, f filter.Filter, params pagination.Params) (*pagination.Page[*webhook_models.WebhookDeliveryModel], error)
+	Redeliver(ctx context.Context, orgID, webhookID, deliveryID int) (*webhook_models.WebhookDeliveryModel, error)
+
+	// Enqueue queues the deliveries of an event to the webhooks subscribed to it.
+	Enqueue(ctx context.Context, event webhook_models.WebhookEventModel) error
+	// DeliverDue sends the deliveries that are due and returns how many were attempted.
+	DeliverDue(ctx context.Context) (int, error)
+}
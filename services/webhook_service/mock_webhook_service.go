@@ -0,0 +1,77 @@
+package webhook_service
+
+import (
+	"avitoTest/services/webhook_service/webhook_models"
+	"avitoTest/shared/filter"
+	"avitoTest/shared/pagination"
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockWebhookService struct {
+	mock.Mock
+}
+
+func (m *MockWebhookService) CreateWebhook(ctx context.Context, model webhook_models.WebhookCreateModel) (*webhook_models.WebhookModel, error) {
+	args := m.Called(ctx, model)
+	if webhook, ok := args.Get(0).(*webhook_models.WebhookModel); ok {
+		return webhook, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockWebhookService) UpdateWebhook(ctx context.Context, model webhook_models.WebhookUpdateModel) (*webhook_models.WebhookModel, error) {
+	args := m.Called(ctx, model)
+	if webhook, ok := args.Get(0).(*webhook_models.WebhookModel); ok {
+		return webhook, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockWebhookService) DeleteWebhook(ctx context.Context, orgID, webhookID int) error {
+	args := m.Called(ctx, orgID, webhookID)
+	return args.Error(0)
+}
+
+func (m *MockWebhookService) GetWebhooks(ctx context.Context, orgID int) ([]*webhook_models.WebhookModel, error) {
+	args := m.Called(ctx, orgID)
+	if webhooks, ok := args.Get(0).([]*webhook_models.WebhookModel); ok {
+		return webhooks, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockWebhookService) GetWebhookByID(ctx context.Context, orgID, webhookID int) (*webhook_models.WebhookModel, error) {
+	args := m.Called(ctx, orgID, webhookID)
+	if webhook, ok := args.Get(0).(*webhook_models.WebhookModel); ok {
+		return webhook, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockWebhookService) GetDeliveries(ctx context.Context, orgID, webhookID int, f filter.Filter, params pagination.Params) (*pagination.Page[*webhook_models.WebhookDeliveryModel], error) {
+	args := m.Called(ctx, orgID, webhookID, f, params)
+	if page, ok := args.Get(0).(*pagination.Page[*webhook_models.WebhookDeliveryModel]); ok {
+		return page, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockWebhookService) Redeliver(ctx context.Context, orgID, webhookID, deliveryID int) (*webhook_models.WebhookDeliveryModel, error) {
+	args := m.Called(ctx, orgID, webhookID, deliveryID)
+	if delivery, ok := args.Get(0).(*webhook_models.WebhookDeliveryModel); ok {
+		return delivery, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockWebhookService) Enqueue(ctx context.Context, event webhook_models.WebhookEventModel) error {
+	args := m.Called(ctx, event)
+	return args.Error(0)
+}
+
+func (m *MockWebhookService) DeliverDue(ctx context.Context) (int, error) {
+	args := m.Called(ctx)
+	return args.Int(0), args.Error(1)
+}
@@ -0,0 +1,153 @@
+package webhook_service
+
+import (
+	"avitoTest/data/entities"
+	"avitoTest/shared"
+	"avitoTest/shared/constants"
+	"avitoTest/shared/metrics"
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	// deliveryBatchSize is the number of deliveries claimed by one DeliverDue call.
+	deliveryBatchSize = 20
+	// maxResponseBody is the number of bytes of a response kept in the delivery history.
+	maxResponseBody = 1024
+)
+
+// Headers of a delivery request.
+const (
+	HeaderEvent     = "X-Webhook-Event"
+	HeaderDelivery  = "X-Webhook-Delivery"
+	HeaderSignature = "X-Webhook-Signature"
+)
+
+// DeliverDue sends the pending deliveries that are due. A delivery succeeds when the
+// endpoint answers 2xx; otherwise it is retried with exponential backoff until the
+// retry policy gives up on it.
+func (s *webhookService) DeliverDue(ctx context.Context) (int, error) {
+	now := time.Now()
+	lease := deliveryBatchSize*s.client.Timeout + time.Minute
+	deliveries, err := s.webhookRepo.ClaimDue(ctx, now, now.Add(lease), deliveryBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, delivery := range deliveries {
+		if ctx.Err() != nil {
+			break
+		}
+		s.attempt(ctx, delivery)
+		if err := s.webhookRepo.UpdateDelivery(ctx, delivery); err != nil {
+			shared.Logger.Errorf("Failed to save webhook delivery %d: %v", delivery.ID, err)
+		}
+	}
+	return len(deliveries), nil
+}
+
+// attempt sends a delivery once and records the outcome on it.
+func (s *webhookService) attempt(ctx context.Context, delivery *entities.WebhookDelivery) {
+	now := time.Now()
+	delivery.Attempts++
+	delivery.LastAttemptAt = &now
+	delivery.ResponseStatus = 0
+	delivery.ResponseBody = ""
+	delivery.Error = ""
+
+	status, body, err := s.send(ctx, delivery, now)
+	delivery.ResponseStatus = status
+	delivery.ResponseBody = body
+	if err == nil && status >= 200 && status < 300 {
+		delivery.Status = string(constants.WebhookDeliverySucceeded)
+		delivery.DeliveredAt = &now
+		metrics.WebhookDeliveries.WithLabelValues(metrics.WebhookSucceeded).Inc()
+		return
+	}
+
+	if err != nil {
+		delivery.Error = err.Error()
+	} else {
+		delivery.Error = fmt.Sprintf("endpoint responded with status %d", status)
+	}
+
+	if delivery.Attempts >= s.retry.MaxAttempts {
+		delivery.Status = string(constants.WebhookDeliveryFailed)
+		metrics.WebhookDeliveries.WithLabelValues(metrics.WebhookFailed).Inc()
+		shared.Logger.Warnf("Giving up on webhook delivery %d after %d attempts: %s", delivery.ID, delivery.Attempts, delivery.Error)
+		return
+	}
+	delivery.NextAttemptAt = now.Add(s.backoff(delivery.Attempts))
+	metrics.WebhookDeliveries.WithLabelValues(metrics.WebhookRetried).Inc()
+}
+
+// send posts the signed payload of a delivery to its webhook and returns the status and
+// the beginning of the body of the response.
+func (s *webhookService) send(ctx context.Context, delivery *entities.WebhookDelivery, now time.Time) (int, string, error) {
+	body := []byte(delivery.Payload)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, delivery.Webhook.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "avitoTest-Webhooks/1.0")
+	req.Header.Set(HeaderEvent, delivery.EventType)
+	req.Header.Set(HeaderDelivery, strconv.Itoa(delivery.ID))
+	req.Header.Set(HeaderSignature, Sign(delivery.Webhook.Secret, now, body))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, "", err
+	}
+	defer resp.Body.Close()
+
+	excerpt, _ := io.ReadAll(io.LimitReader(resp.Body, maxResponseBody))
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64*maxResponseBody))
+	return resp.StatusCode, textExcerpt(excerpt), nil
+}
+
+// textExcerpt returns the start of a response body as text a text column can hold: cut
+// on a rune boundary, without NUL bytes and with invalid UTF-8 replaced.
+func textExcerpt(body []byte) string {
+	// Drop the rune the read limit cut in half
+	for i := len(body) - 1; i >= 0 && i >= len(body)-utf8.UTFMax; i-- {
+		if utf8.RuneStart(body[i]) {
+			if !utf8.FullRune(body[i:]) {
+				body = body[:i]
+			}
+			break
+		}
+	}
+	return strings.ToValidUTF8(strings.ReplaceAll(string(body), "\x00", ""), "\uFFFD")
+}
+
+// backoff returns the delay before the attempt following the given number of failed ones.
+func (s *webhookService) backoff(attempts int) time.Duration {
+	delay := s.retry.Backoff
+	for i := 1; i < attempts && delay < s.retry.MaxBackoff; i++ {
+		delay *= 2
+	}
+	return min(delay, s.retry.MaxBackoff)
+}
+
+// Sign returns the X-Webhook-Signature header of a payload: the time it was signed at
+// and the hex HMAC-SHA256, keyed by the webhook secret, of "<unix time>.<body>".
+// Receivers recompute it to verify the payload and may reject stale timestamps.
+func Sign(secret string, timestamp time.Time, body []byte) string {
+	unix := strconv.FormatInt(timestamp.Unix(), 10)
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(unix))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "t=" + unix + ",v1=" + hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,82 @@
+package webhook_service
+
+import (
+	"avitoTest/services/bid_service"
+	"avitoTest/services/tender_service"
+	"avitoTest/services/webhook_service/webhook_models"
+	"avitoTest/shared"
+	"avitoTest/shared/constants"
+	"avitoTest/shared/events"
+	"context"
+)
+
+var tenderWebhookEvents = map[string]constants.WebhookEventType{
+	events.ActionPublished: constants.WebhookEventTenderPublished,
+	events.ActionClosed:    constants.WebhookEventTenderClosed,
+}
+
+var bidWebhookEvents = map[string]constants.WebhookEventType{
+	events.ActionCreated:  constants.WebhookEventBidCreated,
+	events.ActionApproved: constants.WebhookEventBidApproved,
+	events.ActionRejected: constants.WebhookEventBidRejected,
+}
+
+// SubscribeToEvents queues webhook deliveries for the tender and bid events webhooks
+// can subscribe to and returns a function unsubscribing from them. Tender events go to
+// the webhooks of the tender's organization, bid events to those of the tender's and
+// the bid's organizations.
+func SubscribeToEvents(service WebhookService, tenders tender_service.TenderService, bids bid_service.BidService) func() {
+	unsubscribeTenders := events.Subscribe(events.TopicTender, func(ctx context.Context, event events.Event) {
+		tenderEvent := event.(events.TenderEvent)
+		eventType, ok := tenderWebhookEvents[tenderEvent.Action]
+		if !ok {
+			return
+		}
+
+		tender, err := tenders.GetTenderByID(ctx, tenderEvent.TenderID)
+		if err != nil {
+			shared.Logger.Warnf("Failed to load tender %d for webhooks: %v", tenderEvent.TenderID, err)
+			return
+		}
+		enqueue(ctx, service, webhook_models.WebhookEventModel{
+			Type:            eventType,
+			OrganizationIDs: []int{tender.OrganizationID},
+			Data:            map[string]any{"tender": tender},
+		})
+	})
+
+	unsubscribeBids := events.Subscribe(events.TopicBid, func(ctx context.Context, event events.Event) {
+		bidEvent := event.(events.BidEvent)
+		eventType, ok := bidWebhookEvents[bidEvent.Action]
+		if !ok {
+			return
+		}
+
+		bid, err := bids.GetBidByID(ctx, bidEvent.BidID)
+		if err != nil {
+			shared.Logger.Warnf("Failed to load bid %d for webhooks: %v", bidEvent.BidID, err)
+			return
+		}
+		tender, err := tenders.GetTenderByID(ctx, bidEvent.TenderID)
+		if err != nil {
+			shared.Logger.Warnf("Failed to load tender %d for webhooks: %v", bidEvent.TenderID, err)
+			return
+		}
+		enqueue(ctx, service, webhook_models.WebhookEventModel{
+			Type:            eventType,
+			OrganizationIDs: []int{tender.OrganizationID, bid.OrganizationID},
+			Data:            map[string]any{"bid": bid, "tender": tender},
+		})
+	})
+
+	return func() {
+		unsubscribeTenders()
+		unsubscribeBids()
+	}
+}
+
+func enqueue(ctx context.Context, service WebhookService, event webhook_models.WebhookEventModel) {
+	if err := service.Enqueue(ctx, event); err != nil {
+		shared.Logger.Warnf("Failed to queue %s webhooks: %v", event.Type, err)
+	}
+}
@@ -0,0 +1,10 @@
+package webhook_models
+
+// WebhookCreateModel represents the data needed to register a webhook. A secret is
+// generated when none is given.
+type WebhookCreateModel struct {
+	OrganizationID int      `json:"organization_id" validate:"required"`
+	URL            string   `json:"url" validate:"required,http_url,max=2048"`
+	EventTypes     []string `json:"event_types" validate:"required,min=1,dive,webhook_event"`
+	Secret         string   `json:"secret" validate:"omitempty,min=16,max=100"`
+}
@@ -0,0 +1,31 @@
+package webhook_models
+
+import (
+	"avitoTest/shared/constants"
+	"avitoTest/shared/filter"
+)
+
+// DeliveryFilterSchema lists the fields webhook deliveries can be filtered by.
+var DeliveryFilterSchema = filter.Schema{
+	constants.FilterFieldStatus: {
+		Type:      filter.String,
+		Operators: filter.Equality,
+		Values: []string{
+			string(constants.WebhookDeliveryPending),
+			string(constants.WebhookDeliverySucceeded),
+			string(constants.WebhookDeliveryFailed),
+		},
+	},
+	constants.FilterFieldEventType: {
+		Type:      filter.String,
+		Operators: filter.Equality,
+		Values: []string{
+			string(constants.WebhookEventTenderPublished),
+			string(constants.WebhookEventTenderClosed),
+			string(constants.WebhookEventBidCreated),
+			string(constants.WebhookEventBidApproved),
+			string(constants.WebhookEventBidRejected),
+		},
+	},
+	constants.FilterFieldCreatedAt: {Type: filter.Time, Operators: filter.Comparison},
+}
@@ -0,0 +1,26 @@
+package webhook_models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// WebhookDeliveryModel is a delivery of an event to a webhook and the outcome of its
+// latest attempt. NextAttemptAt is set while the delivery is pending.
+type WebhookDeliveryModel struct {
+	ID             int             `json:"id"`
+	WebhookID      int             `json:"webhook_id"`
+	EventID        string          `json:"event_id"`
+	EventType      string          `json:"event_type"`
+	Payload        json.RawMessage `json:"payload"`
+	Status         string          `json:"status"`
+	Attempts       int             `json:"attempts"`
+	NextAttemptAt  *time.Time      `json:"next_attempt_at,omitempty"`
+	LastAttemptAt  *time.Time      `json:"last_attempt_at,omitempty"`
+	ResponseStatus int             `json:"response_status,omitempty"`
+	ResponseBody   string          `json:"response_body,omitempty"`
+	Error          string          `json:"error,omitempty"`
+	RedeliveryOf   *int            `json:"redelivery_of,omitempty"`
+	DeliveredAt    *time.Time      `json:"delivered_at,omitempty"`
+	CreatedAt      time.Time       `json:"created_at"`
+}
@@ -0,0 +1,23 @@
+package webhook_models
+
+import (
+	"avitoTest/shared/constants"
+	"time"
+)
+
+// WebhookEventModel is an event to deliver to the webhooks of the organizations involved
+// that subscribed to its type.
+type WebhookEventModel struct {
+	Type            constants.WebhookEventType
+	OrganizationIDs []int
+	Data            any
+}
+
+// WebhookPayload is the body of the requests delivering an event. ID is shared by every
+// delivery of the event, including redeliveries.
+type WebhookPayload struct {
+	ID        string    `json:"id"`
+	Type      string    `json:"type"`
+	CreatedAt time.Time `json:"created_at"`
+	Data      any       `json:"data"`
+}
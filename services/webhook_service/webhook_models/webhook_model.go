@@ -0,0 +1,15 @@
+package webhook_models
+
+import "time"
+
+// WebhookModel is a registered webhook. Secret is only returned when it is set.
+type WebhookModel struct {
+	ID             int       `json:"id"`
+	OrganizationID int       `json:"organization_id"`
+	URL            string    `json:"url"`
+	EventTypes     []string  `json:"event_types"`
+	Active         bool      `json:"active"`
+	Secret         string    `json:"secret,omitempty"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
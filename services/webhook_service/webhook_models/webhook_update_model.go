@@ -0,0 +1,12 @@
+package webhook_models
+
+// WebhookUpdateModel represents the changes to a webhook; only the fields that are set
+// are replaced.
+type WebhookUpdateModel struct {
+	ID             int      `json:"id" validate:"required"`
+	OrganizationID int      `json:"organization_id" validate:"required"`
+	URL            *string  `json:"url" validate:"omitempty,http_url,max=2048"`
+	EventTypes     []string `json:"event_types" validate:"omitempty,min=1,dive,webhook_event"`
+	Secret         *string  `json:"secret" validate:"omitempty,min=16,max=100"`
+	Active         *bool    `json:"active"`
+}
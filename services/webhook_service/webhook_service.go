@@ -0,0 +1,330 @@
+package webhook_service
+
+import (
+	"avitoTest/data/entities"
+	"avitoTest/data/repositories/organization_repository"
+	"avitoTest/data/repositories/webhook_repository"
+	"avitoTest/services/webhook_service/webhook_models"
+	"avitoTest/shared/auth"
+	"avitoTest/shared/constants"
+	"avitoTest/shared/errors/domain_errors"
+	"avitoTest/shared/errors/webhook_errors"
+	"avitoTest/shared/filter"
+	"avitoTest/shared/pagination"
+	"avitoTest/shared/validation"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+)
+
+// RetryPolicy decides when failed deliveries are retried: Backoff after the first failed
+// attempt, doubling with every further one up to MaxBackoff, until MaxAttempts attempts
+// have failed.
+type RetryPolicy struct {
+	MaxAttempts int
+	Backoff     time.Duration
+	MaxBackoff  time.Duration
+}
+
+type webhookService struct {
+	webhookRepo webhook_repository.WebhookRepository
+	orgRepo     organization_repository.OrganizationRepository
+	client      *http.Client
+	retry       RetryPolicy
+	validate    *validation.Validator
+}
+
+// NewWebhookService creates a new instance of WebhookService delivering events with client.
+func NewWebhookService(
+	webhookRepo webhook_repository.WebhookRepository,
+	orgRepo organization_repository.OrganizationRepository,
+	client *http.Client,
+	retry RetryPolicy) WebhookService {
+	return &webhookService{
+		webhookRepo: webhookRepo,
+		orgRepo:     orgRepo,
+		client:      client,
+		retry:       retry,
+		validate:    validation.New(nil),
+	}
+}
+
+// CreateWebhook registers a webhook of an organization. The returned webhook holds its
+// secret, which is not returned afterwards.
+func (s *webhookService) CreateWebhook(ctx context.Context, model webhook_models.WebhookCreateModel) (*webhook_models.WebhookModel, error) {
+	if err := s.validate.Struct(ctx, model); err != nil {
+		return nil, err
+	}
+	if err := s.authorize(ctx, model.OrganizationID); err != nil {
+		return nil, err
+	}
+	if _, err := s.orgRepo.FindByID(ctx, model.OrganizationID); err != nil {
+		return nil, err
+	}
+
+	secret := model.Secret
+	if secret == "" {
+		secret = "whsec_" + randomHex(24)
+	}
+
+	webhook := &entities.Webhook{
+		OrganizationID: model.OrganizationID,
+		URL:            model.URL,
+		Secret:         secret,
+		EventTypes:     joinEventTypes(model.EventTypes),
+		Active:         true,
+	}
+	if err := s.webhookRepo.Create(ctx, webhook); err != nil {
+		return nil, err
+	}
+
+	created := toWebhookModel(webhook)
+	created.Secret = webhook.Secret
+	return created, nil
+}
+
+// UpdateWebhook replaces the URL, event types, secret or activity of a webhook. A new
+// secret is returned with the webhook.
+func (s *webhookService) UpdateWebhook(ctx context.Context, model webhook_models.WebhookUpdateModel) (*webhook_models.WebhookModel, error) {
+	if err := s.validate.Struct(ctx, model); err != nil {
+		return nil, err
+	}
+	webhook, err := s.findWebhook(ctx, model.OrganizationID, model.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if model.URL != nil {
+		webhook.URL = *model.URL
+	}
+	if model.EventTypes != nil {
+		webhook.EventTypes = joinEventTypes(model.EventTypes)
+	}
+	if model.Secret != nil {
+		webhook.Secret = *model.Secret
+	}
+	if model.Active != nil {
+		webhook.Active = *model.Active
+	}
+	webhook.UpdatedAt = time.Now()
+
+	if err := s.webhookRepo.Update(ctx, webhook); err != nil {
+		return nil, err
+	}
+
+	updated := toWebhookModel(webhook)
+	if model.Secret != nil {
+		updated.Secret = webhook.Secret
+	}
+	return updated, nil
+}
+
+// DeleteWebhook deletes a webhook with its delivery history.
+func (s *webhookService) DeleteWebhook(ctx context.Context, orgID, webhookID int) error {
+	webhook, err := s.findWebhook(ctx, orgID, webhookID)
+	if err != nil {
+		return err
+	}
+	return s.webhookRepo.Delete(ctx, webhook.ID)
+}
+
+// GetWebhooks returns the webhooks of an organization.
+func (s *webhookService) GetWebhooks(ctx context.Context, orgID int) ([]*webhook_models.WebhookModel, error) {
+	if err := s.authorize(ctx, orgID); err != nil {
+		return nil, err
+	}
+
+	webhooks, err := s.webhookRepo.FindByOrganizationID(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	models := make([]*webhook_models.WebhookModel, 0, len(webhooks))
+	for _, webhook := range webhooks {
+		models = append(models, toWebhookModel(webhook))
+	}
+	return models, nil
+}
+
+// GetWebhookByID returns a webhook of an organization.
+func (s *webhookService) GetWebhookByID(ctx context.Context, orgID, webhookID int) (*webhook_models.WebhookModel, error) {
+	webhook, err := s.findWebhook(ctx, orgID, webhookID)
+	if err != nil {
+		return nil, err
+	}
+	return toWebhookModel(webhook), nil
+}
+
+// GetDeliveries returns a page of the delivery history of a webhook.
+func (s *webhookService) GetDeliveries(ctx context.Context, orgID, webhookID int, f filter.Filter, params pagination.Params) (*pagination.Page[*webhook_models.WebhookDeliveryModel], error) {
+	if _, err := s.findWebhook(ctx, orgID, webhookID); err != nil {
+		return nil, err
+	}
+
+	deliveries, total, err := s.webhookRepo.FindDeliveries(ctx, webhookID, f, params)
+	if err != nil {
+		return nil, err
+	}
+
+	models := make([]*webhook_models.WebhookDeliveryModel, 0, len(deliveries))
+	for _, delivery := range deliveries {
+		models = append(models, toDeliveryModel(delivery))
+	}
+	return pagination.NewPage(models, total, params), nil
+}
+
+// Redeliver queues the event of a delivery to be sent to the webhook again, as a new
+// delivery that keeps the history of the original.
+func (s *webhookService) Redeliver(ctx context.Context, orgID, webhookID, deliveryID int) (*webhook_models.WebhookDeliveryModel, error) {
+	if _, err := s.findWebhook(ctx, orgID, webhookID); err != nil {
+		return nil, err
+	}
+
+	original, err := s.webhookRepo.FindDeliveryByID(ctx, deliveryID)
+	if err != nil {
+		return nil, err
+	}
+	if original.WebhookID != webhookID {
+		return nil, webhook_errors.ErrDeliveryNotFound
+	}
+
+	now := time.Now()
+	delivery := &entities.WebhookDelivery{
+		WebhookID:     webhookID,
+		EventID:       original.EventID,
+		EventType:     original.EventType,
+		Payload:       original.Payload,
+		Status:        string(constants.WebhookDeliveryPending),
+		NextAttemptAt: now,
+		RedeliveryOf:  &original.ID,
+		CreatedAt:     now,
+	}
+	if err := s.webhookRepo.CreateDeliveries(ctx, []*entities.WebhookDelivery{delivery}); err != nil {
+		return nil, err
+	}
+	return toDeliveryModel(delivery), nil
+}
+
+// Enqueue queues a delivery of the event to every active webhook of the organizations
+// involved that subscribed to it; they are sent by DeliverDue.
+func (s *webhookService) Enqueue(ctx context.Context, event webhook_models.WebhookEventModel) error {
+	orgIDs := slices.Compact(slices.Sorted(slices.Values(event.OrganizationIDs)))
+	webhooks, err := s.webhookRepo.FindSubscribed(ctx, orgIDs, string(event.Type))
+	if err != nil || len(webhooks) == 0 {
+		return err
+	}
+
+	now := time.Now()
+	payload := webhook_models.WebhookPayload{
+		ID:        randomHex(16),
+		Type:      string(event.Type),
+		CreatedAt: now.UTC(),
+		Data:      event.Data,
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	deliveries := make([]*entities.WebhookDelivery, 0, len(webhooks))
+	for _, webhook := range webhooks {
+		deliveries = append(deliveries, &entities.WebhookDelivery{
+			WebhookID:     webhook.ID,
+			EventID:       payload.ID,
+			EventType:     payload.Type,
+			Payload:       string(body),
+			Status:        string(constants.WebhookDeliveryPending),
+			NextAttemptAt: now,
+			CreatedAt:     now,
+		})
+	}
+	return s.webhookRepo.CreateDeliveries(ctx, deliveries)
+}
+
+// findWebhook returns a webhook of an organization the caller may manage.
+func (s *webhookService) findWebhook(ctx context.Context, orgID, webhookID int) (*entities.Webhook, error) {
+	if err := s.authorize(ctx, orgID); err != nil {
+		return nil, err
+	}
+
+	webhook, err := s.webhookRepo.FindByID(ctx, webhookID)
+	if err != nil {
+		return nil, err
+	}
+	if webhook.OrganizationID != orgID {
+		return nil, webhook_errors.ErrWebhookNotFound
+	}
+	return webhook, nil
+}
+
+// authorize lets API clients acting on their own behalf manage the webhooks of every
+// organization, and users those of the organizations they are responsible for.
+func (s *webhookService) authorize(ctx context.Context, orgID int) error {
+	principal := auth.FromContext(ctx)
+	if principal.UserID == 0 {
+		if principal.Client == "" {
+			return webhook_errors.ErrAuthenticationRequired
+		}
+		return nil
+	}
+
+	if _, err := s.orgRepo.GetResponsibleByID(ctx, orgID, principal.UserID); err != nil {
+		if errors.Is(err, domain_errors.ErrNotFound) {
+			return webhook_errors.ErrNotOrganizationAdmin
+		}
+		return err
+	}
+	return nil
+}
+
+func joinEventTypes(eventTypes []string) string {
+	return strings.Join(slices.Compact(slices.Sorted(slices.Values(eventTypes))), ",")
+}
+
+// randomHex returns n random bytes in hex.
+func randomHex(n int) string {
+	b := make([]byte, n)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+func toWebhookModel(webhook *entities.Webhook) *webhook_models.WebhookModel {
+	return &webhook_models.WebhookModel{
+		ID:             webhook.ID,
+		OrganizationID: webhook.OrganizationID,
+		URL:            webhook.URL,
+		EventTypes:     strings.Split(webhook.EventTypes, ","),
+		Active:         webhook.Active,
+		CreatedAt:      webhook.CreatedAt,
+		UpdatedAt:      webhook.UpdatedAt,
+	}
+}
+
+func toDeliveryModel(delivery *entities.WebhookDelivery) *webhook_models.WebhookDeliveryModel {
+	model := &webhook_models.WebhookDeliveryModel{
+		ID:             delivery.ID,
+		WebhookID:      delivery.WebhookID,
+		EventID:        delivery.EventID,
+		EventType:      delivery.EventType,
+		Payload:        json.RawMessage(delivery.Payload),
+		Status:         delivery.Status,
+		Attempts:       delivery.Attempts,
+		LastAttemptAt:  delivery.LastAttemptAt,
+		ResponseStatus: delivery.ResponseStatus,
+		ResponseBody:   delivery.ResponseBody,
+		Error:          delivery.Error,
+		RedeliveryOf:   delivery.RedeliveryOf,
+		DeliveredAt:    delivery.DeliveredAt,
+		CreatedAt:      delivery.CreatedAt,
+	}
+	if delivery.Status == string(constants.WebhookDeliveryPending) {
+		model.NextAttemptAt = &delivery.NextAttemptAt
+	}
+	return model
+}
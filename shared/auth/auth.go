@@ -0,0 +1,82 @@
+package auth
+
+import (
+	"avitoTest/shared/errors/auth_errors"
+	"context"
+	"crypto/subtle"
+	"net/http"
+	"strconv"
+)
+
+const (
+	// APIKeyHeader carries the API key a client authenticates with.
+	APIKeyHeader = "X-API-Key"
+	// UserHeader names the user an authenticated client acts on behalf of.
+	UserHeader = "X-User-ID"
+)
+
+// Principal identifies the caller of a request. Clients authenticate with an API key and
+// may act on behalf of one of their users; requests without an API key are anonymous.
+type Principal struct {
+	Client string // name of the client the API key belongs to
+	UserID int    // user the client acts on behalf of, 0 if none
+}
+
+// Anonymous reports whether the request did not authenticate.
+func (p Principal) Anonymous() bool {
+	return p.Client == ""
+}
+
+// Authenticator resolves the principal of requests from the configured API keys.
+type Authenticator struct {
+	keys map[string]string // client name to API key
+}
+
+// NewAuthenticator creates an authenticator accepting apiKeys, which maps the names of
+// the clients to their keys.
+func NewAuthenticator(apiKeys map[string]string) *Authenticator {
+	return &Authenticator{keys: apiKeys}
+}
+
+// Authenticate returns the principal of r. The user is only taken from the X-User-ID
+// header of requests with a valid API key, since only trusted clients may name one.
+func (a *Authenticator) Authenticate(r *http.Request) (Principal, error) {
+	key := r.Header.Get(APIKeyHeader)
+	if key == "" {
+		return Principal{}, nil
+	}
+
+	var principal Principal
+	// Every key is compared in constant time, so the time taken does not reveal a match
+	for client, clientKey := range a.keys {
+		if subtle.ConstantTimeCompare([]byte(key), []byte(clientKey)) == 1 {
+			principal.Client = client
+		}
+	}
+	if principal.Anonymous() {
+		return Principal{}, auth_errors.ErrInvalidAPIKey
+	}
+
+	if user := r.Header.Get(UserHeader); user != "" {
+		id, err := strconv.Atoi(user)
+		if err != nil || id <= 0 {
+			return Principal{}, auth_errors.ErrInvalidUserID
+		}
+		principal.UserID = id
+	}
+	return principal, nil
+}
+
+type contextKey struct{}
+
+// NewContext returns a copy of ctx carrying the principal.
+func NewContext(ctx context.Context, principal Principal) context.Context {
+	return context.WithValue(ctx, contextKey{}, principal)
+}
+
+// FromContext returns the principal of the request ctx belongs to; it is anonymous
+// outside of authenticated requests.
+func FromContext(ctx context.Context) Principal {
+	principal, _ := ctx.Value(contextKey{}).(Principal)
+	return principal
+}
@@ -0,0 +1,62 @@
+package cache
+
+import (
+	"avitoTest/shared"
+	"context"
+	"encoding/json"
+	"time"
+)
+
+// Cache is a byte-oriented key-value store with per-entry expiration. The
+// in-process LRU implements it; the interface deliberately maps onto Redis
+// commands (GET, SET EX, DEL, SCAN+DEL) so a shared backend can be plugged in.
+type Cache interface {
+	Get(ctx context.Context, key string) ([]byte, bool, error)
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+	Delete(ctx context.Context, keys ...string) error
+	DeletePrefix(ctx context.Context, prefix string) error
+}
+
+// GetOrLoad returns the value cached under key or loads it, caches it for ttl and
+// returns it. Values are stored as JSON. Cache failures are logged and the value is
+// loaded as if it was not cached, so an unavailable backend never fails a read.
+func GetOrLoad[T any](ctx context.Context, c Cache, key string, ttl time.Duration, load func() (T, error)) (T, error) {
+	if data, ok, err := c.Get(ctx, key); err != nil {
+		shared.Logger.Warnf("cache: failed to read %q: %v", key, err)
+	} else if ok {
+		var value T
+		if err := json.Unmarshal(data, &value); err == nil {
+			return value, nil
+		}
+		shared.Logger.Warnf("cache: failed to decode %q: %v", key, err)
+	}
+
+	value, err := load()
+	if err != nil {
+		return value, err
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		shared.Logger.Warnf("cache: failed to encode %q: %v", key, err)
+		return value, nil
+	}
+	if err := c.Set(ctx, key, data, ttl); err != nil {
+		shared.Logger.Warnf("cache: failed to write %q: %v", key, err)
+	}
+	return value, nil
+}
+
+// Invalidate deletes keys and key prefixes, logging instead of failing.
+func Invalidate(ctx context.Context, c Cache, keys []string, prefixes ...string) {
+	if len(keys) > 0 {
+		if err := c.Delete(ctx, keys...); err != nil {
+			shared.Logger.Warnf("cache: failed to delete %v: %v", keys, err)
+		}
+	}
+	for _, prefix := range prefixes {
+		if err := c.DeletePrefix(ctx, prefix); err != nil {
+			shared.Logger.Warnf("cache: failed to delete prefix %q: %v", prefix, err)
+		}
+	}
+}
@@ -0,0 +1,92 @@
+package cache
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Stats are the hit and miss counters of a cache namespace.
+type Stats struct {
+	Name   string `json:"name"`
+	Hits   int64  `json:"hits"`
+	Misses int64  `json:"misses"`
+}
+
+// Namespace is a view of a backend whose keys are prefixed with its name. It
+// counts the hits and misses of its reads, so several services can share one
+// backend and still be observed separately.
+type Namespace struct {
+	name    string
+	backend Cache
+	hits    atomic.Int64
+	misses  atomic.Int64
+}
+
+var (
+	registryMu sync.Mutex
+	registry   = map[string]*Namespace{}
+)
+
+// NewNamespace creates a namespace over backend and registers it for AllStats.
+func NewNamespace(backend Cache, name string) *Namespace {
+	namespace := &Namespace{name: name, backend: backend}
+
+	registryMu.Lock()
+	registry[name] = namespace
+	registryMu.Unlock()
+
+	return namespace
+}
+
+// AllStats returns the counters of every registered namespace, sorted by name.
+func AllStats() []Stats {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	stats := make([]Stats, 0, len(registry))
+	for _, namespace := range registry {
+		stats = append(stats, namespace.Stats())
+	}
+	sort.Slice(stats, func(i, j int) bool { return stats[i].Name < stats[j].Name })
+	return stats
+}
+
+// Stats returns the counters of the namespace.
+func (n *Namespace) Stats() Stats {
+	return Stats{Name: n.name, Hits: n.hits.Load(), Misses: n.misses.Load()}
+}
+
+func (n *Namespace) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	value, ok, err := n.backend.Get(ctx, n.key(key))
+	if err == nil {
+		if ok {
+			n.hits.Add(1)
+		} else {
+			n.misses.Add(1)
+		}
+	}
+	return value, ok, err
+}
+
+func (n *Namespace) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	return n.backend.Set(ctx, n.key(key), value, ttl)
+}
+
+func (n *Namespace) Delete(ctx context.Context, keys ...string) error {
+	prefixed := make([]string, len(keys))
+	for i, key := range keys {
+		prefixed[i] = n.key(key)
+	}
+	return n.backend.Delete(ctx, prefixed...)
+}
+
+func (n *Namespace) DeletePrefix(ctx context.Context, prefix string) error {
+	return n.backend.DeletePrefix(ctx, n.key(prefix))
+}
+
+func (n *Namespace) key(key string) string {
+	return n.name + ":" + key
+}
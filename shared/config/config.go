@@ -0,0 +1,216 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// Config is the configuration of the service. Every setting has a default, which the
+// configuration file, the environment and the command line override in that order.
+// Fields are named in the file by their yaml tag, in the environment by their env tag
+// and on the command line by their path in the file, such as -server.read-timeout.
+type Config struct {
+	Server        ServerConfig        `yaml:"server"`
+	Database      DatabaseConfig      `yaml:"database"`
+	Log           LogConfig           `yaml:"log"`
+	Cache         CacheConfig         `yaml:"cache"`
+	Tracing       TracingConfig       `yaml:"tracing"`
+	Scheduler     SchedulerConfig     `yaml:"scheduler"`
+	Auth          AuthConfig          `yaml:"auth"`
+	RateLimit     RateLimitConfig     `yaml:"rate_limit"`
+	Idempotency   IdempotencyConfig   `yaml:"idempotency"`
+	Webhooks      WebhooksConfig      `yaml:"webhooks"`
+	Notifications NotificationsConfig `yaml:"notifications"`
+}
+
+// ServerConfig configures the HTTP server. Routes allowed more time than a request
+// usually takes extend the read and write timeouts for themselves.
+type ServerConfig struct {
+	Address           string        `yaml:"address" env:"SERVER_ADDRESS" usage:"host:port the server listens on"`
+	ReadHeaderTimeout time.Duration `yaml:"read_header_timeout" env:"SERVER_READ_HEADER_TIMEOUT" usage:"time to read the request headers"`
+	ReadTimeout       time.Duration `yaml:"read_timeout" env:"SERVER_READ_TIMEOUT" usage:"time to read the whole request"`
+	WriteTimeout      time.Duration `yaml:"write_timeout" env:"SERVER_WRITE_TIMEOUT" usage:"time to write the response"`
+	IdleTimeout       time.Duration `yaml:"idle_timeout" env:"SERVER_IDLE_TIMEOUT" usage:"time a keep-alive connection waits for the next request"`
+	MaxHeaderBytes    int           `yaml:"max_header_bytes" env:"SERVER_MAX_HEADER_BYTES" usage:"maximum size of the request headers"`
+	ShutdownTimeout   time.Duration `yaml:"shutdown_timeout" env:"SHUTDOWN_TIMEOUT" usage:"time given to requests and jobs to finish on shutdown"`
+
+	// CORSAllowedOrigins lists the origins browsers may call the API from
+	CORSAllowedOrigins []string `yaml:"cors_allowed_origins" env:"CORS_ALLOWED_ORIGINS" usage:"comma-separated origins allowed to call the API from a browser"`
+}
+
+// DatabaseConfig configures the PostgreSQL connection pool. The connection string is
+// used as is when set, otherwise it is built from the host, port, user and name.
+type DatabaseConfig struct {
+	DSN      string `yaml:"dsn" env:"POSTGRES_CONN" secret:"true" usage:"PostgreSQL connection string"`
+	Host     string `yaml:"host" env:"POSTGRES_HOST" usage:"PostgreSQL host"`
+	Port     int    `yaml:"port" env:"POSTGRES_PORT" usage:"PostgreSQL port"`
+	User     string `yaml:"user" env:"POSTGRES_USERNAME" usage:"PostgreSQL user"`
+	Password string `yaml:"password" env:"POSTGRES_PASSWORD" secret:"true" usage:"PostgreSQL password"`
+	Name     string `yaml:"name" env:"POSTGRES_DATABASE" usage:"PostgreSQL database"`
+
+	MaxOpenConns    int           `yaml:"max_open_conns" env:"DB_MAX_OPEN_CONNS" usage:"maximum open connections, 0 for no limit"`
+	MaxIdleConns    int           `yaml:"max_idle_conns" env:"DB_MAX_IDLE_CONNS" usage:"maximum idle connections"`
+	ConnMaxLifetime time.Duration `yaml:"conn_max_lifetime" env:"DB_CONN_MAX_LIFETIME" usage:"time after which a connection is replaced"`
+	ConnMaxIdleTime time.Duration `yaml:"conn_max_idle_time" env:"DB_CONN_MAX_IDLE_TIME" usage:"time after which an idle connection is closed"`
+
+	MigrateOnStart bool `yaml:"migrate_on_start" env:"DB_MIGRATE_ON_START" usage:"apply the pending migrations on startup"`
+}
+
+// ConnectionString returns the connection string of the database.
+func (c DatabaseConfig) ConnectionString() string {
+	if c.DSN != "" {
+		return c.DSN
+	}
+	dsn := url.URL{
+		Scheme: "postgres",
+		User:   url.UserPassword(c.User, c.Password),
+		Host:   fmt.Sprintf("%s:%d", c.Host, c.Port),
+		Path:   c.Name,
+	}
+	return dsn.String()
+}
+
+// LogConfig configures the logger.
+type LogConfig struct {
+	Level string `yaml:"level" env:"LOG_LEVEL" usage:"log level: trace, debug, info, warn, error, fatal or panic"`
+}
+
+// CacheConfig configures the in-process cache of hot reads.
+type CacheConfig struct {
+	Size int           `yaml:"size" env:"CACHE_SIZE" usage:"maximum number of cached entries"`
+	TTL  time.Duration `yaml:"ttl" env:"CACHE_TTL" usage:"time an entry is cached for"`
+}
+
+// TracingConfig configures where spans are sent. The OTLP exporter reads its endpoint
+// from the standard OTEL_EXPORTER_OTLP_* environment variables.
+type TracingConfig struct {
+	Exporter string `yaml:"exporter" env:"TRACING_EXPORTER" usage:"span exporter: none, stdout or otlp"`
+}
+
+// SchedulerConfig configures the background jobs.
+type SchedulerConfig struct {
+	CachePurgeInterval       time.Duration `yaml:"cache_purge_interval" env:"SCHEDULER_CACHE_PURGE_INTERVAL" usage:"time between purges of expired cache entries"`
+	RateLimitPurgeInterval   time.Duration `yaml:"rate_limit_purge_interval" env:"SCHEDULER_RATE_LIMIT_PURGE_INTERVAL" usage:"time between purges of idle rate limit buckets"`
+	IdempotencyPurgeInterval time.Duration `yaml:"idempotency_purge_interval" env:"SCHEDULER_IDEMPOTENCY_PURGE_INTERVAL" usage:"time between purges of expired idempotency keys"`
+	WebhookDeliveryInterval  time.Duration `yaml:"webhook_delivery_interval" env:"SCHEDULER_WEBHOOK_DELIVERY_INTERVAL" usage:"time between sends of the due webhook deliveries"`
+	NotificationSendInterval time.Duration `yaml:"notification_send_interval" env:"SCHEDULER_NOTIFICATION_SEND_INTERVAL" usage:"time between sends of the due notification emails"`
+}
+
+// AuthConfig configures how clients identify themselves.
+type AuthConfig struct {
+	// APIKeys maps the names of the clients to their API keys
+	APIKeys map[string]string `yaml:"api_keys" env:"AUTH_API_KEYS" secret:"true" usage:"comma-separated client:key pairs"`
+}
+
+// RateLimitConfig configures the token buckets limiting the requests of every caller, by
+// group: reads, writes and failed authentications. A bucket holds the burst of requests
+// that may be made at once and refills at the per-minute rate.
+type RateLimitConfig struct {
+	Enabled bool `yaml:"enabled" env:"RATE_LIMIT_ENABLED" usage:"limit the rate of requests"`
+
+	ReadsPerMinute  int `yaml:"reads_per_minute" env:"RATE_LIMIT_READS_PER_MINUTE" usage:"reading requests per minute of a caller"`
+	ReadsBurst      int `yaml:"reads_burst" env:"RATE_LIMIT_READS_BURST" usage:"reading requests a caller may make at once"`
+	WritesPerMinute int `yaml:"writes_per_minute" env:"RATE_LIMIT_WRITES_PER_MINUTE" usage:"changing requests per minute of a caller"`
+	WritesBurst     int `yaml:"writes_burst" env:"RATE_LIMIT_WRITES_BURST" usage:"changing requests a caller may make at once"`
+	AuthPerMinute   int `yaml:"auth_per_minute" env:"RATE_LIMIT_AUTH_PER_MINUTE" usage:"failed authentications per minute of an IP"`
+	AuthBurst       int `yaml:"auth_burst" env:"RATE_LIMIT_AUTH_BURST" usage:"failed authentications an IP may make at once"`
+}
+
+// IdempotencyConfig configures the replay of the responses to retried POST requests.
+type IdempotencyConfig struct {
+	TTL         time.Duration `yaml:"ttl" env:"IDEMPOTENCY_TTL" usage:"time the response to a request with an Idempotency-Key is replayed"`
+	LockTimeout time.Duration `yaml:"lock_timeout" env:"IDEMPOTENCY_LOCK_TIMEOUT" usage:"time after which a request still in progress is considered abandoned"`
+}
+
+// WebhooksConfig configures the delivery of events to the webhooks of organizations. A
+// failed delivery is retried after Backoff, doubled after every further failure up to
+// MaxBackoff, until MaxAttempts attempts have failed.
+type WebhooksConfig struct {
+	Timeout     time.Duration `yaml:"timeout" env:"WEBHOOKS_TIMEOUT" usage:"time a webhook endpoint has to respond"`
+	MaxAttempts int           `yaml:"max_attempts" env:"WEBHOOKS_MAX_ATTEMPTS" usage:"attempts to deliver an event before giving up"`
+	Backoff     time.Duration `yaml:"backoff" env:"WEBHOOKS_BACKOFF" usage:"time before the first retry of a failed delivery"`
+	MaxBackoff  time.Duration `yaml:"max_backoff" env:"WEBHOOKS_MAX_BACKOFF" usage:"maximum time between retries of a failed delivery"`
+
+	// AllowPrivateNetworks lets webhooks point at loopback and private addresses
+	AllowPrivateNetworks bool `yaml:"allow_private_networks" env:"WEBHOOKS_ALLOW_PRIVATE_NETWORKS" usage:"allow webhooks to private and loopback addresses"`
+}
+
+// NotificationsConfig configures the emails users opted in to. An email that fails to
+// send is retried after Backoff, doubled after every further failure up to MaxBackoff,
+// until MaxAttempts attempts have failed.
+type NotificationsConfig struct {
+	Enabled bool       `yaml:"enabled" env:"NOTIFICATIONS_ENABLED" usage:"email users about the events they opted in to"`
+	From    string     `yaml:"from" env:"NOTIFICATIONS_FROM" usage:"sender of the emails, such as Tenders <noreply@example.com>"`
+	SMTP    SMTPConfig `yaml:"smtp"`
+
+	MaxAttempts int           `yaml:"max_attempts" env:"NOTIFICATIONS_MAX_ATTEMPTS" usage:"attempts to send an email before giving up"`
+	Backoff     time.Duration `yaml:"backoff" env:"NOTIFICATIONS_BACKOFF" usage:"time before the first retry of an email that failed to send"`
+	MaxBackoff  time.Duration `yaml:"max_backoff" env:"NOTIFICATIONS_MAX_BACKOFF" usage:"maximum time between retries of an email that failed to send"`
+}
+
+// SMTPConfig configures the server emails are sent through. Without STARTTLS the
+// connection and the credentials are not encrypted, which only suits local stand-ins.
+type SMTPConfig struct {
+	Host     string        `yaml:"host" env:"SMTP_HOST" usage:"SMTP server host"`
+	Port     int           `yaml:"port" env:"SMTP_PORT" usage:"SMTP server port"`
+	Username string        `yaml:"username" env:"SMTP_USERNAME" usage:"SMTP user, empty to send without authentication"`
+	Password string        `yaml:"password" env:"SMTP_PASSWORD" secret:"true" usage:"SMTP password"`
+	StartTLS bool          `yaml:"starttls" env:"SMTP_STARTTLS" usage:"require STARTTLS before sending"`
+	Timeout  time.Duration `yaml:"timeout" env:"SMTP_TIMEOUT" usage:"time to send an email"`
+}
+
+// Default returns the configuration used for the settings that are not given.
+func Default() *Config {
+	return &Config{
+		Server: ServerConfig{
+			Address:           ":8080",
+			ReadHeaderTimeout: 5 * time.Second,
+			ReadTimeout:       30 * time.Second,
+			WriteTimeout:      35 * time.Second,
+			IdleTimeout:       2 * time.Minute,
+			MaxHeaderBytes:    64 << 10,
+			ShutdownTimeout:   30 * time.Second,
+		},
+		Database: DatabaseConfig{
+			Host:            "localhost",
+			Port:            5432,
+			MaxOpenConns:    25,
+			MaxIdleConns:    10,
+			ConnMaxLifetime: 30 * time.Minute,
+			ConnMaxIdleTime: 5 * time.Minute,
+		},
+		Log:     LogConfig{Level: "info"},
+		Cache:   CacheConfig{Size: 10000, TTL: time.Minute},
+		Tracing: TracingConfig{Exporter: "none"},
+		Scheduler: SchedulerConfig{
+			CachePurgeInterval:       time.Minute,
+			RateLimitPurgeInterval:   time.Minute,
+			IdempotencyPurgeInterval: time.Hour,
+			WebhookDeliveryInterval:  5 * time.Second,
+			NotificationSendInterval: 10 * time.Second,
+		},
+		RateLimit: RateLimitConfig{
+			Enabled:         true,
+			ReadsPerMinute:  600,
+			ReadsBurst:      100,
+			WritesPerMinute: 120,
+			WritesBurst:     20,
+			AuthPerMinute:   10,
+			AuthBurst:       5,
+		},
+		Idempotency: IdempotencyConfig{TTL: 24 * time.Hour, LockTimeout: 10 * time.Minute},
+		Webhooks: WebhooksConfig{
+			Timeout:     10 * time.Second,
+			MaxAttempts: 10,
+			Backoff:     30 * time.Second,
+			MaxBackoff:  time.Hour,
+		},
+		Notifications: NotificationsConfig{
+			SMTP:        SMTPConfig{Port: 587, StartTLS: true, Timeout: 10 * time.Second},
+			MaxAttempts: 5,
+			Backoff:     time.Minute,
+			MaxBackoff:  time.Hour,
+		},
+	}
+}
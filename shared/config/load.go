@@ -0,0 +1,216 @@
+package config
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// FileEnv names the environment variable giving the configuration file when the
+// -config flag does not.
+const FileEnv = "CONFIG_FILE"
+
+// Error lists every problem found in the configuration.
+type Error struct {
+	Problems []string
+}
+
+func (e *Error) Error() string {
+	return "invalid configuration:\n  - " + strings.Join(e.Problems, "\n  - ")
+}
+
+// field is a setting of the configuration.
+type field struct {
+	path   string // path in the configuration file, such as server.read_timeout
+	env    string
+	secret bool
+	usage  string
+	value  reflect.Value
+}
+
+// flagName returns the name of the command line flag of the setting.
+func (f field) flagName() string {
+	return strings.ReplaceAll(f.path, "_", "-")
+}
+
+// Load reads the configuration: the defaults, overridden by the YAML file given with
+// -config or CONFIG_FILE, then by the environment, then by the flags in args. Every
+// invalid setting is reported in a single *Error. It returns the arguments left after
+// the flags, such as the name of a command.
+func Load(args []string, output io.Writer) (*Config, []string, error) {
+	conf := Default()
+	fields := settings(conf)
+
+	flags := flag.NewFlagSet("tender-service", flag.ContinueOnError)
+	flags.SetOutput(output)
+	path := flags.String("config", os.Getenv(FileEnv), "path to the YAML configuration file")
+	given := map[string]string{}
+	for _, f := range fields {
+		flags.Func(f.flagName(), f.usage, func(value string) error {
+			given[f.path] = value
+			return nil
+		})
+	}
+	if err := flags.Parse(args); err != nil {
+		return nil, nil, err
+	}
+
+	if *path != "" {
+		if err := readFile(*path, conf); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	var problems []string
+	for _, f := range fields {
+		if value, ok := os.LookupEnv(f.env); ok && f.env != "" {
+			if err := set(f.value, value); err != nil {
+				problems = append(problems, fmt.Sprintf("%s: %v", f.env, err))
+			}
+		}
+	}
+	for _, f := range fields {
+		if value, ok := given[f.path]; ok {
+			if err := set(f.value, value); err != nil {
+				problems = append(problems, fmt.Sprintf("-%s: %v", f.flagName(), err))
+			}
+		}
+	}
+
+	problems = append(problems, conf.validate()...)
+	if len(problems) > 0 {
+		return nil, nil, &Error{Problems: problems}
+	}
+	return conf, flags.Args(), nil
+}
+
+// readFile overrides conf with the settings of a YAML file. Unknown settings are
+// rejected, so a misspelled one is not silently ignored.
+func readFile(path string, conf *Config) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to open the configuration file: %w", err)
+	}
+	defer file.Close()
+
+	decoder := yaml.NewDecoder(file)
+	decoder.KnownFields(true)
+	if err := decoder.Decode(conf); err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("failed to read the configuration file %s: %w", path, err)
+	}
+	return nil
+}
+
+// settings lists the settings of conf in the order they are declared.
+func settings(conf *Config) []field {
+	return collect(reflect.ValueOf(conf).Elem(), "")
+}
+
+func collect(v reflect.Value, prefix string) []field {
+	var fields []field
+	for i := 0; i < v.NumField(); i++ {
+		structField := v.Type().Field(i)
+		path := prefix + structField.Tag.Get("yaml")
+		if structField.Type.Kind() == reflect.Struct {
+			fields = append(fields, collect(v.Field(i), path+".")...)
+			continue
+		}
+		fields = append(fields, field{
+			path:   path,
+			env:    structField.Tag.Get("env"),
+			secret: structField.Tag.Get("secret") == "true",
+			usage:  structField.Tag.Get("usage"),
+			value:  v.Field(i),
+		})
+	}
+	return fields
+}
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// set parses value into v. Lists are comma-separated, maps are comma-separated
+// key:value pairs.
+func set(v reflect.Value, value string) error {
+	switch {
+	case v.Type() == durationType:
+		duration, err := time.ParseDuration(value)
+		if err != nil {
+			return fmt.Errorf("%q is not a duration such as 30s or 5m", value)
+		}
+		v.SetInt(int64(duration))
+	case v.Kind() == reflect.String:
+		v.SetString(value)
+	case v.Kind() == reflect.Int:
+		number, err := strconv.Atoi(value)
+		if err != nil {
+			return fmt.Errorf("%q is not an integer", value)
+		}
+		v.SetInt(int64(number))
+	case v.Kind() == reflect.Bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("%q is not a boolean", value)
+		}
+		v.SetBool(b)
+	case v.Kind() == reflect.Slice:
+		var items []string
+		for _, item := range strings.Split(value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+		v.Set(reflect.ValueOf(items))
+	case v.Kind() == reflect.Map:
+		pairs := map[string]string{}
+		for _, pair := range strings.Split(value, ",") {
+			if pair = strings.TrimSpace(pair); pair == "" {
+				continue
+			}
+			key, item, ok := strings.Cut(pair, ":")
+			if !ok || key == "" {
+				return fmt.Errorf("%q is not a key:value pair", pair)
+			}
+			pairs[key] = item
+		}
+		v.Set(reflect.ValueOf(pairs))
+	default:
+		return fmt.Errorf("unsupported setting type %s", v.Type())
+	}
+	return nil
+}
+
+// String prints every setting with the values of secrets hidden, so the configuration
+// can be logged.
+func (c *Config) String() string {
+	var b strings.Builder
+	for i, f := range settings(c) {
+		if i > 0 {
+			b.WriteByte(' ')
+		}
+		b.WriteString(f.path)
+		b.WriteByte('=')
+		if f.secret && f.value.Kind() == reflect.Map {
+			// The names of the clients are not secret, their keys are
+			masked := map[string]string{}
+			for _, key := range f.value.MapKeys() {
+				masked[key.String()] = "***"
+			}
+			fmt.Fprint(&b, masked)
+			continue
+		}
+		if f.secret && !f.value.IsZero() {
+			b.WriteString("***")
+			continue
+		}
+		fmt.Fprint(&b, f.value.Interface())
+	}
+	return b.String()
+}
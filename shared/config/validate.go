@@ -0,0 +1,120 @@
+package config
+
+import (
+	"fmt"
+	"maps"
+	"net"
+	"net/mail"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	logLevels = []string{"trace", "debug", "info", "warn", "warning", "error", "fatal", "panic"}
+	exporters = []string{"none", "stdout", "otlp"}
+)
+
+// validator collects the problems found in a configuration.
+type validator struct {
+	problems []string
+}
+
+func (v *validator) check(ok bool, format string, args ...any) {
+	if !ok {
+		v.problems = append(v.problems, fmt.Sprintf(format, args...))
+	}
+}
+
+func (v *validator) positive(name string, value time.Duration) {
+	v.check(value > 0, "%s must be a positive duration, got %s", name, value)
+}
+
+// validate returns a description of every invalid setting.
+func (c *Config) validate() []string {
+	v := &validator{}
+
+	v.check(validAddress(c.Server.Address), "server.address must be host:port with a port between 1 and 65535, got %q", c.Server.Address)
+	v.positive("server.read_header_timeout", c.Server.ReadHeaderTimeout)
+	v.positive("server.read_timeout", c.Server.ReadTimeout)
+	v.positive("server.write_timeout", c.Server.WriteTimeout)
+	v.positive("server.idle_timeout", c.Server.IdleTimeout)
+	v.positive("server.shutdown_timeout", c.Server.ShutdownTimeout)
+	v.check(c.Server.MaxHeaderBytes > 0, "server.max_header_bytes must be positive, got %d", c.Server.MaxHeaderBytes)
+
+	if c.Database.DSN == "" {
+		v.check(c.Database.Host != "", "database.host is required when database.dsn is not set")
+		v.check(c.Database.User != "", "database.user is required when database.dsn is not set")
+		v.check(c.Database.Name != "", "database.name is required when database.dsn is not set")
+		v.check(validPort(c.Database.Port), "database.port must be between 1 and 65535, got %d", c.Database.Port)
+	}
+	v.check(c.Database.MaxOpenConns >= 0, "database.max_open_conns must not be negative, got %d", c.Database.MaxOpenConns)
+	v.check(c.Database.MaxIdleConns >= 0, "database.max_idle_conns must not be negative, got %d", c.Database.MaxIdleConns)
+	v.check(c.Database.MaxOpenConns == 0 || c.Database.MaxIdleConns <= c.Database.MaxOpenConns,
+		"database.max_idle_conns must not exceed database.max_open_conns, got %d > %d", c.Database.MaxIdleConns, c.Database.MaxOpenConns)
+	v.positive("database.conn_max_lifetime", c.Database.ConnMaxLifetime)
+	v.positive("database.conn_max_idle_time", c.Database.ConnMaxIdleTime)
+
+	v.check(slices.Contains(logLevels, strings.ToLower(c.Log.Level)), "log.level must be one of %v, got %q", logLevels, c.Log.Level)
+
+	v.check(c.Cache.Size > 0, "cache.size must be positive, got %d", c.Cache.Size)
+	v.positive("cache.ttl", c.Cache.TTL)
+
+	v.check(slices.Contains(exporters, c.Tracing.Exporter), "tracing.exporter must be one of %v, got %q", exporters, c.Tracing.Exporter)
+
+	v.positive("scheduler.cache_purge_interval", c.Scheduler.CachePurgeInterval)
+	v.positive("scheduler.rate_limit_purge_interval", c.Scheduler.RateLimitPurgeInterval)
+	v.positive("scheduler.idempotency_purge_interval", c.Scheduler.IdempotencyPurgeInterval)
+	v.positive("scheduler.webhook_delivery_interval", c.Scheduler.WebhookDeliveryInterval)
+	v.positive("scheduler.notification_send_interval", c.Scheduler.NotificationSendInterval)
+
+	for _, client := range slices.Sorted(maps.Keys(c.Auth.APIKeys)) {
+		v.check(c.Auth.APIKeys[client] != "", "auth.api_keys: the key of %s is empty", client)
+	}
+
+	v.check(c.RateLimit.ReadsPerMinute > 0, "rate_limit.reads_per_minute must be positive, got %d", c.RateLimit.ReadsPerMinute)
+	v.check(c.RateLimit.ReadsBurst > 0, "rate_limit.reads_burst must be positive, got %d", c.RateLimit.ReadsBurst)
+	v.check(c.RateLimit.WritesPerMinute > 0, "rate_limit.writes_per_minute must be positive, got %d", c.RateLimit.WritesPerMinute)
+	v.check(c.RateLimit.WritesBurst > 0, "rate_limit.writes_burst must be positive, got %d", c.RateLimit.WritesBurst)
+	v.check(c.RateLimit.AuthPerMinute > 0, "rate_limit.auth_per_minute must be positive, got %d", c.RateLimit.AuthPerMinute)
+	v.check(c.RateLimit.AuthBurst > 0, "rate_limit.auth_burst must be positive, got %d", c.RateLimit.AuthBurst)
+
+	v.positive("idempotency.ttl", c.Idempotency.TTL)
+	v.positive("idempotency.lock_timeout", c.Idempotency.LockTimeout)
+
+	v.positive("webhooks.timeout", c.Webhooks.Timeout)
+	v.check(c.Webhooks.MaxAttempts > 0, "webhooks.max_attempts must be positive, got %d", c.Webhooks.MaxAttempts)
+	v.positive("webhooks.backoff", c.Webhooks.Backoff)
+	v.check(c.Webhooks.MaxBackoff >= c.Webhooks.Backoff,
+		"webhooks.max_backoff must not be shorter than webhooks.backoff, got %s < %s", c.Webhooks.MaxBackoff, c.Webhooks.Backoff)
+
+	if c.Notifications.Enabled {
+		_, err := mail.ParseAddress(c.Notifications.From)
+		v.check(err == nil, "notifications.from must be an email address when notifications are enabled, got %q", c.Notifications.From)
+		v.check(c.Notifications.SMTP.Host != "", "notifications.smtp.host is required when notifications are enabled")
+	}
+	v.check(validPort(c.Notifications.SMTP.Port), "notifications.smtp.port must be between 1 and 65535, got %d", c.Notifications.SMTP.Port)
+	v.positive("notifications.smtp.timeout", c.Notifications.SMTP.Timeout)
+	v.check(c.Notifications.MaxAttempts > 0, "notifications.max_attempts must be positive, got %d", c.Notifications.MaxAttempts)
+	v.positive("notifications.backoff", c.Notifications.Backoff)
+	v.check(c.Notifications.MaxBackoff >= c.Notifications.Backoff,
+		"notifications.max_backoff must not be shorter than notifications.backoff, got %s < %s", c.Notifications.MaxBackoff, c.Notifications.Backoff)
+
+	return v.problems
+}
+
+// validAddress reports whether address is host:port with a valid port; the host may be
+// empty to listen on every interface.
+func validAddress(address string) bool {
+	_, port, err := net.SplitHostPort(address)
+	if err != nil {
+		return false
+	}
+	number, err := strconv.Atoi(port)
+	return err == nil && validPort(number)
+}
+
+func validPort(port int) bool {
+	return port >= 1 && port <= 65535
+}
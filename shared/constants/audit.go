@@ -0,0 +1,27 @@
+package constants
+
+// AuditEntityType names the kind of entity an audit event is about.
+type AuditEntityType string
+
+const (
+	AuditEntityTender       AuditEntityType = "tender"
+	AuditEntityBid          AuditEntityType = "bid"
+	AuditEntityOrganization AuditEntityType = "organization"
+	AuditEntityResponsible  AuditEntityType = "responsible"
+	AuditEntityUser         AuditEntityType = "user"
+	AuditEntityComment      AuditEntityType = "comment"
+)
+
+// AuditAction names the change an audit event records.
+type AuditAction string
+
+const (
+	AuditActionCreated    AuditAction = "created"
+	AuditActionUpdated    AuditAction = "updated"
+	AuditActionDeleted    AuditAction = "deleted"
+	AuditActionPublished  AuditAction = "published"
+	AuditActionClosed     AuditAction = "closed"
+	AuditActionApproved   AuditAction = "approved"
+	AuditActionRejected   AuditAction = "rejected"
+	AuditActionRolledBack AuditAction = "rolled_back"
+)
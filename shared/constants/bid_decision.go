@@ -0,0 +1,8 @@
+package constants
+
+type BidDecision string
+
+const (
+	BidDecisionApproved BidDecision = "APPROVED"
+	BidDecisionRejected BidDecision = "REJECTED"
+)
@@ -0,0 +1,12 @@
+package constants
+
+type EligibilityRuleType string
+
+const (
+	// EligibilityOrganizationType restricts bidders to the comma-separated organization types, e.g. "LLC,JSC".
+	EligibilityOrganizationType EligibilityRuleType = "ORGANIZATION_TYPE"
+	// EligibilityMinRegistrationMonths requires the bidder to be registered for at least the given number of months.
+	EligibilityMinRegistrationMonths EligibilityRuleType = "MIN_REGISTRATION_MONTHS"
+	// EligibilityServiceType requires the bidder to provide the given service type.
+	EligibilityServiceType EligibilityRuleType = "SERVICE_TYPE"
+)
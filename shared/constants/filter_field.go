@@ -0,0 +1,22 @@
+package constants
+
+// Fields accepted by the filters of the tender, bid, decision, audit event and webhook
+// delivery lists.
+const (
+	FilterFieldStatus         = "status"
+	FilterFieldOrganizationID = "organizationId"
+	FilterFieldCreatorID      = "creatorId"
+	FilterFieldCreatedAt      = "createdAt"
+	FilterFieldBudget         = "budget"
+	FilterFieldCategory       = "category"
+	FilterFieldName           = "name"
+	FilterFieldDecision       = "decision"
+	FilterFieldBidID          = "bidId"
+	FilterFieldTenderID       = "tenderId"
+	FilterFieldUserID         = "userId"
+	FilterFieldEntityType     = "entityType"
+	FilterFieldEntityID       = "entityId"
+	FilterFieldAction         = "action"
+	FilterFieldRequestID      = "requestId"
+	FilterFieldEventType      = "eventType"
+)
@@ -0,0 +1,21 @@
+package constants
+
+// NotificationEventType names an event users can opt in to be emailed about.
+type NotificationEventType string
+
+const (
+	NotificationTenderPublished NotificationEventType = "tender.published"
+	NotificationTenderClosed    NotificationEventType = "tender.closed"
+	NotificationBidCreated      NotificationEventType = "bid.created"
+	NotificationBidApproved     NotificationEventType = "bid.approved"
+	NotificationBidRejected     NotificationEventType = "bid.rejected"
+)
+
+// NotificationStatus is the state of an email in the outbox.
+type NotificationStatus string
+
+const (
+	NotificationPending NotificationStatus = "pending"
+	NotificationSent    NotificationStatus = "sent"
+	NotificationFailed  NotificationStatus = "failed"
+)
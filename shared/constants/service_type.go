@@ -1,5 +1,7 @@
 package constants
 
+// ServiceType is the code of a service category. The categories themselves are
+// managed in the database; these are the initial categories seeded on startup.
 type ServiceType string
 
 const (
@@ -0,0 +1,21 @@
+package constants
+
+// WebhookEventType names an event organizations can subscribe their webhooks to.
+type WebhookEventType string
+
+const (
+	WebhookEventTenderPublished WebhookEventType = "tender.published"
+	WebhookEventTenderClosed    WebhookEventType = "tender.closed"
+	WebhookEventBidCreated      WebhookEventType = "bid.created"
+	WebhookEventBidApproved     WebhookEventType = "bid.approved"
+	WebhookEventBidRejected     WebhookEventType = "bid.rejected"
+)
+
+// WebhookDeliveryStatus is the state of the delivery of an event to a webhook.
+type WebhookDeliveryStatus string
+
+const (
+	WebhookDeliveryPending   WebhookDeliveryStatus = "pending"
+	WebhookDeliverySucceeded WebhookDeliveryStatus = "succeeded"
+	WebhookDeliveryFailed    WebhookDeliveryStatus = "failed"
+)
@@ -1,42 +1,158 @@
 package api_errors
 
 import (
+	"avitoTest/shared"
+	"avitoTest/shared/errors/domain_errors"
+	"avitoTest/shared/requestid"
+	"avitoTest/shared/validation"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
+	"time"
 
-	"github.com/go-chi/render"
+	"go.opentelemetry.io/otel/trace"
 )
 
-// ErrResponse - structure for describing errors.
+// ProblemContentType is the media type of RFC 7807 problem details.
+const ProblemContentType = "application/problem+json"
+
+// ErrResponse - RFC 7807 problem details describing an error.
 type ErrResponse struct {
-	Err            error `json:"-"` // internal error field
-	HTTPStatusCode int   `json:"-"` // HTTP status code
+	Err error `json:"-"` // internal error field
+
+	Type     string       `json:"type"`               // URI identifying the problem type
+	Title    string       `json:"title"`              // short description of the problem type
+	Status   int          `json:"status"`             // HTTP status code
+	Detail   string       `json:"detail,omitempty"`   // detailed error description
+	Instance string       `json:"instance,omitempty"` // path of the request that failed
+	Errors   []FieldError `json:"errors,omitempty"`   // problems with individual request fields
+}
 
-	StatusText string `json:"status"`          // short description of the error
-	ErrorText  string `json:"error,omitempty"` // detailed error description
+// FieldError describes a problem with one field of a request.
+type FieldError struct {
+	In      string `json:"in"`              // path, query, header or body
+	Field   string `json:"field,omitempty"` // parameter name or dotted body path
+	Code    string `json:"code,omitempty"`  // the rule the field fails, e.g. required or maximum
+	Message string `json:"message"`
 }
 
-// Render allows you to use ErrResponse with chi/render.
-func (e *ErrResponse) Render(w http.ResponseWriter, r *http.Request) error {
-	render.Status(r, e.HTTPStatusCode)
-	return nil
+// StatusCode returns the HTTP status code errors of the kind of err are reported with.
+// Oversized bodies and requests running out of time have codes of their own.
+func StatusCode(err error) int {
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		return http.StatusRequestEntityTooLarge
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return http.StatusServiceUnavailable
+	}
+
+	switch domain_errors.Kind(err) {
+	case domain_errors.ErrNotFound:
+		return http.StatusNotFound
+	case domain_errors.ErrValidation:
+		return http.StatusBadRequest
+	case domain_errors.ErrConflict:
+		return http.StatusConflict
+	case domain_errors.ErrForbidden:
+		return http.StatusForbidden
+	case domain_errors.ErrUnauthorized:
+		return http.StatusUnauthorized
+	}
+	return http.StatusInternalServerError
 }
 
-// Err Invalid Request creates an Err Response for invalid requests.
-func ErrInvalidRequest(err error) render.Renderer {
-	return &ErrResponse{
-		Err:            err,
-		HTTPStatusCode: http.StatusBadRequest,
-		StatusText:     "Invalid request",
-		ErrorText:      err.Error(),
+// NewProblem creates problem details with the given status. The details of server
+// errors are not shown to the client.
+func NewProblem(status int, err error) *ErrResponse {
+	problem := &ErrResponse{
+		Err:    err,
+		Type:   "about:blank",
+		Title:  http.StatusText(status),
+		Status: status,
 	}
+	if err != nil && status < http.StatusInternalServerError {
+		problem.Detail = err.Error()
+	}
+	return problem
 }
 
-// ErrInternal creates an ErrResponse for internal server errors.
-func ErrInternal(err error) render.Renderer {
-	return &ErrResponse{
-		Err:            err,
-		HTTPStatusCode: http.StatusInternalServerError,
-		StatusText:     "Internal server error",
-		ErrorText:      err.Error(),
+// ErrInvalidRequest creates problem details for requests that cannot be read.
+func ErrInvalidRequest(err error) *ErrResponse {
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		return NewProblem(http.StatusRequestEntityTooLarge, err)
 	}
+	return NewProblem(http.StatusBadRequest, err)
+}
+
+// ErrBadRequest creates problem details for requests with an invalid parameter.
+func ErrBadRequest(detail string) *ErrResponse {
+	problem := NewProblem(http.StatusBadRequest, nil)
+	problem.Detail = detail
+	return problem
+}
+
+// ErrValidation creates problem details for requests that do not match the API specification.
+func ErrValidation(errors []FieldError) *ErrResponse {
+	problem := NewProblem(http.StatusBadRequest, nil)
+	problem.Detail = "request does not match the API specification"
+	problem.Errors = errors
+	return problem
+}
+
+// ErrTooManyRequests creates problem details for requests over the rate limit of the caller.
+func ErrTooManyRequests(retryAfter time.Duration) *ErrResponse {
+	problem := NewProblem(http.StatusTooManyRequests, nil)
+	problem.Detail = fmt.Sprintf("rate limit exceeded, retry in %s", retryAfter.Round(time.Second))
+	return problem
+}
+
+// ErrInternal creates problem details for internal server errors.
+func ErrInternal(err error) *ErrResponse {
+	return NewProblem(http.StatusInternalServerError, err)
+}
+
+// WriteError reports err with the status code of its kind. Validation errors list every
+// invalid field.
+func WriteError(w http.ResponseWriter, r *http.Request, err error) {
+	problem := NewProblem(StatusCode(err), err)
+
+	var validationErr *validation.Error
+	if errors.As(err, &validationErr) {
+		for _, field := range validationErr.Fields {
+			problem.Errors = append(problem.Errors, FieldError{
+				In:      "body",
+				Field:   field.Field,
+				Code:    field.Code,
+				Message: field.Message,
+			})
+		}
+	}
+
+	WriteProblem(w, r, problem)
+}
+
+// WriteProblem writes problem details as the response to r.
+func WriteProblem(w http.ResponseWriter, r *http.Request, problem *ErrResponse) {
+	WriteProblemBody(w, r, problem, problem)
+}
+
+// WriteProblemBody writes problem details extended with members of their own; body embeds
+// problem. Server errors are logged, since their details are not sent to the client.
+func WriteProblemBody(w http.ResponseWriter, r *http.Request, problem *ErrResponse, body any) {
+	if problem.Status >= http.StatusInternalServerError && problem.Err != nil {
+		entry := shared.Logger.WithField("request_id", requestid.FromContext(r.Context()))
+		if spanContext := trace.SpanContextFromContext(r.Context()); spanContext.HasTraceID() {
+			entry = entry.WithField("trace_id", spanContext.TraceID().String())
+		}
+		entry.Errorf("%s %s failed: %v", r.Method, r.URL.Path, problem.Err)
+	}
+	problem.Instance = r.URL.Path
+
+	w.Header().Set("Content-Type", ProblemContentType)
+	w.WriteHeader(problem.Status)
+	json.NewEncoder(w).Encode(body)
 }
@@ -0,0 +1,9 @@
+package audit_errors
+
+import "avitoTest/shared/errors/domain_errors"
+
+var (
+	ErrAuthenticationRequired = domain_errors.New(domain_errors.ErrUnauthorized, "the audit log is available to authenticated callers only")
+	ErrOrganizationRequired   = domain_errors.New(domain_errors.ErrValidation, "a single organizationId to read the audit log of is required")
+	ErrNotOrganizationAdmin   = domain_errors.New(domain_errors.ErrForbidden, "user is not responsible for the organization")
+)
@@ -0,0 +1,8 @@
+package auth_errors
+
+import "avitoTest/shared/errors/domain_errors"
+
+var (
+	ErrInvalidAPIKey = domain_errors.New(domain_errors.ErrUnauthorized, "invalid API key")
+	ErrInvalidUserID = domain_errors.New(domain_errors.ErrValidation, "X-User-ID must be a positive user ID")
+)
@@ -0,0 +1,33 @@
+package bid_errors
+
+import (
+	"avitoTest/shared/errors/domain_errors"
+	"fmt"
+)
+
+var (
+	ErrConflictOfInterest = domain_errors.New(domain_errors.ErrConflict, "conflict of interest")
+
+	ErrSelfBid                 = fmt.Errorf("%w: organization cannot bid on its own tender", ErrConflictOfInterest)
+	ErrCreatorConflict         = fmt.Errorf("%w: bid creator is responsible for the tender organization", ErrConflictOfInterest)
+	ErrApproverConflict        = fmt.Errorf("%w: approver cannot approve this bid", ErrConflictOfInterest)
+	ErrConflictAlreadyDeclared = domain_errors.New(domain_errors.ErrConflict, "conflict of interest already declared")
+	ErrNoEligibleApprovers     = domain_errors.New(domain_errors.ErrConflict, "no responsibles without a conflict of interest left to approve the bid")
+	ErrAlreadyDecided          = domain_errors.New(domain_errors.ErrConflict, "approver has already decided on this bid")
+)
+
+var (
+	ErrDeclarantRequired = domain_errors.New(domain_errors.ErrUnauthorized, "declaring a conflict of interest requires the user in X-User-ID")
+	ErrNotDeclarant      = domain_errors.New(domain_errors.ErrForbidden, "users can only declare their own conflicts of interest")
+)
+
+var ErrNotEligible = domain_errors.New(domain_errors.ErrForbidden, "organization is not eligible to bid on this tender")
+
+var (
+	ErrBidNotFound           = domain_errors.New(domain_errors.ErrNotFound, "bid not found")
+	ErrBidVersionNotFound    = domain_errors.New(domain_errors.ErrNotFound, "bid version not found")
+	ErrBidAlreadyRejected    = domain_errors.New(domain_errors.ErrConflict, "bid already REJECTED")
+	ErrBidAlreadyApproved    = domain_errors.New(domain_errors.ErrConflict, "bid already APPROVED")
+	ErrNotResponsible        = domain_errors.New(domain_errors.ErrForbidden, "user is not responsible for the organization")
+	ErrInvalidOrganizationID = domain_errors.New(domain_errors.ErrValidation, "invalid organization ID")
+)
@@ -0,0 +1,12 @@
+package category_errors
+
+import "avitoTest/shared/errors/domain_errors"
+
+var (
+	ErrCategoryNotFound    = domain_errors.New(domain_errors.ErrNotFound, "service category not found")
+	ErrCategoryCodeTaken   = domain_errors.New(domain_errors.ErrConflict, "service category code already exists")
+	ErrInvalidParent       = domain_errors.New(domain_errors.ErrValidation, "invalid parent category")
+	ErrCategoryHasChildren = domain_errors.New(domain_errors.ErrConflict, "service category has subcategories")
+	ErrInvalidServiceType  = domain_errors.New(domain_errors.ErrValidation, "invalid service type")
+	ErrCategoryInUse       = domain_errors.New(domain_errors.ErrConflict, "service category is in use, deactivate it with is_active=false instead")
+)
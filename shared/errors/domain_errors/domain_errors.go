@@ -0,0 +1,54 @@
+package domain_errors
+
+import "errors"
+
+// Kinds of domain errors. The errors returned by the services wrap one of them,
+// which decides how the error is reported to the client.
+var (
+	ErrNotFound     = errors.New("not found")
+	ErrValidation   = errors.New("validation failed")
+	ErrConflict     = errors.New("conflict")
+	ErrForbidden    = errors.New("forbidden")
+	ErrUnauthorized = errors.New("unauthorized")
+)
+
+var kinds = []error{ErrNotFound, ErrValidation, ErrConflict, ErrForbidden, ErrUnauthorized}
+
+// domainError is an error of a kind. It keeps its own message, so wrapping
+// a kind does not change what the client is told.
+type domainError struct {
+	kind    error
+	message string
+	cause   error
+}
+
+func (e *domainError) Error() string {
+	return e.message
+}
+
+func (e *domainError) Unwrap() []error {
+	if e.cause == nil {
+		return []error{e.kind}
+	}
+	return []error{e.kind, e.cause}
+}
+
+// New creates an error of the given kind.
+func New(kind error, message string) error {
+	return &domainError{kind: kind, message: message}
+}
+
+// Wrap marks err as an error of the given kind, keeping its message.
+func Wrap(kind error, err error) error {
+	return &domainError{kind: kind, message: err.Error(), cause: err}
+}
+
+// Kind returns the kind of err, or nil if err is not a domain error.
+func Kind(err error) error {
+	for _, kind := range kinds {
+		if errors.Is(err, kind) {
+			return kind
+		}
+	}
+	return nil
+}
@@ -0,0 +1,5 @@
+package export_errors
+
+import "avitoTest/shared/errors/domain_errors"
+
+var ErrUnsupportedFormat = domain_errors.New(domain_errors.ErrValidation, "unsupported export format")
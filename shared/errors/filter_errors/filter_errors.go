@@ -0,0 +1,9 @@
+package filter_errors
+
+import "avitoTest/shared/errors/domain_errors"
+
+var (
+	ErrUnknownField        = domain_errors.New(domain_errors.ErrValidation, "unknown filter field")
+	ErrUnsupportedOperator = domain_errors.New(domain_errors.ErrValidation, "unsupported filter operator")
+	ErrInvalidValue        = domain_errors.New(domain_errors.ErrValidation, "invalid filter value")
+)
@@ -0,0 +1,10 @@
+package idempotency_errors
+
+import "avitoTest/shared/errors/domain_errors"
+
+var (
+	ErrInvalidKey        = domain_errors.New(domain_errors.ErrValidation, "Idempotency-Key must be between 1 and 255 characters")
+	ErrKeyReused         = domain_errors.New(domain_errors.ErrValidation, "Idempotency-Key has already been used for a different request")
+	ErrRequestInProgress = domain_errors.New(domain_errors.ErrConflict, "a request with this Idempotency-Key is still in progress")
+	ErrClaimLost         = domain_errors.New(domain_errors.ErrConflict, "the Idempotency-Key has been taken over by another request")
+)
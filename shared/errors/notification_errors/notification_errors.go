@@ -0,0 +1,12 @@
+package notification_errors
+
+import "avitoTest/shared/errors/domain_errors"
+
+var ErrPreferencesNotFound = domain_errors.New(domain_errors.ErrNotFound, "notification preferences not found")
+
+var ErrEmailRequired = domain_errors.New(domain_errors.ErrValidation, "email is required to opt in to notifications")
+
+var (
+	ErrAuthenticationRequired = domain_errors.New(domain_errors.ErrUnauthorized, "notification preferences are available to authenticated callers only")
+	ErrNotOwnPreferences      = domain_errors.New(domain_errors.ErrForbidden, "users may only manage their own notification preferences")
+)
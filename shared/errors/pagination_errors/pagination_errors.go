@@ -0,0 +1,10 @@
+package pagination_errors
+
+import "avitoTest/shared/errors/domain_errors"
+
+var (
+	ErrInvalidLimit  = domain_errors.New(domain_errors.ErrValidation, "invalid limit")
+	ErrInvalidOffset = domain_errors.New(domain_errors.ErrValidation, "invalid offset")
+	ErrInvalidSort   = domain_errors.New(domain_errors.ErrValidation, "invalid sort key")
+	ErrInvalidOrder  = domain_errors.New(domain_errors.ErrValidation, "invalid sort order")
+)
@@ -0,0 +1,14 @@
+package search_errors
+
+import "avitoTest/shared/errors/domain_errors"
+
+var (
+	ErrEmptyQuery           = domain_errors.New(domain_errors.ErrValidation, "search query is required")
+	ErrUnsupportedLanguage  = domain_errors.New(domain_errors.ErrValidation, "unsupported search language")
+	ErrOrganizationRequired = domain_errors.New(domain_errors.ErrValidation, "organization ID is required")
+)
+
+var (
+	ErrAuthenticationRequired = domain_errors.New(domain_errors.ErrUnauthorized, "searching the tenders and bids of an organization requires authentication")
+	ErrNotResponsible         = domain_errors.New(domain_errors.ErrForbidden, "user is not responsible for the organization")
+)
@@ -1,10 +1,15 @@
 package tendert_erorrs
 
-import "errors"
+import "avitoTest/shared/errors/domain_errors"
 
 var (
-	ErrTenderNotFound        = errors.New("tender not found")
-	ErrUnauthorized          = errors.New("user not authorized")
-	ErrTenderVersionNotFound = errors.New("tender version not found")
-	ErrInvalidStatus         = errors.New("not valid status")
+	ErrTenderNotFound         = domain_errors.New(domain_errors.ErrNotFound, "tender not found")
+	ErrUnauthorized           = domain_errors.New(domain_errors.ErrForbidden, "user not authorized")
+	ErrTenderVersionNotFound  = domain_errors.New(domain_errors.ErrNotFound, "tender version not found")
+	ErrInvalidStatus          = domain_errors.New(domain_errors.ErrValidation, "not valid status")
+	ErrInvalidEligibilityRule = domain_errors.New(domain_errors.ErrValidation, "invalid eligibility rule")
+	ErrInvalidBudget          = domain_errors.New(domain_errors.ErrValidation, "budget must not be negative")
+	ErrInvalidImportFile      = domain_errors.New(domain_errors.ErrValidation, "invalid import file")
+	ErrInvalidImportFormat    = domain_errors.New(domain_errors.ErrValidation, "unsupported import format")
+	ErrInvalidImportMode      = domain_errors.New(domain_errors.ErrValidation, "unsupported import mode")
 )
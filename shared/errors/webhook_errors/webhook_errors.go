@@ -0,0 +1,13 @@
+package webhook_errors
+
+import "avitoTest/shared/errors/domain_errors"
+
+var (
+	ErrWebhookNotFound  = domain_errors.New(domain_errors.ErrNotFound, "webhook not found")
+	ErrDeliveryNotFound = domain_errors.New(domain_errors.ErrNotFound, "webhook delivery not found")
+)
+
+var (
+	ErrAuthenticationRequired = domain_errors.New(domain_errors.ErrUnauthorized, "webhooks are available to authenticated callers only")
+	ErrNotOrganizationAdmin   = domain_errors.New(domain_errors.ErrForbidden, "user is not responsible for the organization")
+)
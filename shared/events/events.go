@@ -0,0 +1,136 @@
+package events
+
+import (
+	"avitoTest/shared"
+	"context"
+	"sync"
+)
+
+// Topics events are published on.
+const (
+	TopicTender       = "tender"
+	TopicOrganization = "organization"
+	TopicCategory     = "category"
+	TopicBid          = "bid"
+)
+
+// Actions describing what happened to an entity.
+const (
+	ActionCreated    = "created"
+	ActionUpdated    = "updated"
+	ActionPublished  = "published"
+	ActionClosed     = "closed"
+	ActionRolledBack = "rolled_back"
+	ActionDeleted    = "deleted"
+	ActionApproved   = "approved"
+	ActionRejected   = "rejected"
+)
+
+// Event is a change notification delivered to the subscribers of its topic.
+type Event interface {
+	Topic() string
+}
+
+// TenderEvent is published after a tender has been changed.
+type TenderEvent struct {
+	TenderID int
+	Action   string
+}
+
+func (TenderEvent) Topic() string { return TopicTender }
+
+// OrganizationEvent is published after an organization has been changed.
+type OrganizationEvent struct {
+	OrganizationID int
+	Action         string
+}
+
+func (OrganizationEvent) Topic() string { return TopicOrganization }
+
+// CategoryEvent is published after a service category has been changed.
+type CategoryEvent struct {
+	CategoryID int
+	Action     string
+}
+
+func (CategoryEvent) Topic() string { return TopicCategory }
+
+// BidEvent is published after a bid has been created or decided on.
+type BidEvent struct {
+	BidID    int
+	TenderID int
+	Action   string
+}
+
+func (BidEvent) Topic() string { return TopicBid }
+
+// Handler processes a published event.
+type Handler func(ctx context.Context, event Event)
+
+// Bus delivers events synchronously to the handlers subscribed to their topic,
+// so by the time Publish returns every subscriber has seen the change.
+type Bus struct {
+	mu       sync.RWMutex
+	nextID   int
+	handlers map[string]map[int]Handler
+}
+
+// NewBus creates an empty bus.
+func NewBus() *Bus {
+	return &Bus{handlers: make(map[string]map[int]Handler)}
+}
+
+// Subscribe registers handler for topic and returns a function removing it.
+func (b *Bus) Subscribe(topic string, handler Handler) func() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.handlers[topic] == nil {
+		b.handlers[topic] = make(map[int]Handler)
+	}
+	id := b.nextID
+	b.nextID++
+	b.handlers[topic][id] = handler
+
+	return func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		delete(b.handlers[topic], id)
+	}
+}
+
+// Publish calls every handler subscribed to the topic of event. A panicking handler
+// is logged and does not prevent the others from running.
+func (b *Bus) Publish(ctx context.Context, event Event) {
+	b.mu.RLock()
+	handlers := make([]Handler, 0, len(b.handlers[event.Topic()]))
+	for _, handler := range b.handlers[event.Topic()] {
+		handlers = append(handlers, handler)
+	}
+	b.mu.RUnlock()
+
+	for _, handler := range handlers {
+		deliver(ctx, handler, event)
+	}
+}
+
+func deliver(ctx context.Context, handler Handler, event Event) {
+	defer func() {
+		if r := recover(); r != nil {
+			shared.Logger.Errorf("events: %s handler panicked: %v", event.Topic(), r)
+		}
+	}()
+	handler(ctx, event)
+}
+
+var defaultBus = NewBus()
+
+// Subscribe registers handler for topic on the application bus.
+func Subscribe(topic string, handler Handler) func() {
+	return defaultBus.Subscribe(topic, handler)
+}
+
+// Publish publishes event on the application bus.
+func Publish(ctx context.Context, event Event) {
+	defaultBus.Publish(ctx, event)
+}
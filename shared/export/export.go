@@ -0,0 +1,152 @@
+package export
+
+import (
+	"avitoTest/shared/errors/export_errors"
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// Format is the encoding of an export.
+type Format string
+
+const (
+	FormatCSV   Format = "csv"
+	FormatJSONL Format = "jsonl"
+)
+
+// flushEvery is the number of records after which buffered output is sent to the client.
+const flushEvery = 500
+
+// ParseFormat returns the format named by value; an empty value selects CSV.
+func ParseFormat(value string) (Format, error) {
+	switch Format(strings.ToLower(value)) {
+	case "", FormatCSV:
+		return FormatCSV, nil
+	case FormatJSONL:
+		return FormatJSONL, nil
+	}
+	return "", fmt.Errorf("%w: %q, allowed: %s, %s", export_errors.ErrUnsupportedFormat, value, FormatCSV, FormatJSONL)
+}
+
+// ContentType returns the media type of the format.
+func (f Format) ContentType() string {
+	if f == FormatJSONL {
+		return "application/x-ndjson"
+	}
+	return "text/csv; charset=utf-8"
+}
+
+// Column is a CSV column of records of type T.
+type Column[T any] struct {
+	Name  string
+	Value func(T) string
+}
+
+// Writer encodes records one by one: as CSV rows under a header built from the columns,
+// or as one JSON object per line. Output is buffered and flushed every flushEvery
+// records, so an export is never held in memory as a whole.
+type Writer[T any] struct {
+	out     io.Writer
+	buf     *bufio.Writer
+	format  Format
+	columns []Column[T]
+	csv     *csv.Writer
+	json    *json.Encoder
+	count   int
+}
+
+// NewWriter creates a writer encoding records to out.
+func NewWriter[T any](out io.Writer, format Format, columns []Column[T]) *Writer[T] {
+	buf := bufio.NewWriter(out)
+	w := &Writer[T]{out: out, buf: buf, format: format, columns: columns}
+	if format == FormatJSONL {
+		w.json = json.NewEncoder(buf)
+	} else {
+		w.csv = csv.NewWriter(buf)
+	}
+	return w
+}
+
+// Count returns the number of records written.
+func (w *Writer[T]) Count() int {
+	return w.count
+}
+
+// Write encodes record.
+func (w *Writer[T]) Write(record T) error {
+	if w.count == 0 {
+		if err := w.writeHeader(); err != nil {
+			return err
+		}
+	}
+	w.count++
+
+	if w.json != nil {
+		if err := w.json.Encode(record); err != nil {
+			return err
+		}
+	} else {
+		row := make([]string, len(w.columns))
+		for i, column := range w.columns {
+			row[i] = sanitizeCell(column.Value(record))
+		}
+		if err := w.csv.Write(row); err != nil {
+			return err
+		}
+	}
+
+	if w.count%flushEvery == 0 {
+		return w.flush()
+	}
+	return nil
+}
+
+// Close writes the CSV header of an empty export and flushes the buffered output.
+func (w *Writer[T]) Close() error {
+	if w.count == 0 {
+		if err := w.writeHeader(); err != nil {
+			return err
+		}
+	}
+	return w.flush()
+}
+
+func (w *Writer[T]) writeHeader() error {
+	if w.csv == nil {
+		return nil
+	}
+	header := make([]string, len(w.columns))
+	for i, column := range w.columns {
+		header[i] = column.Name
+	}
+	return w.csv.Write(header)
+}
+
+func (w *Writer[T]) flush() error {
+	if w.csv != nil {
+		w.csv.Flush()
+		if err := w.csv.Error(); err != nil {
+			return err
+		}
+	}
+	if err := w.buf.Flush(); err != nil {
+		return err
+	}
+	if flusher, ok := w.out.(http.Flusher); ok {
+		flusher.Flush()
+	}
+	return nil
+}
+
+// sanitizeCell keeps spreadsheets from evaluating user input as a formula.
+func sanitizeCell(value string) string {
+	if value != "" && strings.ContainsRune("=+-@\t\r", rune(value[0])) {
+		return "'" + value
+	}
+	return value
+}
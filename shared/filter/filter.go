@@ -0,0 +1,183 @@
+package filter
+
+import (
+	"avitoTest/shared/errors/filter_errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Operator is a comparison applied to a filtered field.
+type Operator string
+
+const (
+	OpEq       Operator = "eq"
+	OpNe       Operator = "ne"
+	OpGt       Operator = "gt"
+	OpGte      Operator = "gte"
+	OpLt       Operator = "lt"
+	OpLte      Operator = "lte"
+	OpIn       Operator = "in"
+	OpContains Operator = "contains"
+)
+
+// Common operator sets.
+var (
+	Equality   = []Operator{OpEq, OpNe, OpIn}
+	Comparison = []Operator{OpEq, OpGt, OpGte, OpLt, OpLte}
+)
+
+// FieldType tells how the raw query values of a field are parsed.
+type FieldType int
+
+const (
+	String FieldType = iota
+	Int
+	Number
+	Time
+)
+
+// Field describes a filterable field: its type, the operators it accepts and,
+// for enumerations, the allowed values.
+type Field struct {
+	Type      FieldType
+	Operators []Operator
+	Values    []string
+}
+
+// Schema is the allow-list of fields a list endpoint can be filtered by.
+type Schema map[string]Field
+
+// Condition is a single parsed filter condition. Values hold parsed values:
+// string, int, float64 or time.Time depending on the field type.
+type Condition struct {
+	Field    string
+	Operator Operator
+	Values   []any
+}
+
+// Value returns the single value of a non-IN condition.
+func (c Condition) Value() any {
+	return c.Values[0]
+}
+
+// Filter is a conjunction of conditions.
+type Filter []Condition
+
+// Fields returns the conditions on the given field.
+func (f Filter) Fields(field string) []Condition {
+	var conditions []Condition
+	for _, condition := range f {
+		if condition.Field == field {
+			conditions = append(conditions, condition)
+		}
+	}
+	return conditions
+}
+
+// Without returns the filter with the conditions on the given field removed.
+func (f Filter) Without(field string) Filter {
+	var rest Filter
+	for _, condition := range f {
+		if condition.Field != field {
+			rest = append(rest, condition)
+		}
+	}
+	return rest
+}
+
+// Parse builds a filter from query parameters of the form `field=value` and
+// `field[op]=value`. IN values are comma separated. Plain parameters that are not
+// in the schema are left to the caller (pagination, search, ...), while any
+// `name[op]` parameter must refer to a field of the schema.
+func Parse(query url.Values, schema Schema) (Filter, error) {
+	keys := make([]string, 0, len(query))
+	for key := range query {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var result Filter
+	for _, key := range keys {
+		name, op, hasOperator, err := splitKey(key)
+		if err != nil {
+			return nil, err
+		}
+
+		field, ok := schema[name]
+		if !ok {
+			if hasOperator {
+				return nil, fmt.Errorf("%w: %q", filter_errors.ErrUnknownField, name)
+			}
+			continue
+		}
+		if !slices.Contains(field.Operators, op) {
+			return nil, fmt.Errorf("%w: %q for field %q", filter_errors.ErrUnsupportedOperator, op, name)
+		}
+
+		for _, raw := range query[key] {
+			values, err := parseValues(field, op, raw)
+			if err != nil {
+				return nil, fmt.Errorf("%w for field %q: %v", filter_errors.ErrInvalidValue, name, err)
+			}
+			result = append(result, Condition{Field: name, Operator: op, Values: values})
+		}
+	}
+	return result, nil
+}
+
+// splitKey splits `name[op]` into its parts; a plain `name` means equality.
+func splitKey(key string) (string, Operator, bool, error) {
+	open := strings.IndexByte(key, '[')
+	if open < 0 {
+		return key, OpEq, false, nil
+	}
+	if !strings.HasSuffix(key, "]") || open == 0 {
+		return "", "", false, fmt.Errorf("%w: malformed parameter %q", filter_errors.ErrUnsupportedOperator, key)
+	}
+	return key[:open], Operator(key[open+1 : len(key)-1]), true, nil
+}
+
+func parseValues(field Field, op Operator, raw string) ([]any, error) {
+	parts := []string{raw}
+	if op == OpIn {
+		parts = strings.Split(raw, ",")
+	}
+
+	values := make([]any, 0, len(parts))
+	for _, part := range parts {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			return nil, fmt.Errorf("empty value")
+		}
+		value, err := parseValue(field, part)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+	}
+	return values, nil
+}
+
+func parseValue(field Field, raw string) (any, error) {
+	switch field.Type {
+	case Int:
+		return strconv.Atoi(raw)
+	case Number:
+		return strconv.ParseFloat(raw, 64)
+	case Time:
+		if t, err := time.Parse(time.RFC3339, raw); err == nil {
+			return t, nil
+		}
+		return time.Parse(time.DateOnly, raw)
+	default:
+		if len(field.Values) > 0 && !slices.Contains(field.Values, raw) {
+			return nil, fmt.Errorf("%q is not one of %s", raw, strings.Join(field.Values, ", "))
+		}
+		return raw, nil
+	}
+}
@@ -0,0 +1,122 @@
+package health
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+)
+
+// checkTimeout bounds the time a check may take before its component is reported down.
+const checkTimeout = 2 * time.Second
+
+// Statuses of the service and its components.
+const (
+	StatusUp   = "up"
+	StatusDown = "down"
+)
+
+// Check reports whether a component the service depends on works, with optional
+// details describing its state.
+type Check func(ctx context.Context) (details any, err error)
+
+// Component is the outcome of the check of one component.
+type Component struct {
+	Status   string `json:"status"`
+	Duration string `json:"duration"`
+	Error    string `json:"error,omitempty"`
+	Details  any    `json:"details,omitempty"`
+}
+
+// Report is the outcome of every check; the service is up when all its components are.
+type Report struct {
+	Status     string               `json:"status"`
+	Components map[string]Component `json:"components"`
+}
+
+var (
+	mu     sync.RWMutex
+	checks = map[string]Check{}
+)
+
+// Register adds the check of a component, replacing the check registered under the
+// same name.
+func Register(name string, check Check) {
+	mu.Lock()
+	defer mu.Unlock()
+	checks[name] = check
+}
+
+// Unregister removes the check of a component.
+func Unregister(name string) {
+	mu.Lock()
+	defer mu.Unlock()
+	delete(checks, name)
+}
+
+// Run runs every registered check concurrently and reports their outcome.
+func Run(ctx context.Context) Report {
+	mu.RLock()
+	names := make([]string, 0, len(checks))
+	for name := range checks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	registered := make([]Check, len(names))
+	for i, name := range names {
+		registered[i] = checks[name]
+	}
+	mu.RUnlock()
+
+	components := make([]Component, len(names))
+	var wg sync.WaitGroup
+	for i, check := range registered {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			components[i] = run(ctx, check)
+		}()
+	}
+	wg.Wait()
+
+	report := Report{Status: StatusUp, Components: make(map[string]Component, len(names))}
+	for i, name := range names {
+		report.Components[name] = components[i]
+		if components[i].Status != StatusUp {
+			report.Status = StatusDown
+		}
+	}
+	return report
+}
+
+// run runs check, reporting the component down if the check does not return in time,
+// even if it ignores its context.
+func run(ctx context.Context, check Check) Component {
+	ctx, cancel := context.WithTimeout(ctx, checkTimeout)
+	defer cancel()
+
+	type result struct {
+		details any
+		err     error
+	}
+	results := make(chan result, 1)
+	start := time.Now()
+	go func() {
+		details, err := check(ctx)
+		results <- result{details, err}
+	}()
+
+	var r result
+	select {
+	case r = <-results:
+	case <-ctx.Done():
+		r.err = ctx.Err()
+	}
+
+	component := Component{Status: StatusUp, Duration: time.Since(start).String(), Details: r.details}
+	if r.err != nil {
+		component.Status = StatusDown
+		component.Error = r.err.Error()
+	}
+	return component
+}
@@ -1,6 +1,7 @@
 package shared
 
 import (
+	"avitoTest/shared/config"
 	"os"
 	"strings"
 
@@ -11,14 +12,14 @@ import (
 var Logger = logrus.New()
 
 // InitLogger - function for configuring the logger.
-func InitLogger(config *Config) {
+func InitLogger(conf config.LogConfig) {
 	Logger.SetFormatter(&logrus.TextFormatter{
 		FullTimestamp: true,
 	})
 
-	level, err := logrus.ParseLevel(strings.ToLower(config.LogLevel))
+	level, err := logrus.ParseLevel(strings.ToLower(conf.Level))
 	if err != nil {
-		Logger.Warnf("Invalid log level '%s' provided. Falling back to 'info' level.", config.LogLevel)
+		Logger.Warnf("Invalid log level '%s' provided. Falling back to 'info' level.", conf.Level)
 		level = logrus.InfoLevel // Default level
 	}
 	Logger.SetLevel(level)
@@ -0,0 +1,107 @@
+package metrics
+
+import (
+	"database/sql"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+)
+
+// namespace prefixes the names of the metrics of the service.
+const namespace = "tender_service"
+
+// Registry holds the metrics exposed at /metrics.
+var Registry = prometheus.NewRegistry()
+
+// HTTP metrics, labelled with the route template rather than the path, so the number of
+// series does not grow with the IDs requested.
+var (
+	HTTPRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "http_requests_total",
+		Help:      "HTTP requests served, by route, method and status class.",
+	}, []string{"route", "method", "status"})
+
+	HTTPRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "http_request_duration_seconds",
+		Help:      "Time spent serving HTTP requests, by route and method.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"route", "method"})
+
+	RateLimited = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "http_rate_limited_total",
+		Help:      "HTTP requests rejected for exceeding a rate limit, by rate limit group.",
+	}, []string{"group"})
+)
+
+// Domain metrics, counted by the services once a change is stored.
+var (
+	Tenders = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "tenders_total",
+		Help:      "Tender changes, by action such as created, published or closed.",
+	}, []string{"action"})
+
+	Bids = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "bids_total",
+		Help:      "Bid changes, by action such as created, approved or rejected.",
+	}, []string{"action"})
+)
+
+// WebhookDeliveries counts the attempts to deliver events to webhooks.
+var WebhookDeliveries = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Namespace: namespace,
+	Name:      "webhook_delivery_attempts_total",
+	Help:      "Attempts to deliver webhook events, by result: succeeded, retried or failed.",
+}, []string{"result"})
+
+// Results of the attempts to deliver webhook events.
+const (
+	WebhookSucceeded = "succeeded"
+	WebhookRetried   = "retried"
+	WebhookFailed    = "failed"
+)
+
+// NotificationEmails counts the attempts to send notification emails.
+var NotificationEmails = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Namespace: namespace,
+	Name:      "notification_email_attempts_total",
+	Help:      "Attempts to send notification emails, by result: sent, retried or failed.",
+}, []string{"result"})
+
+// Results of the attempts to send notification emails.
+const (
+	NotificationSent    = "sent"
+	NotificationRetried = "retried"
+	NotificationFailed  = "failed"
+)
+
+// Actions counted for bids.
+const (
+	BidCreated  = "created"
+	BidApproved = "approved"
+	BidRejected = "rejected"
+)
+
+func init() {
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		HTTPRequests,
+		HTTPRequestDuration,
+		RateLimited,
+		Tenders,
+		Bids,
+		WebhookDeliveries,
+		NotificationEmails,
+		cacheCollector{},
+	)
+}
+
+// RegisterDB exposes the connection pool statistics of db.
+func RegisterDB(db *sql.DB, name string) {
+	Registry.MustRegister(collectors.NewDBStatsCollector(db, name))
+}
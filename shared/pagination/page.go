@@ -0,0 +1,43 @@
+package pagination
+
+// Page is the response envelope of a paginated list.
+type Page[T any] struct {
+	Items      []T   `json:"items"`
+	Total      int64 `json:"total"`
+	Limit      int   `json:"limit"`
+	Offset     int   `json:"offset"`
+	NextOffset *int  `json:"next_offset"`
+}
+
+// NewPage wraps the items of the requested page together with the total count.
+func NewPage[T any](items []T, total int64, params Params) *Page[T] {
+	if items == nil {
+		items = []T{}
+	}
+
+	page := &Page[T]{
+		Items:  items,
+		Total:  total,
+		Limit:  params.Limit,
+		Offset: params.Offset,
+	}
+	if next := params.Offset + len(items); int64(next) < total {
+		page.NextOffset = &next
+	}
+	return page
+}
+
+// MapPage converts the items of a page, keeping the paging information.
+func MapPage[T, U any](page *Page[T], convert func(T) U) *Page[U] {
+	items := make([]U, 0, len(page.Items))
+	for _, item := range page.Items {
+		items = append(items, convert(item))
+	}
+	return &Page[U]{
+		Items:      items,
+		Total:      page.Total,
+		Limit:      page.Limit,
+		Offset:     page.Offset,
+		NextOffset: page.NextOffset,
+	}
+}
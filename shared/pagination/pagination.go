@@ -0,0 +1,92 @@
+package pagination
+
+import (
+	"avitoTest/shared/errors/pagination_errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+const (
+	DefaultLimit = 20
+	MaxLimit     = 100
+)
+
+// Sort keys accepted by list endpoints.
+const (
+	SortCreatedAt = "created_at"
+	SortName      = "name"
+	SortStatus    = "status"
+)
+
+// Sort orders.
+const (
+	OrderAsc  = "asc"
+	OrderDesc = "desc"
+)
+
+// Params describes the requested page of a list.
+type Params struct {
+	Limit  int
+	Offset int
+	Sort   string
+	Order  string
+}
+
+// DefaultParams returns the first page sorted by creation time, newest first.
+func DefaultParams() Params {
+	return Params{
+		Limit: DefaultLimit,
+		Sort:  SortCreatedAt,
+		Order: OrderDesc,
+	}
+}
+
+// FromRequest reads limit, offset, sort and order from the query string.
+// The sort key must be one of allowedSorts.
+func FromRequest(r *http.Request, allowedSorts ...string) (Params, error) {
+	params := DefaultParams()
+	query := r.URL.Query()
+
+	if value := query.Get("limit"); value != "" {
+		limit, err := strconv.Atoi(value)
+		if err != nil || limit <= 0 {
+			return params, pagination_errors.ErrInvalidLimit
+		}
+		params.Limit = min(limit, MaxLimit)
+	}
+
+	if value := query.Get("offset"); value != "" {
+		offset, err := strconv.Atoi(value)
+		if err != nil || offset < 0 {
+			return params, pagination_errors.ErrInvalidOffset
+		}
+		params.Offset = offset
+	}
+
+	if value := query.Get("sort"); value != "" {
+		if !contains(allowedSorts, value) {
+			return params, fmt.Errorf("%w: %q, allowed: %s", pagination_errors.ErrInvalidSort, value, strings.Join(allowedSorts, ", "))
+		}
+		params.Sort = value
+	}
+
+	if value := strings.ToLower(query.Get("order")); value != "" {
+		if value != OrderAsc && value != OrderDesc {
+			return params, pagination_errors.ErrInvalidOrder
+		}
+		params.Order = value
+	}
+
+	return params, nil
+}
+
+func contains(values []string, target string) bool {
+	for _, value := range values {
+		if value == target {
+			return true
+		}
+	}
+	return false
+}
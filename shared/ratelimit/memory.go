@@ -0,0 +1,86 @@
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+)
+
+// MemoryStore keeps the token buckets in the memory of the process.
+type MemoryStore struct {
+	mu      sync.Mutex
+	buckets map[string]*bucket
+	now     func() time.Time
+}
+
+type bucket struct {
+	tokens  float64
+	updated time.Time
+	policy  Policy
+}
+
+// NewMemoryStore creates an empty in-memory store.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{buckets: map[string]*bucket{}, now: time.Now}
+}
+
+func (s *MemoryStore) Take(_ context.Context, key string, policy Policy, cost int) (Decision, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	b, ok := s.buckets[key]
+	if !ok {
+		b = &bucket{tokens: float64(policy.Burst), updated: now}
+		s.buckets[key] = b
+	}
+	b.refill(now, policy)
+
+	needed := float64(max(cost, 1))
+	decision := Decision{Limit: policy.Burst, Allowed: b.tokens >= needed}
+	if decision.Allowed {
+		b.tokens -= float64(cost)
+	} else {
+		decision.RetryAfter = policy.duration(needed - b.tokens)
+	}
+	decision.Remaining = int(math.Floor(b.tokens))
+	decision.Reset = policy.duration(float64(policy.Burst) - b.tokens)
+	return decision, nil
+}
+
+// PurgeIdle removes the buckets that have refilled completely, which are the same as
+// new ones, and returns the number removed.
+func (s *MemoryStore) PurgeIdle(_ context.Context) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	purged := 0
+	for key, b := range s.buckets {
+		if b.refill(now, b.policy); b.tokens >= float64(b.policy.Burst) {
+			delete(s.buckets, key)
+			purged++
+		}
+	}
+	return purged
+}
+
+// refill adds the tokens accumulated since the bucket was last updated.
+func (b *bucket) refill(now time.Time, policy Policy) {
+	elapsed := now.Sub(b.updated).Seconds()
+	b.tokens = math.Min(float64(policy.Burst), b.tokens+max(elapsed, 0)*policy.Rate)
+	b.updated = now
+	b.policy = policy
+}
+
+// duration returns the time the policy takes to refill tokens.
+func (p Policy) duration(tokens float64) time.Duration {
+	if tokens <= 0 {
+		return 0
+	}
+	if p.Rate <= 0 {
+		return math.MaxInt64
+	}
+	return time.Duration(tokens / p.Rate * float64(time.Second))
+}
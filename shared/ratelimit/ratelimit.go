@@ -0,0 +1,74 @@
+package ratelimit
+
+import (
+	"avitoTest/shared"
+	"context"
+	"time"
+)
+
+// Groups of requests sharing a policy.
+const (
+	GroupReads  = "reads"  // requests reading data
+	GroupWrites = "writes" // requests changing data
+	GroupAuth   = "auth"   // failed authentications
+)
+
+// Policy is a token bucket: it holds at most Burst tokens and refills at Rate tokens per
+// second. Every request takes a token, so Burst requests may be made at once and Rate
+// per second on average.
+type Policy struct {
+	Rate  float64
+	Burst int
+}
+
+// PerMinute returns a policy allowing requests per minute on average, with bursts of burst.
+func PerMinute(requests, burst int) Policy {
+	return Policy{Rate: float64(requests) / 60, Burst: burst}
+}
+
+// Decision is the outcome of taking tokens from a bucket.
+type Decision struct {
+	Allowed    bool
+	Limit      int           // size of the bucket
+	Remaining  int           // tokens left in the bucket
+	RetryAfter time.Duration // time until the request would be allowed, 0 if it is
+	Reset      time.Duration // time until the bucket is full again
+}
+
+// Store keeps the token buckets. The in-memory store limits the requests one instance
+// serves; a store shared by the instances, such as Redis, limits them all together.
+type Store interface {
+	// Take removes cost tokens from the bucket of key, refilled according to policy since
+	// it was last used, if the bucket holds enough of them. With a cost of zero it only
+	// reports whether a request would be allowed.
+	Take(ctx context.Context, key string, policy Policy, cost int) (Decision, error)
+}
+
+// Limiter applies the policy of a group to the requests of every caller.
+type Limiter struct {
+	store    Store
+	policies map[string]Policy
+}
+
+// NewLimiter creates a limiter keeping its buckets in store. Groups without a policy are
+// not limited.
+func NewLimiter(store Store, policies map[string]Policy) *Limiter {
+	return &Limiter{store: store, policies: policies}
+}
+
+// Take takes cost tokens from the bucket of the caller identified by key in group. Store
+// failures are logged and the request is allowed, so an unavailable store never fails
+// a request.
+func (l *Limiter) Take(ctx context.Context, group, key string, cost int) Decision {
+	policy, ok := l.policies[group]
+	if !ok {
+		return Decision{Allowed: true}
+	}
+
+	decision, err := l.store.Take(ctx, group+":"+key, policy, cost)
+	if err != nil {
+		shared.Logger.Warnf("ratelimit: failed to take from the bucket of %s in %s: %v", key, group, err)
+		return Decision{Allowed: true}
+	}
+	return decision
+}
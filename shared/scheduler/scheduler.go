@@ -0,0 +1,205 @@
+package scheduler
+
+import (
+	"avitoTest/shared"
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// staleRuns is the number of intervals a job may go without a successful run before
+// the scheduler reports it as unhealthy.
+const staleRuns = 3
+
+// Job is work run in the background every Interval.
+type Job struct {
+	Name     string
+	Interval time.Duration
+	Run      func(ctx context.Context) error
+}
+
+// JobStatus describes the runs of a job so far.
+type JobStatus struct {
+	Name        string     `json:"name"`
+	Interval    string     `json:"interval"`
+	Healthy     bool       `json:"healthy"`
+	Running     bool       `json:"running"`
+	Runs        int        `json:"runs"`
+	Failures    int        `json:"failures"`
+	LastRun     *time.Time `json:"last_run,omitempty"`
+	LastSuccess *time.Time `json:"last_success,omitempty"`
+	LastError   string     `json:"last_error,omitempty"`
+}
+
+type jobState struct {
+	job    Job
+	status JobStatus
+}
+
+// Scheduler runs jobs in the background, each in a goroutine of its own, and keeps
+// track of how their runs went.
+type Scheduler struct {
+	mu      sync.Mutex
+	jobs    []*jobState
+	started time.Time
+	running bool
+	cancel  context.CancelFunc
+	wg      sync.WaitGroup
+	now     func() time.Time
+}
+
+// New creates a scheduler without jobs.
+func New() *Scheduler {
+	return &Scheduler{now: time.Now}
+}
+
+// Add registers job; jobs added after Start are not run.
+func (s *Scheduler) Add(job Job) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.jobs = append(s.jobs, &jobState{job: job, status: JobStatus{Name: job.Name, Interval: job.Interval.String()}})
+}
+
+// Start runs every job once per interval until Stop is called. A stopped scheduler
+// is not started again.
+func (s *Scheduler) Start() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.cancel != nil {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	s.cancel = cancel
+	s.started = s.now()
+	s.running = true
+	for _, state := range s.jobs {
+		s.wg.Add(1)
+		go s.loop(ctx, state)
+	}
+}
+
+// Stop stops scheduling jobs and waits for the runs in progress to finish, at most
+// until ctx is done. Running jobs see their context cancelled.
+func (s *Scheduler) Stop(ctx context.Context) error {
+	s.mu.Lock()
+	cancel := s.cancel
+	s.running = false
+	s.mu.Unlock()
+	if cancel == nil {
+		return nil
+	}
+	cancel()
+
+	done := make(chan struct{})
+	go func() {
+		s.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (s *Scheduler) loop(ctx context.Context, state *jobState) {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(state.job.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.run(ctx, state)
+		}
+	}
+}
+
+// run runs a job once, recording the outcome. A panicking job fails the run rather
+// than the service.
+func (s *Scheduler) run(ctx context.Context, state *jobState) {
+	s.mu.Lock()
+	state.status.Running = true
+	s.mu.Unlock()
+
+	err := func() (err error) {
+		defer func() {
+			if p := recover(); p != nil {
+				err = fmt.Errorf("panic: %v", p)
+			}
+		}()
+		return state.job.Run(ctx)
+	}()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := s.now()
+	state.status.Running = false
+	state.status.Runs++
+	state.status.LastRun = &now
+	if err != nil {
+		state.status.Failures++
+		state.status.LastError = err.Error()
+		shared.Logger.Errorf("scheduler: job %s failed: %v", state.job.Name, err)
+		return
+	}
+	state.status.LastSuccess = &now
+	state.status.LastError = ""
+}
+
+// Status returns the status of every job.
+func (s *Scheduler) Status() []JobStatus {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	statuses := make([]JobStatus, 0, len(s.jobs))
+	for _, state := range s.jobs {
+		status := state.status
+		status.Healthy = s.healthy(state)
+		statuses = append(statuses, status)
+	}
+	return statuses
+}
+
+// healthy reports whether the job has succeeded recently enough. Jobs are given a few
+// intervals from the start of the scheduler to succeed for the first time.
+func (s *Scheduler) healthy(state *jobState) bool {
+	if !s.running {
+		return false
+	}
+	since := s.started
+	if state.status.LastSuccess != nil {
+		since = *state.status.LastSuccess
+	}
+	return s.now().Sub(since) < staleRuns*state.job.Interval
+}
+
+// Check is a health check failing when the scheduler is not running or one of its
+// jobs has not succeeded for a while. The status of the jobs is given as details.
+func (s *Scheduler) Check(context.Context) (any, error) {
+	statuses := s.Status()
+
+	s.mu.Lock()
+	running := s.running
+	s.mu.Unlock()
+	if !running {
+		return statuses, errors.New("scheduler is not running")
+	}
+
+	var unhealthy []string
+	for _, status := range statuses {
+		if !status.Healthy {
+			unhealthy = append(unhealthy, status.Name)
+		}
+	}
+	if len(unhealthy) > 0 {
+		return statuses, fmt.Errorf("jobs without a recent successful run: %v", unhealthy)
+	}
+	return statuses, nil
+}
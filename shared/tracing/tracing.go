@@ -0,0 +1,91 @@
+package tracing
+
+import (
+	"avitoTest/shared/errors/domain_errors"
+	"context"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// serviceName names the service the spans are reported for.
+const serviceName = "tender-service"
+
+// instrumentationName names the tracer the spans of the service are started with.
+const instrumentationName = "avitoTest"
+
+// Exporters the spans can be sent with.
+const (
+	ExporterNone   = "none"
+	ExporterStdout = "stdout"
+	ExporterOTLP   = "otlp"
+)
+
+// shutdownTimeout bounds the time spent sending the spans left when the service stops.
+const shutdownTimeout = 5 * time.Second
+
+// Propagator reads and writes the W3C trace context and baggage headers.
+var Propagator = propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{})
+
+// Init sets up the global tracer provider sending spans with the given exporter and
+// returns the function flushing and stopping it. The OTLP exporter is configured with
+// the standard OTEL_EXPORTER_OTLP_* environment variables. With ExporterNone spans are
+// not recorded, but the trace context of incoming requests is still passed on.
+func Init(exporter string) (func() error, error) {
+	otel.SetTextMapPropagator(Propagator)
+
+	var spanExporter sdktrace.SpanExporter
+	var err error
+	switch exporter {
+	case ExporterNone, "":
+		return func() error { return nil }, nil
+	case ExporterStdout:
+		spanExporter, err = stdouttrace.New()
+	case ExporterOTLP:
+		spanExporter, err = otlptracehttp.New(context.Background())
+	default:
+		return nil, fmt.Errorf("unknown tracing exporter %q", exporter)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(spanExporter),
+		sdktrace.WithResource(resource.NewSchemaless(semconv.ServiceName(serviceName))),
+	)
+	otel.SetTracerProvider(provider)
+
+	return func() error {
+		ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+		return provider.Shutdown(ctx)
+	}, nil
+}
+
+// Start starts a span with the tracer of the service.
+func Start(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	return otel.Tracer(instrumentationName).Start(ctx, name, opts...)
+}
+
+// End ends span, recording err if there is one. Errors of a domain kind, such as a
+// missing tender, are answers to the client rather than failures, so only the others
+// mark the span as failed.
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		if domain_errors.Kind(err) == nil {
+			span.SetStatus(codes.Error, err.Error())
+		}
+	}
+	span.End()
+}
@@ -0,0 +1,97 @@
+package validation
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"avitoTest/shared/errors/domain_errors"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// FieldError describes a field that failed validation.
+type FieldError struct {
+	Field   string // dotted path of the field, e.g. eligibility_rules.0.value
+	Code    string // the rule the field fails, e.g. required or max
+	Message string
+
+	err error // the error of the services matching the failed rule, if any
+}
+
+// Error is a validation error listing every invalid field of a model. It is a validation
+// domain error and also matches the errors of the services its fields fail with.
+type Error struct {
+	Fields []FieldError
+}
+
+func (e *Error) Error() string {
+	messages := make([]string, 0, len(e.Fields))
+	for _, field := range e.Fields {
+		messages = append(messages, field.Message)
+	}
+	return strings.Join(messages, "; ")
+}
+
+func (e *Error) Unwrap() []error {
+	errs := []error{domain_errors.ErrValidation}
+	for _, field := range e.Fields {
+		if field.err != nil {
+			errs = append(errs, field.err)
+		}
+	}
+	return errs
+}
+
+// NewFieldError reports a field failing a check made by a service rather than a tag;
+// err is the error of the service and provides the message.
+func NewFieldError(field, code string, err error) *Error {
+	return &Error{Fields: []FieldError{{Field: field, Code: code, Message: err.Error(), err: err}}}
+}
+
+func newFieldError(fieldErr validator.FieldError) FieldError {
+	field := fieldPath(fieldErr)
+	return FieldError{
+		Field:   field,
+		Code:    fieldErr.Tag(),
+		Message: message(field, fieldErr),
+		err:     ruleErrors[fieldErr.Tag()],
+	}
+}
+
+// message describes the rule a field fails.
+func message(field string, fieldErr validator.FieldError) string {
+	switch fieldErr.Tag() {
+	case "required":
+		return field + " is required"
+	case "min", "gte":
+		return fmt.Sprintf("%s must be at least %s%s", field, fieldErr.Param(), unit(fieldErr))
+	case "max", "lte":
+		return fmt.Sprintf("%s must be at most %s%s", field, fieldErr.Param(), unit(fieldErr))
+	case "gt":
+		return fmt.Sprintf("%s must be greater than %s", field, fieldErr.Param())
+	case "oneof":
+		return fmt.Sprintf("%s must be one of %s", field, strings.ReplaceAll(fieldErr.Param(), " ", ", "))
+	case "http_url":
+		return field + " must be an absolute http or https URL"
+	case "email":
+		return field + " must be an email address"
+	case ruleServiceType:
+		return field + " must be the code of an active service category"
+	}
+	if values, ok := enumRules[fieldErr.Tag()]; ok {
+		return fmt.Sprintf("%s must be one of %s", field, strings.Join(values, ", "))
+	}
+	return fmt.Sprintf("%s fails the %s rule", field, fieldErr.Tag())
+}
+
+// unit names what the length limits of strings, slices and maps count.
+func unit(fieldErr validator.FieldError) string {
+	switch fieldErr.Kind() {
+	case reflect.String:
+		return " characters"
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return " items"
+	}
+	return ""
+}
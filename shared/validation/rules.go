@@ -0,0 +1,83 @@
+package validation
+
+import (
+	"context"
+	"slices"
+
+	"avitoTest/shared/constants"
+	"avitoTest/shared/errors/category_errors"
+	"avitoTest/shared/errors/tendert_erorrs"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// Rules added to those of the validator package.
+const (
+	ruleOrganizationType = "org_type"
+	ruleTenderStatus     = "tender_status"
+	ruleBidStatus        = "bid_status"
+	ruleServiceType      = "service_type"
+	ruleWebhookEvent     = "webhook_event"
+	ruleNotification     = "notification_event"
+)
+
+// enumRules lists the values accepted by the rules checking a field against a fixed set.
+var enumRules = map[string][]string{
+	ruleOrganizationType: {string(constants.IE), string(constants.LLC), string(constants.JSC)},
+	ruleTenderStatus: {
+		string(constants.TenderStatusCreated),
+		string(constants.TenderStatusPublished),
+		string(constants.TenderStatusClosed),
+	},
+	ruleBidStatus: {
+		string(constants.BidStatusCreated),
+		string(constants.BidStatusPublished),
+		string(constants.BidStatusClosed),
+		string(constants.BidStatusRejected),
+		string(constants.BidStatusApproved),
+	},
+	ruleWebhookEvent: {
+		string(constants.WebhookEventTenderPublished),
+		string(constants.WebhookEventTenderClosed),
+		string(constants.WebhookEventBidCreated),
+		string(constants.WebhookEventBidApproved),
+		string(constants.WebhookEventBidRejected),
+	},
+	ruleNotification: {
+		string(constants.NotificationTenderPublished),
+		string(constants.NotificationTenderClosed),
+		string(constants.NotificationBidCreated),
+		string(constants.NotificationBidApproved),
+		string(constants.NotificationBidRejected),
+	},
+}
+
+// ruleErrors are the errors of the services that a field failing a rule also matches.
+var ruleErrors = map[string]error{
+	ruleTenderStatus: tendert_erorrs.ErrInvalidStatus,
+	ruleServiceType:  category_errors.ErrInvalidServiceType,
+}
+
+func oneOf(values []string) validator.Func {
+	return func(fl validator.FieldLevel) bool {
+		return slices.Contains(values, fl.Field().String())
+	}
+}
+
+// lookupErrorKey keys the place Struct collects a failed service type lookup in.
+type lookupErrorKey struct{}
+
+// serviceTypeRule accepts the codes of active service categories. A failed lookup is
+// reported by Struct instead of an invalid field.
+func serviceTypeRule(serviceTypes ServiceTypes) validator.FuncCtx {
+	return func(ctx context.Context, fl validator.FieldLevel) bool {
+		active, err := serviceTypes.IsActiveCode(ctx, fl.Field().String())
+		if err != nil {
+			if lookupErr, ok := ctx.Value(lookupErrorKey{}).(*error); ok && *lookupErr == nil {
+				*lookupErr = err
+			}
+			return true
+		}
+		return active
+	}
+}
@@ -0,0 +1,78 @@
+package validation
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// ServiceTypes reports whether a service type is the code of an active service category.
+type ServiceTypes interface {
+	IsActiveCode(ctx context.Context, code string) (bool, error)
+}
+
+// Validator checks models against their `validate` tags. Besides the rules of the validator
+// package it knows the org_type, tender_status, bid_status, webhook_event,
+// notification_event and service_type rules.
+type Validator struct {
+	validate *validator.Validate
+}
+
+// New creates a validator. The service_type rule looks the codes up in serviceTypes, so it
+// may only be used by validators created with them.
+func New(serviceTypes ServiceTypes) *Validator {
+	validate := validator.New(validator.WithRequiredStructEnabled())
+	validate.RegisterTagNameFunc(jsonName)
+	for rule, values := range enumRules {
+		validate.RegisterValidation(rule, oneOf(values))
+	}
+	if serviceTypes != nil {
+		validate.RegisterValidationCtx(ruleServiceType, serviceTypeRule(serviceTypes))
+	}
+	return &Validator{validate: validate}
+}
+
+// Struct validates a model, returning an *Error listing every invalid field.
+func (v *Validator) Struct(ctx context.Context, model any) error {
+	var lookupErr error
+	ctx = context.WithValue(ctx, lookupErrorKey{}, &lookupErr)
+
+	err := v.validate.StructCtx(ctx, model)
+	if lookupErr != nil {
+		return lookupErr
+	}
+
+	var validationErrs validator.ValidationErrors
+	if !errors.As(err, &validationErrs) {
+		return err
+	}
+	fields := make([]FieldError, 0, len(validationErrs))
+	for _, fieldErr := range validationErrs {
+		fields = append(fields, newFieldError(fieldErr))
+	}
+	return &Error{Fields: fields}
+}
+
+// jsonName names the fields of a model after their JSON keys, so errors refer to the
+// fields as the client sent them.
+func jsonName(field reflect.StructField) string {
+	name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+	switch name {
+	case "-":
+		return ""
+	case "":
+		return field.Name
+	}
+	return name
+}
+
+// fieldPath returns the dotted path of an invalid field without the model name, such as
+// eligibility_rules.0.value.
+func fieldPath(fieldErr validator.FieldError) string {
+	_, path, _ := strings.Cut(fieldErr.Namespace(), ".")
+	path = strings.NewReplacer("[", ".", "]", "").Replace(path)
+	return path
+}
@@ -0,0 +1,90 @@
+package api_tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"avitoTest/api/handlers/audit_handler"
+	"avitoTest/services/audit_service"
+	"avitoTest/services/audit_service/audit_models"
+	"avitoTest/shared/constants"
+	"avitoTest/shared/errors/audit_errors"
+	"avitoTest/shared/filter"
+	"avitoTest/shared/pagination"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func setupMocks() (*audit_service.MockAuditService, *audit_handler.AuditHandler) {
+	service := new(audit_service.MockAuditService)
+	return service, audit_handler.NewAuditHandler(service)
+}
+
+func TestGetAuditEvents(t *testing.T) {
+	service, handler := setupMocks()
+
+	orgID := 1
+	params := pagination.DefaultParams()
+	service.On("GetEvents", mock.Anything, filter.Filter{
+		{Field: constants.FilterFieldAction, Operator: filter.OpEq, Values: []any{"published"}},
+		{Field: constants.FilterFieldOrganizationID, Operator: filter.OpEq, Values: []any{1}},
+	}, params).Return(pagination.NewPage([]*audit_models.AuditEventModel{{
+		ID:             42,
+		EntityType:     "tender",
+		EntityID:       7,
+		OrganizationID: &orgID,
+		Action:         "published",
+		RequestID:      "req-1",
+		Before:         json.RawMessage(`{"status":"CREATED"}`),
+		After:          json.RawMessage(`{"status":"PUBLISHED"}`),
+		CreatedAt:      time.Date(2024, 9, 1, 12, 30, 0, 0, time.UTC),
+	}}, 1, params), nil)
+
+	rr := httptest.NewRecorder()
+	handler.GetAuditEvents(rr, httptest.NewRequest("GET", "/api/audit?organizationId=1&action=published", nil))
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.JSONEq(t, `{
+		"items": [{
+			"id": 42,
+			"entity_type": "tender",
+			"entity_id": 7,
+			"organization_id": 1,
+			"action": "published",
+			"request_id": "req-1",
+			"before": {"status": "CREATED"},
+			"after": {"status": "PUBLISHED"},
+			"created_at": "2024-09-01T12:30:00Z"
+		}],
+		"total": 1,
+		"limit": 20,
+		"offset": 0,
+		"next_offset": null
+	}`, rr.Body.String())
+	service.AssertExpectations(t)
+}
+
+func TestGetAuditEvents_InvalidFilter(t *testing.T) {
+	service, handler := setupMocks()
+
+	rr := httptest.NewRecorder()
+	handler.GetAuditEvents(rr, httptest.NewRequest("GET", "/api/audit?entityType=tenders", nil))
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	service.AssertNotCalled(t, "GetEvents", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestGetAuditEvents_NotOrganizationAdmin(t *testing.T) {
+	service, handler := setupMocks()
+
+	service.On("GetEvents", mock.Anything, mock.Anything, mock.Anything).Return(nil, audit_errors.ErrNotOrganizationAdmin)
+
+	rr := httptest.NewRecorder()
+	handler.GetAuditEvents(rr, httptest.NewRequest("GET", "/api/audit?organizationId=2", nil))
+
+	assert.Equal(t, http.StatusForbidden, rr.Code)
+}
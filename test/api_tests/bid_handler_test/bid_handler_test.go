@@ -5,8 +5,11 @@ import (
 	"avitoTest/api/handlers/bid_handler/bid_handler_models"
 	"avitoTest/services/bid_service"
 	"avitoTest/services/bid_service/bid_models"
+	"avitoTest/shared/errors/api_errors"
+	"avitoTest/shared/errors/bid_errors"
 	"bytes"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -68,6 +71,90 @@ func TestCreateBid_Success(t *testing.T) {
 	mockService.AssertExpectations(t)
 }
 
+// Test CreateBid endpoint when the bid is blocked by a conflict of interest
+func TestCreateBid_ConflictOfInterest(t *testing.T) {
+	handler, mockService := setupTestHandler()
+
+	reqBody := bid_handler_models.CreateBidRequest{
+		Name:           "New Bid",
+		Description:    "Description for new bid",
+		TenderID:       1,
+		OrganizationID: 1,
+		CreatorID:      1,
+		Status:         "CREATED",
+	}
+	reqBodyBytes, _ := json.Marshal(reqBody)
+
+	mockService.On("CreateBid", mock.Anything, mock.AnythingOfType("bid_models.BidCreateModel")).Return((*bid_models.BidModel)(nil), bid_errors.ErrSelfBid)
+
+	req := httptest.NewRequest("POST", "/bids", bytes.NewReader(reqBodyBytes))
+	req.Header.Set("Content-Type", "application/json")
+	rr := httptest.NewRecorder()
+
+	handler.CreateBid(rr, req)
+
+	assert.Equal(t, http.StatusConflict, rr.Code)
+	mockService.AssertExpectations(t)
+}
+
+// Test CreateBid endpoint when the organization fails the tender eligibility rules
+func TestCreateBid_NotEligible(t *testing.T) {
+	handler, mockService := setupTestHandler()
+
+	reqBodyBytes, _ := json.Marshal(bid_handler_models.CreateBidRequest{
+		Name:           "New Bid",
+		Description:    "Description for new bid",
+		TenderID:       1,
+		OrganizationID: 2,
+		CreatorID:      1,
+	})
+
+	eligibilityErr := &bid_service.EligibilityError{FailedRules: []bid_models.FailedEligibilityRule{
+		{RuleType: "ORGANIZATION_TYPE", Expected: "LLC", Actual: "IE", Message: "organization type must be one of LLC"},
+	}}
+	mockService.On("CreateBid", mock.Anything, mock.AnythingOfType("bid_models.BidCreateModel")).Return((*bid_models.BidModel)(nil), eligibilityErr)
+
+	req := httptest.NewRequest("POST", "/bids", bytes.NewReader(reqBodyBytes))
+	rr := httptest.NewRecorder()
+
+	handler.CreateBid(rr, req)
+
+	assert.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+
+	assert.Equal(t, api_errors.ProblemContentType, rr.Header().Get("Content-Type"))
+
+	var resp bid_handler_models.NotEligibleResponse
+	assert.NoError(t, json.NewDecoder(rr.Body).Decode(&resp))
+	assert.Equal(t, http.StatusUnprocessableEntity, resp.Status)
+	assert.Equal(t, bid_errors.ErrNotEligible.Error(), resp.Detail)
+	assert.Len(t, resp.FailedRules, 1)
+	assert.Equal(t, "IE", resp.FailedRules[0].Actual)
+	mockService.AssertExpectations(t)
+}
+
+// Test DeclareConflict endpoint
+func TestDeclareConflict_Success(t *testing.T) {
+	handler, mockService := setupTestHandler()
+
+	reqBodyBytes, _ := json.Marshal(bid_handler_models.DeclareConflictRequest{Reason: "Related party"})
+
+	expected := &bid_models.BidConflictModel{ID: 1, BidID: 1, UserID: 2, Reason: "Related party", CreatedAt: time.Now()}
+	mockService.On("DeclareConflict", mock.Anything, bid_models.BidConflictCreateModel{BidID: 1, UserID: 2, Reason: "Related party"}).Return(expected, nil)
+
+	req := httptest.NewRequest("POST", "/api/bids/1/conflicts/2/new", bytes.NewReader(reqBodyBytes))
+	req = mux.SetURLVars(req, map[string]string{"bidId": "1", "userId": "2"})
+	rr := httptest.NewRecorder()
+
+	handler.DeclareConflict(rr, req)
+
+	assert.Equal(t, http.StatusCreated, rr.Code)
+
+	var resp bid_handler_models.BidConflictResponse
+	assert.NoError(t, json.NewDecoder(rr.Body).Decode(&resp))
+	assert.Equal(t, expected.UserID, resp.UserID)
+	mockService.AssertExpectations(t)
+}
+
 // Test UpdateBid endpoint
 func TestUpdateBid_Success(t *testing.T) {
 	handler, mockService := setupTestHandler()
@@ -137,6 +224,68 @@ func TestGetBidByID_Success(t *testing.T) {
 	mockService.AssertExpectations(t)
 }
 
+// Test GetBidByID endpoint when the bid does not exist
+func TestGetBidByID_NotFound(t *testing.T) {
+	handler, mockService := setupTestHandler()
+
+	mockService.On("GetBidByID", mock.Anything, 42).Return((*bid_models.BidModel)(nil), bid_errors.ErrBidNotFound)
+
+	req := httptest.NewRequest("GET", "/api/bids/42", nil)
+	req = mux.SetURLVars(req, map[string]string{"bidId": "42"})
+	rr := httptest.NewRecorder()
+
+	handler.GetBidByID(rr, req)
+
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+	assert.Equal(t, api_errors.ProblemContentType, rr.Header().Get("Content-Type"))
+
+	var resp api_errors.ErrResponse
+	assert.NoError(t, json.NewDecoder(rr.Body).Decode(&resp))
+	assert.Equal(t, "about:blank", resp.Type)
+	assert.Equal(t, "Not Found", resp.Title)
+	assert.Equal(t, http.StatusNotFound, resp.Status)
+	assert.Equal(t, "bid not found", resp.Detail)
+	assert.Equal(t, "/api/bids/42", resp.Instance)
+	mockService.AssertExpectations(t)
+}
+
+// Test GetBidByID endpoint when the service fails, which must not leak the cause
+func TestGetBidByID_InternalError(t *testing.T) {
+	handler, mockService := setupTestHandler()
+
+	mockService.On("GetBidByID", mock.Anything, 1).Return((*bid_models.BidModel)(nil), errors.New("connection refused"))
+
+	req := httptest.NewRequest("GET", "/api/bids/1", nil)
+	req = mux.SetURLVars(req, map[string]string{"bidId": "1"})
+	rr := httptest.NewRecorder()
+
+	handler.GetBidByID(rr, req)
+
+	assert.Equal(t, http.StatusInternalServerError, rr.Code)
+
+	var resp api_errors.ErrResponse
+	assert.NoError(t, json.NewDecoder(rr.Body).Decode(&resp))
+	assert.Equal(t, http.StatusInternalServerError, resp.Status)
+	assert.Empty(t, resp.Detail)
+	mockService.AssertExpectations(t)
+}
+
+// Test ApproveBid endpoint when the approver is not responsible for the organization
+func TestApproveBid_NotResponsible(t *testing.T) {
+	handler, mockService := setupTestHandler()
+
+	mockService.On("ApproveBid", mock.Anything, 1, 2).Return(bid_errors.ErrNotResponsible)
+
+	req := httptest.NewRequest("POST", "/api/bids/1/approve/2", nil)
+	req = mux.SetURLVars(req, map[string]string{"bidId": "1", "approverId": "2"})
+	rr := httptest.NewRecorder()
+
+	handler.ApproveBid(rr, req)
+
+	assert.Equal(t, http.StatusForbidden, rr.Code)
+	mockService.AssertExpectations(t)
+}
+
 // Test DeleteBid endpoint
 func TestDeleteBid_Success(t *testing.T) {
 	handler, mockService := setupTestHandler()
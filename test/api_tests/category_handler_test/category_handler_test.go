@@ -0,0 +1,86 @@
+package category_handler_test
+
+import (
+	"avitoTest/api/handlers/category_handler"
+	"avitoTest/api/handlers/category_handler/category_handler_models"
+	"avitoTest/services/category_service"
+	"avitoTest/services/category_service/category_models"
+	"avitoTest/shared/errors/category_errors"
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func setupTestHandler() (*category_handler.CategoryHandler, *category_service.MockCategoryService) {
+	mockService := new(category_service.MockCategoryService)
+	handler := category_handler.NewCategoryHandler(mockService)
+	return handler, mockService
+}
+
+// Test CreateCategory endpoint
+func TestCreateCategory_Success(t *testing.T) {
+	handler, mockService := setupTestHandler()
+
+	reqBodyBytes, _ := json.Marshal(category_handler_models.CreateCategoryRequest{
+		Code:  "Software Development",
+		Names: map[string]string{"en": "Software Development"},
+	})
+
+	expected := &category_models.CategoryModel{ID: 4, Code: "Software Development", IsActive: true, Name: "Software Development"}
+	mockService.On("CreateCategory", mock.Anything, mock.AnythingOfType("category_models.CategoryCreateModel")).Return(expected, nil)
+
+	req := httptest.NewRequest("POST", "/api/categories/new", bytes.NewReader(reqBodyBytes))
+	rr := httptest.NewRecorder()
+
+	handler.CreateCategory(rr, req)
+
+	assert.Equal(t, http.StatusCreated, rr.Code)
+
+	var resp category_handler_models.CategoryResponse
+	assert.NoError(t, json.NewDecoder(rr.Body).Decode(&resp))
+	assert.Equal(t, expected.Code, resp.Code)
+	mockService.AssertExpectations(t)
+}
+
+// Test GetCategories endpoint with a requested language
+func TestGetCategories_Success(t *testing.T) {
+	handler, mockService := setupTestHandler()
+
+	mockService.On("GetCategories", mock.Anything, "en", true).Return([]*category_models.CategoryModel{
+		{ID: 1, Code: "Construction", IsActive: true, Name: "Construction"},
+	}, nil)
+
+	req := httptest.NewRequest("GET", "/api/categories/?lang=en&includeInactive=true", nil)
+	rr := httptest.NewRecorder()
+
+	handler.GetCategories(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	var resp []category_handler_models.CategoryResponse
+	assert.NoError(t, json.NewDecoder(rr.Body).Decode(&resp))
+	assert.Len(t, resp, 1)
+	mockService.AssertExpectations(t)
+}
+
+// Test DeleteCategory endpoint when the category still has subcategories
+func TestDeleteCategory_HasChildren(t *testing.T) {
+	handler, mockService := setupTestHandler()
+
+	mockService.On("DeleteCategory", mock.Anything, 2).Return(category_errors.ErrCategoryHasChildren)
+
+	req := httptest.NewRequest("DELETE", "/api/categories/2/delete", nil)
+	req = mux.SetURLVars(req, map[string]string{"categoryId": "2"})
+	rr := httptest.NewRecorder()
+
+	handler.DeleteCategory(rr, req)
+
+	assert.Equal(t, http.StatusConflict, rr.Code)
+	mockService.AssertExpectations(t)
+}
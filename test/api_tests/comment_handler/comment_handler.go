@@ -4,6 +4,7 @@ import (
 	"avitoTest/api/handlers/comment_handler"
 	"avitoTest/services/comment_service"
 	"avitoTest/services/comment_service/comment_models"
+	"avitoTest/shared/pagination"
 	"bytes"
 	"encoding/json"
 	"errors"
@@ -115,7 +116,8 @@ func TestGetReviews_Success(t *testing.T) {
 		},
 	}
 
-	mockService.On("GetCommentsByFilters", mock.Anything, "testuser", 1).Return(expectedComments, nil)
+	mockService.On("GetCommentsByFilters", mock.Anything, "testuser", 1, pagination.DefaultParams()).
+		Return(pagination.NewPage(expectedComments, 2, pagination.DefaultParams()), nil)
 
 	req := httptest.NewRequest(http.MethodGet, "/api/bids/1/reviews?authorUsername=testuser&organizationId=1", nil)
 	w := httptest.NewRecorder()
@@ -123,11 +125,12 @@ func TestGetReviews_Success(t *testing.T) {
 	handler.GetReviews(w, req)
 
 	assert.Equal(t, http.StatusOK, w.Code)
-	var response []*comment_models.CommentModel
+	var response pagination.Page[*comment_models.CommentModel]
 	err := json.NewDecoder(w.Body).Decode(&response)
 	assert.NoError(t, err)
-	assert.Len(t, response, 2)
-	assert.Equal(t, "First comment", response[0].Content)
+	assert.Len(t, response.Items, 2)
+	assert.Equal(t, int64(2), response.Total)
+	assert.Equal(t, "First comment", response.Items[0].Content)
 	mockService.AssertExpectations(t)
 }
 
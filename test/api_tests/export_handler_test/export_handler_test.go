@@ -0,0 +1,153 @@
+package api_tests
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"avitoTest/api/handlers/export_handler"
+	"avitoTest/data/repositories/organization_repository"
+	"avitoTest/services/bid_service"
+	"avitoTest/services/bid_service/bid_models"
+	"avitoTest/services/organization_service"
+	"avitoTest/services/organization_service/organization_models"
+	"avitoTest/services/tender_service"
+	"avitoTest/services/tender_service/tender_models"
+	"avitoTest/shared/constants"
+	"avitoTest/shared/errors/category_errors"
+	"avitoTest/shared/filter"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func setupMocks() (*organization_service.MockOrganizationService, *tender_service.MockTenderService, *bid_service.MockBidService, *export_handler.ExportHandler) {
+	orgService := new(organization_service.MockOrganizationService)
+	tenderService := new(tender_service.MockTenderService)
+	bidService := new(bid_service.MockBidService)
+	handler := export_handler.NewExportHandler(orgService, tenderService, bidService)
+	return orgService, tenderService, bidService, handler
+}
+
+func newExportRequest(target string) *http.Request {
+	req := httptest.NewRequest("GET", target, nil)
+	return mux.SetURLVars(req, map[string]string{"org_id": "1"})
+}
+
+func TestExportTenders_CSV(t *testing.T) {
+	orgService, tenderService, _, handler := setupMocks()
+
+	budget := 1500.5
+	createdAt := time.Date(2024, 9, 1, 12, 0, 0, 0, time.UTC)
+	orgService.On("GetOrganizationByID", mock.Anything, 1).Return(&organization_models.OrganizationModel{ID: 1}, nil)
+	tenderService.On("ExportTenders", mock.Anything, 1, filter.Filter{
+		{Field: constants.FilterFieldStatus, Operator: filter.OpEq, Values: []any{"PUBLISHED"}},
+	}).Return([]*tender_models.TenderModel{
+		{ID: 1, OrganizationID: 1, Name: "Road repair", Description: "Repair, then paint", ServiceType: "Construction", Budget: &budget, Status: constants.TenderStatusPublished, Version: 2, CreatedAt: createdAt},
+		{ID: 2, OrganizationID: 1, Name: "=HYPERLINK(\"x\")", ServiceType: "IT", Status: constants.TenderStatusPublished, Version: 1, CreatedAt: createdAt},
+	}, nil)
+
+	rr := httptest.NewRecorder()
+	handler.ExportTenders(rr, newExportRequest("/api/organizations/1/export/tenders?status=PUBLISHED"))
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "text/csv; charset=utf-8", rr.Header().Get("Content-Type"))
+	assert.Equal(t, `attachment; filename="organization-1-tenders.csv"`, rr.Header().Get("Content-Disposition"))
+
+	records, err := csv.NewReader(rr.Body).ReadAll()
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{
+		{"id", "organization_id", "name", "description", "service_type", "budget", "status", "version", "created_at"},
+		{"1", "1", "Road repair", "Repair, then paint", "Construction", "1500.50", "PUBLISHED", "2", "2024-09-01T12:00:00Z"},
+		{"2", "1", "'=HYPERLINK(\"x\")", "", "IT", "", "PUBLISHED", "1", "2024-09-01T12:00:00Z"},
+	}, records)
+	tenderService.AssertExpectations(t)
+}
+
+func TestExportBids_JSONL(t *testing.T) {
+	orgService, _, bidService, handler := setupMocks()
+
+	orgService.On("GetOrganizationByID", mock.Anything, 1).Return(&organization_models.OrganizationModel{ID: 1}, nil)
+	bidService.On("ExportBids", mock.Anything, 1, filter.Filter(nil)).Return([]*bid_models.BidModel{
+		{ID: 1, TenderID: 3, Name: "Bid 1", Status: "CREATED"},
+		{ID: 2, TenderID: 4, Name: "Bid 2", Status: "APPROVED"},
+	}, nil)
+
+	rr := httptest.NewRecorder()
+	handler.ExportBids(rr, newExportRequest("/api/organizations/1/export/bids?format=jsonl"))
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "application/x-ndjson", rr.Header().Get("Content-Type"))
+
+	lines := strings.Split(strings.TrimSpace(rr.Body.String()), "\n")
+	assert.Len(t, lines, 2)
+	var bid bid_models.BidModel
+	assert.NoError(t, json.Unmarshal([]byte(lines[1]), &bid))
+	assert.Equal(t, 2, bid.ID)
+	assert.Equal(t, "APPROVED", bid.Status)
+}
+
+func TestExportDecisions_EmptyCSVHasHeader(t *testing.T) {
+	orgService, _, bidService, handler := setupMocks()
+
+	orgService.On("GetOrganizationByID", mock.Anything, 1).Return(&organization_models.OrganizationModel{ID: 1}, nil)
+	bidService.On("ExportDecisions", mock.Anything, 1, filter.Filter(nil)).Return([]*bid_models.BidDecisionModel{}, nil)
+
+	rr := httptest.NewRecorder()
+	handler.ExportDecisions(rr, newExportRequest("/api/organizations/1/export/decisions"))
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "id,bid_id,tender_id,user_id,decision,created_at\n", rr.Body.String())
+}
+
+func TestExport_InvalidRequest(t *testing.T) {
+	tests := []struct {
+		name   string
+		target string
+	}{
+		{"unsupported format", "/api/organizations/1/export/tenders?format=xlsx"},
+		{"invalid filter", "/api/organizations/1/export/tenders?budget[gte]=lots"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, tenderService, _, handler := setupMocks()
+
+			rr := httptest.NewRecorder()
+			handler.ExportTenders(rr, newExportRequest(tt.target))
+
+			assert.Equal(t, http.StatusBadRequest, rr.Code)
+			tenderService.AssertNotCalled(t, "ExportTenders")
+		})
+	}
+}
+
+func TestExport_OrganizationNotFound(t *testing.T) {
+	orgService, tenderService, _, handler := setupMocks()
+
+	orgService.On("GetOrganizationByID", mock.Anything, 1).Return((*organization_models.OrganizationModel)(nil), organization_repository.ErrOrganizationNotFound)
+
+	rr := httptest.NewRecorder()
+	handler.ExportTenders(rr, newExportRequest("/api/organizations/1/export/tenders"))
+
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+	tenderService.AssertNotCalled(t, "ExportTenders")
+}
+
+func TestExport_ErrorBeforeFirstRecord(t *testing.T) {
+	orgService, tenderService, _, handler := setupMocks()
+
+	orgService.On("GetOrganizationByID", mock.Anything, 1).Return(&organization_models.OrganizationModel{ID: 1}, nil)
+	tenderService.On("ExportTenders", mock.Anything, 1, mock.Anything).Return(nil, category_errors.ErrInvalidServiceType)
+
+	rr := httptest.NewRecorder()
+	handler.ExportTenders(rr, newExportRequest("/api/organizations/1/export/tenders?category=unknown"))
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	assert.Empty(t, rr.Header().Get("Content-Disposition"))
+}
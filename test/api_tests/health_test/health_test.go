@@ -0,0 +1,135 @@
+package api_tests
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"avitoTest/api"
+	"avitoTest/shared/health"
+	"avitoTest/shared/scheduler"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupRouter() *mux.Router {
+	router := mux.NewRouter()
+	api.InitRoutes(router, api.Services{})
+	return router
+}
+
+// registerChecks registers checks for the duration of a test.
+func registerChecks(t *testing.T, checks map[string]health.Check) {
+	for name, check := range checks {
+		health.Register(name, check)
+		t.Cleanup(func() { health.Unregister(name) })
+	}
+}
+
+func get(t *testing.T, path string) (*httptest.ResponseRecorder, health.Report) {
+	rr := httptest.NewRecorder()
+	setupRouter().ServeHTTP(rr, httptest.NewRequest("GET", path, nil))
+
+	var report health.Report
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&report))
+	return rr, report
+}
+
+func up(context.Context) (any, error) { return nil, nil }
+
+func TestLiveness_IgnoresDependencies(t *testing.T) {
+	registerChecks(t, map[string]health.Check{
+		"database": func(context.Context) (any, error) { return nil, errors.New("connection refused") },
+	})
+
+	rr, report := get(t, "/api/health/live")
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, health.StatusUp, report.Status)
+}
+
+func TestReadiness_AllComponentsUp(t *testing.T) {
+	registerChecks(t, map[string]health.Check{
+		"database":   func(context.Context) (any, error) { return map[string]int{"open_connections": 2}, nil },
+		"migrations": up,
+	})
+
+	rr, report := get(t, "/api/health/ready")
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "application/json", rr.Header().Get("Content-Type"))
+	assert.Equal(t, health.StatusUp, report.Status)
+	require.Contains(t, report.Components, "database")
+	assert.Equal(t, health.StatusUp, report.Components["database"].Status)
+	assert.Equal(t, map[string]any{"open_connections": float64(2)}, report.Components["database"].Details)
+	assert.Equal(t, health.StatusUp, report.Components["migrations"].Status)
+}
+
+func TestReadiness_ComponentDown(t *testing.T) {
+	registerChecks(t, map[string]health.Check{
+		"database":   func(context.Context) (any, error) { return nil, errors.New("connection refused") },
+		"migrations": up,
+	})
+
+	rr, report := get(t, "/api/health/ready")
+
+	assert.Equal(t, http.StatusServiceUnavailable, rr.Code)
+	assert.Equal(t, health.StatusDown, report.Status)
+	assert.Equal(t, health.StatusDown, report.Components["database"].Status)
+	assert.Equal(t, "connection refused", report.Components["database"].Error)
+	assert.Equal(t, health.StatusUp, report.Components["migrations"].Status)
+}
+
+func TestReadiness_Scheduler(t *testing.T) {
+	jobs := scheduler.New()
+	ran := make(chan struct{}, 1)
+	jobs.Add(scheduler.Job{Name: "purge", Interval: 10 * time.Millisecond, Run: func(context.Context) error {
+		select {
+		case ran <- struct{}{}:
+		default:
+		}
+		return nil
+	}})
+	registerChecks(t, map[string]health.Check{"scheduler": jobs.Check})
+
+	rr, report := get(t, "/api/health/ready")
+	assert.Equal(t, http.StatusServiceUnavailable, rr.Code)
+	assert.Equal(t, "scheduler is not running", report.Components["scheduler"].Error)
+
+	jobs.Start()
+	t.Cleanup(func() { jobs.Stop(context.Background()) })
+	<-ran
+
+	rr, report = get(t, "/api/health/ready")
+	assert.Equal(t, http.StatusOK, rr.Code)
+	statuses, _ := report.Components["scheduler"].Details.([]any)
+	require.Len(t, statuses, 1)
+	assert.Equal(t, "purge", statuses[0].(map[string]any)["name"])
+	assert.Equal(t, true, statuses[0].(map[string]any)["healthy"])
+}
+
+func TestReadiness_FailingJob(t *testing.T) {
+	jobs := scheduler.New()
+	jobs.Add(scheduler.Job{Name: "purge", Interval: time.Millisecond, Run: func(context.Context) error {
+		return errors.New("backend unavailable")
+	}})
+	registerChecks(t, map[string]health.Check{"scheduler": jobs.Check})
+
+	jobs.Start()
+	t.Cleanup(func() { jobs.Stop(context.Background()) })
+
+	require.Eventually(t, func() bool {
+		_, report := get(t, "/api/health/ready")
+		return report.Status == health.StatusDown
+	}, time.Second, 5*time.Millisecond)
+
+	_, report := get(t, "/api/health/ready")
+	statuses := report.Components["scheduler"].Details.([]any)
+	assert.Equal(t, "backend unavailable", statuses[0].(map[string]any)["last_error"])
+}
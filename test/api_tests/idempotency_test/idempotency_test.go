@@ -0,0 +1,254 @@
+package api_tests
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"avitoTest/api/middlewares"
+	"avitoTest/data/entities"
+	"avitoTest/services/idempotency_service"
+	"avitoTest/services/idempotency_service/idempotency_models"
+	"avitoTest/shared/auth"
+	"avitoTest/shared/errors/idempotency_errors"
+	"avitoTest/shared/ratelimit"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryRepository keeps the idempotency keys in memory, like the table does.
+type memoryRepository struct {
+	mu   sync.Mutex
+	keys map[[2]string]*entities.IdempotencyKey
+}
+
+func newMemoryRepository() *memoryRepository {
+	return &memoryRepository{keys: map[[2]string]*entities.IdempotencyKey{}}
+}
+
+func (m *memoryRepository) Claim(_ context.Context, record *entities.IdempotencyKey) (*entities.IdempotencyKey, bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	id := [2]string{record.Scope, record.Key}
+	existing, ok := m.keys[id]
+	if ok && existing.ExpiresAt.After(record.CreatedAt) && (existing.Completed || existing.LockedUntil.After(record.CreatedAt)) {
+		copied := *existing
+		return &copied, false, nil
+	}
+	copied := *record
+	m.keys[id] = &copied
+	return nil, true, nil
+}
+
+func (m *memoryRepository) Complete(_ context.Context, scope, key, claim string, status int, header string, body []byte) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	record, ok := m.keys[[2]string{scope, key}]
+	if !ok || record.Claim != claim || record.Completed {
+		return idempotency_errors.ErrClaimLost
+	}
+	record.Completed, record.Status, record.Header, record.Body = true, status, header, body
+	return nil
+}
+
+func (m *memoryRepository) Release(_ context.Context, scope, key, claim string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if record, ok := m.keys[[2]string{scope, key}]; ok && record.Claim == claim && !record.Completed {
+		delete(m.keys, [2]string{scope, key})
+	}
+	return nil
+}
+
+func (m *memoryRepository) DeleteExpired(_ context.Context, now time.Time) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var deleted int64
+	for id, record := range m.keys {
+		if !record.ExpiresAt.After(now) {
+			delete(m.keys, id)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+// setupRouter routes POST /api/tenders/new to a handler creating numbered tenders. The
+// handler answers with status when it is set, and waits for release when it is not nil.
+func setupRouter(ttl time.Duration) (*mux.Router, *int, *int, *chan struct{}) {
+	service := idempotency_service.NewIdempotencyService(newMemoryRepository(), ttl, time.Minute)
+	created := 0
+	status := 0
+	var release chan struct{}
+
+	router := mux.NewRouter()
+	router.Use(middlewares.IdempotencyMiddleware(service))
+	router.HandleFunc("/api/tenders/new", func(w http.ResponseWriter, r *http.Request) {
+		if release != nil {
+			<-release
+		}
+		body, _ := io.ReadAll(r.Body)
+		if status != 0 {
+			w.WriteHeader(status)
+			return
+		}
+		created++
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Location", "/api/tenders/"+strconv.Itoa(created))
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"id":` + strconv.Itoa(created) + `,"request":` + string(body) + `}`))
+	}).Methods("POST")
+	router.HandleFunc("/api/tenders/", func(w http.ResponseWriter, r *http.Request) {}).Methods("GET")
+	return router, &created, &status, &release
+}
+
+func post(router http.Handler, key, body string, headers ...string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest("POST", "/api/tenders/new", strings.NewReader(body))
+	if key != "" {
+		req.Header.Set(middlewares.IdempotencyKeyHeader, key)
+	}
+	for i := 0; i+1 < len(headers); i += 2 {
+		req.Header.Set(headers[i], headers[i+1])
+	}
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+	return rr
+}
+
+func TestIdempotency_RetryReplaysResponse(t *testing.T) {
+	router, created, _, _ := setupRouter(time.Hour)
+
+	first := post(router, "key-1", `{"name":"Tender"}`)
+	require.Equal(t, http.StatusCreated, first.Code)
+	assert.Empty(t, first.Header().Get(middlewares.IdempotentReplayedHeader))
+
+	retry := post(router, "key-1", `{"name":"Tender"}`)
+	assert.Equal(t, http.StatusCreated, retry.Code)
+	assert.Equal(t, "true", retry.Header().Get(middlewares.IdempotentReplayedHeader))
+	assert.Equal(t, "/api/tenders/1", retry.Header().Get("Location"))
+	assert.Equal(t, "application/json", retry.Header().Get("Content-Type"))
+	assert.Equal(t, first.Body.String(), retry.Body.String())
+	assert.Equal(t, 1, *created)
+
+	// Another key creates another tender
+	assert.Equal(t, http.StatusCreated, post(router, "key-2", `{"name":"Tender"}`).Code)
+	assert.Equal(t, 2, *created)
+}
+
+func TestIdempotency_WithoutKeyEveryRequestRuns(t *testing.T) {
+	router, created, _, _ := setupRouter(time.Hour)
+
+	post(router, "", `{"name":"Tender"}`)
+	post(router, "", `{"name":"Tender"}`)
+
+	assert.Equal(t, 2, *created)
+}
+
+func TestIdempotency_KeyReusedForDifferentPayload(t *testing.T) {
+	router, created, _, _ := setupRouter(time.Hour)
+
+	post(router, "key-1", `{"name":"Tender"}`)
+	rr := post(router, "key-1", `{"name":"Other tender"}`)
+
+	assert.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+	assert.Contains(t, rr.Body.String(), "different request")
+	assert.Equal(t, 1, *created)
+}
+
+func TestIdempotency_KeysAreScopedToTheCaller(t *testing.T) {
+	router, created, _, _ := setupRouter(time.Hour)
+	handler := middlewares.AuthenticationMiddleware(auth.NewAuthenticator(map[string]string{"erp": "erp-key", "crm": "crm-key"}),
+		ratelimit.NewLimiter(ratelimit.NewMemoryStore(), nil))(router)
+
+	post(handler, "key-1", `{"name":"Tender"}`, auth.APIKeyHeader, "erp-key")
+	rr := post(handler, "key-1", `{"name":"Tender"}`, auth.APIKeyHeader, "crm-key")
+
+	assert.Equal(t, http.StatusCreated, rr.Code)
+	assert.Empty(t, rr.Header().Get(middlewares.IdempotentReplayedHeader))
+	assert.Equal(t, 2, *created)
+}
+
+func TestIdempotency_RetryWhileInProgress(t *testing.T) {
+	router, created, _, release := setupRouter(time.Hour)
+	*release = make(chan struct{})
+
+	done := make(chan *httptest.ResponseRecorder)
+	go func() { done <- post(router, "key-1", `{"name":"Tender"}`) }()
+
+	// Wait for the first request to claim the key
+	var rr *httptest.ResponseRecorder
+	require.Eventually(t, func() bool {
+		rr = post(router, "key-1", `{"name":"Tender"}`)
+		return rr.Code == http.StatusConflict
+	}, time.Second, time.Millisecond)
+	assert.Contains(t, rr.Body.String(), "in progress")
+
+	close(*release)
+	assert.Equal(t, http.StatusCreated, (<-done).Code)
+	assert.Equal(t, 1, *created)
+}
+
+func TestIdempotency_ServerErrorsAreNotStored(t *testing.T) {
+	router, created, status, _ := setupRouter(time.Hour)
+
+	*status = http.StatusServiceUnavailable
+	assert.Equal(t, http.StatusServiceUnavailable, post(router, "key-1", `{"name":"Tender"}`).Code)
+
+	*status = 0
+	rr := post(router, "key-1", `{"name":"Tender"}`)
+	assert.Equal(t, http.StatusCreated, rr.Code)
+	assert.Empty(t, rr.Header().Get(middlewares.IdempotentReplayedHeader))
+	assert.Equal(t, 1, *created)
+}
+
+func TestIdempotency_ExpiredKeyRunsAgain(t *testing.T) {
+	router, created, _, _ := setupRouter(time.Millisecond)
+
+	post(router, "key-1", `{"name":"Tender"}`)
+	time.Sleep(5 * time.Millisecond)
+	rr := post(router, "key-1", `{"name":"Tender"}`)
+
+	assert.Equal(t, http.StatusCreated, rr.Code)
+	assert.Equal(t, 2, *created)
+}
+
+func TestIdempotency_InvalidKey(t *testing.T) {
+	router, created, _, _ := setupRouter(time.Hour)
+
+	rr := post(router, strings.Repeat("k", 256), `{"name":"Tender"}`)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	assert.Zero(t, *created)
+}
+
+func TestIdempotency_AbandonedRequestCannotComplete(t *testing.T) {
+	// Every request is abandoned as soon as it has claimed its key
+	service := idempotency_service.NewIdempotencyService(newMemoryRepository(), time.Hour, -time.Second)
+	ctx := context.Background()
+
+	_, abandoned, err := service.Begin(ctx, "ip:1", "key-1", "abc")
+	require.NoError(t, err)
+	_, retry, err := service.Begin(ctx, "ip:1", "key-1", "abc")
+	require.NoError(t, err)
+	require.NoError(t, service.Complete(ctx, "ip:1", "key-1", retry, idempotency_models.StoredResponse{Status: http.StatusCreated}))
+
+	err = service.Complete(ctx, "ip:1", "key-1", abandoned, idempotency_models.StoredResponse{Status: http.StatusOK})
+	assert.ErrorIs(t, err, idempotency_errors.ErrClaimLost)
+	require.NoError(t, service.Release(ctx, "ip:1", "key-1", abandoned))
+
+	stored, _, err := service.Begin(ctx, "ip:1", "key-1", "abc")
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusCreated, stored.Status)
+}
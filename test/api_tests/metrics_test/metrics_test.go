@@ -0,0 +1,63 @@
+package api_tests
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"avitoTest/api"
+	"avitoTest/services/tender_service"
+	"avitoTest/services/tender_service/tender_models"
+	"avitoTest/shared/constants"
+	"avitoTest/shared/errors/tendert_erorrs"
+	"avitoTest/shared/metrics"
+
+	"github.com/gorilla/mux"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func setupRouter() (*mux.Router, *tender_service.MockTenderService) {
+	tenderService := new(tender_service.MockTenderService)
+
+	router := mux.NewRouter()
+	api.InitRoutes(router, api.Services{Tenders: tenderService})
+	return router, tenderService
+}
+
+func TestMetrics_CountsRequestsByRoute(t *testing.T) {
+	router, tenderService := setupRouter()
+	requests := func(status string) float64 {
+		return testutil.ToFloat64(metrics.HTTPRequests.WithLabelValues("/api/tenders/{tenderId}", "GET", status))
+	}
+	ok, notFound := requests("2xx"), requests("4xx")
+
+	tenderService.On("GetTenderByID", mock.Anything, 1).Return(&tender_models.TenderModel{
+		ID: 1, Name: "Tender 1", Status: constants.TenderStatusCreated, CreatedAt: time.Now(),
+	}, nil)
+	tenderService.On("GetTenderByID", mock.Anything, 2).Return((*tender_models.TenderModel)(nil), tendert_erorrs.ErrTenderNotFound)
+
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/api/tenders/1", nil))
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/api/tenders/2", nil))
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/api/tenders/abc", nil))
+
+	assert.Equal(t, ok+1, requests("2xx"))
+	assert.Equal(t, notFound+2, requests("4xx"))
+}
+
+func TestMetrics_Endpoint(t *testing.T) {
+	router, _ := setupRouter()
+
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/api/ping", nil))
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, httptest.NewRequest("GET", "/metrics", nil))
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Contains(t, rr.Header().Get("Content-Type"), "text/plain")
+	body := rr.Body.String()
+	assert.Contains(t, body, `tender_service_http_requests_total{method="GET",route="/api/ping",status="2xx"}`)
+	assert.Contains(t, body, `tender_service_http_request_duration_seconds_bucket{method="GET",route="/api/ping"`)
+	assert.Contains(t, body, "go_goroutines")
+}
@@ -0,0 +1,216 @@
+package api_tests
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"avitoTest/api/middlewares"
+	"avitoTest/shared/errors/api_errors"
+	"avitoTest/shared/requestid"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequestID_Generated(t *testing.T) {
+	var seen string
+	handler := middlewares.RequestIDMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seen = requestid.FromContext(r.Context())
+	}))
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, httptest.NewRequest("GET", "/api/ping", nil))
+
+	assert.Len(t, seen, 32)
+	assert.Equal(t, seen, rr.Header().Get(requestid.Header))
+}
+
+func TestRequestID_Propagated(t *testing.T) {
+	var seen string
+	handler := middlewares.RequestIDMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seen = requestid.FromContext(r.Context())
+	}))
+
+	req := httptest.NewRequest("GET", "/api/ping", nil)
+	req.Header.Set(requestid.Header, "client-id.42")
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+
+	assert.Equal(t, "client-id.42", seen)
+	assert.Equal(t, "client-id.42", rr.Header().Get(requestid.Header))
+
+	req.Header.Set(requestid.Header, "not valid\n")
+	rr = httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+
+	assert.NotEqual(t, "not valid\n", seen)
+	assert.Equal(t, seen, rr.Header().Get(requestid.Header))
+}
+
+func TestRecovery_Panic(t *testing.T) {
+	handler := middlewares.Chain(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic("boom")
+	}), middlewares.RequestIDMiddleware, middlewares.AccessLogMiddleware, middlewares.RecoveryMiddleware)
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, httptest.NewRequest("GET", "/api/tenders/1", nil))
+
+	assert.Equal(t, http.StatusInternalServerError, rr.Code)
+	assert.Equal(t, api_errors.ProblemContentType, rr.Header().Get("Content-Type"))
+	var resp api_errors.ErrResponse
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&resp))
+	assert.Equal(t, http.StatusInternalServerError, resp.Status)
+	assert.Empty(t, resp.Detail)
+	assert.Equal(t, "/api/tenders/1", resp.Instance)
+}
+
+func TestRecovery_AbortHandlerIsPassedOn(t *testing.T) {
+	handler := middlewares.RecoveryMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("partial"))
+		panic(http.ErrAbortHandler)
+	}))
+
+	assert.PanicsWithValue(t, http.ErrAbortHandler, func() {
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/api/organizations/1/export/tenders", nil))
+	})
+}
+
+func TestRecovery_FlushesThroughRecorder(t *testing.T) {
+	handler := middlewares.Chain(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("row"))
+		w.(http.Flusher).Flush()
+	}), middlewares.AccessLogMiddleware, middlewares.RecoveryMiddleware)
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, httptest.NewRequest("GET", "/api/organizations/1/export/tenders", nil))
+
+	assert.True(t, rr.Flushed)
+	assert.Equal(t, "row", rr.Body.String())
+}
+
+func newCORSHandler(origins ...string) http.Handler {
+	router := mux.NewRouter()
+	router.HandleFunc("/api/ping", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("ok"))
+	}).Methods("GET")
+
+	return middlewares.CORSMiddleware(middlewares.CORSConfig{
+		AllowedOrigins: origins,
+		AllowedMethods: []string{"GET", "POST"},
+		AllowedHeaders: []string{"Content-Type"},
+		ExposedHeaders: []string{requestid.Header},
+		MaxAge:         time.Minute,
+	})(router)
+}
+
+func TestCORS_Preflight(t *testing.T) {
+	handler := newCORSHandler("https://app.example.com")
+
+	req := httptest.NewRequest("OPTIONS", "/api/ping", nil)
+	req.Header.Set("Origin", "https://app.example.com")
+	req.Header.Set("Access-Control-Request-Method", "GET")
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusNoContent, rr.Code)
+	assert.Equal(t, "https://app.example.com", rr.Header().Get("Access-Control-Allow-Origin"))
+	assert.Equal(t, "GET, POST", rr.Header().Get("Access-Control-Allow-Methods"))
+	assert.Equal(t, "Content-Type", rr.Header().Get("Access-Control-Allow-Headers"))
+	assert.Equal(t, "60", rr.Header().Get("Access-Control-Max-Age"))
+}
+
+func TestCORS_AllowedOrigin(t *testing.T) {
+	handler := newCORSHandler("*")
+
+	req := httptest.NewRequest("GET", "/api/ping", nil)
+	req.Header.Set("Origin", "https://other.example.com")
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "*", rr.Header().Get("Access-Control-Allow-Origin"))
+	assert.Equal(t, requestid.Header, rr.Header().Get("Access-Control-Expose-Headers"))
+}
+
+func TestCORS_DisallowedOrigin(t *testing.T) {
+	handler := newCORSHandler("https://app.example.com")
+
+	req := httptest.NewRequest("GET", "/api/ping", nil)
+	req.Header.Set("Origin", "https://evil.example.com")
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Empty(t, rr.Header().Get("Access-Control-Allow-Origin"))
+}
+
+func newLimitedRouter(handler http.HandlerFunc) *mux.Router {
+	router := mux.NewRouter()
+	router.Use(middlewares.TimeoutMiddleware(time.Second, map[string]time.Duration{"/api/slow/{id}": 0}))
+	router.Use(middlewares.BodyLimitMiddleware(8, map[string]int64{"/api/slow/{id}": 0}))
+	router.HandleFunc("/api/fast/{id}", handler).Methods("POST")
+	router.HandleFunc("/api/slow/{id}", handler).Methods("POST")
+	return router
+}
+
+func TestBodyLimit(t *testing.T) {
+	router := newLimitedRouter(func(w http.ResponseWriter, r *http.Request) {
+		if _, err := io.ReadAll(r.Body); err != nil {
+			api_errors.WriteProblem(w, r, api_errors.ErrInvalidRequest(err))
+		}
+	})
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, httptest.NewRequest("POST", "/api/fast/1", strings.NewReader("0123456789")))
+	assert.Equal(t, http.StatusRequestEntityTooLarge, rr.Code)
+
+	rr = httptest.NewRecorder()
+	router.ServeHTTP(rr, httptest.NewRequest("POST", "/api/fast/1", strings.NewReader("01234567")))
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	rr = httptest.NewRecorder()
+	router.ServeHTTP(rr, httptest.NewRequest("POST", "/api/slow/1", strings.NewReader("0123456789")))
+	assert.Equal(t, http.StatusOK, rr.Code)
+}
+
+func TestTimeout(t *testing.T) {
+	var deadlines []bool
+	router := newLimitedRouter(func(w http.ResponseWriter, r *http.Request) {
+		_, ok := r.Context().Deadline()
+		deadlines = append(deadlines, ok)
+	})
+
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("POST", "/api/fast/1", nil))
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("POST", "/api/slow/1", nil))
+
+	assert.Equal(t, []bool{true, false}, deadlines)
+}
+
+func TestTimeout_RouteOutlivesServerWriteTimeout(t *testing.T) {
+	router := newLimitedRouter(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(200 * time.Millisecond)
+		w.Write([]byte("done"))
+	})
+	server := httptest.NewUnstartedServer(router)
+	server.Config.WriteTimeout = 50 * time.Millisecond
+	server.Start()
+	defer server.Close()
+
+	// The server drops the response of a route bounded by its write timeout
+	_, err := http.Post(server.URL+"/api/fast/1", "text/plain", nil)
+	assert.Error(t, err)
+
+	// A route allowed more time moves the deadline of its connection
+	resp, err := http.Post(server.URL+"/api/slow/1", "text/plain", nil)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Equal(t, "done", string(body))
+}
@@ -0,0 +1,93 @@
+package api_tests
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"avitoTest/api/handlers/notification_handler"
+	"avitoTest/services/notification_service"
+	"avitoTest/services/notification_service/notification_models"
+	"avitoTest/shared/errors/notification_errors"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func setupMocks() (*notification_service.MockNotificationService, *notification_handler.NotificationHandler) {
+	service := new(notification_service.MockNotificationService)
+	return service, notification_handler.NewNotificationHandler(service)
+}
+
+func TestGetPreferences(t *testing.T) {
+	service, handler := setupMocks()
+
+	service.On("GetPreferences", mock.Anything, 3).Return(&notification_models.NotificationPreferencesModel{UserID: 3}, nil)
+
+	req := httptest.NewRequest("GET", "/api/users/3/notifications/preferences", nil)
+	req = mux.SetURLVars(req, map[string]string{"user_id": "3"})
+	rr := httptest.NewRecorder()
+	handler.GetPreferences(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.JSONEq(t, `{"user_id": 3, "email": "", "event_types": []}`, rr.Body.String())
+}
+
+func TestUpdatePreferences(t *testing.T) {
+	service, handler := setupMocks()
+
+	updatedAt := time.Date(2024, 9, 1, 12, 30, 0, 0, time.UTC)
+	service.On("UpdatePreferences", mock.Anything, notification_models.NotificationPreferencesUpdateModel{
+		UserID:     3,
+		Email:      "anna@example.com",
+		EventTypes: []string{"bid.rejected"},
+	}).Return(&notification_models.NotificationPreferencesModel{
+		UserID:     3,
+		Email:      "anna@example.com",
+		EventTypes: []string{"bid.rejected"},
+		UpdatedAt:  &updatedAt,
+	}, nil)
+
+	body := []byte(`{"email": "anna@example.com", "event_types": ["bid.rejected"]}`)
+	req := httptest.NewRequest("PUT", "/api/users/3/notifications/preferences", bytes.NewReader(body))
+	req = mux.SetURLVars(req, map[string]string{"user_id": "3"})
+	rr := httptest.NewRecorder()
+	handler.UpdatePreferences(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.JSONEq(t, `{
+		"user_id": 3,
+		"email": "anna@example.com",
+		"event_types": ["bid.rejected"],
+		"updated_at": "2024-09-01T12:30:00Z"
+	}`, rr.Body.String())
+	service.AssertExpectations(t)
+}
+
+func TestUpdatePreferences_NotOwnPreferences(t *testing.T) {
+	service, handler := setupMocks()
+
+	service.On("UpdatePreferences", mock.Anything, mock.Anything).Return(nil, notification_errors.ErrNotOwnPreferences)
+
+	req := httptest.NewRequest("PUT", "/api/users/3/notifications/preferences", bytes.NewReader([]byte(`{"event_types": []}`)))
+	req = mux.SetURLVars(req, map[string]string{"user_id": "3"})
+	rr := httptest.NewRecorder()
+	handler.UpdatePreferences(rr, req)
+
+	assert.Equal(t, http.StatusForbidden, rr.Code)
+}
+
+func TestGetPreferences_InvalidID(t *testing.T) {
+	service, handler := setupMocks()
+
+	req := httptest.NewRequest("GET", "/api/users/abc/notifications/preferences", nil)
+	req = mux.SetURLVars(req, map[string]string{"user_id": "abc"})
+	rr := httptest.NewRecorder()
+	handler.GetPreferences(rr, req)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	service.AssertNotCalled(t, "GetPreferences", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,200 @@
+package api_tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"avitoTest/api"
+	"avitoTest/api/openapi"
+	"avitoTest/services/tender_service"
+	"avitoTest/services/tender_service/tender_models"
+	"avitoTest/services/user_service"
+	"avitoTest/shared/constants"
+	"avitoTest/shared/errors/api_errors"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func setupRouter() (*mux.Router, *tender_service.MockTenderService, *user_service.MockUserService) {
+	tenderService := new(tender_service.MockTenderService)
+	userService := new(user_service.MockUserService)
+
+	router := mux.NewRouter()
+	api.InitRoutes(router, api.Services{Users: userService, Tenders: tenderService})
+	return router, tenderService, userService
+}
+
+func decodeValidationError(t *testing.T, rr *httptest.ResponseRecorder) api_errors.ErrResponse {
+	var resp api_errors.ErrResponse
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&resp))
+	return resp
+}
+
+func TestSpecIsValid(t *testing.T) {
+	_, err := openapi.Load()
+	assert.NoError(t, err)
+}
+
+func TestSpecDescribesEveryRoute(t *testing.T) {
+	doc, err := openapi.Load()
+	require.NoError(t, err)
+	router, _, _ := setupRouter()
+
+	registered := map[string]bool{}
+	err = router.Walk(func(route *mux.Route, _ *mux.Router, _ []*mux.Route) error {
+		path, err := route.GetPathTemplate()
+		if err != nil {
+			return err
+		}
+		methods, err := route.GetMethods()
+		if err != nil {
+			return err
+		}
+		for _, method := range methods {
+			registered[method+" "+path] = true
+			pathItem := doc.Paths.Value(path)
+			if assert.NotNil(t, pathItem, "path %s is not in the specification", path) {
+				assert.NotNil(t, pathItem.GetOperation(method), "%s %s is not in the specification", method, path)
+			}
+		}
+		return nil
+	})
+	require.NoError(t, err)
+
+	for path, pathItem := range doc.Paths.Map() {
+		for method := range pathItem.Operations() {
+			assert.True(t, registered[method+" "+path], "%s %s is documented but not routed", method, path)
+		}
+	}
+}
+
+func TestGetSpec(t *testing.T) {
+	router, _, _ := setupRouter()
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, httptest.NewRequest("GET", "/api/openapi.json", nil))
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "application/json", rr.Header().Get("Content-Type"))
+
+	var spec map[string]any
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&spec))
+	assert.Equal(t, "3.0.3", spec["openapi"])
+	assert.Contains(t, spec["paths"], "/api/tenders/{tenderId}")
+}
+
+func TestValidation_ValidRequestReachesHandler(t *testing.T) {
+	router, tenderService, _ := setupRouter()
+
+	tenderService.On("GetTenderByID", mock.Anything, 1).Return(&tender_models.TenderModel{
+		ID: 1, Name: "Tender 1", Status: constants.TenderStatusCreated, CreatedAt: time.Now(),
+	}, nil)
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, httptest.NewRequest("GET", "/api/tenders/1", nil))
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	tenderService.AssertExpectations(t)
+}
+
+func TestValidation_InvalidParameters(t *testing.T) {
+	router, tenderService, _ := setupRouter()
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, httptest.NewRequest("GET", "/api/tenders/?limit=0&status=OPEN", nil))
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	assert.Equal(t, api_errors.ProblemContentType, rr.Header().Get("Content-Type"))
+	resp := decodeValidationError(t, rr)
+	assert.ElementsMatch(t, []string{"query limit", "query status"}, []string{
+		resp.Errors[0].In + " " + resp.Errors[0].Field,
+		resp.Errors[1].In + " " + resp.Errors[1].Field,
+	})
+	tenderService.AssertNotCalled(t, "GetAllTenders", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestValidation_InvalidPathParameter(t *testing.T) {
+	router, tenderService, _ := setupRouter()
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, httptest.NewRequest("GET", "/api/tenders/abc", nil))
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	resp := decodeValidationError(t, rr)
+	require.Len(t, resp.Errors, 1)
+	assert.Equal(t, "path", resp.Errors[0].In)
+	assert.Equal(t, "tenderId", resp.Errors[0].Field)
+	tenderService.AssertNotCalled(t, "GetTenderByID", mock.Anything, mock.Anything)
+}
+
+func TestValidation_InvalidBody(t *testing.T) {
+	router, _, userService := setupRouter()
+
+	body := `{"username": "jo", "first_name": 5}`
+	req := httptest.NewRequest("POST", "/api/users/new", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	resp := decodeValidationError(t, rr)
+	var fields []string
+	for _, detail := range resp.Errors {
+		assert.Equal(t, "body", detail.In)
+		fields = append(fields, detail.Field)
+	}
+	assert.ElementsMatch(t, []string{"username", "first_name"}, fields)
+	userService.AssertNotCalled(t, "CreateUser", mock.Anything, mock.Anything)
+}
+
+func TestValidation_RawBodyIsLeftToHandler(t *testing.T) {
+	router, tenderService, _ := setupRouter()
+
+	tenderService.On("ImportTenders", mock.Anything, mock.Anything, tender_models.TenderImportOptions{Mode: "per_row"}).
+		Return(&tender_models.TenderImportReport{Mode: "per_row", Total: 1, Failed: 1}, nil)
+
+	body := `[{"name": "Tender", "organization_id": "one"}]`
+	req := httptest.NewRequest("POST", "/api/tenders/import?mode=per_row", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	tenderService.AssertExpectations(t)
+}
+
+func TestValidation_BodyWithoutContentTypeIsValidatedAsJSON(t *testing.T) {
+	router, _, userService := setupRouter()
+
+	req := httptest.NewRequest("POST", "/api/users/new", strings.NewReader(`{"username": 5}`))
+	req.Header.Del("Content-Type")
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	resp := decodeValidationError(t, rr)
+	require.Len(t, resp.Errors, 1)
+	assert.Equal(t, "username", resp.Errors[0].Field)
+	userService.AssertNotCalled(t, "CreateUser", mock.Anything, mock.Anything)
+}
+
+func TestValidation_BodyTooLarge(t *testing.T) {
+	router, _, userService := setupRouter()
+
+	body := `{"username": "` + strings.Repeat("a", 2<<20) + `"}`
+	req := httptest.NewRequest("POST", "/api/users/new", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusRequestEntityTooLarge, rr.Code)
+	assert.Equal(t, api_errors.ProblemContentType, rr.Header().Get("Content-Type"))
+	userService.AssertNotCalled(t, "CreateUser", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,209 @@
+package api_tests
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"avitoTest/api"
+	"avitoTest/shared/auth"
+	"avitoTest/shared/config"
+	"avitoTest/shared/ratelimit"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setupHandler serves a few routes behind the middlewares of the service, limiting reads
+// and writes to bursts of 2 and failed authentications to bursts of 1, refilled slowly.
+func setupHandler(policies map[string]ratelimit.Policy) (http.Handler, *[]auth.Principal) {
+	if policies == nil {
+		policies = map[string]ratelimit.Policy{
+			ratelimit.GroupReads:  ratelimit.PerMinute(1, 2),
+			ratelimit.GroupWrites: ratelimit.PerMinute(1, 2),
+			ratelimit.GroupAuth:   ratelimit.PerMinute(1, 1),
+		}
+	}
+	principals := &[]auth.Principal{}
+	ok := func(w http.ResponseWriter, r *http.Request) {
+		*principals = append(*principals, auth.FromContext(r.Context()))
+	}
+
+	router := mux.NewRouter()
+	router.HandleFunc("/api/ping", ok).Methods("GET")
+	router.HandleFunc("/api/tenders/", ok).Methods("GET")
+	router.HandleFunc("/api/bids/new", ok).Methods("POST")
+
+	conf := config.Default()
+	conf.Auth.APIKeys = map[string]string{"erp": "erp-key", "crm": "crm-key"}
+	return api.WithMiddlewares(router, conf, ratelimit.NewLimiter(ratelimit.NewMemoryStore(), policies)), principals
+}
+
+func request(handler http.Handler, method, path, ip string, headers ...string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, nil)
+	req.RemoteAddr = ip + ":40000"
+	for i := 0; i+1 < len(headers); i += 2 {
+		req.Header.Set(headers[i], headers[i+1])
+	}
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+	return rr
+}
+
+func TestRateLimit_WritesOverBurstAreRejected(t *testing.T) {
+	handler, _ := setupHandler(nil)
+
+	rr := request(handler, "POST", "/api/bids/new", "10.0.0.1")
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "2", rr.Header().Get("X-RateLimit-Limit"))
+	assert.Equal(t, "1", rr.Header().Get("X-RateLimit-Remaining"))
+	assert.Equal(t, "60", rr.Header().Get("X-RateLimit-Reset"))
+
+	assert.Equal(t, http.StatusOK, request(handler, "POST", "/api/bids/new", "10.0.0.1").Code)
+
+	rr = request(handler, "POST", "/api/bids/new", "10.0.0.1")
+	assert.Equal(t, http.StatusTooManyRequests, rr.Code)
+	assert.Equal(t, "0", rr.Header().Get("X-RateLimit-Remaining"))
+	retryAfter, err := strconv.Atoi(rr.Header().Get("Retry-After"))
+	require.NoError(t, err)
+	assert.InDelta(t, 60, retryAfter, 1)
+	assert.Contains(t, rr.Body.String(), "rate limit exceeded")
+
+	// Reads have a bucket of their own, and so has every other IP
+	assert.Equal(t, http.StatusOK, request(handler, "GET", "/api/tenders/", "10.0.0.1").Code)
+	assert.Equal(t, http.StatusOK, request(handler, "POST", "/api/bids/new", "10.0.0.2").Code)
+}
+
+func TestRateLimit_ProbesAreNotLimited(t *testing.T) {
+	handler, _ := setupHandler(nil)
+
+	for range 5 {
+		rr := request(handler, "GET", "/api/ping", "10.0.0.1")
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Empty(t, rr.Header().Get("X-RateLimit-Limit"))
+	}
+}
+
+func TestRateLimit_CallersAreKeyedByClientAndUser(t *testing.T) {
+	handler, principals := setupHandler(nil)
+
+	// The client has one bucket, whatever IP it calls from
+	assert.Equal(t, http.StatusOK, request(handler, "POST", "/api/bids/new", "10.0.0.1", auth.APIKeyHeader, "erp-key").Code)
+	assert.Equal(t, http.StatusOK, request(handler, "POST", "/api/bids/new", "10.0.0.2", auth.APIKeyHeader, "erp-key").Code)
+	assert.Equal(t, http.StatusTooManyRequests, request(handler, "POST", "/api/bids/new", "10.0.0.3", auth.APIKeyHeader, "erp-key").Code)
+
+	// The users a client acts for have buckets of their own, even across clients
+	assert.Equal(t, http.StatusOK, request(handler, "POST", "/api/bids/new", "10.0.0.1", auth.APIKeyHeader, "erp-key", auth.UserHeader, "7").Code)
+	assert.Equal(t, http.StatusOK, request(handler, "POST", "/api/bids/new", "10.0.0.1", auth.APIKeyHeader, "crm-key", auth.UserHeader, "7").Code)
+	assert.Equal(t, http.StatusTooManyRequests, request(handler, "POST", "/api/bids/new", "10.0.0.1", auth.APIKeyHeader, "erp-key", auth.UserHeader, "7").Code)
+
+	require.Len(t, *principals, 4)
+	assert.Equal(t, auth.Principal{Client: "erp"}, (*principals)[0])
+	assert.Equal(t, auth.Principal{Client: "crm", UserID: 7}, (*principals)[3])
+}
+
+func TestAuthentication_AnonymousRequestsIgnoreUserHeader(t *testing.T) {
+	handler, principals := setupHandler(nil)
+
+	rr := request(handler, "GET", "/api/tenders/", "10.0.0.1", auth.UserHeader, "7")
+	assert.Equal(t, http.StatusOK, rr.Code)
+	require.Len(t, *principals, 1)
+	assert.True(t, (*principals)[0].Anonymous())
+	assert.Zero(t, (*principals)[0].UserID)
+}
+
+func TestAuthentication_InvalidUserID(t *testing.T) {
+	handler, principals := setupHandler(nil)
+
+	rr := request(handler, "GET", "/api/tenders/", "10.0.0.1", auth.APIKeyHeader, "erp-key", auth.UserHeader, "abc")
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	assert.Empty(t, *principals)
+}
+
+func TestAuthentication_FailuresLockTheIPOut(t *testing.T) {
+	handler, principals := setupHandler(nil)
+
+	rr := request(handler, "GET", "/api/tenders/", "10.0.0.1", auth.APIKeyHeader, "guess")
+	assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	assert.Equal(t, "0", rr.Header().Get("X-RateLimit-Remaining"))
+
+	// Once the bucket is empty, not even the right key is checked
+	rr = request(handler, "GET", "/api/tenders/", "10.0.0.1", auth.APIKeyHeader, "erp-key")
+	assert.Equal(t, http.StatusTooManyRequests, rr.Code)
+	assert.NotEmpty(t, rr.Header().Get("Retry-After"))
+	assert.Empty(t, *principals)
+
+	// Other IPs and anonymous requests are not affected
+	assert.Equal(t, http.StatusOK, request(handler, "GET", "/api/tenders/", "10.0.0.2", auth.APIKeyHeader, "erp-key").Code)
+	assert.Equal(t, http.StatusOK, request(handler, "GET", "/api/tenders/", "10.0.0.1").Code)
+}
+
+func TestRateLimit_WithoutPoliciesEverythingIsAllowed(t *testing.T) {
+	handler, _ := setupHandler(map[string]ratelimit.Policy{})
+
+	for range 5 {
+		rr := request(handler, "POST", "/api/bids/new", "10.0.0.1")
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Empty(t, rr.Header().Get("X-RateLimit-Limit"))
+	}
+	assert.Equal(t, http.StatusUnauthorized, request(handler, "GET", "/api/tenders/", "10.0.0.1", auth.APIKeyHeader, "guess").Code)
+}
+
+func TestMemoryStore_Refills(t *testing.T) {
+	store := ratelimit.NewMemoryStore()
+	policy := ratelimit.Policy{Rate: 100, Burst: 1}
+	ctx := context.Background()
+
+	decision, err := store.Take(ctx, "key", policy, 1)
+	require.NoError(t, err)
+	assert.True(t, decision.Allowed)
+
+	decision, err = store.Take(ctx, "key", policy, 1)
+	require.NoError(t, err)
+	assert.False(t, decision.Allowed)
+	assert.Greater(t, decision.RetryAfter, time.Duration(0))
+	assert.LessOrEqual(t, decision.RetryAfter, 10*time.Millisecond)
+
+	time.Sleep(20 * time.Millisecond)
+	decision, err = store.Take(ctx, "key", policy, 1)
+	require.NoError(t, err)
+	assert.True(t, decision.Allowed)
+
+	// A peek does not take a token
+	decision, err = store.Take(ctx, "other", policy, 0)
+	require.NoError(t, err)
+	assert.True(t, decision.Allowed)
+	assert.Equal(t, 1, decision.Remaining)
+}
+
+func TestMemoryStore_PurgeIdle(t *testing.T) {
+	store := ratelimit.NewMemoryStore()
+	ctx := context.Background()
+
+	store.Take(ctx, "idle", ratelimit.Policy{Rate: 1000, Burst: 1}, 1)
+	store.Take(ctx, "busy", ratelimit.PerMinute(1, 1), 1)
+	time.Sleep(10 * time.Millisecond)
+
+	assert.Equal(t, 1, store.PurgeIdle(ctx))
+	decision, _ := store.Take(ctx, "busy", ratelimit.PerMinute(1, 1), 1)
+	assert.False(t, decision.Allowed, "the busy bucket must be kept")
+}
+
+type failingStore struct{}
+
+func (failingStore) Take(context.Context, string, ratelimit.Policy, int) (ratelimit.Decision, error) {
+	return ratelimit.Decision{}, errors.New("store unavailable")
+}
+
+func TestLimiter_StoreFailureAllowsRequests(t *testing.T) {
+	limiter := ratelimit.NewLimiter(failingStore{}, map[string]ratelimit.Policy{ratelimit.GroupWrites: ratelimit.PerMinute(1, 1)})
+
+	for range 3 {
+		assert.True(t, limiter.Take(context.Background(), ratelimit.GroupWrites, "ip:10.0.0.1", 1).Allowed)
+	}
+}
@@ -0,0 +1,76 @@
+package search_handler_test
+
+import (
+	"avitoTest/api/handlers/search_handler"
+	"avitoTest/api/handlers/search_handler/search_handler_models"
+	"avitoTest/services/search_service"
+	"avitoTest/services/search_service/search_models"
+	"avitoTest/shared/errors/search_errors"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func setupTestHandler() (*search_handler.SearchHandler, *search_service.MockSearchService) {
+	mockService := new(search_service.MockSearchService)
+	handler := search_handler.NewSearchHandler(mockService)
+	return handler, mockService
+}
+
+// Test SearchTenders endpoint
+func TestSearchTenders_Success(t *testing.T) {
+	handler, mockService := setupTestHandler()
+
+	query := search_models.SearchQueryModel{Query: "road repair", Language: "en", Limit: 5}
+	mockService.On("SearchTenders", mock.Anything, query).Return([]*search_models.TenderSearchResultModel{
+		{TenderID: 1, Name: "Road repair", Snippet: "<b>Road</b> <b>repair</b>"},
+	}, nil)
+
+	req := httptest.NewRequest("GET", "/api/search/tenders?q=road+repair&lang=en&limit=5", nil)
+	rr := httptest.NewRecorder()
+
+	handler.SearchTenders(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	var resp []search_handler_models.TenderSearchResponse
+	assert.NoError(t, json.NewDecoder(rr.Body).Decode(&resp))
+	assert.Len(t, resp, 1)
+	assert.Equal(t, 1, resp[0].TenderID)
+	mockService.AssertExpectations(t)
+}
+
+// Test SearchBids endpoint without an organization
+func TestSearchBids_OrganizationRequired(t *testing.T) {
+	handler, mockService := setupTestHandler()
+
+	mockService.On("SearchBids", mock.Anything, search_models.SearchQueryModel{Query: "road"}).Return(nil, search_errors.ErrOrganizationRequired)
+
+	req := httptest.NewRequest("GET", "/api/search/bids?q=road", nil)
+	rr := httptest.NewRecorder()
+
+	handler.SearchBids(rr, req)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	mockService.AssertExpectations(t)
+}
+
+// Test SearchBids endpoint by a user who is not responsible for the organization
+func TestSearchBids_NotResponsible(t *testing.T) {
+	handler, mockService := setupTestHandler()
+
+	query := search_models.SearchQueryModel{Query: "road", OrganizationID: 1}
+	mockService.On("SearchBids", mock.Anything, query).Return(nil, search_errors.ErrNotResponsible)
+
+	req := httptest.NewRequest("GET", "/api/search/bids?q=road&organizationId=1", nil)
+	rr := httptest.NewRecorder()
+
+	handler.SearchBids(rr, req)
+
+	assert.Equal(t, http.StatusForbidden, rr.Code)
+	mockService.AssertExpectations(t)
+}
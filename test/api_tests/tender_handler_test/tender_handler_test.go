@@ -7,16 +7,23 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
 	"avitoTest/api/handlers/tender_handler"
 	"avitoTest/api/handlers/tender_handler/tender_handler_models"
+	"avitoTest/data/repositories/user_repository"
 	"avitoTest/services/tender_service"
 	"avitoTest/services/tender_service/tender_models"
 	"avitoTest/services/user_service"
 	"avitoTest/services/user_service/user_models"
 	"avitoTest/shared/constants"
+	"avitoTest/shared/errors/api_errors"
+	"avitoTest/shared/errors/tendert_erorrs"
+	"avitoTest/shared/filter"
+	"avitoTest/shared/pagination"
+	"avitoTest/shared/validation"
 
 	"github.com/gorilla/mux"
 	"github.com/stretchr/testify/assert"
@@ -153,18 +160,209 @@ func TestGetTenders(t *testing.T) {
 		},
 	}
 
-	service.On("GetAllTenders", mock.Anything, "").Return(expectedTenders, nil)
+	service.On("GetAllTenders", mock.Anything, filter.Filter(nil), pagination.DefaultParams()).
+		Return(pagination.NewPage(expectedTenders, 2, pagination.DefaultParams()), nil)
 
 	handler.GetTenders(rr, req)
 
 	assert.Equal(t, http.StatusOK, rr.Code)
 
-	var response []tender_handler_models.TenderResponse
+	var response pagination.Page[tender_handler_models.TenderResponse]
 	json.Unmarshal(rr.Body.Bytes(), &response)
 
-	assert.Len(t, response, 2)
-	assert.Equal(t, expectedTenders[0].ID, response[0].ID)
-	assert.Equal(t, expectedTenders[1].ID, response[1].ID)
+	assert.Len(t, response.Items, 2)
+	assert.Equal(t, int64(2), response.Total)
+	assert.Equal(t, expectedTenders[0].ID, response.Items[0].ID)
+	assert.Equal(t, expectedTenders[1].ID, response.Items[1].ID)
+
+	service.AssertExpectations(t)
+}
+
+func TestGetTenders_Paginated(t *testing.T) {
+	service, _, handler := setupMocks()
+
+	req := httptest.NewRequest("GET", "/api/tenders?limit=1&offset=1&sort=name&order=asc", nil)
+	rr := httptest.NewRecorder()
+
+	params := pagination.Params{Limit: 1, Offset: 1, Sort: pagination.SortName, Order: pagination.OrderAsc}
+	expectedTenders := []*tender_models.TenderModel{
+		{ID: 2, Name: "Tender 2", Status: constants.TenderStatusPublished, Version: 1},
+	}
+
+	service.On("GetAllTenders", mock.Anything, filter.Filter(nil), params).
+		Return(pagination.NewPage(expectedTenders, 3, params), nil)
+
+	handler.GetTenders(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	var response pagination.Page[tender_handler_models.TenderResponse]
+	json.Unmarshal(rr.Body.Bytes(), &response)
+
+	assert.Len(t, response.Items, 1)
+	assert.Equal(t, int64(3), response.Total)
+	assert.Equal(t, 1, response.Limit)
+	assert.Equal(t, 1, response.Offset)
+	if assert.NotNil(t, response.NextOffset) {
+		assert.Equal(t, 2, *response.NextOffset)
+	}
+
+	service.AssertExpectations(t)
+}
+
+func TestGetTenders_InvalidSort(t *testing.T) {
+	service, _, handler := setupMocks()
+
+	req := httptest.NewRequest("GET", "/api/tenders?sort=budget", nil)
+	rr := httptest.NewRecorder()
+
+	handler.GetTenders(rr, req)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	service.AssertNotCalled(t, "GetAllTenders")
+}
+
+func TestGetTenders_Filtered(t *testing.T) {
+	service, _, handler := setupMocks()
+
+	req := httptest.NewRequest("GET", "/api/tenders?status[in]=CREATED,PUBLISHED&budget[gte]=1000&createdAt[lt]=2024-10-01&serviceType=construction", nil)
+	rr := httptest.NewRecorder()
+
+	expectedFilter := filter.Filter{
+		{Field: constants.FilterFieldBudget, Operator: filter.OpGte, Values: []any{1000.0}},
+		{Field: constants.FilterFieldCreatedAt, Operator: filter.OpLt, Values: []any{time.Date(2024, 10, 1, 0, 0, 0, 0, time.UTC)}},
+		{Field: constants.FilterFieldStatus, Operator: filter.OpIn, Values: []any{"CREATED", "PUBLISHED"}},
+		{Field: constants.FilterFieldCategory, Operator: filter.OpEq, Values: []any{"construction"}},
+	}
+	service.On("GetAllTenders", mock.Anything, expectedFilter, pagination.DefaultParams()).
+		Return(pagination.NewPage([]*tender_models.TenderModel{}, 0, pagination.DefaultParams()), nil)
+
+	handler.GetTenders(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	service.AssertExpectations(t)
+}
+
+func TestGetTenders_InvalidFilter(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+	}{
+		{"unknown field", "/api/tenders?secret[eq]=1"},
+		{"unsupported operator", "/api/tenders?status[gte]=CREATED"},
+		{"invalid value", "/api/tenders?budget[gte]=lots"},
+		{"unknown status", "/api/tenders?status=DRAFT"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, _, handler := setupMocks()
+
+			req := httptest.NewRequest("GET", tt.query, nil)
+			rr := httptest.NewRecorder()
+
+			handler.GetTenders(rr, req)
+
+			assert.Equal(t, http.StatusBadRequest, rr.Code)
+			service.AssertNotCalled(t, "GetAllTenders")
+		})
+	}
+}
+
+func TestImportTenders(t *testing.T) {
+	service, _, handler := setupMocks()
+
+	report := &tender_models.TenderImportReport{
+		Mode: tender_models.ImportModePerRow, DryRun: true, Total: 1,
+		Rows: []tender_models.TenderImportRowResult{{Row: 1, Status: tender_models.ImportRowValid}},
+	}
+	service.On("ImportTenders", mock.Anything, mock.MatchedBy(func(rows []tender_models.TenderImportRow) bool {
+		return len(rows) == 1 && rows[0].Name == "Road repair" && rows[0].CreatorUsername == "alice"
+	}), tender_models.TenderImportOptions{Mode: tender_models.ImportModePerRow, DryRun: true}).Return(report, nil)
+
+	body := "name,service_type,organization_id,creator_username\nRoad repair,Construction,1,alice\n"
+	req := httptest.NewRequest("POST", "/api/tenders/import?mode=per_row&dryRun=true", strings.NewReader(body))
+	req.Header.Set("Content-Type", "text/csv")
+	rr := httptest.NewRecorder()
+
+	handler.ImportTenders(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	var response tender_models.TenderImportReport
+	json.Unmarshal(rr.Body.Bytes(), &response)
+	assert.Equal(t, *report, response)
+	service.AssertExpectations(t)
+}
+
+func TestImportTenders_InvalidRequest(t *testing.T) {
+	tests := []struct {
+		name        string
+		target      string
+		contentType string
+		body        string
+	}{
+		{"invalid json", "/api/tenders/import", "application/json", `{"name": "A"}`},
+		{"unknown csv column", "/api/tenders/import?format=csv", "", "color\nred\n"},
+		{"invalid dry run", "/api/tenders/import?dryRun=maybe", "text/csv", "name\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, _, handler := setupMocks()
+
+			req := httptest.NewRequest("POST", tt.target, strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", tt.contentType)
+			rr := httptest.NewRecorder()
+
+			handler.ImportTenders(rr, req)
+
+			assert.Equal(t, http.StatusBadRequest, rr.Code)
+			service.AssertNotCalled(t, "ImportTenders")
+		})
+	}
+}
+
+func TestCreateTender_Forbidden(t *testing.T) {
+	service, userService, handler := setupMocks()
+
+	userService.On("GetUserByUsername", mock.Anything, "outsider").Return(&user_models.UserModel{ID: 7, Username: "outsider"}, nil)
+	service.On("CreateTender", mock.Anything, mock.AnythingOfType("tender_models.TenderCreateModel")).
+		Return((*tender_models.TenderModel)(nil), tendert_erorrs.ErrUnauthorized)
+
+	body := `{"name": "Tender", "service_type": "Construction", "status": "CREATED", "organization_id": 1, "creator_username": "outsider"}`
+	req := httptest.NewRequest("POST", "/api/tenders/new", strings.NewReader(body))
+	rr := httptest.NewRecorder()
+
+	handler.CreateTender(rr, req)
+
+	assert.Equal(t, http.StatusForbidden, rr.Code)
+
+	service.AssertExpectations(t)
+}
+
+func TestCreateTender_InvalidFields(t *testing.T) {
+	service, userService, handler := setupMocks()
+
+	userService.On("GetUserByUsername", mock.Anything, "alice").Return(&user_models.UserModel{ID: 7, Username: "alice"}, nil)
+	service.On("CreateTender", mock.Anything, mock.AnythingOfType("tender_models.TenderCreateModel")).
+		Return((*tender_models.TenderModel)(nil), &validation.Error{Fields: []validation.FieldError{
+			{Field: "name", Code: "required", Message: "name is required"},
+			{Field: "service_type", Code: "service_type", Message: "service_type must be the code of an active service category"},
+		}})
+
+	body := `{"service_type": "Legacy", "organization_id": 1, "creator_username": "alice"}`
+	req := httptest.NewRequest("POST", "/api/tenders/new", strings.NewReader(body))
+	rr := httptest.NewRecorder()
+
+	handler.CreateTender(rr, req)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	var resp api_errors.ErrResponse
+	assert.NoError(t, json.NewDecoder(rr.Body).Decode(&resp))
+	assert.Equal(t, []api_errors.FieldError{
+		{In: "body", Field: "name", Code: "required", Message: "name is required"},
+		{In: "body", Field: "service_type", Code: "service_type", Message: "service_type must be the code of an active service category"},
+	}, resp.Errors)
 
 	service.AssertExpectations(t)
 }
@@ -277,7 +475,8 @@ func TestGetTendersByUsername(t *testing.T) {
 		},
 	}
 
-	service.On("GetTendersByUsername", mock.Anything, "testuser").Return(expectedTenders, nil)
+	service.On("GetTendersByUsername", mock.Anything, "testuser", pagination.DefaultParams()).
+		Return(pagination.NewPage(expectedTenders, 2, pagination.DefaultParams()), nil)
 
 	vars := map[string]string{
 		"username": "testuser",
@@ -288,12 +487,31 @@ func TestGetTendersByUsername(t *testing.T) {
 
 	assert.Equal(t, http.StatusOK, rr.Code)
 
-	var response []tender_handler_models.TenderResponse
+	var response pagination.Page[tender_handler_models.TenderResponse]
 	json.Unmarshal(rr.Body.Bytes(), &response)
 
-	assert.Len(t, response, 2)
-	assert.Equal(t, expectedTenders[0].ID, response[0].ID)
-	assert.Equal(t, expectedTenders[1].ID, response[1].ID)
+	assert.Len(t, response.Items, 2)
+	assert.Equal(t, int64(2), response.Total)
+	assert.Equal(t, expectedTenders[0].ID, response.Items[0].ID)
+	assert.Equal(t, expectedTenders[1].ID, response.Items[1].ID)
+
+	service.AssertExpectations(t)
+}
+
+func TestGetTendersByUsername_UserNotFound(t *testing.T) {
+	service, _, handler := setupMocks()
+
+	service.On("GetTendersByUsername", mock.Anything, "ghost", pagination.DefaultParams()).
+		Return((*pagination.Page[*tender_models.TenderModel])(nil), user_repository.ErrUserNotFound)
+
+	req := httptest.NewRequest("GET", "/api/tenders/my/ghost", nil)
+	req = mux.SetURLVars(req, map[string]string{"username": "ghost"})
+	rr := httptest.NewRecorder()
+
+	handler.GetTendersByUsername(rr, req)
+
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+	assert.Contains(t, rr.Body.String(), "user not found")
 
 	service.AssertExpectations(t)
 }
@@ -0,0 +1,120 @@
+package api_tests
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"avitoTest/api"
+	"avitoTest/services/bid_service"
+	"avitoTest/services/tender_service"
+	"avitoTest/services/tender_service/tender_models"
+	"avitoTest/shared/errors/bid_errors"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// recorder records the spans of every test; each test looks at the spans it ended.
+var recorder = tracetest.NewSpanRecorder()
+
+func init() {
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+}
+
+func setupRouter() (*mux.Router, *tender_service.MockTenderService, *bid_service.MockBidService) {
+	tenderService := new(tender_service.MockTenderService)
+	bidService := new(bid_service.MockBidService)
+
+	router := mux.NewRouter()
+	api.InitRoutes(router, api.Services{
+		Tenders: tender_service.NewTracedTenderService(tenderService),
+		Bids:    bid_service.NewTracedBidService(bidService),
+	})
+	return router, tenderService, bidService
+}
+
+// serve serves req and returns the spans ended meanwhile, the request span last.
+func serve(router http.Handler, req *http.Request) (*httptest.ResponseRecorder, []sdktrace.ReadOnlySpan) {
+	before := len(recorder.Ended())
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+	return rr, recorder.Ended()[before:]
+}
+
+func spanAttribute(span sdktrace.ReadOnlySpan, key attribute.Key) attribute.Value {
+	for _, kv := range span.Attributes() {
+		if kv.Key == key {
+			return kv.Value
+		}
+	}
+	return attribute.Value{}
+}
+
+func TestTracing_RequestAndServiceSpans(t *testing.T) {
+	router, _, bidService := setupRouter()
+	bidService.On("ApproveBid", mock.Anything, 1, 2).Return(nil)
+
+	rr, spans := serve(router, httptest.NewRequest("POST", "/api/bids/1/approve/2", nil))
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	require.Len(t, spans, 2)
+	service, request := spans[0], spans[1]
+
+	assert.Equal(t, "POST /api/bids/{bidId}/approve/{approverId}", request.Name())
+	assert.Equal(t, "/api/bids/{bidId}/approve/{approverId}", spanAttribute(request, "http.route").AsString())
+	assert.Equal(t, int64(http.StatusOK), spanAttribute(request, "http.response.status_code").AsInt64())
+	assert.Equal(t, codes.Unset, request.Status().Code)
+
+	assert.Equal(t, "BidService.ApproveBid", service.Name())
+	assert.Equal(t, request.SpanContext().SpanID(), service.Parent().SpanID())
+	assert.Equal(t, request.SpanContext().TraceID(), service.SpanContext().TraceID())
+}
+
+func TestTracing_ContinuesTraceOfCaller(t *testing.T) {
+	router, _, _ := setupRouter()
+
+	req := httptest.NewRequest("GET", "/api/ping", nil)
+	req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	_, spans := serve(router, req)
+
+	require.Len(t, spans, 1)
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", spans[0].SpanContext().TraceID().String())
+	assert.Equal(t, "00f067aa0ba902b7", spans[0].Parent().SpanID().String())
+	assert.True(t, spans[0].Parent().IsRemote())
+}
+
+func TestTracing_DomainErrorDoesNotFailSpans(t *testing.T) {
+	router, _, bidService := setupRouter()
+	bidService.On("ApproveBid", mock.Anything, 1, 2).Return(bid_errors.ErrNotResponsible)
+
+	rr, spans := serve(router, httptest.NewRequest("POST", "/api/bids/1/approve/2", nil))
+
+	assert.Equal(t, http.StatusForbidden, rr.Code)
+	require.Len(t, spans, 2)
+	assert.Equal(t, codes.Unset, spans[0].Status().Code)
+	assert.Len(t, spans[0].Events(), 1, "the error is recorded on the service span")
+	assert.Equal(t, codes.Unset, spans[1].Status().Code)
+}
+
+func TestTracing_ServerErrorFailsSpans(t *testing.T) {
+	router, tenderService, _ := setupRouter()
+	tenderService.On("GetTenderByID", mock.Anything, 1).Return((*tender_models.TenderModel)(nil), errors.New("connection refused"))
+
+	rr, spans := serve(router, httptest.NewRequest("GET", "/api/tenders/1", nil))
+
+	assert.Equal(t, http.StatusInternalServerError, rr.Code)
+	require.Len(t, spans, 2)
+	assert.Equal(t, "TenderService.GetTenderByID", spans[0].Name())
+	assert.Equal(t, codes.Error, spans[0].Status().Code)
+	assert.Equal(t, "connection refused", spans[0].Status().Description)
+	assert.Equal(t, codes.Error, spans[1].Status().Code)
+}
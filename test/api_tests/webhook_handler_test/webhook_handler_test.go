@@ -0,0 +1,197 @@
+package api_tests
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"avitoTest/api/handlers/webhook_handler"
+	"avitoTest/services/webhook_service"
+	"avitoTest/services/webhook_service/webhook_models"
+	"avitoTest/shared/constants"
+	"avitoTest/shared/errors/webhook_errors"
+	"avitoTest/shared/filter"
+	"avitoTest/shared/pagination"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func setupMocks() (*webhook_service.MockWebhookService, *webhook_handler.WebhookHandler) {
+	service := new(webhook_service.MockWebhookService)
+	return service, webhook_handler.NewWebhookHandler(service)
+}
+
+func TestCreateWebhook(t *testing.T) {
+	service, handler := setupMocks()
+
+	createdAt := time.Date(2024, 9, 1, 12, 30, 0, 0, time.UTC)
+	service.On("CreateWebhook", mock.Anything, webhook_models.WebhookCreateModel{
+		OrganizationID: 1,
+		URL:            "https://hooks.example.com",
+		EventTypes:     []string{"bid.created"},
+	}).Return(&webhook_models.WebhookModel{
+		ID:             5,
+		OrganizationID: 1,
+		URL:            "https://hooks.example.com",
+		EventTypes:     []string{"bid.created"},
+		Active:         true,
+		Secret:         "whsec_abc",
+		CreatedAt:      createdAt,
+		UpdatedAt:      createdAt,
+	}, nil)
+
+	body, _ := json.Marshal(map[string]any{"url": "https://hooks.example.com", "event_types": []string{"bid.created"}})
+	req := httptest.NewRequest("POST", "/api/organizations/1/webhooks/new", bytes.NewReader(body))
+	req = mux.SetURLVars(req, map[string]string{"org_id": "1"})
+	rr := httptest.NewRecorder()
+	handler.CreateWebhook(rr, req)
+
+	assert.Equal(t, http.StatusCreated, rr.Code)
+	assert.JSONEq(t, `{
+		"id": 5,
+		"organization_id": 1,
+		"url": "https://hooks.example.com",
+		"event_types": ["bid.created"],
+		"active": true,
+		"secret": "whsec_abc",
+		"created_at": "2024-09-01T12:30:00Z",
+		"updated_at": "2024-09-01T12:30:00Z"
+	}`, rr.Body.String())
+	service.AssertExpectations(t)
+}
+
+func TestUpdateWebhook_Deactivates(t *testing.T) {
+	service, handler := setupMocks()
+
+	active := false
+	service.On("UpdateWebhook", mock.Anything, webhook_models.WebhookUpdateModel{ID: 5, OrganizationID: 1, Active: &active}).
+		Return(&webhook_models.WebhookModel{ID: 5, OrganizationID: 1, EventTypes: []string{"bid.created"}}, nil)
+
+	req := httptest.NewRequest("PATCH", "/api/organizations/1/webhooks/5/edit", bytes.NewReader([]byte(`{"active": false}`)))
+	req = mux.SetURLVars(req, map[string]string{"org_id": "1", "webhook_id": "5"})
+	rr := httptest.NewRecorder()
+	handler.UpdateWebhook(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	service.AssertExpectations(t)
+}
+
+func TestGetWebhookByID_NotFound(t *testing.T) {
+	service, handler := setupMocks()
+
+	service.On("GetWebhookByID", mock.Anything, 1, 5).Return(nil, webhook_errors.ErrWebhookNotFound)
+
+	req := httptest.NewRequest("GET", "/api/organizations/1/webhooks/5", nil)
+	req = mux.SetURLVars(req, map[string]string{"org_id": "1", "webhook_id": "5"})
+	rr := httptest.NewRecorder()
+	handler.GetWebhookByID(rr, req)
+
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+}
+
+func TestGetWebhooks_NotOrganizationAdmin(t *testing.T) {
+	service, handler := setupMocks()
+
+	service.On("GetWebhooks", mock.Anything, 1).Return(nil, webhook_errors.ErrNotOrganizationAdmin)
+
+	req := httptest.NewRequest("GET", "/api/organizations/1/webhooks", nil)
+	req = mux.SetURLVars(req, map[string]string{"org_id": "1"})
+	rr := httptest.NewRecorder()
+	handler.GetWebhooks(rr, req)
+
+	assert.Equal(t, http.StatusForbidden, rr.Code)
+}
+
+func TestDeleteWebhook_InvalidID(t *testing.T) {
+	service, handler := setupMocks()
+
+	req := httptest.NewRequest("DELETE", "/api/organizations/1/webhooks/abc/delete", nil)
+	req = mux.SetURLVars(req, map[string]string{"org_id": "1", "webhook_id": "abc"})
+	rr := httptest.NewRecorder()
+	handler.DeleteWebhook(rr, req)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	service.AssertNotCalled(t, "DeleteWebhook", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestGetDeliveries(t *testing.T) {
+	service, handler := setupMocks()
+
+	params := pagination.DefaultParams()
+	createdAt := time.Date(2024, 9, 1, 12, 30, 0, 0, time.UTC)
+	service.On("GetDeliveries", mock.Anything, 1, 5, filter.Filter{
+		{Field: constants.FilterFieldStatus, Operator: filter.OpEq, Values: []any{"failed"}},
+	}, params).Return(pagination.NewPage([]*webhook_models.WebhookDeliveryModel{{
+		ID:             11,
+		WebhookID:      5,
+		EventID:        "evt",
+		EventType:      "bid.approved",
+		Payload:        json.RawMessage(`{"id":"evt"}`),
+		Status:         "failed",
+		Attempts:       10,
+		ResponseStatus: 500,
+		Error:          "endpoint responded with status 500",
+		CreatedAt:      createdAt,
+	}}, 1, params), nil)
+
+	req := httptest.NewRequest("GET", "/api/organizations/1/webhooks/5/deliveries?status=failed", nil)
+	req = mux.SetURLVars(req, map[string]string{"org_id": "1", "webhook_id": "5"})
+	rr := httptest.NewRecorder()
+	handler.GetDeliveries(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.JSONEq(t, `{
+		"items": [{
+			"id": 11,
+			"webhook_id": 5,
+			"event_id": "evt",
+			"event_type": "bid.approved",
+			"payload": {"id": "evt"},
+			"status": "failed",
+			"attempts": 10,
+			"response_status": 500,
+			"error": "endpoint responded with status 500",
+			"created_at": "2024-09-01T12:30:00Z"
+		}],
+		"total": 1,
+		"limit": 20,
+		"offset": 0,
+		"next_offset": null
+	}`, rr.Body.String())
+	service.AssertExpectations(t)
+}
+
+func TestGetDeliveries_InvalidFilter(t *testing.T) {
+	service, handler := setupMocks()
+
+	req := httptest.NewRequest("GET", "/api/organizations/1/webhooks/5/deliveries?status=sent", nil)
+	req = mux.SetURLVars(req, map[string]string{"org_id": "1", "webhook_id": "5"})
+	rr := httptest.NewRecorder()
+	handler.GetDeliveries(rr, req)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	service.AssertNotCalled(t, "GetDeliveries", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestRedeliver(t *testing.T) {
+	service, handler := setupMocks()
+
+	original := 11
+	service.On("Redeliver", mock.Anything, 1, 5, 11).Return(&webhook_models.WebhookDeliveryModel{
+		ID: 12, WebhookID: 5, Status: "pending", RedeliveryOf: &original,
+	}, nil)
+
+	req := httptest.NewRequest("POST", "/api/organizations/1/webhooks/5/deliveries/11/redeliver", nil)
+	req = mux.SetURLVars(req, map[string]string{"org_id": "1", "webhook_id": "5", "delivery_id": "11"})
+	rr := httptest.NewRecorder()
+	handler.Redeliver(rr, req)
+
+	assert.Equal(t, http.StatusAccepted, rr.Code)
+	assert.Contains(t, rr.Body.String(), `"redelivery_of":11`)
+	service.AssertExpectations(t)
+}
@@ -0,0 +1,146 @@
+package listing_benchmark_test
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// countingDriver is a database/sql driver that answers the queries of the tender
+// and bid list endpoints with generated rows and counts every query it receives.
+// It lets the repositories run their real GORM queries without a database.
+type countingDriver struct {
+	mu        sync.Mutex
+	databases map[string]*fakeDatabase
+}
+
+// fakeDatabase holds the number of tenders and bids a connection pretends to have.
+type fakeDatabase struct {
+	rows    int
+	queries atomic.Int64
+}
+
+var drivers = &countingDriver{databases: map[string]*fakeDatabase{}}
+
+func init() {
+	sql.Register("counting", drivers)
+}
+
+// newFakeDatabase registers a fake database with the given number of rows per table
+// and returns the DSN to open it with.
+func newFakeDatabase(rows int) (string, *fakeDatabase) {
+	drivers.mu.Lock()
+	defer drivers.mu.Unlock()
+
+	dsn := fmt.Sprintf("fake-%d", len(drivers.databases))
+	db := &fakeDatabase{rows: rows}
+	drivers.databases[dsn] = db
+	return dsn, db
+}
+
+func (d *countingDriver) Open(dsn string) (driver.Conn, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	db, ok := d.databases[dsn]
+	if !ok {
+		return nil, fmt.Errorf("unknown fake database %q", dsn)
+	}
+	return &fakeConn{db: db}, nil
+}
+
+type fakeConn struct {
+	db *fakeDatabase
+}
+
+func (c *fakeConn) Prepare(string) (driver.Stmt, error) {
+	return nil, errors.New("prepared statements are not supported")
+}
+
+func (c *fakeConn) Close() error { return nil }
+
+func (c *fakeConn) Begin() (driver.Tx, error) {
+	return nil, errors.New("transactions are not supported")
+}
+
+var limitPattern = regexp.MustCompile(`LIMIT (\d+)`)
+
+func (c *fakeConn) QueryContext(_ context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	c.db.queries.Add(1)
+
+	createdAt := time.Date(2024, 9, 1, 0, 0, 0, 0, time.UTC)
+	switch {
+	case strings.Contains(query, "count(*)"):
+		return &fakeRows{columns: []string{"count"}, values: [][]driver.Value{{int64(c.db.rows)}}}, nil
+
+	case strings.Contains(query, `FROM "tender_versions"`), strings.Contains(query, `FROM "bid_versions"`):
+		// One current version for every requested parent: either the IN list of a
+		// preload or the single ID of a per-row lookup.
+		parent := "tender_id"
+		if strings.Contains(query, `FROM "bid_versions"`) {
+			parent = "bid_id"
+		}
+		rows := &fakeRows{columns: []string{"id", parent, "name", "description", "version", "updated_at"}}
+		for _, arg := range args {
+			id, ok := arg.Value.(int64)
+			if !ok {
+				continue
+			}
+			rows.values = append(rows.values, []driver.Value{id, id, fmt.Sprintf("Version of %d", id), "Description", int64(3), createdAt})
+		}
+		return rows, nil
+
+	case strings.Contains(query, `FROM "tenders"`):
+		rows := &fakeRows{columns: []string{"id", "organization_id", "creator_id", "status", "service_type", "budget", "created_at"}}
+		for id := 1; id <= c.pageSize(query); id++ {
+			rows.values = append(rows.values, []driver.Value{int64(id), int64(1), int64(1), "PUBLISHED", "Construction", nil, createdAt})
+		}
+		return rows, nil
+
+	case strings.Contains(query, `FROM "bids"`):
+		rows := &fakeRows{columns: []string{"id", "tender_id", "organization_id", "creator_id", "approval_count", "status", "created_at"}}
+		for id := 1; id <= c.pageSize(query); id++ {
+			rows.values = append(rows.values, []driver.Value{int64(id), int64(1), int64(1), int64(1), int64(0), "CREATED", createdAt})
+		}
+		return rows, nil
+	}
+	return nil, fmt.Errorf("unexpected query: %s", query)
+}
+
+// pageSize returns the number of rows a query with a LIMIT clause gets back.
+func (c *fakeConn) pageSize(query string) int {
+	size := c.db.rows
+	if match := limitPattern.FindStringSubmatch(query); match != nil {
+		limit, _ := strconv.Atoi(match[1])
+		size = min(size, limit)
+	}
+	return size
+}
+
+type fakeRows struct {
+	columns []string
+	values  [][]driver.Value
+	next    int
+}
+
+func (r *fakeRows) Columns() []string { return r.columns }
+
+func (r *fakeRows) Close() error { return nil }
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if r.next >= len(r.values) {
+		return io.EOF
+	}
+	copy(dest, r.values[r.next])
+	r.next++
+	return nil
+}
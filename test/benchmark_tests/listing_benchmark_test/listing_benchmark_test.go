@@ -0,0 +1,152 @@
+package listing_benchmark_test
+
+import (
+	"avitoTest/data/repositories/bid_repository"
+	"avitoTest/data/repositories/tender_repository"
+	"avitoTest/services/bid_service"
+	"avitoTest/services/tender_service"
+	"avitoTest/shared/pagination"
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+var pageSizes = []int{20, 100}
+
+func openFakeDB(tb testing.TB, rows int) (*gorm.DB, *fakeDatabase) {
+	dsn, fake := newFakeDatabase(rows)
+	db, err := gorm.Open(postgres.New(postgres.Config{DriverName: "counting", DSN: dsn}), &gorm.Config{
+		Logger:               logger.Discard,
+		DisableAutomaticPing: true,
+	})
+	require.NoError(tb, err)
+	return db, fake
+}
+
+func pageParams(size int) pagination.Params {
+	params := pagination.DefaultParams()
+	params.Limit = size
+	return params
+}
+
+// The list endpoints must run a fixed number of queries whatever the page size:
+// the count, the page itself and the current versions of the page.
+func TestListTenders_QueryCount(t *testing.T) {
+	for _, size := range pageSizes {
+		t.Run(fmt.Sprintf("page_%d", size), func(t *testing.T) {
+			db, fake := openFakeDB(t, size)
+			service := tender_service.NewTenderService(tender_repository.NewTenderRepository(db), nil, nil, nil)
+
+			page, err := service.GetAllTenders(context.Background(), nil, pageParams(size))
+
+			require.NoError(t, err)
+			assert.Len(t, page.Items, size)
+			assert.Equal(t, 3, page.Items[0].Version)
+			assert.Equal(t, int64(3), fake.queries.Load())
+		})
+	}
+}
+
+func TestListBids_QueryCount(t *testing.T) {
+	for _, size := range pageSizes {
+		t.Run(fmt.Sprintf("page_%d", size), func(t *testing.T) {
+			db, fake := openFakeDB(t, size)
+			service := bid_service.NewBidService(bid_repository.NewBidRepository(db), nil, nil, nil)
+
+			page, err := service.GetBidsByTenderID(context.Background(), 1, nil, pageParams(size))
+
+			require.NoError(t, err)
+			assert.Len(t, page.Items, size)
+			assert.Equal(t, "Version of 1", page.Items[0].Name)
+			assert.Equal(t, int64(3), fake.queries.Load())
+		})
+	}
+}
+
+// BenchmarkListTenders compares the current read path, which preloads the current
+// versions of a page in one query, with the previous one, which looked up the latest
+// version of every tender separately.
+func BenchmarkListTenders(b *testing.B) {
+	for _, size := range pageSizes {
+		b.Run(fmt.Sprintf("current_version/page_%d", size), func(b *testing.B) {
+			db, fake := openFakeDB(b, size)
+			service := tender_service.NewTenderService(tender_repository.NewTenderRepository(db), nil, nil, nil)
+			params := pageParams(size)
+
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if _, err := service.GetAllTenders(context.Background(), nil, params); err != nil {
+					b.Fatal(err)
+				}
+			}
+			b.ReportMetric(float64(fake.queries.Load())/float64(b.N), "queries/op")
+		})
+
+		b.Run(fmt.Sprintf("per_row_latest_version/page_%d", size), func(b *testing.B) {
+			db, fake := openFakeDB(b, size)
+			repo := tender_repository.NewTenderRepository(db)
+			params := pageParams(size)
+
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				tenders, _, err := repo.GetAll(context.Background(), nil, params)
+				if err != nil {
+					b.Fatal(err)
+				}
+				for _, tender := range tenders {
+					if _, err := repo.FindLatestVersion(context.Background(), tender.ID); err != nil {
+						b.Fatal(err)
+					}
+				}
+			}
+			// GetAll already preloads the current versions, so only the per-row lookups
+			// are added on top of the three list queries.
+			b.ReportMetric(float64(fake.queries.Load())/float64(b.N), "queries/op")
+		})
+	}
+}
+
+// BenchmarkListBids does the same comparison for the bids of a tender.
+func BenchmarkListBids(b *testing.B) {
+	for _, size := range pageSizes {
+		b.Run(fmt.Sprintf("current_version/page_%d", size), func(b *testing.B) {
+			db, fake := openFakeDB(b, size)
+			service := bid_service.NewBidService(bid_repository.NewBidRepository(db), nil, nil, nil)
+			params := pageParams(size)
+
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if _, err := service.GetBidsByTenderID(context.Background(), 1, nil, params); err != nil {
+					b.Fatal(err)
+				}
+			}
+			b.ReportMetric(float64(fake.queries.Load())/float64(b.N), "queries/op")
+		})
+
+		b.Run(fmt.Sprintf("per_row_latest_version/page_%d", size), func(b *testing.B) {
+			db, fake := openFakeDB(b, size)
+			repo := bid_repository.NewBidRepository(db)
+			params := pageParams(size)
+
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				bids, _, err := repo.FindByTenderID(context.Background(), 1, nil, params)
+				if err != nil {
+					b.Fatal(err)
+				}
+				for _, bid := range bids {
+					if _, err := repo.FindLatestVersion(context.Background(), bid.ID); err != nil {
+						b.Fatal(err)
+					}
+				}
+			}
+			b.ReportMetric(float64(fake.queries.Load())/float64(b.N), "queries/op")
+		})
+	}
+}
@@ -0,0 +1,167 @@
+package data_tests
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// migrationsDriver is a database/sql driver that keeps the schema_migrations table in
+// memory and records the migration scripts it runs, so the migrator can be tested
+// without PostgreSQL. Scripts containing "FAIL" return an error.
+type migrationsDriver struct {
+	mu        sync.Mutex
+	databases map[string]*fakeDatabase
+}
+
+// fakeDatabase is the state of one fake database.
+type fakeDatabase struct {
+	mu          sync.Mutex
+	tableExists bool
+	applied     map[int64]appliedRow
+	scripts     []string
+	locks       int
+}
+
+type appliedRow struct {
+	name      string
+	appliedAt time.Time
+}
+
+var drivers = &migrationsDriver{databases: map[string]*fakeDatabase{}}
+
+func init() {
+	sql.Register("migrations", drivers)
+}
+
+// openFakeDatabase opens an empty fake database.
+func openFakeDatabase() (*sql.DB, *fakeDatabase) {
+	drivers.mu.Lock()
+	dsn := fmt.Sprintf("fake-%d", len(drivers.databases))
+	fake := &fakeDatabase{applied: map[int64]appliedRow{}}
+	drivers.databases[dsn] = fake
+	drivers.mu.Unlock()
+
+	db, _ := sql.Open("migrations", dsn)
+	return db, fake
+}
+
+func (d *migrationsDriver) Open(dsn string) (driver.Conn, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	db, ok := d.databases[dsn]
+	if !ok {
+		return nil, fmt.Errorf("unknown fake database %q", dsn)
+	}
+	return &fakeConn{db: db}, nil
+}
+
+type fakeConn struct {
+	db *fakeDatabase
+	// snapshot is the state restored when the transaction in progress is rolled back
+	snapshot *fakeDatabase
+}
+
+func (c *fakeConn) Prepare(string) (driver.Stmt, error) {
+	return nil, errors.New("prepared statements are not supported")
+}
+
+func (c *fakeConn) Close() error { return nil }
+
+func (c *fakeConn) Begin() (driver.Tx, error) {
+	c.db.mu.Lock()
+	defer c.db.mu.Unlock()
+
+	c.snapshot = &fakeDatabase{
+		tableExists: c.db.tableExists,
+		applied:     maps.Clone(c.db.applied),
+		scripts:     slices.Clone(c.db.scripts),
+	}
+	return c, nil
+}
+
+func (c *fakeConn) Commit() error {
+	c.snapshot = nil
+	return nil
+}
+
+func (c *fakeConn) Rollback() error {
+	if c.snapshot == nil {
+		return nil
+	}
+	c.db.mu.Lock()
+	defer c.db.mu.Unlock()
+
+	c.db.tableExists, c.db.applied, c.db.scripts = c.snapshot.tableExists, c.snapshot.applied, c.snapshot.scripts
+	c.snapshot = nil
+	return nil
+}
+
+func (c *fakeConn) ExecContext(_ context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	c.db.mu.Lock()
+	defer c.db.mu.Unlock()
+
+	switch {
+	case strings.Contains(query, "pg_advisory_lock"):
+		c.db.locks++
+	case strings.Contains(query, "pg_advisory_unlock"):
+		c.db.locks--
+	case strings.Contains(query, "CREATE TABLE IF NOT EXISTS schema_migrations"):
+		c.db.tableExists = true
+	case strings.HasPrefix(query, "INSERT INTO schema_migrations"):
+		c.db.applied[args[0].Value.(int64)] = appliedRow{name: args[1].Value.(string), appliedAt: time.Now()}
+	case strings.HasPrefix(query, "DELETE FROM schema_migrations"):
+		delete(c.db.applied, args[0].Value.(int64))
+	case strings.Contains(query, "FAIL"):
+		return nil, errors.New("syntax error")
+	default:
+		c.db.scripts = append(c.db.scripts, strings.TrimSpace(query))
+	}
+	return driver.RowsAffected(1), nil
+}
+
+func (c *fakeConn) QueryContext(_ context.Context, query string, _ []driver.NamedValue) (driver.Rows, error) {
+	c.db.mu.Lock()
+	defer c.db.mu.Unlock()
+
+	switch {
+	case strings.Contains(query, "to_regclass"):
+		return &fakeRows{columns: []string{"exists"}, values: [][]driver.Value{{c.db.tableExists}}}, nil
+	case strings.Contains(query, "FROM schema_migrations"):
+		rows := &fakeRows{columns: []string{"version", "name", "applied_at"}}
+		for _, version := range slices.Sorted(maps.Keys(c.db.applied)) {
+			row := c.db.applied[version]
+			rows.values = append(rows.values, []driver.Value{version, row.name, row.appliedAt})
+		}
+		return rows, nil
+	}
+	return nil, fmt.Errorf("unexpected query: %s", query)
+}
+
+type fakeRows struct {
+	columns []string
+	values  [][]driver.Value
+	next    int
+}
+
+func (r *fakeRows) Columns() []string { return r.columns }
+
+func (r *fakeRows) Close() error { return nil }
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if r.next >= len(r.values) {
+		return io.EOF
+	}
+	copy(dest, r.values[r.next])
+	r.next++
+	return nil
+}
@@ -0,0 +1,179 @@
+package data_tests
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	"avitoTest/data/migrations"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func source(files map[string]string) fstest.MapFS {
+	fs := fstest.MapFS{}
+	for name, content := range files {
+		fs[name] = &fstest.MapFile{Data: []byte(content)}
+	}
+	return fs
+}
+
+var threeMigrations = source(map[string]string{
+	"0001_users.up.sql":      "CREATE TABLE users",
+	"0001_users.down.sql":    "DROP TABLE users",
+	"0002_tenders.up.sql":    "CREATE TABLE tenders",
+	"0002_tenders.down.sql":  "DROP TABLE tenders",
+	"0010_comments.up.sql":   "CREATE TABLE comments",
+	"0010_comments.down.sql": "DROP TABLE comments",
+})
+
+func TestMigrations_UpAppliesPendingInOrder(t *testing.T) {
+	db, fake := openFakeDatabase()
+	migrator, err := migrations.New(db, threeMigrations)
+	require.NoError(t, err)
+
+	applied, err := migrator.Up(context.Background())
+	require.NoError(t, err)
+	require.Len(t, applied, 3)
+	assert.Equal(t, []string{"CREATE TABLE users", "CREATE TABLE tenders", "CREATE TABLE comments"}, fake.scripts)
+	assert.Len(t, fake.applied, 3)
+	assert.Equal(t, "comments", fake.applied[10].name)
+	assert.Zero(t, fake.locks, "the migration lock must be released")
+
+	// Applied migrations are not run again
+	applied, err = migrator.Up(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, applied)
+	assert.Len(t, fake.scripts, 3)
+}
+
+func TestMigrations_FailedMigrationIsRolledBack(t *testing.T) {
+	db, fake := openFakeDatabase()
+	migrator, err := migrations.New(db, source(map[string]string{
+		"0001_users.up.sql":   "CREATE TABLE users",
+		"0002_broken.up.sql":  "CREATE TABLE FAIL",
+		"0003_tenders.up.sql": "CREATE TABLE tenders",
+	}))
+	require.NoError(t, err)
+
+	applied, err := migrator.Up(context.Background())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "2_broken")
+	require.Len(t, applied, 1)
+	assert.Equal(t, []string{"CREATE TABLE users"}, fake.scripts)
+	assert.NotContains(t, fake.applied, int64(2))
+	assert.NotContains(t, fake.applied, int64(3))
+	assert.Zero(t, fake.locks)
+}
+
+func TestMigrations_DownRevertsNewestFirst(t *testing.T) {
+	db, fake := openFakeDatabase()
+	migrator, err := migrations.New(db, threeMigrations)
+	require.NoError(t, err)
+	_, err = migrator.Up(context.Background())
+	require.NoError(t, err)
+
+	reverted, err := migrator.Down(context.Background(), 2)
+	require.NoError(t, err)
+	require.Len(t, reverted, 2)
+	assert.Equal(t, 10, reverted[0].Version)
+	assert.Equal(t, 2, reverted[1].Version)
+	assert.Equal(t, []string{"DROP TABLE comments", "DROP TABLE tenders"}, fake.scripts[3:])
+	assert.Len(t, fake.applied, 1)
+	assert.Contains(t, fake.applied, int64(1))
+}
+
+func TestMigrations_DownWithoutDownFileFails(t *testing.T) {
+	db, fake := openFakeDatabase()
+	migrator, err := migrations.New(db, source(map[string]string{"0001_users.up.sql": "CREATE TABLE users"}))
+	require.NoError(t, err)
+	_, err = migrator.Up(context.Background())
+	require.NoError(t, err)
+
+	_, err = migrator.Down(context.Background(), 1)
+	require.Error(t, err)
+	assert.Contains(t, fake.applied, int64(1))
+}
+
+func TestMigrations_CheckReportsPendingMigrations(t *testing.T) {
+	db, fake := openFakeDatabase()
+	migrator, err := migrations.New(db, threeMigrations)
+	require.NoError(t, err)
+
+	// Probing a new database does not create the schema table
+	err = migrator.Check(context.Background())
+	var pending *migrations.PendingError
+	require.ErrorAs(t, err, &pending)
+	assert.Len(t, pending.Pending, 3)
+	assert.False(t, fake.tableExists)
+
+	_, err = migrator.Up(context.Background())
+	require.NoError(t, err)
+	assert.NoError(t, migrator.Check(context.Background()))
+}
+
+func TestMigrations_StatusListsUnknownMigrations(t *testing.T) {
+	db, _ := openFakeDatabase()
+	newer, err := migrations.New(db, source(map[string]string{
+		"0001_users.up.sql":   "CREATE TABLE users",
+		"0002_tenders.up.sql": "CREATE TABLE tenders",
+	}))
+	require.NoError(t, err)
+	_, err = newer.Up(context.Background())
+	require.NoError(t, err)
+
+	// An older build only knows the first migration
+	older, err := migrations.New(db, source(map[string]string{"0001_users.up.sql": "CREATE TABLE users"}))
+	require.NoError(t, err)
+
+	statuses, err := older.Status(context.Background())
+	require.NoError(t, err)
+	require.Len(t, statuses, 2)
+	assert.True(t, statuses[0].Applied)
+	assert.False(t, statuses[0].Unknown)
+	assert.Equal(t, "tenders", statuses[1].Name)
+	assert.True(t, statuses[1].Unknown)
+	assert.NoError(t, older.Check(context.Background()))
+}
+
+func TestMigrations_LoadRejectsInvalidFiles(t *testing.T) {
+	_, err := migrations.Load(source(map[string]string{"01-users.sql": "CREATE TABLE users"}))
+	assert.Error(t, err)
+
+	_, err = migrations.Load(source(map[string]string{"0001_users.down.sql": "DROP TABLE users"}))
+	assert.Error(t, err)
+
+	_, err = migrations.Load(source(map[string]string{
+		"0001_users.up.sql":   "CREATE TABLE users",
+		"0001_tenders.up.sql": "CREATE TABLE tenders",
+	}))
+	assert.Error(t, err)
+}
+
+func TestMigrations_EmbeddedMigrationsCanBeReverted(t *testing.T) {
+	list, err := migrations.Load(migrations.Files)
+	require.NoError(t, err)
+	require.NotEmpty(t, list)
+	for i, migration := range list {
+		assert.Equal(t, i+1, migration.Version, "versions must be consecutive")
+		assert.NotEmpty(t, migration.Down, "migration %d_%s has no down file", migration.Version, migration.Name)
+	}
+}
+
+func TestMigrations_CreateAddsNextVersion(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "0007_users.up.sql"), []byte("CREATE TABLE users"), 0o644))
+
+	up, down, err := migrations.Create(dir, "Add tender deadlines!")
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(dir, "0008_add_tender_deadlines.up.sql"), up)
+	assert.Equal(t, filepath.Join(dir, "0008_add_tender_deadlines.down.sql"), down)
+	assert.FileExists(t, up)
+	assert.FileExists(t, down)
+
+	_, _, err = migrations.Create(dir, "!!!")
+	assert.Error(t, err)
+}
@@ -0,0 +1,63 @@
+package data_tests
+
+import (
+	"context"
+	"testing"
+
+	dbcontext "avitoTest/data/context"
+	"avitoTest/data/entities"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func setupDB(t *testing.T) (*gorm.DB, *tracetest.SpanRecorder) {
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+
+	// A dry run builds the statements without a database to run them against
+	db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=localhost"}), &gorm.Config{
+		DryRun:               true,
+		DisableAutomaticPing: true,
+	})
+	require.NoError(t, err)
+	require.NoError(t, db.Use(dbcontext.TracingPlugin{}))
+	return db, recorder
+}
+
+func attributes(span sdktrace.ReadOnlySpan) map[attribute.Key]attribute.Value {
+	values := map[attribute.Key]attribute.Value{}
+	for _, kv := range span.Attributes() {
+		values[kv.Key] = kv.Value
+	}
+	return values
+}
+
+func TestTracingPlugin_SpanPerQuery(t *testing.T) {
+	db, recorder := setupDB(t)
+
+	ctx, parent := otel.Tracer("test").Start(context.Background(), "parent")
+	var tender entities.Tender
+	db.WithContext(ctx).Where("id = ?", 1).Find(&tender)
+	db.WithContext(ctx).Create(&entities.User{Username: "user"})
+	parent.End()
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 3)
+	query, create := spans[0], spans[1]
+
+	assert.Equal(t, "gorm.query", query.Name())
+	assert.Equal(t, parent.SpanContext().SpanID(), query.Parent().SpanID())
+	assert.Equal(t, parent.SpanContext().TraceID(), query.SpanContext().TraceID())
+	assert.Contains(t, attributes(query)["db.query.text"].AsString(), `SELECT * FROM "tenders" WHERE id = $1`)
+	assert.Equal(t, "tenders", attributes(query)["db.collection.name"].AsString())
+
+	assert.Equal(t, "gorm.create", create.Name())
+	assert.Equal(t, parent.SpanContext().SpanID(), create.Parent().SpanID())
+}
@@ -0,0 +1,160 @@
+package audit_service_test
+
+import (
+	"avitoTest/data/entities"
+	"avitoTest/data/repositories/audit_repository"
+	"avitoTest/data/repositories/organization_repository"
+	"avitoTest/services/audit_service"
+	"avitoTest/services/audit_service/audit_models"
+	"avitoTest/services/tender_service/tender_models"
+	"avitoTest/shared/auth"
+	"avitoTest/shared/constants"
+	"avitoTest/shared/errors/audit_errors"
+	"avitoTest/shared/filter"
+	"avitoTest/shared/pagination"
+	"avitoTest/shared/requestid"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func setupMocks() (*audit_repository.MockAuditRepository, *organization_repository.MockOrganizationRepository, audit_service.AuditService) {
+	mockAuditRepo := new(audit_repository.MockAuditRepository)
+	mockOrgRepo := new(organization_repository.MockOrganizationRepository)
+	service := audit_service.NewAuditService(mockAuditRepo, mockOrgRepo)
+	return mockAuditRepo, mockOrgRepo, service
+}
+
+func organizationFilter(orgID int) filter.Filter {
+	return filter.Filter{{Field: constants.FilterFieldOrganizationID, Operator: filter.OpEq, Values: []any{orgID}}}
+}
+
+func TestRecord_AttributesEventToCallerAndRequest(t *testing.T) {
+	mockAuditRepo, _, service := setupMocks()
+
+	var recorded *entities.AuditEvent
+	mockAuditRepo.On("Create", mock.Anything, mock.AnythingOfType("*entities.AuditEvent")).Return(nil).Run(func(args mock.Arguments) {
+		recorded = args.Get(1).(*entities.AuditEvent)
+	})
+
+	ctx := auth.NewContext(context.Background(), auth.Principal{Client: "portal", UserID: 2})
+	ctx = requestid.NewContext(ctx, "req-1")
+	err := service.Record(ctx, audit_models.AuditRecordModel{
+		EntityType:     constants.AuditEntityTender,
+		EntityID:       7,
+		OrganizationID: 1,
+		Action:         constants.AuditActionPublished,
+		Before:         &tender_models.TenderModel{ID: 7, Status: constants.TenderStatusCreated},
+		After:          &tender_models.TenderModel{ID: 7, Status: constants.TenderStatusPublished},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "tender", recorded.EntityType)
+	assert.Equal(t, 7, recorded.EntityID)
+	assert.Equal(t, 1, *recorded.OrganizationID)
+	assert.Equal(t, "published", recorded.Action)
+	assert.Equal(t, 2, *recorded.ActorUserID)
+	assert.Equal(t, "portal", recorded.ActorClient)
+	assert.Equal(t, "req-1", recorded.RequestID)
+	assert.Contains(t, *recorded.Before, `"status":"CREATED"`)
+	assert.Contains(t, *recorded.After, `"status":"PUBLISHED"`)
+	assert.WithinDuration(t, time.Now(), recorded.CreatedAt, time.Minute)
+	mockAuditRepo.AssertExpectations(t)
+}
+
+func TestRecord_OmitsMissingSnapshotsAndFallsBackToActorOfRecord(t *testing.T) {
+	mockAuditRepo, _, service := setupMocks()
+
+	var recorded *entities.AuditEvent
+	mockAuditRepo.On("Create", mock.Anything, mock.AnythingOfType("*entities.AuditEvent")).Return(nil).Run(func(args mock.Arguments) {
+		recorded = args.Get(1).(*entities.AuditEvent)
+	})
+
+	var deleted *tender_models.TenderModel
+	err := service.Record(context.Background(), audit_models.AuditRecordModel{
+		EntityType:  constants.AuditEntityBid,
+		EntityID:    4,
+		Action:      constants.AuditActionApproved,
+		ActorUserID: 3,
+		Before:      deleted,
+	})
+
+	assert.NoError(t, err)
+	assert.Nil(t, recorded.Before)
+	assert.Nil(t, recorded.After)
+	assert.Nil(t, recorded.OrganizationID)
+	assert.Equal(t, 3, *recorded.ActorUserID)
+	assert.Empty(t, recorded.ActorClient)
+}
+
+func TestGetEvents_ClientReadsWholeLog(t *testing.T) {
+	mockAuditRepo, mockOrgRepo, service := setupMocks()
+
+	before := `{"id":7}`
+	params := pagination.DefaultParams()
+	mockAuditRepo.On("FindByFilters", mock.Anything, filter.Filter(nil), params).Return([]*entities.AuditEvent{
+		{ID: 1, EntityType: "tender", EntityID: 7, Action: "deleted", Before: &before},
+	}, int64(1), nil)
+
+	ctx := auth.NewContext(context.Background(), auth.Principal{Client: "erp"})
+	page, err := service.GetEvents(ctx, nil, params)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), page.Total)
+	assert.JSONEq(t, before, string(page.Items[0].Before))
+	assert.Nil(t, page.Items[0].After)
+	mockOrgRepo.AssertNotCalled(t, "GetResponsibleByID", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestGetEvents_RequiresAuthentication(t *testing.T) {
+	mockAuditRepo, _, service := setupMocks()
+
+	_, err := service.GetEvents(context.Background(), organizationFilter(1), pagination.DefaultParams())
+
+	assert.ErrorIs(t, err, audit_errors.ErrAuthenticationRequired)
+	mockAuditRepo.AssertNotCalled(t, "FindByFilters", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestGetEvents_UserMustSelectOrganization(t *testing.T) {
+	_, _, service := setupMocks()
+	ctx := auth.NewContext(context.Background(), auth.Principal{Client: "portal", UserID: 2})
+
+	_, err := service.GetEvents(ctx, nil, pagination.DefaultParams())
+	assert.ErrorIs(t, err, audit_errors.ErrOrganizationRequired)
+
+	_, err = service.GetEvents(ctx, filter.Filter{
+		{Field: constants.FilterFieldOrganizationID, Operator: filter.OpIn, Values: []any{1, 2}},
+	}, pagination.DefaultParams())
+	assert.ErrorIs(t, err, audit_errors.ErrOrganizationRequired)
+}
+
+func TestGetEvents_UserMustBeResponsibleForOrganization(t *testing.T) {
+	mockAuditRepo, mockOrgRepo, service := setupMocks()
+
+	mockOrgRepo.On("GetResponsibleByID", mock.Anything, 1, 2).Return(nil, organization_repository.ErrResponsibleNotFound)
+
+	ctx := auth.NewContext(context.Background(), auth.Principal{Client: "portal", UserID: 2})
+	_, err := service.GetEvents(ctx, organizationFilter(1), pagination.DefaultParams())
+
+	assert.ErrorIs(t, err, audit_errors.ErrNotOrganizationAdmin)
+	mockAuditRepo.AssertNotCalled(t, "FindByFilters", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestGetEvents_ResponsibleReadsOrganizationLog(t *testing.T) {
+	mockAuditRepo, mockOrgRepo, service := setupMocks()
+
+	params := pagination.DefaultParams()
+	mockOrgRepo.On("GetResponsibleByID", mock.Anything, 1, 2).Return(&entities.User{ID: 2}, nil)
+	mockAuditRepo.On("FindByFilters", mock.Anything, organizationFilter(1), params).Return([]*entities.AuditEvent{}, int64(0), nil)
+
+	ctx := auth.NewContext(context.Background(), auth.Principal{Client: "portal", UserID: 2})
+	page, err := service.GetEvents(ctx, organizationFilter(1), params)
+
+	assert.NoError(t, err)
+	assert.Empty(t, page.Items)
+	mockAuditRepo.AssertExpectations(t)
+	mockOrgRepo.AssertExpectations(t)
+}
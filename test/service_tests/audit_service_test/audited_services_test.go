@@ -0,0 +1,213 @@
+package audit_service_test
+
+import (
+	"avitoTest/data/repositories/transaction"
+	"avitoTest/services/audit_service"
+	"avitoTest/services/audit_service/audit_models"
+	"avitoTest/services/bid_service"
+	"avitoTest/services/bid_service/bid_models"
+	"avitoTest/services/comment_service"
+	"avitoTest/services/organization_service"
+	"avitoTest/services/tender_service"
+	"avitoTest/services/tender_service/tender_models"
+	"avitoTest/services/user_service/user_models"
+	"avitoTest/shared/auth"
+	"avitoTest/shared/constants"
+	"avitoTest/shared/errors/tendert_erorrs"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// newTransactions returns a transaction manager running every function it is given.
+func newTransactions() *transaction.MockManager {
+	transactions := new(transaction.MockManager)
+	transactions.On("WithinTransaction", mock.Anything).Return(nil)
+	return transactions
+}
+
+func TestAuditedTenderService_RecordsPublishWithSnapshots(t *testing.T) {
+	tenderService := new(tender_service.MockTenderService)
+	auditService := new(audit_service.MockAuditService)
+	service := tender_service.NewAuditedTenderService(tenderService, auditService, newTransactions())
+
+	created := &tender_models.TenderModel{ID: 7, OrganizationID: 1, Status: constants.TenderStatusCreated}
+	published := &tender_models.TenderModel{ID: 7, OrganizationID: 1, Status: constants.TenderStatusPublished}
+	tenderService.On("GetTenderByID", mock.Anything, 7).Return(created, nil).Once()
+	tenderService.On("PublishTender", mock.Anything, 7).Return(nil)
+	tenderService.On("GetTenderByID", mock.Anything, 7).Return(published, nil).Once()
+	auditService.On("Record", mock.Anything, audit_models.AuditRecordModel{
+		EntityType:     constants.AuditEntityTender,
+		EntityID:       7,
+		OrganizationID: 1,
+		Action:         constants.AuditActionPublished,
+		ActorUserID:    5,
+		Before:         created,
+		After:          published,
+	}).Return(nil)
+
+	ctx := auth.NewContext(context.Background(), auth.Principal{Client: "web", UserID: 5})
+	err := service.PublishTender(ctx, 7)
+
+	assert.NoError(t, err)
+	tenderService.AssertExpectations(t)
+	auditService.AssertExpectations(t)
+}
+
+func TestAuditedTenderService_AttributesCreationToCreator(t *testing.T) {
+	tenderService := new(tender_service.MockTenderService)
+	auditService := new(audit_service.MockAuditService)
+	service := tender_service.NewAuditedTenderService(tenderService, auditService, newTransactions())
+
+	tender := tender_models.TenderCreateModel{Name: "Road repair", OrganizationID: 1, CreatorID: 5}
+	created := &tender_models.TenderModel{ID: 7, OrganizationID: 1, Status: constants.TenderStatusCreated}
+	tenderService.On("CreateTender", mock.Anything, tender).Return(created, nil)
+	auditService.On("Record", mock.Anything, audit_models.AuditRecordModel{
+		EntityType:     constants.AuditEntityTender,
+		EntityID:       7,
+		OrganizationID: 1,
+		Action:         constants.AuditActionCreated,
+		ActorUserID:    5,
+		Before:         (*tender_models.TenderModel)(nil),
+		After:          created,
+	}).Return(nil)
+
+	_, err := service.CreateTender(context.Background(), tender)
+
+	assert.NoError(t, err)
+	auditService.AssertExpectations(t)
+}
+
+func TestAuditedTenderService_DoesNotRecordFailedChanges(t *testing.T) {
+	tenderService := new(tender_service.MockTenderService)
+	auditService := new(audit_service.MockAuditService)
+	service := tender_service.NewAuditedTenderService(tenderService, auditService, newTransactions())
+
+	tenderService.On("GetTenderByID", mock.Anything, 7).Return((*tender_models.TenderModel)(nil), tendert_erorrs.ErrTenderNotFound)
+	tenderService.On("DeleteTender", mock.Anything, 7).Return(tendert_erorrs.ErrTenderNotFound)
+
+	err := service.DeleteTender(context.Background(), 7)
+
+	assert.ErrorIs(t, err, tendert_erorrs.ErrTenderNotFound)
+	auditService.AssertNotCalled(t, "Record", mock.Anything, mock.Anything)
+}
+
+func TestAuditedTenderService_AuditFailureFailsChange(t *testing.T) {
+	tenderService := new(tender_service.MockTenderService)
+	auditService := new(audit_service.MockAuditService)
+	service := tender_service.NewAuditedTenderService(tenderService, auditService, newTransactions())
+
+	created := &tender_models.TenderModel{ID: 7, OrganizationID: 1}
+	tenderService.On("CreateTender", mock.Anything, mock.Anything).Return(created, nil)
+	auditService.On("Record", mock.Anything, mock.Anything).Return(errors.New("connection refused"))
+
+	tender, err := service.CreateTender(context.Background(), tender_models.TenderCreateModel{Name: "Road repair"})
+
+	assert.ErrorContains(t, err, "connection refused")
+	assert.Nil(t, tender)
+	auditService.AssertExpectations(t)
+}
+
+func TestAuditedBidService_AttributesApprovalToApprover(t *testing.T) {
+	bidService := new(bid_service.MockBidService)
+	tenderService := new(tender_service.MockTenderService)
+	auditService := new(audit_service.MockAuditService)
+	service := bid_service.NewAuditedBidService(bidService, tenderService, auditService, newTransactions())
+
+	before := &bid_models.BidModel{ID: 4, TenderID: 7, OrganizationID: 2, Status: "PUBLISHED"}
+	after := &bid_models.BidModel{ID: 4, TenderID: 7, OrganizationID: 2, Status: "PUBLISHED", ApprovalCount: 1}
+	tender := &tender_models.TenderModel{ID: 7, OrganizationID: 1, Status: constants.TenderStatusPublished}
+	bidService.On("GetBidByID", mock.Anything, 4).Return(before, nil).Once()
+	tenderService.On("GetTenderByID", mock.Anything, 7).Return(tender, nil)
+	bidService.On("ApproveBid", mock.Anything, 4, 3).Return(nil)
+	bidService.On("GetBidByID", mock.Anything, 4).Return(after, nil).Once()
+	auditService.On("Record", mock.Anything, audit_models.AuditRecordModel{
+		EntityType:     constants.AuditEntityBid,
+		EntityID:       4,
+		OrganizationID: 2,
+		Action:         constants.AuditActionApproved,
+		ActorUserID:    3,
+		Before:         before,
+		After:          after,
+	}).Return(nil)
+
+	err := service.ApproveBid(context.Background(), 4, 3)
+
+	assert.NoError(t, err)
+	auditService.AssertExpectations(t)
+	auditService.AssertNumberOfCalls(t, "Record", 1)
+}
+
+func TestAuditedBidService_RecordsTenderClosedByApproval(t *testing.T) {
+	bidService := new(bid_service.MockBidService)
+	tenderService := new(tender_service.MockTenderService)
+	auditService := new(audit_service.MockAuditService)
+	service := bid_service.NewAuditedBidService(bidService, tenderService, auditService, newTransactions())
+
+	before := &bid_models.BidModel{ID: 4, TenderID: 7, OrganizationID: 2, Status: "PUBLISHED"}
+	after := &bid_models.BidModel{ID: 4, TenderID: 7, OrganizationID: 2, Status: "APPROVED", ApprovalCount: 1}
+	published := &tender_models.TenderModel{ID: 7, OrganizationID: 1, Status: constants.TenderStatusPublished}
+	closed := &tender_models.TenderModel{ID: 7, OrganizationID: 1, Status: constants.TenderStatusClosed}
+	bidService.On("GetBidByID", mock.Anything, 4).Return(before, nil).Once()
+	tenderService.On("GetTenderByID", mock.Anything, 7).Return(published, nil).Once()
+	bidService.On("ApproveBid", mock.Anything, 4, 3).Return(nil)
+	bidService.On("GetBidByID", mock.Anything, 4).Return(after, nil).Once()
+	tenderService.On("GetTenderByID", mock.Anything, 7).Return(closed, nil).Once()
+	auditService.On("Record", mock.Anything, mock.MatchedBy(func(record audit_models.AuditRecordModel) bool {
+		return record.EntityType == constants.AuditEntityBid
+	})).Return(nil)
+	auditService.On("Record", mock.Anything, audit_models.AuditRecordModel{
+		EntityType:     constants.AuditEntityTender,
+		EntityID:       7,
+		OrganizationID: 1,
+		Action:         constants.AuditActionClosed,
+		ActorUserID:    3,
+		Before:         published,
+		After:          closed,
+	}).Return(nil)
+
+	err := service.ApproveBid(context.Background(), 4, 3)
+
+	assert.NoError(t, err)
+	tenderService.AssertExpectations(t)
+	auditService.AssertExpectations(t)
+}
+
+func TestAuditedOrganizationService_RecordsAddedResponsible(t *testing.T) {
+	orgService := new(organization_service.MockOrganizationService)
+	auditService := new(audit_service.MockAuditService)
+	service := organization_service.NewAuditedOrganizationService(orgService, auditService, newTransactions())
+
+	responsible := &user_models.UserModel{ID: 2, Username: "ivan"}
+	orgService.On("AddResponsible", mock.Anything, 1, 2).Return(nil)
+	orgService.On("GetResponsibleByID", mock.Anything, 1, 2).Return(responsible, nil)
+	auditService.On("Record", mock.Anything, audit_models.AuditRecordModel{
+		EntityType:     constants.AuditEntityResponsible,
+		EntityID:       2,
+		OrganizationID: 1,
+		Action:         constants.AuditActionCreated,
+		After:          responsible,
+	}).Return(nil)
+
+	err := service.AddResponsible(context.Background(), 1, 2)
+
+	assert.NoError(t, err)
+	auditService.AssertExpectations(t)
+}
+
+func TestAuditedCommentService_SkipsDeletionOfMissingComment(t *testing.T) {
+	commentService := new(comment_service.MockCommentService)
+	auditService := new(audit_service.MockAuditService)
+	service := comment_service.NewAuditedCommentService(commentService, auditService, newTransactions())
+
+	commentService.On("GetCommentByID", mock.Anything, 5).Return(nil, errors.New("comment not found"))
+	commentService.On("DeleteComment", mock.Anything, 5).Return(nil)
+
+	err := service.DeleteComment(context.Background(), 5)
+
+	assert.NoError(t, err)
+	auditService.AssertNotCalled(t, "Record", mock.Anything, mock.Anything)
+}
@@ -9,11 +9,19 @@ import (
 	"avitoTest/services/bid_service"
 	"avitoTest/services/bid_service/bid_models"
 	"avitoTest/services/user_service/user_models"
+	"avitoTest/shared/auth"
+	"avitoTest/shared/constants"
+	"avitoTest/shared/errors/bid_errors"
+	"avitoTest/shared/events"
+	"avitoTest/shared/filter"
+	"avitoTest/shared/metrics"
+	"avitoTest/shared/pagination"
 	"context"
 	"errors"
 	"testing"
 	"time"
 
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
@@ -28,7 +36,7 @@ func setupMocks() (*bid_repository.MockBidRepository, *organization_repository.M
 }
 
 func TestCreateBid_Success(t *testing.T) {
-	mockBidRepo, _, _, _, service := setupMocks()
+	mockBidRepo, _, _, mockTenderRepo, service := setupMocks()
 
 	bidCreate := bid_models.BidCreateModel{
 		Name:           "Bid 1",
@@ -47,6 +55,10 @@ func TestCreateBid_Success(t *testing.T) {
 		CreatedAt:      time.Now(),
 	}
 
+	mockTenderRepo.On("FindByID", mock.Anything, bidCreate.TenderID).Return(&entities.Tender{ID: 1, OrganizationID: 2}, nil)
+	mockTenderRepo.On("FindUserOrganizationResponsibility", mock.Anything, bidCreate.CreatorID, 2).Return(nil, nil)
+	mockTenderRepo.On("FindEligibilityRules", mock.Anything, bidCreate.TenderID).Return([]*entities.TenderEligibilityRule{}, nil)
+
 	mockBidRepo.On("Create", mock.Anything, mock.AnythingOfType("*entities.Bid")).Return(nil).Run(func(args mock.Arguments) {
 		bid := args.Get(1).(*entities.Bid)
 		bid.ID = expectedEntity.ID
@@ -70,13 +82,129 @@ func TestCreateBid_Success(t *testing.T) {
 		version.UpdatedAt = expectedVersion.UpdatedAt
 	})
 
+	created := testutil.ToFloat64(metrics.Bids.WithLabelValues(metrics.BidCreated))
+
 	result, err := service.CreateBid(context.Background(), bidCreate)
 
 	assert.NoError(t, err)
 	assert.Equal(t, expectedEntity.ID, result.ID)
 	assert.Equal(t, bidCreate.Name, result.Name)
 	assert.Equal(t, bidCreate.Description, result.Description)
+	assert.Equal(t, created+1, testutil.ToFloat64(metrics.Bids.WithLabelValues(metrics.BidCreated)))
 	mockBidRepo.AssertExpectations(t)
+	mockTenderRepo.AssertExpectations(t)
+}
+
+func TestCreateBid_OwnTender(t *testing.T) {
+	mockBidRepo, _, _, mockTenderRepo, service := setupMocks()
+
+	bidCreate := bid_models.BidCreateModel{
+		Name:           "Bid 1",
+		Description:    "Test Description",
+		TenderID:       1,
+		OrganizationID: 1,
+		CreatorID:      1,
+	}
+
+	mockTenderRepo.On("FindByID", mock.Anything, 1).Return(&entities.Tender{ID: 1, OrganizationID: 1}, nil)
+
+	_, err := service.CreateBid(context.Background(), bidCreate)
+
+	assert.ErrorIs(t, err, bid_errors.ErrSelfBid)
+	assert.ErrorIs(t, err, bid_errors.ErrConflictOfInterest)
+	mockBidRepo.AssertNotCalled(t, "Create")
+	mockTenderRepo.AssertExpectations(t)
+}
+
+func TestCreateBid_CreatorResponsibleForTenderOrganization(t *testing.T) {
+	mockBidRepo, _, _, mockTenderRepo, service := setupMocks()
+
+	bidCreate := bid_models.BidCreateModel{
+		Name:           "Bid 1",
+		Description:    "Test Description",
+		TenderID:       1,
+		OrganizationID: 2,
+		CreatorID:      7,
+	}
+
+	mockTenderRepo.On("FindByID", mock.Anything, 1).Return(&entities.Tender{ID: 1, OrganizationID: 1}, nil)
+	mockTenderRepo.On("FindUserOrganizationResponsibility", mock.Anything, 7, 1).Return(&entities.OrganizationResponsible{UserID: 7, OrganizationID: 1}, nil)
+
+	_, err := service.CreateBid(context.Background(), bidCreate)
+
+	assert.ErrorIs(t, err, bid_errors.ErrCreatorConflict)
+	mockBidRepo.AssertNotCalled(t, "Create")
+	mockTenderRepo.AssertExpectations(t)
+}
+
+func TestCreateBid_NotEligible(t *testing.T) {
+	mockBidRepo, mockOrgRepo, _, mockTenderRepo, service := setupMocks()
+
+	bidCreate := bid_models.BidCreateModel{
+		Name:           "Bid 1",
+		Description:    "Test Description",
+		TenderID:       1,
+		OrganizationID: 2,
+		CreatorID:      7,
+	}
+
+	mockTenderRepo.On("FindByID", mock.Anything, 1).Return(&entities.Tender{ID: 1, OrganizationID: 1}, nil)
+	mockTenderRepo.On("FindUserOrganizationResponsibility", mock.Anything, 7, 1).Return(nil, nil)
+	mockTenderRepo.On("FindEligibilityRules", mock.Anything, 1).Return([]*entities.TenderEligibilityRule{
+		{TenderID: 1, RuleType: string(constants.EligibilityOrganizationType), Value: "LLC,JSC"},
+		{TenderID: 1, RuleType: string(constants.EligibilityMinRegistrationMonths), Value: "12"},
+	}, nil)
+	mockOrgRepo.On("FindByID", mock.Anything, 2).Return(&entities.Organization{ID: 2, Type: constants.IE, CreatedAt: time.Now().AddDate(0, -2, 0)}, nil)
+
+	_, err := service.CreateBid(context.Background(), bidCreate)
+
+	assert.ErrorIs(t, err, bid_errors.ErrNotEligible)
+	var eligibilityErr *bid_service.EligibilityError
+	assert.ErrorAs(t, err, &eligibilityErr)
+	assert.Len(t, eligibilityErr.FailedRules, 2)
+	mockBidRepo.AssertNotCalled(t, "Create")
+	mockOrgRepo.AssertExpectations(t)
+}
+
+func TestCheckEligibility_Eligible(t *testing.T) {
+	_, mockOrgRepo, _, mockTenderRepo, service := setupMocks()
+
+	mockTenderRepo.On("FindByID", mock.Anything, 1).Return(&entities.Tender{ID: 1, OrganizationID: 1}, nil)
+	mockTenderRepo.On("FindEligibilityRules", mock.Anything, 1).Return([]*entities.TenderEligibilityRule{
+		{TenderID: 1, RuleType: string(constants.EligibilityServiceType), Value: "Construction"},
+	}, nil)
+	mockOrgRepo.On("FindByID", mock.Anything, 2).Return(&entities.Organization{
+		ID:           2,
+		Type:         constants.LLC,
+		ServiceTypes: []entities.OrganizationServiceType{{OrganizationID: 2, ServiceType: "Construction"}},
+	}, nil)
+
+	result, err := service.CheckEligibility(context.Background(), 1, 2)
+
+	assert.NoError(t, err)
+	assert.True(t, result.Eligible)
+	assert.Empty(t, result.FailedRules)
+	mockTenderRepo.AssertExpectations(t)
+}
+
+func TestCheckEligibility_InvalidRulesFail(t *testing.T) {
+	_, mockOrgRepo, _, mockTenderRepo, service := setupMocks()
+
+	mockTenderRepo.On("FindByID", mock.Anything, 1).Return(&entities.Tender{ID: 1, OrganizationID: 1}, nil)
+	mockTenderRepo.On("FindEligibilityRules", mock.Anything, 1).Return([]*entities.TenderEligibilityRule{
+		{TenderID: 1, RuleType: string(constants.EligibilityMinRegistrationMonths), Value: "0"},
+		{TenderID: 1, RuleType: "MIN_EMPLOYEES", Value: "10"},
+	}, nil)
+	mockOrgRepo.On("FindByID", mock.Anything, 2).Return(&entities.Organization{ID: 2, Type: constants.LLC, CreatedAt: time.Now().AddDate(-5, 0, 0)}, nil)
+
+	result, err := service.CheckEligibility(context.Background(), 1, 2)
+
+	assert.NoError(t, err)
+	assert.False(t, result.Eligible)
+	if assert.Len(t, result.FailedRules, 2) {
+		assert.Equal(t, "invalid MIN_REGISTRATION_MONTHS rule: months must be a positive number", result.FailedRules[0].Message)
+		assert.Equal(t, "invalid MIN_EMPLOYEES rule: unknown rule type", result.FailedRules[1].Message)
+	}
 }
 
 func TestCreateBid_ValidationFail(t *testing.T) {
@@ -221,23 +349,28 @@ func TestGetBidsByTenderID_Success(t *testing.T) {
 		UpdatedAt: time.Now(),
 	}
 
-	mockBidRepo.On("FindByTenderID", mock.Anything, 1).Return(expectedBids, nil)
-	mockBidRepo.On("FindLatestVersion", mock.Anything, 1).Return(latestVersion, nil)
+	expectedBids[0].CurrentVersion = latestVersion
+
+	mockBidRepo.On("FindByTenderID", mock.Anything, 1, filter.Filter(nil), pagination.DefaultParams()).Return(expectedBids, int64(1), nil)
 
-	result, err := service.GetBidsByTenderID(context.Background(), 1)
+	result, err := service.GetBidsByTenderID(context.Background(), 1, nil, pagination.DefaultParams())
 
 	assert.NoError(t, err)
-	assert.Len(t, result, 1)
-	assert.Equal(t, latestVersion.Name, result[0].Name)
+	assert.Len(t, result.Items, 1)
+	assert.Equal(t, int64(1), result.Total)
+	assert.Nil(t, result.NextOffset)
+	assert.Equal(t, latestVersion.Name, result.Items[0].Name)
+	assert.Equal(t, latestVersion.Version, result.Items[0].Version)
 	mockBidRepo.AssertExpectations(t)
+	mockBidRepo.AssertNotCalled(t, "FindLatestVersion")
 }
 
 func TestGetBidsByTenderID_NotFound(t *testing.T) {
 	mockBidRepo, _, _, _, service := setupMocks()
 
-	mockBidRepo.On("FindByTenderID", mock.Anything, 1).Return(nil, errors.New("no bids found"))
+	mockBidRepo.On("FindByTenderID", mock.Anything, 1, filter.Filter(nil), pagination.DefaultParams()).Return(nil, int64(0), errors.New("no bids found"))
 
-	_, err := service.GetBidsByTenderID(context.Background(), 1)
+	_, err := service.GetBidsByTenderID(context.Background(), 1, nil, pagination.DefaultParams())
 
 	assert.Error(t, err)
 	assert.Equal(t, "no bids found", err.Error())
@@ -271,7 +404,7 @@ func TestGetBidsByUsername_Success(t *testing.T) {
 			CreatedAt:      time.Now(),
 		},
 	}
-	mockBidRepo.On("FindByCreatorID", mock.Anything, expectedUser.ID).Return(expectedBids, nil)
+	mockBidRepo.On("FindByCreatorID", mock.Anything, expectedUser.ID, pagination.DefaultParams()).Return(expectedBids, int64(2), nil)
 
 	latestVersion1 := &entities.BidVersion{
 		BidID:       1,
@@ -287,22 +420,23 @@ func TestGetBidsByUsername_Success(t *testing.T) {
 		Description: "Test Description 2",
 		UpdatedAt:   time.Now(),
 	}
-	mockBidRepo.On("FindLatestVersion", mock.Anything, 1).Return(latestVersion1, nil)
-	mockBidRepo.On("FindLatestVersion", mock.Anything, 2).Return(latestVersion2, nil)
+	expectedBids[0].CurrentVersion = latestVersion1
+	expectedBids[1].CurrentVersion = latestVersion2
 
-	result, err := service.GetBidsByUsername(context.Background(), "testuser")
+	result, err := service.GetBidsByUsername(context.Background(), "testuser", pagination.DefaultParams())
 
 	assert.NoError(t, err)
-	assert.Len(t, result, 2)
+	assert.Len(t, result.Items, 2)
+	assert.Equal(t, int64(2), result.Total)
 
-	t.Logf("Result[0]: %+v", result[0])
-	t.Logf("Result[1]: %+v", result[1])
+	t.Logf("Result[0]: %+v", result.Items[0])
+	t.Logf("Result[1]: %+v", result.Items[1])
 
-	assert.Equal(t, latestVersion1.Name, result[0].Name)
-	assert.Equal(t, latestVersion1.Description, result[0].Description)
+	assert.Equal(t, latestVersion1.Name, result.Items[0].Name)
+	assert.Equal(t, latestVersion1.Description, result.Items[0].Description)
 
-	assert.Equal(t, latestVersion2.Name, result[1].Name)
-	assert.Equal(t, latestVersion2.Description, result[1].Description)
+	assert.Equal(t, latestVersion2.Name, result.Items[1].Name)
+	assert.Equal(t, latestVersion2.Description, result.Items[1].Description)
 
 	mockBidRepo.AssertExpectations(t)
 	mockUserRepo.AssertExpectations(t)
@@ -313,7 +447,7 @@ func TestGetBidsByUsername_UserNotFound(t *testing.T) {
 
 	mockUserRepo.On("FindByUsername", mock.Anything, "unknownuser").Return(nil, errors.New("user not found"))
 
-	_, err := service.GetBidsByUsername(context.Background(), "unknownuser")
+	_, err := service.GetBidsByUsername(context.Background(), "unknownuser", pagination.DefaultParams())
 
 	assert.Error(t, err)
 	assert.Equal(t, "user not found", err.Error())
@@ -330,9 +464,9 @@ func TestGetBidsByUsername_NoBidsFound(t *testing.T) {
 	}
 	mockUserRepo.On("FindByUsername", mock.Anything, "testuser").Return(expectedUser, nil)
 
-	mockBidRepo.On("FindByCreatorID", mock.Anything, expectedUser.ID).Return(nil, errors.New("no bids found"))
+	mockBidRepo.On("FindByCreatorID", mock.Anything, expectedUser.ID, pagination.DefaultParams()).Return(nil, int64(0), errors.New("no bids found"))
 
-	_, err := service.GetBidsByUsername(context.Background(), "testuser")
+	_, err := service.GetBidsByUsername(context.Background(), "testuser", pagination.DefaultParams())
 
 	assert.Error(t, err)
 	assert.Equal(t, "no bids found", err.Error())
@@ -419,6 +553,205 @@ func TestApproveBid_UserNotResponsible(t *testing.T) {
 	mockOrgRepo.AssertExpectations(t)
 }
 
+func TestApproveBid_DeclaredConflict(t *testing.T) {
+	mockBidRepo, mockOrgRepo, _, mockTenderRepo, service := setupMocks()
+
+	existingBid := &entities.Bid{
+		ID:             1,
+		TenderID:       3,
+		OrganizationID: 1,
+		CreatorID:      9,
+		Status:         "CREATED",
+		CreatedAt:      time.Now(),
+	}
+
+	mockBidRepo.On("FindByID", mock.Anything, 1).Return(existingBid, nil)
+	mockOrgRepo.On("GetResponsibles", mock.Anything, 1).Return([]entities.User{{ID: 2}, {ID: 3}}, nil)
+	mockTenderRepo.On("FindByID", mock.Anything, 3).Return(&entities.Tender{ID: 3, OrganizationID: 5}, nil)
+	mockTenderRepo.On("FindUserOrganizationResponsibility", mock.Anything, 2, 5).Return(nil, nil)
+	mockBidRepo.On("FindConflictsByBidID", mock.Anything, 1).Return([]*entities.BidConflict{{BidID: 1, UserID: 2}}, nil)
+
+	err := service.ApproveBid(context.Background(), 1, 2)
+
+	assert.ErrorIs(t, err, bid_errors.ErrApproverConflict)
+	mockBidRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	mockTenderRepo.AssertExpectations(t)
+}
+
+func TestApproveBid_QuorumExcludesDeclaredConflicts(t *testing.T) {
+	mockBidRepo, mockOrgRepo, _, mockTenderRepo, service := setupMocks()
+
+	existingBid := &entities.Bid{
+		ID:             1,
+		TenderID:       3,
+		OrganizationID: 1,
+		CreatorID:      9,
+		Status:         "CREATED",
+		CreatedAt:      time.Now(),
+	}
+
+	mockBidRepo.On("FindByID", mock.Anything, 1).Return(existingBid, nil)
+	mockOrgRepo.On("GetResponsibles", mock.Anything, 1).Return([]entities.User{{ID: 2}, {ID: 3}}, nil)
+	mockTenderRepo.On("FindByID", mock.Anything, 3).Return(&entities.Tender{ID: 3, OrganizationID: 5}, nil)
+	mockTenderRepo.On("FindUserOrganizationResponsibility", mock.Anything, 2, 5).Return(nil, nil)
+	mockBidRepo.On("FindConflictsByBidID", mock.Anything, 1).Return([]*entities.BidConflict{{BidID: 1, UserID: 3}}, nil)
+	mockBidRepo.On("HasDecision", mock.Anything, 1, 2).Return(false, nil)
+	mockTenderRepo.On("CloseTender", mock.Anything, 3).Return(nil)
+	mockBidRepo.On("Update", mock.Anything, existingBid).Return(nil)
+	mockBidRepo.On("CreateDecision", mock.Anything, mock.MatchedBy(func(decision *entities.BidDecision) bool {
+		return decision.BidID == 1 && decision.UserID == 2 && decision.Decision == string(constants.BidDecisionApproved)
+	})).Return(nil)
+
+	err := service.ApproveBid(context.Background(), 1, 2)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "APPROVED", existingBid.Status)
+	mockBidRepo.AssertExpectations(t)
+	mockTenderRepo.AssertExpectations(t)
+}
+
+func TestApproveBid_AlreadyDecided(t *testing.T) {
+	mockBidRepo, mockOrgRepo, _, mockTenderRepo, service := setupMocks()
+
+	existingBid := &entities.Bid{ID: 1, TenderID: 3, OrganizationID: 1, CreatorID: 9, Status: "CREATED", ApprovalCount: 1}
+
+	mockBidRepo.On("FindByID", mock.Anything, 1).Return(existingBid, nil)
+	mockOrgRepo.On("GetResponsibles", mock.Anything, 1).Return([]entities.User{{ID: 2}, {ID: 3}, {ID: 4}}, nil)
+	mockTenderRepo.On("FindByID", mock.Anything, 3).Return(&entities.Tender{ID: 3, OrganizationID: 5}, nil)
+	mockTenderRepo.On("FindUserOrganizationResponsibility", mock.Anything, 2, 5).Return(nil, nil)
+	mockBidRepo.On("FindConflictsByBidID", mock.Anything, 1).Return([]*entities.BidConflict{}, nil)
+	mockBidRepo.On("HasDecision", mock.Anything, 1, 2).Return(true, nil)
+
+	err := service.ApproveBid(context.Background(), 1, 2)
+
+	assert.ErrorIs(t, err, bid_errors.ErrAlreadyDecided)
+	assert.Equal(t, 1, existingBid.ApprovalCount)
+	mockBidRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	mockBidRepo.AssertNotCalled(t, "CreateDecision", mock.Anything, mock.Anything)
+}
+
+func TestApproveBid_AlreadyApproved(t *testing.T) {
+	mockBidRepo, mockOrgRepo, _, mockTenderRepo, service := setupMocks()
+
+	existingBid := &entities.Bid{ID: 1, TenderID: 3, OrganizationID: 1, CreatorID: 9, Status: "APPROVED", ApprovalCount: 1}
+
+	mockBidRepo.On("FindByID", mock.Anything, 1).Return(existingBid, nil)
+	mockOrgRepo.On("GetResponsibles", mock.Anything, 1).Return([]entities.User{{ID: 2}, {ID: 3}}, nil)
+	mockTenderRepo.On("FindByID", mock.Anything, 3).Return(&entities.Tender{ID: 3, OrganizationID: 5}, nil)
+	mockTenderRepo.On("FindUserOrganizationResponsibility", mock.Anything, 3, 5).Return(nil, nil)
+	mockBidRepo.On("FindConflictsByBidID", mock.Anything, 1).Return([]*entities.BidConflict{}, nil)
+	mockBidRepo.On("HasDecision", mock.Anything, 1, 3).Return(false, nil)
+
+	var published []events.Event
+	unsubscribeTenders := events.Subscribe(events.TopicTender, func(_ context.Context, event events.Event) {
+		published = append(published, event)
+	})
+	defer unsubscribeTenders()
+	unsubscribeBids := events.Subscribe(events.TopicBid, func(_ context.Context, event events.Event) {
+		published = append(published, event)
+	})
+	defer unsubscribeBids()
+
+	err := service.ApproveBid(context.Background(), 1, 3)
+
+	assert.ErrorIs(t, err, bid_errors.ErrBidAlreadyApproved)
+	assert.Empty(t, published)
+	assert.Equal(t, 1, existingBid.ApprovalCount)
+	mockTenderRepo.AssertNotCalled(t, "CloseTender", mock.Anything, mock.Anything)
+	mockBidRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	mockBidRepo.AssertNotCalled(t, "CreateDecision", mock.Anything, mock.Anything)
+}
+
+func TestRejectBid_RecordsDecision(t *testing.T) {
+	mockBidRepo, mockOrgRepo, _, _, service := setupMocks()
+
+	existingBid := &entities.Bid{ID: 1, TenderID: 3, OrganizationID: 1, Status: "CREATED"}
+
+	mockBidRepo.On("FindByID", mock.Anything, 1).Return(existingBid, nil)
+	mockOrgRepo.On("GetResponsibles", mock.Anything, 1).Return([]entities.User{{ID: 2}}, nil)
+	mockBidRepo.On("Update", mock.Anything, existingBid).Return(nil)
+	mockBidRepo.On("CreateDecision", mock.Anything, mock.MatchedBy(func(decision *entities.BidDecision) bool {
+		return decision.BidID == 1 && decision.UserID == 2 && decision.Decision == string(constants.BidDecisionRejected)
+	})).Return(nil)
+
+	err := service.RejectBid(context.Background(), 1, 2)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "REJECTED", existingBid.Status)
+	mockBidRepo.AssertExpectations(t)
+}
+
+func TestExportDecisions_Success(t *testing.T) {
+	mockBidRepo, _, _, _, service := setupMocks()
+
+	mockBidRepo.On("StreamDecisionsByTenderOrganizationID", mock.Anything, 1, filter.Filter(nil)).Return([][]*entities.BidDecision{
+		{
+			{ID: 1, BidID: 4, Bid: entities.Bid{ID: 4, TenderID: 7}, UserID: 2, Decision: string(constants.BidDecisionApproved)},
+			{ID: 2, BidID: 5, Bid: entities.Bid{ID: 5, TenderID: 7}, UserID: 3, Decision: string(constants.BidDecisionRejected)},
+		},
+	}, nil)
+
+	var decisions []*bid_models.BidDecisionModel
+	err := service.ExportDecisions(context.Background(), 1, nil, func(decision *bid_models.BidDecisionModel) error {
+		decisions = append(decisions, decision)
+		return nil
+	})
+
+	assert.NoError(t, err)
+	assert.Len(t, decisions, 2)
+	assert.Equal(t, 7, decisions[0].TenderID)
+	assert.Equal(t, string(constants.BidDecisionRejected), decisions[1].Decision)
+	mockBidRepo.AssertExpectations(t)
+}
+
+func TestDeclareConflict_Success(t *testing.T) {
+	mockBidRepo, mockOrgRepo, _, _, service := setupMocks()
+
+	existingBid := &entities.Bid{ID: 1, TenderID: 3, OrganizationID: 1, Status: "CREATED"}
+
+	mockBidRepo.On("FindByID", mock.Anything, 1).Return(existingBid, nil)
+	mockOrgRepo.On("GetResponsibles", mock.Anything, 1).Return([]entities.User{{ID: 2}}, nil)
+	mockBidRepo.On("FindConflictsByBidID", mock.Anything, 1).Return([]*entities.BidConflict{}, nil)
+	mockBidRepo.On("CreateConflict", mock.Anything, mock.AnythingOfType("*entities.BidConflict")).Return(nil)
+
+	ctx := auth.NewContext(context.Background(), auth.Principal{Client: "web", UserID: 2})
+	result, err := service.DeclareConflict(ctx, bid_models.BidConflictCreateModel{BidID: 1, UserID: 2, Reason: "Former employee"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, result.UserID)
+	assert.Equal(t, "Former employee", result.Reason)
+	mockBidRepo.AssertExpectations(t)
+}
+
+func TestDeclareConflict_AlreadyDeclared(t *testing.T) {
+	mockBidRepo, mockOrgRepo, _, _, service := setupMocks()
+
+	existingBid := &entities.Bid{ID: 1, TenderID: 3, OrganizationID: 1, Status: "CREATED"}
+
+	mockBidRepo.On("FindByID", mock.Anything, 1).Return(existingBid, nil)
+	mockOrgRepo.On("GetResponsibles", mock.Anything, 1).Return([]entities.User{{ID: 2}}, nil)
+	mockBidRepo.On("FindConflictsByBidID", mock.Anything, 1).Return([]*entities.BidConflict{{BidID: 1, UserID: 2}}, nil)
+
+	ctx := auth.NewContext(context.Background(), auth.Principal{Client: "web", UserID: 2})
+	_, err := service.DeclareConflict(ctx, bid_models.BidConflictCreateModel{BidID: 1, UserID: 2})
+
+	assert.ErrorIs(t, err, bid_errors.ErrConflictAlreadyDeclared)
+	mockBidRepo.AssertNotCalled(t, "CreateConflict", mock.Anything, mock.Anything)
+}
+
+func TestDeclareConflict_OnBehalfOfAnotherUser(t *testing.T) {
+	mockBidRepo, _, _, _, service := setupMocks()
+
+	_, err := service.DeclareConflict(context.Background(), bid_models.BidConflictCreateModel{BidID: 1, UserID: 2})
+	assert.ErrorIs(t, err, bid_errors.ErrDeclarantRequired)
+
+	ctx := auth.NewContext(context.Background(), auth.Principal{Client: "web", UserID: 3})
+	_, err = service.DeclareConflict(ctx, bid_models.BidConflictCreateModel{BidID: 1, UserID: 2})
+	assert.ErrorIs(t, err, bid_errors.ErrNotDeclarant)
+
+	mockBidRepo.AssertNotCalled(t, "CreateConflict", mock.Anything, mock.Anything)
+}
+
 // func TestRejectBid_Success(t *testing.T) {
 // 	mockBidRepo, mockOrgRepo, _, _, service := setupMocks()
 
@@ -0,0 +1,119 @@
+package category_service_test
+
+import (
+	"avitoTest/data/entities"
+	"avitoTest/data/repositories/category_repository"
+	"avitoTest/services/category_service"
+	"avitoTest/services/category_service/category_models"
+	"avitoTest/shared/errors/category_errors"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func setupMocks() (*category_repository.MockCategoryRepository, category_service.CategoryService) {
+	mockCategoryRepo := new(category_repository.MockCategoryRepository)
+	service := category_service.NewCategoryService(mockCategoryRepo)
+	return mockCategoryRepo, service
+}
+
+func intPtr(v int) *int {
+	return &v
+}
+
+func TestCreateCategory_Success(t *testing.T) {
+	mockCategoryRepo, service := setupMocks()
+
+	model := category_models.CategoryCreateModel{
+		Code:     "Software Development",
+		ParentID: intPtr(2),
+		Names:    map[string]string{"ru": "Разработка ПО", "en": "Software Development"},
+	}
+
+	mockCategoryRepo.On("FindByCode", mock.Anything, "Software Development").Return(nil, category_errors.ErrCategoryNotFound)
+	mockCategoryRepo.On("FindByID", mock.Anything, 2).Return(&entities.ServiceCategory{ID: 2, Code: "IT Services", IsActive: true}, nil)
+	mockCategoryRepo.On("Create", mock.Anything, mock.AnythingOfType("*entities.ServiceCategory")).Return(nil).Run(func(args mock.Arguments) {
+		args.Get(1).(*entities.ServiceCategory).ID = 4
+	})
+
+	result, err := service.CreateCategory(context.Background(), model)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 4, result.ID)
+	assert.True(t, result.IsActive)
+	assert.Equal(t, "Разработка ПО", result.Name)
+	assert.Equal(t, 2, *result.ParentID)
+	mockCategoryRepo.AssertExpectations(t)
+}
+
+func TestCreateCategory_CodeTaken(t *testing.T) {
+	mockCategoryRepo, service := setupMocks()
+
+	mockCategoryRepo.On("FindByCode", mock.Anything, "Consulting").Return(&entities.ServiceCategory{ID: 3, Code: "Consulting"}, nil)
+
+	_, err := service.CreateCategory(context.Background(), category_models.CategoryCreateModel{
+		Code:  "Consulting",
+		Names: map[string]string{"en": "Consulting"},
+	})
+
+	assert.ErrorIs(t, err, category_errors.ErrCategoryCodeTaken)
+	mockCategoryRepo.AssertNotCalled(t, "Create")
+}
+
+func TestUpdateCategory_ParentCycle(t *testing.T) {
+	mockCategoryRepo, service := setupMocks()
+
+	mockCategoryRepo.On("FindByID", mock.Anything, 2).Return(&entities.ServiceCategory{ID: 2, Code: "IT Services"}, nil)
+	mockCategoryRepo.On("FindByID", mock.Anything, 4).Return(&entities.ServiceCategory{ID: 4, Code: "Software Development", ParentID: intPtr(2)}, nil)
+
+	_, err := service.UpdateCategory(context.Background(), category_models.CategoryUpdateModel{
+		ID:       2,
+		ParentID: intPtr(4),
+		IsActive: true,
+		Names:    map[string]string{"en": "IT Services"},
+	})
+
+	assert.ErrorIs(t, err, category_errors.ErrInvalidParent)
+	mockCategoryRepo.AssertNotCalled(t, "Update")
+}
+
+func TestDeleteCategory_HasChildren(t *testing.T) {
+	mockCategoryRepo, service := setupMocks()
+
+	mockCategoryRepo.On("FindByID", mock.Anything, 2).Return(&entities.ServiceCategory{ID: 2, Code: "IT Services"}, nil)
+	mockCategoryRepo.On("CountChildren", mock.Anything, 2).Return(int64(1), nil)
+
+	err := service.DeleteCategory(context.Background(), 2)
+
+	assert.ErrorIs(t, err, category_errors.ErrCategoryHasChildren)
+	mockCategoryRepo.AssertNotCalled(t, "Delete")
+}
+
+func TestDeleteCategory_InUse(t *testing.T) {
+	mockCategoryRepo, service := setupMocks()
+
+	mockCategoryRepo.On("FindByID", mock.Anything, 2).Return(&entities.ServiceCategory{ID: 2, Code: "IT Services"}, nil)
+	mockCategoryRepo.On("CountChildren", mock.Anything, 2).Return(int64(0), nil)
+	mockCategoryRepo.On("IsCodeInUse", mock.Anything, "IT Services").Return(true, nil)
+
+	err := service.DeleteCategory(context.Background(), 2)
+
+	assert.ErrorIs(t, err, category_errors.ErrCategoryInUse)
+	mockCategoryRepo.AssertNotCalled(t, "Delete")
+}
+
+func TestGetCategories_FallbackName(t *testing.T) {
+	mockCategoryRepo, service := setupMocks()
+
+	mockCategoryRepo.On("GetAll", mock.Anything, false).Return([]*entities.ServiceCategory{
+		{ID: 1, Code: "Construction", IsActive: true, Names: []entities.ServiceCategoryName{{Language: "ru", Name: "Строительство"}}},
+	}, nil)
+
+	result, err := service.GetCategories(context.Background(), "en", false)
+
+	assert.NoError(t, err)
+	assert.Len(t, result, 1)
+	assert.Equal(t, "Строительство", result[0].Name)
+}
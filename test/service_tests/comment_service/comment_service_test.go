@@ -5,6 +5,7 @@ import (
 	"avitoTest/data/repositories/comment_repository"
 	"avitoTest/services/comment_service"
 	"avitoTest/services/comment_service/comment_models"
+	"avitoTest/shared/pagination"
 	"context"
 	"errors"
 	"testing"
@@ -74,7 +75,7 @@ func TestCreateComment_ValidationFail(t *testing.T) {
 	_, err := service.CreateComment(context.Background(), commentCreate)
 
 	assert.Error(t, err)
-	assert.Contains(t, err.Error(), "user ID is required")
+	assert.Contains(t, err.Error(), "user_id is required")
 
 	mockCommentRepo.AssertNotCalled(t, "Create")
 }
@@ -109,14 +110,19 @@ func TestGetCommentsByFilters_Success(t *testing.T) {
 		},
 	}
 
-	mockCommentRepo.On("FindByFilters", mock.Anything, "testuser", 1).Return(expectedComments, nil)
+	params := pagination.Params{Limit: 2, Offset: 0, Sort: pagination.SortCreatedAt, Order: pagination.OrderDesc}
+	mockCommentRepo.On("FindByFilters", mock.Anything, "testuser", 1, params).Return(expectedComments, int64(5), nil)
 
-	result, err := service.GetCommentsByFilters(context.Background(), "testuser", 1)
+	result, err := service.GetCommentsByFilters(context.Background(), "testuser", 1, params)
 
 	assert.NoError(t, err)
-	assert.Len(t, result, 2)
-	assert.Equal(t, "First comment", result[0].Content)
-	assert.Equal(t, "Second comment", result[1].Content)
+	assert.Len(t, result.Items, 2)
+	assert.Equal(t, int64(5), result.Total)
+	if assert.NotNil(t, result.NextOffset) {
+		assert.Equal(t, 2, *result.NextOffset)
+	}
+	assert.Equal(t, "First comment", result.Items[0].Content)
+	assert.Equal(t, "Second comment", result.Items[1].Content)
 	mockCommentRepo.AssertExpectations(t)
 }
 
@@ -138,22 +144,22 @@ func TestGetCommentsByFilters_NoOrganization_Success(t *testing.T) {
 		},
 	}
 
-	mockCommentRepo.On("FindByFilters", mock.Anything, "testuser", 0).Return(expectedComments, nil)
+	mockCommentRepo.On("FindByFilters", mock.Anything, "testuser", 0, pagination.DefaultParams()).Return(expectedComments, int64(1), nil)
 
-	result, err := service.GetCommentsByFilters(context.Background(), "testuser", 0)
+	result, err := service.GetCommentsByFilters(context.Background(), "testuser", 0, pagination.DefaultParams())
 
 	assert.NoError(t, err)
-	assert.Len(t, result, 1)
-	assert.Equal(t, "First comment", result[0].Content)
+	assert.Len(t, result.Items, 1)
+	assert.Equal(t, "First comment", result.Items[0].Content)
 	mockCommentRepo.AssertExpectations(t)
 }
 
 func TestGetCommentsByFilters_UserNotFound(t *testing.T) {
 	mockCommentRepo, service := setupMocks()
 
-	mockCommentRepo.On("FindByFilters", mock.Anything, "unknownuser", 0).Return(nil, errors.New("no comments found"))
+	mockCommentRepo.On("FindByFilters", mock.Anything, "unknownuser", 0, pagination.DefaultParams()).Return(nil, int64(0), errors.New("no comments found"))
 
-	_, err := service.GetCommentsByFilters(context.Background(), "unknownuser", 0)
+	_, err := service.GetCommentsByFilters(context.Background(), "unknownuser", 0, pagination.DefaultParams())
 
 	assert.Error(t, err)
 	assert.Equal(t, "no comments found", err.Error())
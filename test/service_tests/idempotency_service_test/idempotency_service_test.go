@@ -0,0 +1,110 @@
+package idempotency_service_test
+
+import (
+	"avitoTest/data/entities"
+	"avitoTest/data/repositories/idempotency_repository"
+	"avitoTest/services/idempotency_service"
+	"avitoTest/services/idempotency_service/idempotency_models"
+	"avitoTest/shared/errors/idempotency_errors"
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func setupMocks() (*idempotency_repository.MockIdempotencyRepository, idempotency_service.IdempotencyService) {
+	mockRepo := new(idempotency_repository.MockIdempotencyRepository)
+	service := idempotency_service.NewIdempotencyService(mockRepo, 24*time.Hour, 10*time.Minute)
+	return mockRepo, service
+}
+
+func TestBegin_ClaimsNewKey(t *testing.T) {
+	mockRepo, service := setupMocks()
+
+	var claimed *entities.IdempotencyKey
+	mockRepo.On("Claim", mock.Anything, mock.AnythingOfType("*entities.IdempotencyKey")).Return(nil, true, nil).Run(func(args mock.Arguments) {
+		claimed = args.Get(1).(*entities.IdempotencyKey)
+	})
+
+	stored, claim, err := service.Begin(context.Background(), "client:erp", "key-1", "abc")
+
+	assert.NoError(t, err)
+	assert.Nil(t, stored)
+	assert.NotEmpty(t, claim)
+	assert.Equal(t, claim, claimed.Claim)
+	assert.Equal(t, "client:erp", claimed.Scope)
+	assert.Equal(t, "abc", claimed.Fingerprint)
+	assert.False(t, claimed.Completed)
+	assert.WithinDuration(t, time.Now().Add(10*time.Minute), claimed.LockedUntil, time.Minute)
+	assert.WithinDuration(t, time.Now().Add(24*time.Hour), claimed.ExpiresAt, time.Minute)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestBegin_ReturnsStoredResponse(t *testing.T) {
+	mockRepo, service := setupMocks()
+
+	mockRepo.On("Claim", mock.Anything, mock.Anything).Return(&entities.IdempotencyKey{
+		Fingerprint: "abc",
+		Completed:   true,
+		Status:      http.StatusCreated,
+		Header:      `{"Content-Type":["application/json"]}`,
+		Body:        []byte(`{"id":1}`),
+	}, false, nil)
+
+	stored, _, err := service.Begin(context.Background(), "client:erp", "key-1", "abc")
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusCreated, stored.Status)
+	assert.Equal(t, "application/json", stored.Header.Get("Content-Type"))
+	assert.JSONEq(t, `{"id":1}`, string(stored.Body))
+}
+
+func TestBegin_DifferentRequest(t *testing.T) {
+	mockRepo, service := setupMocks()
+
+	mockRepo.On("Claim", mock.Anything, mock.Anything).Return(&entities.IdempotencyKey{Fingerprint: "abc", Completed: true}, false, nil)
+
+	_, _, err := service.Begin(context.Background(), "client:erp", "key-1", "def")
+
+	assert.ErrorIs(t, err, idempotency_errors.ErrKeyReused)
+}
+
+func TestBegin_RequestInProgress(t *testing.T) {
+	mockRepo, service := setupMocks()
+
+	mockRepo.On("Claim", mock.Anything, mock.Anything).Return(&entities.IdempotencyKey{Fingerprint: "abc"}, false, nil)
+
+	_, _, err := service.Begin(context.Background(), "client:erp", "key-1", "abc")
+
+	assert.ErrorIs(t, err, idempotency_errors.ErrRequestInProgress)
+}
+
+func TestBegin_InvalidKey(t *testing.T) {
+	mockRepo, service := setupMocks()
+
+	_, _, err := service.Begin(context.Background(), "client:erp", "", "abc")
+	assert.ErrorIs(t, err, idempotency_errors.ErrInvalidKey)
+
+	_, _, err = service.Begin(context.Background(), "client:erp", strings.Repeat("k", 256), "abc")
+	assert.ErrorIs(t, err, idempotency_errors.ErrInvalidKey)
+	mockRepo.AssertNotCalled(t, "Claim", mock.Anything, mock.Anything)
+}
+
+func TestComplete_StoresHeadersAsJSON(t *testing.T) {
+	mockRepo, service := setupMocks()
+
+	mockRepo.On("Complete", mock.Anything, "client:erp", "key-1", "claim-1", http.StatusCreated, `{"Location":["/api/tenders/1"]}`, []byte("{}")).Return(nil)
+
+	err := service.Complete(context.Background(), "client:erp", "key-1", "claim-1", idempotency_models.StoredResponse{
+		Status: http.StatusCreated,
+		Header: http.Header{"Location": {"/api/tenders/1"}},
+		Body:   []byte("{}"),
+	})
+
+	assert.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+}
@@ -0,0 +1,128 @@
+package notification_service_test
+
+import (
+	"avitoTest/services/notification_service"
+	"bufio"
+	"context"
+	"net"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// smtpServer is a minimal SMTP server accepting every message, standing in for Mailpit.
+type smtpServer struct {
+	listener net.Listener
+	messages chan string
+}
+
+func startSMTPServer(t *testing.T) *smtpServer {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { listener.Close() })
+
+	server := &smtpServer{listener: listener, messages: make(chan string, 1)}
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go server.serve(conn)
+		}
+	}()
+	return server
+}
+
+func (s *smtpServer) port() int {
+	return s.listener.Addr().(*net.TCPAddr).Port
+}
+
+func (s *smtpServer) serve(conn net.Conn) {
+	defer conn.Close()
+	text := textproto.NewConn(conn)
+	text.PrintfLine("220 localhost ESMTP")
+	for {
+		line, err := text.ReadLine()
+		if err != nil {
+			return
+		}
+		switch command := strings.ToUpper(strings.Fields(line)[0]); command {
+		case "EHLO", "HELO":
+			text.PrintfLine("250-localhost")
+			text.PrintfLine("250 8BITMIME")
+		case "DATA":
+			text.PrintfLine("354 go ahead")
+			data, err := text.ReadDotBytes()
+			if err != nil {
+				return
+			}
+			s.messages <- string(data)
+			text.PrintfLine("250 queued")
+		case "QUIT":
+			text.PrintfLine("221 bye")
+			return
+		default:
+			text.PrintfLine("250 ok")
+		}
+	}
+}
+
+func mailerConfig(port int, startTLS bool) notification_service.SMTPConfig {
+	return notification_service.SMTPConfig{
+		Host:     "127.0.0.1",
+		Port:     port,
+		From:     "Tenders <noreply@tenders.example.com>",
+		StartTLS: startTLS,
+		Timeout:  5 * time.Second,
+	}
+}
+
+func TestSMTPMailer_SendsMessage(t *testing.T) {
+	server := startSMTPServer(t)
+	mailer := notification_service.NewSMTPMailer(mailerConfig(server.port(), false))
+
+	err := mailer.Send(context.Background(), notification_service.Email{
+		To:      "ivan@example.com",
+		Subject: `New bid on tender "Уборка офиса"`,
+		Body:    "Hello, Ivan.\n\nA new bid was submitted.",
+	})
+	require.NoError(t, err)
+
+	message := <-server.messages
+	header, err := textproto.NewReader(bufio.NewReader(strings.NewReader(message))).ReadMIMEHeader()
+	require.NoError(t, err)
+	assert.Equal(t, `"Tenders" <noreply@tenders.example.com>`, header.Get("From"))
+	assert.Equal(t, "<ivan@example.com>", header.Get("To"))
+	assert.True(t, strings.HasPrefix(header.Get("Subject"), "=?utf-8?q?"))
+	assert.True(t, strings.HasSuffix(header.Get("Message-Id"), "@tenders.example.com>"))
+	assert.Equal(t, "quoted-printable", header.Get("Content-Transfer-Encoding"))
+	assert.Contains(t, message, "Hello, Ivan.")
+}
+
+func TestSMTPMailer_RequiresStartTLSWhenConfigured(t *testing.T) {
+	server := startSMTPServer(t)
+	mailer := notification_service.NewSMTPMailer(mailerConfig(server.port(), true))
+
+	err := mailer.Send(context.Background(), notification_service.Email{To: "ivan@example.com", Subject: "Hi", Body: "Hello"})
+
+	assert.ErrorContains(t, err, "STARTTLS")
+	assert.Empty(t, server.messages)
+}
+
+func TestSMTPMailer_FailsWhenServerIsDown(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	port := listener.Addr().(*net.TCPAddr).Port
+	listener.Close()
+
+	mailer := notification_service.NewSMTPMailer(mailerConfig(port, false))
+	err = mailer.Send(context.Background(), notification_service.Email{To: "ivan@example.com", Subject: "Hi", Body: "Hello"})
+
+	assert.ErrorContains(t, err, strconv.Itoa(port))
+}
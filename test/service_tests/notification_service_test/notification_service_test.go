@@ -0,0 +1,266 @@
+package notification_service_test
+
+import (
+	"avitoTest/data/entities"
+	"avitoTest/data/repositories/bid_repository"
+	"avitoTest/data/repositories/notification_repository"
+	"avitoTest/data/repositories/organization_repository"
+	"avitoTest/data/repositories/user_repository"
+	"avitoTest/services/bid_service/bid_models"
+	"avitoTest/services/notification_service"
+	"avitoTest/services/notification_service/notification_models"
+	"avitoTest/services/tender_service/tender_models"
+	"avitoTest/shared/auth"
+	"avitoTest/shared/constants"
+	"avitoTest/shared/errors/domain_errors"
+	"avitoTest/shared/errors/notification_errors"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var retryPolicy = notification_service.RetryPolicy{MaxAttempts: 3, Backoff: time.Minute, MaxBackoff: 90 * time.Second}
+
+// fakeMailer records the emails sent through it and fails with err when it is set.
+type fakeMailer struct {
+	sent []notification_service.Email
+	err  error
+}
+
+func (m *fakeMailer) Send(_ context.Context, email notification_service.Email) error {
+	if m.err != nil {
+		return m.err
+	}
+	m.sent = append(m.sent, email)
+	return nil
+}
+
+type mocks struct {
+	notificationRepo *notification_repository.MockNotificationRepository
+	userRepo         *user_repository.MockUserRepository
+	orgRepo          *organization_repository.MockOrganizationRepository
+	bidRepo          *bid_repository.MockBidRepository
+	mailer           *fakeMailer
+}
+
+func setupMocks() (*mocks, notification_service.NotificationService) {
+	m := &mocks{
+		notificationRepo: new(notification_repository.MockNotificationRepository),
+		userRepo:         new(user_repository.MockUserRepository),
+		orgRepo:          new(organization_repository.MockOrganizationRepository),
+		bidRepo:          new(bid_repository.MockBidRepository),
+		mailer:           new(fakeMailer),
+	}
+	service := notification_service.NewNotificationService(m.notificationRepo, m.userRepo, m.orgRepo, m.bidRepo, m.mailer, retryPolicy)
+	return m, service
+}
+
+func userContext(userID int) context.Context {
+	return auth.NewContext(context.Background(), auth.Principal{UserID: userID})
+}
+
+func TestGetPreferences_DefaultsToNoEvents(t *testing.T) {
+	m, service := setupMocks()
+
+	m.userRepo.On("FindByID", mock.Anything, 3).Return(&entities.User{ID: 3}, nil)
+	m.notificationRepo.On("FindPreferences", mock.Anything, 3).Return(nil, notification_errors.ErrPreferencesNotFound)
+
+	prefs, err := service.GetPreferences(userContext(3), 3)
+
+	require.NoError(t, err)
+	assert.Equal(t, 3, prefs.UserID)
+	assert.Empty(t, prefs.Email)
+	assert.Equal(t, []string{}, prefs.EventTypes)
+	assert.Nil(t, prefs.UpdatedAt)
+}
+
+func TestPreferences_OnlyOfTheUserThemselves(t *testing.T) {
+	m, service := setupMocks()
+
+	_, err := service.GetPreferences(context.Background(), 3)
+	assert.ErrorIs(t, err, notification_errors.ErrAuthenticationRequired)
+
+	_, err = service.UpdatePreferences(userContext(4), notification_models.NotificationPreferencesUpdateModel{UserID: 3})
+	assert.ErrorIs(t, err, notification_errors.ErrNotOwnPreferences)
+
+	m.notificationRepo.AssertNotCalled(t, "SavePreferences", mock.Anything, mock.Anything)
+}
+
+func TestUpdatePreferences_SavesSortedEventTypes(t *testing.T) {
+	m, service := setupMocks()
+
+	m.userRepo.On("FindByID", mock.Anything, 3).Return(&entities.User{ID: 3}, nil)
+	var saved *entities.NotificationPreference
+	m.notificationRepo.On("SavePreferences", mock.Anything, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		saved = args.Get(1).(*entities.NotificationPreference)
+	})
+
+	prefs, err := service.UpdatePreferences(userContext(3), notification_models.NotificationPreferencesUpdateModel{
+		UserID:     3,
+		Email:      "anna@example.com",
+		EventTypes: []string{"tender.closed", "bid.rejected", "tender.closed"},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "bid.rejected,tender.closed", saved.EventTypes)
+	assert.Equal(t, "anna@example.com", saved.Email)
+	assert.Equal(t, []string{"bid.rejected", "tender.closed"}, prefs.EventTypes)
+	assert.NotNil(t, prefs.UpdatedAt)
+}
+
+func TestUpdatePreferences_RejectsInvalidPreferences(t *testing.T) {
+	m, service := setupMocks()
+
+	_, err := service.UpdatePreferences(userContext(3), notification_models.NotificationPreferencesUpdateModel{
+		UserID:     3,
+		EventTypes: []string{"bid.created"},
+	})
+	assert.ErrorIs(t, err, domain_errors.ErrValidation)
+
+	_, err = service.UpdatePreferences(userContext(3), notification_models.NotificationPreferencesUpdateModel{
+		UserID:     3,
+		Email:      "not an email",
+		EventTypes: []string{"tender.deleted"},
+	})
+	assert.ErrorIs(t, err, domain_errors.ErrValidation)
+
+	m.notificationRepo.AssertNotCalled(t, "SavePreferences", mock.Anything, mock.Anything)
+}
+
+func TestNotify_BidCreatedEmailsOptedInResponsiblesButNotTheCreator(t *testing.T) {
+	m, service := setupMocks()
+
+	m.orgRepo.On("GetResponsibles", mock.Anything, 2).Return([]entities.User{{ID: 5}, {ID: 6}, {ID: 7}}, nil)
+	m.notificationRepo.On("FindOptedIn", mock.Anything, []int{6}, "bid.created").Return([]*entities.NotificationPreference{
+		{UserID: 6, Email: "ivan@example.com", User: entities.User{ID: 6, Username: "ivan", FirstName: "Ivan"}},
+	}, nil)
+	var queued []*entities.Notification
+	m.notificationRepo.On("CreateNotifications", mock.Anything, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		queued = args.Get(1).([]*entities.Notification)
+	})
+
+	err := service.Notify(userContext(5), notification_models.NotificationEventModel{
+		Type:   constants.NotificationBidCreated,
+		Tender: &tender_models.TenderModel{ID: 1, OrganizationID: 1, Name: "Office cleaning"},
+		Bid:    &bid_models.BidModel{ID: 9, Name: "Cleaning by Chisto", TenderID: 1, OrganizationID: 2, CreatorID: 7, CreatedAt: time.Now()},
+	})
+
+	require.NoError(t, err)
+	require.Len(t, queued, 1)
+	assert.Equal(t, 6, queued[0].UserID)
+	assert.Equal(t, "ivan@example.com", queued[0].Recipient)
+	assert.Equal(t, string(constants.NotificationPending), queued[0].Status)
+	assert.Equal(t, `New bid on tender "Office cleaning"`, queued[0].Subject)
+	assert.Contains(t, queued[0].Body, "Hello, Ivan.")
+	assert.Contains(t, queued[0].Body, "Cleaning by Chisto (#9)")
+}
+
+func TestNotify_TenderPublishedEmailsResponsiblesWithBudget(t *testing.T) {
+	m, service := setupMocks()
+
+	m.orgRepo.On("GetResponsibles", mock.Anything, 1).Return([]entities.User{{ID: 5}}, nil)
+	m.notificationRepo.On("FindOptedIn", mock.Anything, []int{5}, "tender.published").Return([]*entities.NotificationPreference{
+		{UserID: 5, Email: "anna@example.com", User: entities.User{ID: 5, Username: "anna", FirstName: "Anna"}},
+	}, nil)
+	var queued []*entities.Notification
+	m.notificationRepo.On("CreateNotifications", mock.Anything, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		queued = args.Get(1).([]*entities.Notification)
+	})
+
+	budget := 150000.5
+	err := service.Notify(auth.NewContext(context.Background(), auth.Principal{Client: "portal"}), notification_models.NotificationEventModel{
+		Type:   constants.NotificationTenderPublished,
+		Tender: &tender_models.TenderModel{ID: 1, OrganizationID: 1, Name: "Office cleaning", ServiceType: "Delivery", Budget: &budget},
+	})
+
+	require.NoError(t, err)
+	require.Len(t, queued, 1)
+	assert.Equal(t, `Tender "Office cleaning" was published`, queued[0].Subject)
+	assert.Contains(t, queued[0].Body, "Service type: Delivery")
+	assert.Contains(t, queued[0].Body, "Budget: 150000.50\n")
+}
+
+func TestNotify_TenderClosedEmailsResponsiblesAndBidders(t *testing.T) {
+	m, service := setupMocks()
+
+	m.orgRepo.On("GetResponsibles", mock.Anything, 1).Return([]entities.User{{ID: 5}}, nil)
+	m.bidRepo.On("FindByTenderID", mock.Anything, 1, mock.Anything, mock.Anything).
+		Return([]*entities.Bid{{ID: 9, CreatorID: 7}, {ID: 10, CreatorID: 8}, {ID: 11, CreatorID: 7}}, int64(3), nil)
+	m.notificationRepo.On("FindOptedIn", mock.Anything, []int{5, 7, 8}, "tender.closed").Return([]*entities.NotificationPreference{}, nil)
+
+	err := service.Notify(auth.NewContext(context.Background(), auth.Principal{Client: "portal"}), notification_models.NotificationEventModel{
+		Type:   constants.NotificationTenderClosed,
+		Tender: &tender_models.TenderModel{ID: 1, OrganizationID: 1, Name: "Office cleaning"},
+	})
+
+	require.NoError(t, err)
+	m.notificationRepo.AssertNotCalled(t, "CreateNotifications", mock.Anything, mock.Anything)
+}
+
+func TestNotify_BidRejectedEmailsTheCreator(t *testing.T) {
+	m, service := setupMocks()
+
+	m.notificationRepo.On("FindOptedIn", mock.Anything, []int{7}, "bid.rejected").Return([]*entities.NotificationPreference{
+		{UserID: 7, Email: "olga@example.com", User: entities.User{ID: 7, Username: "olga"}},
+	}, nil)
+	var queued []*entities.Notification
+	m.notificationRepo.On("CreateNotifications", mock.Anything, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		queued = args.Get(1).([]*entities.Notification)
+	})
+
+	err := service.Notify(userContext(5), notification_models.NotificationEventModel{
+		Type:   constants.NotificationBidRejected,
+		Tender: &tender_models.TenderModel{ID: 1, OrganizationID: 1, Name: "Office cleaning"},
+		Bid:    &bid_models.BidModel{ID: 9, Name: "Cleaning by Chisto", TenderID: 1, OrganizationID: 2, CreatorID: 7},
+	})
+
+	require.NoError(t, err)
+	require.Len(t, queued, 1)
+	assert.Equal(t, "olga@example.com", queued[0].Recipient)
+	assert.Contains(t, queued[0].Body, "Hello, olga.")
+}
+
+func TestSendDue_MarksSentEmails(t *testing.T) {
+	m, service := setupMocks()
+
+	notification := &entities.Notification{ID: 1, UserID: 6, Recipient: "ivan@example.com", Subject: "Hi", Body: "Hello", Status: "pending"}
+	m.notificationRepo.On("ClaimDue", mock.Anything, mock.Anything, mock.Anything, 20).Return([]*entities.Notification{notification}, nil)
+	m.notificationRepo.On("UpdateNotification", mock.Anything, notification).Return(nil)
+
+	sent, err := service.SendDue(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, sent)
+	assert.Equal(t, []notification_service.Email{{To: "ivan@example.com", Subject: "Hi", Body: "Hello"}}, m.mailer.sent)
+	assert.Equal(t, string(constants.NotificationSent), notification.Status)
+	assert.Equal(t, 1, notification.Attempts)
+	assert.NotNil(t, notification.SentAt)
+}
+
+func TestSendDue_RetriesWithBackoffThenGivesUp(t *testing.T) {
+	m, service := setupMocks()
+	m.mailer.err = errors.New("connection refused")
+
+	notification := &entities.Notification{ID: 1, Recipient: "ivan@example.com", Status: "pending", Attempts: 1}
+	m.notificationRepo.On("ClaimDue", mock.Anything, mock.Anything, mock.Anything, 20).Return([]*entities.Notification{notification}, nil)
+	m.notificationRepo.On("UpdateNotification", mock.Anything, notification).Return(nil)
+
+	before := time.Now()
+	_, err := service.SendDue(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, string(constants.NotificationPending), notification.Status)
+	assert.Equal(t, "connection refused", notification.Error)
+	assert.WithinDuration(t, before.Add(90*time.Second), notification.NextAttemptAt, 5*time.Second)
+
+	_, err = service.SendDue(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, 3, notification.Attempts)
+	assert.Equal(t, string(constants.NotificationFailed), notification.Status)
+}
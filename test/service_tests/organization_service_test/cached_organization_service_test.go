@@ -0,0 +1,56 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"avitoTest/data/entities"
+	"avitoTest/services/organization_service"
+	"avitoTest/services/organization_service/organization_models"
+	"avitoTest/shared/cache"
+	"avitoTest/shared/constants"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCachedGetOrganizationByID_InvalidatedOnUpdate(t *testing.T) {
+	mockOrgRepo, mockUserRepo, mockCategoryRepo, _ := setupMocks()
+	namespace := cache.NewNamespace(cache.NewLRU(100), "organizations_test")
+	service := organization_service.NewCachedOrganizationService(
+		organization_service.NewOrganizationService(mockOrgRepo, mockUserRepo, mockCategoryRepo),
+		namespace,
+		time.Minute,
+	)
+
+	entity := &entities.Organization{
+		ID:        1,
+		Name:      "My Organization",
+		Type:      constants.OrganizationType("LLC"),
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+	mockOrgRepo.On("FindByID", mock.Anything, entity.ID).Return(entity, nil)
+	mockOrgRepo.On("Update", mock.Anything, mock.AnythingOfType("*entities.Organization")).Return(nil)
+
+	for range 2 {
+		org, err := service.GetOrganizationByID(context.Background(), entity.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, "My Organization", org.Name)
+	}
+	mockOrgRepo.AssertNumberOfCalls(t, "FindByID", 1)
+	assert.Equal(t, cache.Stats{Name: "organizations_test", Hits: 1, Misses: 1}, namespace.Stats())
+
+	_, err := service.UpdateOrganization(context.Background(), organization_models.OrganizationUpdateModel{
+		ID:   entity.ID,
+		Name: "Renamed Organization",
+		Type: "LLC",
+	})
+	assert.NoError(t, err)
+
+	org, err := service.GetOrganizationByID(context.Background(), entity.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, "Renamed Organization", org.Name)
+	mockOrgRepo.AssertNumberOfCalls(t, "FindByID", 3)
+}
@@ -7,25 +7,30 @@ import (
 	"time"
 
 	"avitoTest/data/entities"
+	"avitoTest/data/repositories/category_repository"
 	"avitoTest/data/repositories/organization_repository"
 	"avitoTest/data/repositories/user_repository"
 	"avitoTest/services/organization_service"
 	"avitoTest/services/organization_service/organization_models"
 	"avitoTest/shared/constants"
+	"avitoTest/shared/errors/category_errors"
+	"avitoTest/shared/pagination"
+	"avitoTest/shared/validation"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
 
-func setupMocks() (*organization_repository.MockOrganizationRepository, *user_repository.MockUserRepository, organization_service.OrganizationService) {
+func setupMocks() (*organization_repository.MockOrganizationRepository, *user_repository.MockUserRepository, *category_repository.MockCategoryRepository, organization_service.OrganizationService) {
 	mockOrgRepo := new(organization_repository.MockOrganizationRepository)
 	mockUserRepo := new(user_repository.MockUserRepository)
-	service := organization_service.NewOrganizationService(mockOrgRepo, mockUserRepo)
-	return mockOrgRepo, mockUserRepo, service
+	mockCategoryRepo := new(category_repository.MockCategoryRepository)
+	service := organization_service.NewOrganizationService(mockOrgRepo, mockUserRepo, mockCategoryRepo)
+	return mockOrgRepo, mockUserRepo, mockCategoryRepo, service
 }
 
 func TestCreateOrganization_Success(t *testing.T) {
-	mockOrgRepo, _, service := setupMocks()
+	mockOrgRepo, _, _, service := setupMocks()
 
 	orgCreate := organization_models.OrganizationCreateModel{
 		Name:        "My Organization",
@@ -58,7 +63,7 @@ func TestCreateOrganization_Success(t *testing.T) {
 }
 
 func TestCreateOrganization_ValidationFail(t *testing.T) {
-	_, _, service := setupMocks()
+	_, _, _, service := setupMocks()
 
 	orgCreate := organization_models.OrganizationCreateModel{
 		Name:        "",
@@ -69,11 +74,37 @@ func TestCreateOrganization_ValidationFail(t *testing.T) {
 	_, err := service.CreateOrganization(context.Background(), orgCreate)
 
 	assert.Error(t, err)
-	assert.Contains(t, err.Error(), "failed on the 'required' tag")
+	assert.Contains(t, err.Error(), "name is required")
+}
+
+func TestCreateOrganization_InactiveServiceType(t *testing.T) {
+	mockOrgRepo, _, mockCategoryRepo, service := setupMocks()
+
+	orgCreate := organization_models.OrganizationCreateModel{
+		Name:         "My Organization",
+		Type:         "LTD",
+		ServiceTypes: []string{"Construction", "Legacy"},
+	}
+
+	mockCategoryRepo.On("IsActiveCode", mock.Anything, "Construction").Return(true, nil)
+	mockCategoryRepo.On("IsActiveCode", mock.Anything, "Legacy").Return(false, nil)
+
+	_, err := service.CreateOrganization(context.Background(), orgCreate)
+
+	var validationErr *validation.Error
+	if assert.ErrorAs(t, err, &validationErr) && assert.Len(t, validationErr.Fields, 2) {
+		assert.Equal(t, "type", validationErr.Fields[0].Field)
+		assert.Equal(t, "org_type", validationErr.Fields[0].Code)
+		assert.Equal(t, "type must be one of IE, LLC, JSC", validationErr.Fields[0].Message)
+		assert.Equal(t, "service_types.1", validationErr.Fields[1].Field)
+		assert.Equal(t, "service_type", validationErr.Fields[1].Code)
+	}
+	assert.ErrorIs(t, err, category_errors.ErrInvalidServiceType)
+	mockOrgRepo.AssertNotCalled(t, "Create")
 }
 
 func TestGetOrganizations_Success(t *testing.T) {
-	mockOrgRepo, _, service := setupMocks()
+	mockOrgRepo, _, _, service := setupMocks()
 
 	expectedEntities := []entities.Organization{
 		{
@@ -94,23 +125,24 @@ func TestGetOrganizations_Success(t *testing.T) {
 		},
 	}
 
-	mockOrgRepo.On("GetAll", mock.Anything).Return(expectedEntities, nil)
+	mockOrgRepo.On("GetAll", mock.Anything, pagination.DefaultParams()).Return(expectedEntities, int64(2), nil)
 
-	result, err := service.GetOrganizations(context.Background())
+	result, err := service.GetOrganizations(context.Background(), pagination.DefaultParams())
 
 	assert.NoError(t, err)
-	assert.Len(t, result, 2)
-	assert.Equal(t, expectedEntities[0].ID, result[0].ID)
-	assert.Equal(t, expectedEntities[1].ID, result[1].ID)
+	assert.Len(t, result.Items, 2)
+	assert.Equal(t, int64(2), result.Total)
+	assert.Equal(t, expectedEntities[0].ID, result.Items[0].ID)
+	assert.Equal(t, expectedEntities[1].ID, result.Items[1].ID)
 	mockOrgRepo.AssertExpectations(t)
 }
 
 func TestGetOrganizations_Failure(t *testing.T) {
-	mockOrgRepo, _, service := setupMocks()
+	mockOrgRepo, _, _, service := setupMocks()
 
-	mockOrgRepo.On("GetAll", mock.Anything).Return(nil, organization_repository.ErrOrganizationNotFound)
+	mockOrgRepo.On("GetAll", mock.Anything, pagination.DefaultParams()).Return(nil, int64(0), organization_repository.ErrOrganizationNotFound)
 
-	_, err := service.GetOrganizations(context.Background())
+	_, err := service.GetOrganizations(context.Background(), pagination.DefaultParams())
 
 	assert.Error(t, err)
 	assert.Equal(t, organization_repository.ErrOrganizationNotFound, err)
@@ -119,7 +151,7 @@ func TestGetOrganizations_Failure(t *testing.T) {
 
 // Tests for AddResponsible, DeleteResponsible, and GetResponsibles methods
 func TestAddResponsible_Success(t *testing.T) {
-	mockOrgRepo, mockUserRepo, service := setupMocks()
+	mockOrgRepo, mockUserRepo, _, service := setupMocks()
 
 	// Mock organization and user existence
 	mockOrgRepo.On("FindByID", mock.Anything, 1).Return(&entities.Organization{ID: 1}, nil)
@@ -134,7 +166,7 @@ func TestAddResponsible_Success(t *testing.T) {
 }
 
 func TestDeleteResponsible_Success(t *testing.T) {
-	mockOrgRepo, mockUserRepo, service := setupMocks()
+	mockOrgRepo, mockUserRepo, _, service := setupMocks()
 
 	// Mock organization and user existence
 	mockOrgRepo.On("FindByID", mock.Anything, 1).Return(&entities.Organization{ID: 1}, nil)
@@ -149,7 +181,7 @@ func TestDeleteResponsible_Success(t *testing.T) {
 }
 
 func TestGetResponsibles_Success(t *testing.T) {
-	mockOrgRepo, _, service := setupMocks()
+	mockOrgRepo, _, _, service := setupMocks()
 
 	expectedUsers := []entities.User{
 		{
@@ -179,7 +211,7 @@ func TestGetResponsibles_Success(t *testing.T) {
 
 // Test case for GetResponsibleByID
 func TestGetResponsibleByID_Success(t *testing.T) {
-	mockOrgRepo, _, service := setupMocks()
+	mockOrgRepo, _, _, service := setupMocks()
 
 	expectedUser := &entities.User{
 		ID:        1,
@@ -203,7 +235,7 @@ func TestGetResponsibleByID_Success(t *testing.T) {
 }
 
 func TestGetResponsibleByID_OrganizationNotFound(t *testing.T) {
-	mockOrgRepo, _, service := setupMocks()
+	mockOrgRepo, _, _, service := setupMocks()
 
 	// Mock organization not found
 	mockOrgRepo.On("FindByID", mock.Anything, 1).Return(nil, organization_repository.ErrOrganizationNotFound)
@@ -216,7 +248,7 @@ func TestGetResponsibleByID_OrganizationNotFound(t *testing.T) {
 }
 
 func TestGetResponsibleByID_ResponsibleNotFound(t *testing.T) {
-	mockOrgRepo, _, service := setupMocks()
+	mockOrgRepo, _, _, service := setupMocks()
 
 	// Mock organization existence
 	mockOrgRepo.On("FindByID", mock.Anything, 1).Return(&entities.Organization{ID: 1}, nil)
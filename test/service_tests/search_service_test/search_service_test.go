@@ -0,0 +1,122 @@
+package search_service_test
+
+import (
+	"avitoTest/data/entities"
+	"avitoTest/data/repositories/organization_repository"
+	"avitoTest/data/repositories/search_repository"
+	"avitoTest/services/search_service"
+	"avitoTest/services/search_service/search_models"
+	"avitoTest/shared/auth"
+	"avitoTest/shared/errors/search_errors"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func setupMocks() (*search_repository.MockSearchRepository, *organization_repository.MockOrganizationRepository, search_service.SearchService) {
+	mockSearchRepo := new(search_repository.MockSearchRepository)
+	mockOrgRepo := new(organization_repository.MockOrganizationRepository)
+	service := search_service.NewSearchService(mockSearchRepo, mockOrgRepo)
+	return mockSearchRepo, mockOrgRepo, service
+}
+
+func TestSearchTenders_AppliesDefaults(t *testing.T) {
+	mockSearchRepo, _, service := setupMocks()
+
+	expectedParams := search_repository.SearchParams{Query: "ремонт дороги", Language: "ru", Limit: 20}
+	mockSearchRepo.On("SearchTenders", mock.Anything, expectedParams).Return([]*search_repository.TenderSearchRow{
+		{TenderID: 1, Name: "Ремонт дороги", Rank: 0.6, Snippet: "<b>Ремонт</b> <b>дороги</b>"},
+	}, nil)
+
+	results, err := service.SearchTenders(context.Background(), search_models.SearchQueryModel{Query: "  ремонт дороги "})
+
+	assert.NoError(t, err)
+	assert.Len(t, results, 1)
+	assert.Equal(t, "<b>Ремонт</b> <b>дороги</b>", results[0].Snippet)
+	mockSearchRepo.AssertExpectations(t)
+}
+
+func TestSearchTenders_CapsLimit(t *testing.T) {
+	mockSearchRepo, _, service := setupMocks()
+
+	expectedParams := search_repository.SearchParams{Query: "road", Language: "en", Limit: 100}
+	mockSearchRepo.On("SearchTenders", mock.Anything, expectedParams).Return([]*search_repository.TenderSearchRow{}, nil)
+
+	_, err := service.SearchTenders(context.Background(), search_models.SearchQueryModel{Query: "road", Language: "en", Limit: 1000})
+
+	assert.NoError(t, err)
+	mockSearchRepo.AssertExpectations(t)
+}
+
+func TestSearchTenders_Validation(t *testing.T) {
+	mockSearchRepo, _, service := setupMocks()
+
+	_, err := service.SearchTenders(context.Background(), search_models.SearchQueryModel{Query: " "})
+	assert.ErrorIs(t, err, search_errors.ErrEmptyQuery)
+
+	_, err = service.SearchTenders(context.Background(), search_models.SearchQueryModel{Query: "road", Language: "de"})
+	assert.ErrorIs(t, err, search_errors.ErrUnsupportedLanguage)
+
+	mockSearchRepo.AssertNotCalled(t, "SearchTenders")
+}
+
+func TestSearchBids_RequiresOrganization(t *testing.T) {
+	mockSearchRepo, _, service := setupMocks()
+
+	_, err := service.SearchBids(context.Background(), search_models.SearchQueryModel{Query: "road"})
+
+	assert.ErrorIs(t, err, search_errors.ErrOrganizationRequired)
+	mockSearchRepo.AssertNotCalled(t, "SearchBids")
+}
+
+func TestSearchBids_RequiresAuthentication(t *testing.T) {
+	mockSearchRepo, _, service := setupMocks()
+
+	_, err := service.SearchBids(context.Background(), search_models.SearchQueryModel{Query: "road", OrganizationID: 1})
+
+	assert.ErrorIs(t, err, search_errors.ErrAuthenticationRequired)
+	mockSearchRepo.AssertNotCalled(t, "SearchBids")
+}
+
+func TestSearchBids_NotResponsible(t *testing.T) {
+	mockSearchRepo, mockOrgRepo, service := setupMocks()
+
+	mockOrgRepo.On("GetResponsibles", mock.Anything, 1).Return([]entities.User{{ID: 2}}, nil)
+
+	ctx := auth.NewContext(context.Background(), auth.Principal{Client: "web", UserID: 3})
+	_, err := service.SearchBids(ctx, search_models.SearchQueryModel{Query: "road", OrganizationID: 1})
+
+	assert.ErrorIs(t, err, search_errors.ErrNotResponsible)
+	mockSearchRepo.AssertNotCalled(t, "SearchBids")
+}
+
+func TestSearchTenders_OrganizationResponsible(t *testing.T) {
+	mockSearchRepo, mockOrgRepo, service := setupMocks()
+
+	mockOrgRepo.On("GetResponsibles", mock.Anything, 1).Return([]entities.User{{ID: 2}}, nil)
+	expectedParams := search_repository.SearchParams{Query: "road", Language: "ru", OrganizationID: 1, Limit: 20}
+	mockSearchRepo.On("SearchTenders", mock.Anything, expectedParams).Return([]*search_repository.TenderSearchRow{}, nil)
+
+	ctx := auth.NewContext(context.Background(), auth.Principal{Client: "web", UserID: 2})
+	_, err := service.SearchTenders(ctx, search_models.SearchQueryModel{Query: "road", OrganizationID: 1})
+
+	assert.NoError(t, err)
+	mockSearchRepo.AssertExpectations(t)
+	mockOrgRepo.AssertExpectations(t)
+}
+
+func TestSearchBids_ClientWithoutUser(t *testing.T) {
+	mockSearchRepo, mockOrgRepo, service := setupMocks()
+
+	expectedParams := search_repository.SearchParams{Query: "road", Language: "ru", OrganizationID: 1, Limit: 20}
+	mockSearchRepo.On("SearchBids", mock.Anything, expectedParams).Return([]*search_repository.BidSearchRow{}, nil)
+
+	ctx := auth.NewContext(context.Background(), auth.Principal{Client: "backoffice"})
+	_, err := service.SearchBids(ctx, search_models.SearchQueryModel{Query: "road", OrganizationID: 1})
+
+	assert.NoError(t, err)
+	mockSearchRepo.AssertExpectations(t)
+	mockOrgRepo.AssertNotCalled(t, "GetResponsibles", mock.Anything, mock.Anything)
+}
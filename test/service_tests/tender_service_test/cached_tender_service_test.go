@@ -0,0 +1,146 @@
+package service_test
+
+import (
+	"avitoTest/data/entities"
+	"avitoTest/data/repositories/tender_repository"
+	"avitoTest/data/repositories/transaction"
+	"avitoTest/services/tender_service"
+	"avitoTest/shared/cache"
+	"avitoTest/shared/constants"
+	"avitoTest/shared/errors/tendert_erorrs"
+	"avitoTest/shared/events"
+	"avitoTest/shared/filter"
+	"avitoTest/shared/pagination"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func setupCachedService() (*tender_repository.MockTenderRepository, *cache.Namespace, tender_service.TenderService) {
+	mockTenderRepo, mockUserRepo, mockCategoryRepo, _ := setupMocks()
+	namespace := cache.NewNamespace(cache.NewLRU(100), "tenders_test")
+	service := tender_service.NewCachedTenderService(
+		tender_service.NewTenderService(mockTenderRepo, mockUserRepo, mockCategoryRepo, new(transaction.MockManager)),
+		namespace,
+		time.Minute,
+	)
+	return mockTenderRepo, namespace, service
+}
+
+func newCachedTenderEntity() *entities.Tender {
+	return &entities.Tender{
+		ID:             1,
+		OrganizationID: 1,
+		CreatorID:      1,
+		ServiceType:    "Construction",
+		Status:         string(constants.TenderStatusCreated),
+		CreatedAt:      time.Now(),
+		CurrentVersion: &entities.TenderVersion{TenderID: 1, Name: "Tender 1", Version: 1},
+	}
+}
+
+func TestCachedGetTenderByID_ServesRepeatedReadsFromCache(t *testing.T) {
+	mockTenderRepo, namespace, service := setupCachedService()
+	entity := newCachedTenderEntity()
+
+	mockTenderRepo.On("FindByID", mock.Anything, entity.ID).Return(entity, nil).Once()
+
+	first, err := service.GetTenderByID(context.Background(), entity.ID)
+	assert.NoError(t, err)
+	second, err := service.GetTenderByID(context.Background(), entity.ID)
+	assert.NoError(t, err)
+
+	assert.Equal(t, first.Name, second.Name)
+	assert.Equal(t, cache.Stats{Name: "tenders_test", Hits: 1, Misses: 1}, namespace.Stats())
+	mockTenderRepo.AssertExpectations(t)
+}
+
+func TestCachedGetTenderByID_InvalidatedOnPublish(t *testing.T) {
+	mockTenderRepo, _, service := setupCachedService()
+	entity := newCachedTenderEntity()
+
+	mockTenderRepo.On("FindByID", mock.Anything, entity.ID).Return(entity, nil)
+	mockTenderRepo.On("Update", mock.Anything, mock.AnythingOfType("*entities.Tender")).Return(nil)
+
+	before, err := service.GetTenderByID(context.Background(), entity.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, constants.TenderStatusCreated, before.Status)
+
+	assert.NoError(t, service.PublishTender(context.Background(), entity.ID))
+
+	after, err := service.GetTenderByID(context.Background(), entity.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, constants.TenderStatusPublished, after.Status)
+	mockTenderRepo.AssertNumberOfCalls(t, "FindByID", 3)
+}
+
+func TestCachedGetAllTenders_InvalidatedOnClose(t *testing.T) {
+	mockTenderRepo, _, service := setupCachedService()
+	entity := newCachedTenderEntity()
+	params := pagination.DefaultParams()
+
+	mockTenderRepo.On("GetAll", mock.Anything, filter.Filter(nil), params).Return([]*entities.Tender{entity}, int64(1), nil)
+	mockTenderRepo.On("FindByID", mock.Anything, entity.ID).Return(entity, nil)
+	mockTenderRepo.On("Update", mock.Anything, mock.AnythingOfType("*entities.Tender")).Return(nil)
+
+	_, err := service.GetAllTenders(context.Background(), nil, params)
+	assert.NoError(t, err)
+	_, err = service.GetAllTenders(context.Background(), nil, params)
+	assert.NoError(t, err)
+	mockTenderRepo.AssertNumberOfCalls(t, "GetAll", 1)
+
+	assert.NoError(t, service.CloseTender(context.Background(), entity.ID))
+
+	page, err := service.GetAllTenders(context.Background(), nil, params)
+	assert.NoError(t, err)
+	assert.Equal(t, constants.TenderStatusClosed, page.Items[0].Status)
+	mockTenderRepo.AssertNumberOfCalls(t, "GetAll", 2)
+}
+
+func TestCachedGetTenderByID_DoesNotCacheErrors(t *testing.T) {
+	mockTenderRepo, _, service := setupCachedService()
+
+	mockTenderRepo.On("FindByID", mock.Anything, 2).Return((*entities.Tender)(nil), tendert_erorrs.ErrTenderNotFound)
+
+	for range 2 {
+		_, err := service.GetTenderByID(context.Background(), 2)
+		assert.ErrorIs(t, err, tendert_erorrs.ErrTenderNotFound)
+	}
+	mockTenderRepo.AssertNumberOfCalls(t, "FindByID", 2)
+}
+
+func TestCachedGetTenderByID_InvalidatedOnOrganizationDelete(t *testing.T) {
+	mockTenderRepo, _, service := setupCachedService()
+	entity := newCachedTenderEntity()
+
+	mockTenderRepo.On("FindByID", mock.Anything, entity.ID).Return(entity, nil)
+
+	_, err := service.GetTenderByID(context.Background(), entity.ID)
+	assert.NoError(t, err)
+
+	events.Publish(context.Background(), events.OrganizationEvent{OrganizationID: entity.OrganizationID, Action: events.ActionDeleted})
+
+	_, err = service.GetTenderByID(context.Background(), entity.ID)
+	assert.NoError(t, err)
+	mockTenderRepo.AssertNumberOfCalls(t, "FindByID", 2)
+}
+
+func TestCachedGetAllTenders_InvalidatedOnCategoryChange(t *testing.T) {
+	mockTenderRepo, _, service := setupCachedService()
+	entity := newCachedTenderEntity()
+	params := pagination.DefaultParams()
+
+	mockTenderRepo.On("GetAll", mock.Anything, filter.Filter(nil), params).Return([]*entities.Tender{entity}, int64(1), nil)
+
+	_, err := service.GetAllTenders(context.Background(), nil, params)
+	assert.NoError(t, err)
+
+	events.Publish(context.Background(), events.CategoryEvent{CategoryID: 1, Action: events.ActionUpdated})
+
+	_, err = service.GetAllTenders(context.Background(), nil, params)
+	assert.NoError(t, err)
+	mockTenderRepo.AssertNumberOfCalls(t, "GetAll", 2)
+}
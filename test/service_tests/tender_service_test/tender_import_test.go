@@ -0,0 +1,161 @@
+package service_test
+
+import (
+	"avitoTest/data/entities"
+	"avitoTest/data/repositories/category_repository"
+	"avitoTest/data/repositories/tender_repository"
+	"avitoTest/data/repositories/transaction"
+	"avitoTest/data/repositories/user_repository"
+	"avitoTest/services/tender_service"
+	"avitoTest/services/tender_service/tender_models"
+	"avitoTest/shared/errors/tendert_erorrs"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const importCSV = `name,description,service_type,organization_id,creator_username,budget
+Road repair,Main street,Construction,1,alice,1500
+Website,,Unknown,1,alice,
+`
+
+// setupImportMocks prepares a creator responsible for organization 1, the Construction
+// category, a Broken category whose lookup fails, and numbers the created tenders from 1.
+func setupImportMocks() (*tender_repository.MockTenderRepository, *transaction.MockManager, tender_service.TenderService) {
+	mockTenderRepo := new(tender_repository.MockTenderRepository)
+	mockUserRepo := new(user_repository.MockUserRepository)
+	mockCategoryRepo := new(category_repository.MockCategoryRepository)
+	mockTransactions := new(transaction.MockManager)
+	service := tender_service.NewTenderService(mockTenderRepo, mockUserRepo, mockCategoryRepo, mockTransactions)
+
+	mockUserRepo.On("FindByUsername", mock.Anything, "alice").Return(&entities.User{ID: 7, Username: "alice"}, nil)
+	mockTenderRepo.On("FindUserOrganizationResponsibility", mock.Anything, 7, 1).Return(&entities.OrganizationResponsible{}, nil)
+	mockCategoryRepo.On("IsActiveCode", mock.Anything, "Construction").Return(true, nil)
+	mockCategoryRepo.On("IsActiveCode", mock.Anything, "Unknown").Return(false, nil)
+	mockCategoryRepo.On("IsActiveCode", mock.Anything, "Broken").Return(false, errors.New("connection reset by peer"))
+
+	nextID := 1
+	mockTenderRepo.On("Create", mock.Anything, mock.AnythingOfType("*entities.Tender")).Return(nil).Run(func(args mock.Arguments) {
+		args.Get(1).(*entities.Tender).ID = nextID
+		nextID++
+	})
+	mockTenderRepo.On("CreateVersion", mock.Anything, mock.AnythingOfType("*entities.TenderVersion")).Return(nil)
+	mockTransactions.On("WithinTransaction", mock.Anything).Return(nil)
+
+	return mockTenderRepo, mockTransactions, service
+}
+
+func parseImport(t *testing.T, data, format string) []tender_models.TenderImportRow {
+	rows, err := tender_service.ParseTenderImport(strings.NewReader(data), format)
+	assert.NoError(t, err)
+	return rows
+}
+
+func TestImportTenders_PerRow(t *testing.T) {
+	_, mockTransactions, service := setupImportMocks()
+
+	report, err := service.ImportTenders(context.Background(), parseImport(t, importCSV, "csv"), tender_models.TenderImportOptions{Mode: tender_models.ImportModePerRow})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, report.Total)
+	assert.Equal(t, 1, report.Imported)
+	assert.Equal(t, 1, report.Failed)
+	assert.Equal(t, tender_models.ImportRowCreated, report.Rows[0].Status)
+	assert.Equal(t, 1, *report.Rows[0].TenderID)
+	assert.Equal(t, tender_models.ImportRowFailed, report.Rows[1].Status)
+	assert.Equal(t, []string{"service_type must be the code of an active service category"}, report.Rows[1].Errors)
+	// One transaction per row and no surrounding one
+	mockTransactions.AssertNumberOfCalls(t, "WithinTransaction", 2)
+}
+
+func TestImportTenders_HidesInternalErrors(t *testing.T) {
+	_, _, service := setupImportMocks()
+	data := "name,service_type,organization_id,creator_username\nRoad repair,Broken,1,alice\n"
+
+	report, err := service.ImportTenders(context.Background(), parseImport(t, data, "csv"), tender_models.TenderImportOptions{Mode: tender_models.ImportModePerRow})
+
+	assert.NoError(t, err)
+	assert.Equal(t, tender_models.ImportRowFailed, report.Rows[0].Status)
+	assert.Equal(t, []string{"internal error"}, report.Rows[0].Errors)
+}
+
+func TestImportTenders_AtomicRollsBackOnFailure(t *testing.T) {
+	_, mockTransactions, service := setupImportMocks()
+
+	report, err := service.ImportTenders(context.Background(), parseImport(t, importCSV, "csv"), tender_models.TenderImportOptions{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, tender_models.ImportModeAtomic, report.Mode)
+	assert.Equal(t, 0, report.Imported)
+	assert.Equal(t, 1, report.Failed)
+	assert.Equal(t, tender_models.ImportRowValid, report.Rows[0].Status)
+	assert.Nil(t, report.Rows[0].TenderID)
+	mockTransactions.AssertNumberOfCalls(t, "WithinTransaction", 3)
+}
+
+func TestImportTenders_DryRun(t *testing.T) {
+	mockTenderRepo, _, service := setupImportMocks()
+
+	rows := parseImport(t, `[{"name": "Road repair", "service_type": "Construction", "organization_id": 1, "creator_username": "alice", "status": "PUBLISHED"}]`, "json")
+	report, err := service.ImportTenders(context.Background(), rows, tender_models.TenderImportOptions{Mode: tender_models.ImportModePerRow, DryRun: true})
+
+	assert.NoError(t, err)
+	assert.True(t, report.DryRun)
+	assert.Equal(t, 0, report.Imported)
+	assert.Equal(t, 0, report.Failed)
+	assert.Equal(t, tender_models.ImportRowValid, report.Rows[0].Status)
+	mockTenderRepo.AssertCalled(t, "Create", mock.Anything, mock.MatchedBy(func(tender *entities.Tender) bool {
+		return tender.Status == "PUBLISHED" && tender.CreatorID == 7
+	}))
+}
+
+func TestImportTenders_InvalidMode(t *testing.T) {
+	_, _, service := setupImportMocks()
+
+	_, err := service.ImportTenders(context.Background(), nil, tender_models.TenderImportOptions{Mode: "all_or_some"})
+
+	assert.ErrorIs(t, err, tendert_erorrs.ErrInvalidImportMode)
+}
+
+func TestParseTenderImport_RowErrors(t *testing.T) {
+	rows := parseImport(t, "name,service_type,organization_id,creator_username,budget\nA,IT,one,alice,lots\nB,IT,1\n", "csv")
+
+	assert.Len(t, rows, 2)
+	assert.Equal(t, []string{"organization_id: must be an integer", "budget: must be a number"}, rows[0].Errors)
+	assert.Equal(t, []string{"expected 5 fields, got 3"}, rows[1].Errors)
+
+	rows = parseImport(t, `[{"name": "A", "organization_id": "one"}, {"name": "B"}]`, "json")
+
+	assert.Len(t, rows, 2)
+	assert.Equal(t, []string{"organization_id: must be int"}, rows[0].Errors)
+	assert.Empty(t, rows[1].Errors)
+	assert.Equal(t, 2, rows[1].Row)
+}
+
+func TestParseTenderImport_InvalidFile(t *testing.T) {
+	tests := []struct {
+		name   string
+		data   string
+		format string
+	}{
+		{"empty csv", "", "csv"},
+		{"unknown column", "name,service_type,organization_id,creator_username,color\n", "csv"},
+		{"missing column", "name,service_type\n", "csv"},
+		{"json object", `{"name": "A"}`, "json"},
+		{"truncated json", `[{"name": "A"}`, "json"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tender_service.ParseTenderImport(strings.NewReader(tt.data), tt.format)
+			assert.ErrorIs(t, err, tendert_erorrs.ErrInvalidImportFile)
+		})
+	}
+
+	_, err := tender_service.ParseTenderImport(strings.NewReader(""), "xml")
+	assert.ErrorIs(t, err, tendert_erorrs.ErrInvalidImportFormat)
+}
@@ -2,29 +2,39 @@ package service_test
 
 import (
 	"avitoTest/data/entities"
+	"avitoTest/data/repositories/category_repository"
 	"avitoTest/data/repositories/tender_repository"
+	"avitoTest/data/repositories/transaction"
 	"avitoTest/data/repositories/user_repository"
 	"avitoTest/services/tender_service"
 	"avitoTest/services/tender_service/tender_models"
 	"avitoTest/shared/constants"
+	"avitoTest/shared/errors/category_errors"
+	"avitoTest/shared/errors/tendert_erorrs"
+	"avitoTest/shared/filter"
+	"avitoTest/shared/pagination"
+	"avitoTest/shared/validation"
 	"context"
+	"errors"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
-func setupMocks() (*tender_repository.MockTenderRepository, *user_repository.MockUserRepository, tender_service.TenderService) {
+func setupMocks() (*tender_repository.MockTenderRepository, *user_repository.MockUserRepository, *category_repository.MockCategoryRepository, tender_service.TenderService) {
 	mockTenderRepo := new(tender_repository.MockTenderRepository)
 	mockUserRepo := new(user_repository.MockUserRepository)
-	service := tender_service.NewTenderService(mockTenderRepo, mockUserRepo)
-	return mockTenderRepo, mockUserRepo, service
+	mockCategoryRepo := new(category_repository.MockCategoryRepository)
+	service := tender_service.NewTenderService(mockTenderRepo, mockUserRepo, mockCategoryRepo, new(transaction.MockManager))
+	return mockTenderRepo, mockUserRepo, mockCategoryRepo, service
 }
 
 // Test for CreateTender
 func TestCreateTender_Success(t *testing.T) {
-	mockTenderRepo, _, service := setupMocks()
+	mockTenderRepo, _, mockCategoryRepo, service := setupMocks()
 
 	tenderCreate := tender_models.TenderCreateModel{
 		Name:           "Tender 1",
@@ -53,6 +63,7 @@ func TestCreateTender_Success(t *testing.T) {
 	}
 
 	mockTenderRepo.On("FindUserOrganizationResponsibility", mock.Anything, tenderCreate.CreatorID, tenderCreate.OrganizationID).Return(&entities.OrganizationResponsible{}, nil)
+	mockCategoryRepo.On("IsActiveCode", mock.Anything, "Construction").Return(true, nil)
 	mockTenderRepo.On("Create", mock.Anything, mock.AnythingOfType("*entities.Tender")).Return(nil).Run(func(args mock.Arguments) {
 		tender := args.Get(1).(*entities.Tender)
 		tender.ID = expectedEntity.ID
@@ -72,9 +83,209 @@ func TestCreateTender_Success(t *testing.T) {
 	mockTenderRepo.AssertExpectations(t)
 }
 
+// Test for CreateTender with a service type that is not an active category
+func TestCreateTender_InactiveServiceType(t *testing.T) {
+	mockTenderRepo, _, mockCategoryRepo, service := setupMocks()
+
+	tenderCreate := tender_models.TenderCreateModel{
+		Name:           "Tender 1",
+		ServiceType:    "Legacy",
+		OrganizationID: 1,
+		CreatorID:      1,
+		Status:         constants.TenderStatusCreated,
+	}
+
+	mockTenderRepo.On("FindUserOrganizationResponsibility", mock.Anything, 1, 1).Return(&entities.OrganizationResponsible{}, nil)
+	mockCategoryRepo.On("IsActiveCode", mock.Anything, "Legacy").Return(false, nil)
+
+	_, err := service.CreateTender(context.Background(), tenderCreate)
+
+	assert.ErrorIs(t, err, category_errors.ErrInvalidServiceType)
+	mockTenderRepo.AssertNotCalled(t, "Create")
+}
+
+// Test for UpdateTender changing the budget
+func TestUpdateTender_Budget(t *testing.T) {
+	mockTenderRepo, _, _, service := setupMocks()
+
+	budget := 250000.0
+	tenderUpdate := tender_models.TenderUpdateModel{
+		ID:          1,
+		Name:        "Updated Tender",
+		Description: "Updated Description",
+		Budget:      &budget,
+	}
+
+	existingEntity := &entities.Tender{ID: 1, OrganizationID: 1, CreatorID: 1, ServiceType: "Renovation"}
+
+	mockTenderRepo.On("FindByID", mock.Anything, tenderUpdate.ID).Return(existingEntity, nil)
+	mockTenderRepo.On("Update", mock.Anything, mock.MatchedBy(func(tender *entities.Tender) bool {
+		return tender.Budget != nil && *tender.Budget == budget
+	})).Return(nil)
+	mockTenderRepo.On("FindLatestVersion", mock.Anything, tenderUpdate.ID).Return(&entities.TenderVersion{TenderID: 1, Version: 1}, nil)
+	mockTenderRepo.On("CreateVersion", mock.Anything, mock.AnythingOfType("*entities.TenderVersion")).Return(nil)
+
+	result, err := service.UpdateTender(context.Background(), tenderUpdate)
+
+	assert.NoError(t, err)
+	assert.Equal(t, budget, *result.Budget)
+	assert.Equal(t, 2, result.Version)
+	mockTenderRepo.AssertExpectations(t)
+}
+
+// Test for GetAllTenders filtered by a category including its subcategories
+func TestGetAllTenders_FilterByCategorySubtree(t *testing.T) {
+	mockTenderRepo, _, mockCategoryRepo, service := setupMocks()
+
+	mockCategoryRepo.On("FindByCode", mock.Anything, "IT Services").Return(&entities.ServiceCategory{ID: 2, Code: "IT Services", IsActive: true}, nil)
+	mockCategoryRepo.On("FindSubtreeCodes", mock.Anything, 2).Return([]string{"IT Services", "Software Development"}, nil)
+	expandedFilter := filter.Filter{
+		{Field: constants.FilterFieldStatus, Operator: filter.OpEq, Values: []any{"PUBLISHED"}},
+		{Field: constants.FilterFieldCategory, Operator: filter.OpIn, Values: []any{"IT Services", "Software Development"}},
+	}
+	mockTenderRepo.On("GetAll", mock.Anything, expandedFilter, pagination.DefaultParams()).Return([]*entities.Tender{
+		{
+			ID: 1, OrganizationID: 1, ServiceType: "Software Development", Status: "PUBLISHED",
+			CurrentVersion: &entities.TenderVersion{TenderID: 1, Name: "Backend", Version: 1},
+		},
+	}, int64(1), nil)
+
+	f := filter.Filter{
+		{Field: constants.FilterFieldCategory, Operator: filter.OpEq, Values: []any{"IT Services"}},
+		{Field: constants.FilterFieldStatus, Operator: filter.OpEq, Values: []any{"PUBLISHED"}},
+	}
+	result, err := service.GetAllTenders(context.Background(), f, pagination.DefaultParams())
+
+	assert.NoError(t, err)
+	assert.Len(t, result.Items, 1)
+	assert.Equal(t, "Software Development", result.Items[0].ServiceType)
+	mockCategoryRepo.AssertExpectations(t)
+	mockTenderRepo.AssertExpectations(t)
+}
+
+// Test for GetAllTenders with an unknown category
+func TestGetAllTenders_UnknownCategory(t *testing.T) {
+	_, _, mockCategoryRepo, service := setupMocks()
+
+	mockCategoryRepo.On("FindByCode", mock.Anything, "Unknown").Return(nil, category_errors.ErrCategoryNotFound)
+
+	f := filter.Filter{{Field: constants.FilterFieldCategory, Operator: filter.OpEq, Values: []any{"Unknown"}}}
+	_, err := service.GetAllTenders(context.Background(), f, pagination.DefaultParams())
+
+	assert.ErrorIs(t, err, category_errors.ErrInvalidServiceType)
+}
+
+// Test for ExportTenders streaming every batch with the category filter expanded
+func TestExportTenders_Batches(t *testing.T) {
+	mockTenderRepo, _, mockCategoryRepo, service := setupMocks()
+
+	mockCategoryRepo.On("FindByCode", mock.Anything, "IT Services").Return(&entities.ServiceCategory{ID: 2, Code: "IT Services", IsActive: true}, nil)
+	mockCategoryRepo.On("FindSubtreeCodes", mock.Anything, 2).Return([]string{"IT Services"}, nil)
+	expandedFilter := filter.Filter{{Field: constants.FilterFieldCategory, Operator: filter.OpIn, Values: []any{"IT Services"}}}
+	mockTenderRepo.On("StreamByOrganizationID", mock.Anything, 1, expandedFilter).Return([][]*entities.Tender{
+		{
+			{ID: 1, OrganizationID: 1, CurrentVersion: &entities.TenderVersion{TenderID: 1, Name: "Backend", Version: 2}},
+			{ID: 2, OrganizationID: 1, CurrentVersion: &entities.TenderVersion{TenderID: 2, Name: "Frontend", Version: 1}},
+		},
+		{
+			{ID: 3, OrganizationID: 1, CurrentVersion: &entities.TenderVersion{TenderID: 3, Name: "Mobile", Version: 1}},
+		},
+	}, nil)
+
+	var names []string
+	f := filter.Filter{{Field: constants.FilterFieldCategory, Operator: filter.OpEq, Values: []any{"IT Services"}}}
+	err := service.ExportTenders(context.Background(), 1, f, func(tender *tender_models.TenderModel) error {
+		names = append(names, tender.Name)
+		return nil
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Backend", "Frontend", "Mobile"}, names)
+	mockTenderRepo.AssertExpectations(t)
+}
+
+// Test for CreateTender with a negative budget
+func TestCreateTender_NegativeBudget(t *testing.T) {
+	mockTenderRepo, _, mockCategoryRepo, service := setupMocks()
+
+	budget := -100.0
+	tenderCreate := tender_models.TenderCreateModel{
+		Name:           "Tender 1",
+		ServiceType:    "Construction",
+		Budget:         &budget,
+		OrganizationID: 1,
+		CreatorID:      1,
+		Status:         constants.TenderStatusCreated,
+	}
+
+	mockTenderRepo.On("FindUserOrganizationResponsibility", mock.Anything, 1, 1).Return(&entities.OrganizationResponsible{}, nil)
+	mockCategoryRepo.On("IsActiveCode", mock.Anything, "Construction").Return(true, nil)
+
+	_, err := service.CreateTender(context.Background(), tenderCreate)
+
+	assert.ErrorIs(t, err, tendert_erorrs.ErrInvalidBudget)
+	mockTenderRepo.AssertNotCalled(t, "Create")
+}
+
+// Test for CreateTender listing every invalid field
+func TestCreateTender_InvalidFields(t *testing.T) {
+	mockTenderRepo, _, mockCategoryRepo, service := setupMocks()
+
+	tenderCreate := tender_models.TenderCreateModel{
+		ServiceType: "Legacy",
+		CreatorID:   1,
+		Status:      "OPEN",
+		EligibilityRules: []tender_models.EligibilityRuleModel{
+			{Value: "LLC"},
+		},
+	}
+
+	mockCategoryRepo.On("IsActiveCode", mock.Anything, "Legacy").Return(false, nil)
+
+	_, err := service.CreateTender(context.Background(), tenderCreate)
+
+	var validationErr *validation.Error
+	require.ErrorAs(t, err, &validationErr)
+	codes := map[string]string{}
+	for _, field := range validationErr.Fields {
+		codes[field.Field] = field.Code
+	}
+	assert.Equal(t, map[string]string{
+		"name":                          "required",
+		"service_type":                  "service_type",
+		"organization_id":               "required",
+		"status":                        "tender_status",
+		"eligibility_rules.0.rule_type": "required",
+	}, codes)
+	assert.ErrorIs(t, err, category_errors.ErrInvalidServiceType)
+	assert.ErrorIs(t, err, tendert_erorrs.ErrInvalidStatus)
+	mockTenderRepo.AssertNotCalled(t, "FindUserOrganizationResponsibility")
+}
+
+// Test for CreateTender when the service types cannot be looked up
+func TestCreateTender_ServiceTypeLookupFails(t *testing.T) {
+	mockTenderRepo, _, mockCategoryRepo, service := setupMocks()
+
+	lookupErr := errors.New("database is down")
+	tenderCreate := tender_models.TenderCreateModel{
+		Name:           "Tender 1",
+		ServiceType:    "Construction",
+		OrganizationID: 1,
+		CreatorID:      1,
+		Status:         constants.TenderStatusCreated,
+	}
+
+	mockCategoryRepo.On("IsActiveCode", mock.Anything, "Construction").Return(false, lookupErr)
+
+	_, err := service.CreateTender(context.Background(), tenderCreate)
+
+	assert.Equal(t, lookupErr, err)
+	mockTenderRepo.AssertNotCalled(t, "Create")
+}
+
 // Test for UpdateTender
 func TestUpdateTender_Success(t *testing.T) {
-	mockTenderRepo, _, service := setupMocks()
+	mockTenderRepo, _, _, service := setupMocks()
 
 	tenderUpdate := tender_models.TenderUpdateModel{
 		ID:          1,
@@ -113,7 +324,7 @@ func TestUpdateTender_Success(t *testing.T) {
 
 // Test for GetTendersByUsername
 func TestGetTendersByUsername_Success(t *testing.T) {
-	mockTenderRepo, mockUserRepo, service := setupMocks()
+	mockTenderRepo, mockUserRepo, _, service := setupMocks()
 
 	username := "test_user"
 	expectedUser := &entities.User{ID: 1, Username: username}
@@ -138,21 +349,25 @@ func TestGetTendersByUsername_Success(t *testing.T) {
 	}
 
 	mockUserRepo.On("FindByUsername", mock.Anything, username).Return(expectedUser, nil)
-	mockTenderRepo.On("GetAllByCreatorID", mock.Anything, expectedUser.ID).Return(tenders, nil)
-	mockTenderRepo.On("FindLatestVersion", mock.Anything, tenders[0].ID).Return(latestVersion, nil)
+	tenders[0].CurrentVersion = latestVersion
 
-	result, err := service.GetTendersByUsername(context.Background(), username)
+	mockTenderRepo.On("GetAllByCreatorID", mock.Anything, expectedUser.ID, pagination.DefaultParams()).Return(tenders, int64(len(tenders)), nil)
+
+	result, err := service.GetTendersByUsername(context.Background(), username, pagination.DefaultParams())
 
 	assert.NoError(t, err)
-	assert.Equal(t, len(tenders), len(result))
-	assert.Equal(t, tenders[0].ID, result[0].ID)
+	assert.Equal(t, len(tenders), len(result.Items))
+	assert.Equal(t, int64(len(tenders)), result.Total)
+	assert.Equal(t, tenders[0].ID, result.Items[0].ID)
+	assert.Equal(t, latestVersion.Name, result.Items[0].Name)
 	mockTenderRepo.AssertExpectations(t)
+	mockTenderRepo.AssertNotCalled(t, "FindLatestVersion")
 	mockUserRepo.AssertExpectations(t)
 }
 
 // Test for DeleteTender
 func TestDeleteTender_Success(t *testing.T) {
-	mockTenderRepo, _, service := setupMocks()
+	mockTenderRepo, _, _, service := setupMocks()
 
 	existingEntity := &entities.Tender{
 		ID:             1,
@@ -173,7 +388,7 @@ func TestDeleteTender_Success(t *testing.T) {
 
 // Test for GetTenderByID
 func TestGetTenderByID_Success(t *testing.T) {
-	mockTenderRepo, _, service := setupMocks()
+	mockTenderRepo, _, _, service := setupMocks()
 
 	existingEntity := &entities.Tender{
 		ID:             1,
@@ -192,8 +407,9 @@ func TestGetTenderByID_Success(t *testing.T) {
 		UpdatedAt:   time.Now(),
 	}
 
+	existingEntity.CurrentVersion = latestVersion
+
 	mockTenderRepo.On("FindByID", mock.Anything, 1).Return(existingEntity, nil)
-	mockTenderRepo.On("FindLatestVersion", mock.Anything, 1).Return(latestVersion, nil)
 
 	result, err := service.GetTenderByID(context.Background(), 1)
 
@@ -205,7 +421,7 @@ func TestGetTenderByID_Success(t *testing.T) {
 
 // Test for PublishTender
 func TestPublishTender_Success(t *testing.T) {
-	mockTenderRepo, _, service := setupMocks()
+	mockTenderRepo, _, _, service := setupMocks()
 
 	tenderID := 1
 	existingEntity := &entities.Tender{
@@ -231,7 +447,7 @@ func TestPublishTender_Success(t *testing.T) {
 
 // Test for CloseTender
 func TestCloseTender_Success(t *testing.T) {
-	mockTenderRepo, _, service := setupMocks()
+	mockTenderRepo, _, _, service := setupMocks()
 
 	tenderID := 1
 	existingEntity := &entities.Tender{
@@ -257,7 +473,7 @@ func TestCloseTender_Success(t *testing.T) {
 
 // Test for RollbackTenderVersion
 func TestRollbackTenderVersion_Success(t *testing.T) {
-	mockTenderRepo, _, service := setupMocks()
+	mockTenderRepo, _, _, service := setupMocks()
 
 	tenderID := 1
 	rollbackVersion := 1
@@ -305,3 +521,39 @@ func TestRollbackTenderVersion_Success(t *testing.T) {
 	assert.Equal(t, rollbackEntity.Description, result.Description)
 	mockTenderRepo.AssertExpectations(t)
 }
+
+// Test for SetEligibilityRules
+func TestSetEligibilityRules_Success(t *testing.T) {
+	mockTenderRepo, _, _, service := setupMocks()
+
+	rules := []tender_models.EligibilityRuleModel{
+		{RuleType: constants.EligibilityOrganizationType, Value: "LLC,JSC"},
+		{RuleType: constants.EligibilityMinRegistrationMonths, Value: "6"},
+	}
+
+	mockTenderRepo.On("FindByID", mock.Anything, 1).Return(&entities.Tender{ID: 1}, nil)
+	mockTenderRepo.On("ReplaceEligibilityRules", mock.Anything, 1, mock.AnythingOfType("[]*entities.TenderEligibilityRule")).Return(nil)
+
+	result, err := service.SetEligibilityRules(context.Background(), 1, rules)
+
+	assert.NoError(t, err)
+	assert.Len(t, result, 2)
+	assert.Equal(t, constants.EligibilityOrganizationType, result[0].RuleType)
+	mockTenderRepo.AssertExpectations(t)
+}
+
+// Test for SetEligibilityRules with an invalid rule
+func TestSetEligibilityRules_InvalidRule(t *testing.T) {
+	mockTenderRepo, _, _, service := setupMocks()
+
+	rules := []tender_models.EligibilityRuleModel{
+		{RuleType: constants.EligibilityMinRegistrationMonths, Value: "0"},
+	}
+
+	mockTenderRepo.On("FindByID", mock.Anything, 1).Return(&entities.Tender{ID: 1}, nil)
+
+	_, err := service.SetEligibilityRules(context.Background(), 1, rules)
+
+	assert.ErrorIs(t, err, tendert_erorrs.ErrInvalidEligibilityRule)
+	mockTenderRepo.AssertNotCalled(t, "ReplaceEligibilityRules")
+}
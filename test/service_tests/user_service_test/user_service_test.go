@@ -5,6 +5,7 @@ import (
 	"avitoTest/data/repositories/user_repository"
 	"avitoTest/services/user_service"
 	"avitoTest/services/user_service/user_models"
+	"avitoTest/shared/errors/domain_errors"
 	"context"
 	"testing"
 	"time"
@@ -63,7 +64,7 @@ func TestCreateUser_ValidationFail(t *testing.T) {
 	_, err := service.CreateUser(context.Background(), userCreate)
 
 	assert.Error(t, err)
-	assert.Contains(t, err.Error(), "Field validation for 'Username' failed on the 'required' tag")
+	assert.Contains(t, err.Error(), "username is required")
 
 	mockUserRepo.AssertNotCalled(t, "Create")
 }
@@ -191,5 +192,6 @@ func TestDeleteUser_NotFound(t *testing.T) {
 
 	assert.Error(t, err)
 	assert.Equal(t, "user not found", err.Error())
+	assert.ErrorIs(t, err, domain_errors.ErrNotFound)
 	mockUserRepo.AssertExpectations(t)
 }
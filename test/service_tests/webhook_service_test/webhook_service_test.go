@@ -0,0 +1,309 @@
+package webhook_service_test
+
+import (
+	"avitoTest/data/entities"
+	"avitoTest/data/repositories/organization_repository"
+	"avitoTest/data/repositories/webhook_repository"
+	"avitoTest/services/webhook_service"
+	"avitoTest/services/webhook_service/webhook_models"
+	"avitoTest/shared/auth"
+	"avitoTest/shared/constants"
+	"avitoTest/shared/errors/domain_errors"
+	"avitoTest/shared/errors/webhook_errors"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var retryPolicy = webhook_service.RetryPolicy{MaxAttempts: 3, Backoff: time.Minute, MaxBackoff: 90 * time.Second}
+
+func setupMocks() (*webhook_repository.MockWebhookRepository, *organization_repository.MockOrganizationRepository, webhook_service.WebhookService) {
+	mockWebhookRepo := new(webhook_repository.MockWebhookRepository)
+	mockOrgRepo := new(organization_repository.MockOrganizationRepository)
+	client := webhook_service.NewHTTPClient(5*time.Second, true)
+	service := webhook_service.NewWebhookService(mockWebhookRepo, mockOrgRepo, client, retryPolicy)
+	return mockWebhookRepo, mockOrgRepo, service
+}
+
+// clientContext is the context of an API client acting on its own behalf.
+func clientContext() context.Context {
+	return auth.NewContext(context.Background(), auth.Principal{Client: "portal"})
+}
+
+func TestCreateWebhook_GeneratesSecretAndReturnsItOnce(t *testing.T) {
+	mockWebhookRepo, mockOrgRepo, service := setupMocks()
+
+	mockOrgRepo.On("FindByID", mock.Anything, 1).Return(&entities.Organization{ID: 1}, nil)
+	var created *entities.Webhook
+	mockWebhookRepo.On("Create", mock.Anything, mock.AnythingOfType("*entities.Webhook")).Return(nil).Run(func(args mock.Arguments) {
+		created = args.Get(1).(*entities.Webhook)
+		created.ID = 5
+	})
+
+	webhook, err := service.CreateWebhook(clientContext(), webhook_models.WebhookCreateModel{
+		OrganizationID: 1,
+		URL:            "https://hooks.example.com/tenders",
+		EventTypes:     []string{"tender.published", "bid.created", "tender.published"},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, 5, webhook.ID)
+	assert.Equal(t, []string{"bid.created", "tender.published"}, webhook.EventTypes)
+	assert.True(t, webhook.Active)
+	assert.True(t, strings.HasPrefix(webhook.Secret, "whsec_"))
+	assert.Equal(t, created.Secret, webhook.Secret)
+	assert.Equal(t, "bid.created,tender.published", created.EventTypes)
+
+	mockWebhookRepo.On("FindByID", mock.Anything, 5).Return(created, nil)
+	fetched, err := service.GetWebhookByID(clientContext(), 1, 5)
+	require.NoError(t, err)
+	assert.Empty(t, fetched.Secret)
+}
+
+func TestCreateWebhook_RejectsInvalidWebhook(t *testing.T) {
+	mockWebhookRepo, _, service := setupMocks()
+
+	_, err := service.CreateWebhook(clientContext(), webhook_models.WebhookCreateModel{
+		OrganizationID: 1,
+		URL:            "ftp://hooks.example.com",
+		EventTypes:     []string{"tender.deleted"},
+	})
+
+	assert.ErrorIs(t, err, domain_errors.ErrValidation)
+	mockWebhookRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestWebhooks_RequireResponsibleUser(t *testing.T) {
+	_, mockOrgRepo, service := setupMocks()
+
+	_, err := service.GetWebhooks(context.Background(), 1)
+	assert.ErrorIs(t, err, webhook_errors.ErrAuthenticationRequired)
+
+	mockOrgRepo.On("GetResponsibleByID", mock.Anything, 1, 3).Return(nil, domain_errors.New(domain_errors.ErrNotFound, "responsible not found"))
+	ctx := auth.NewContext(context.Background(), auth.Principal{UserID: 3})
+	_, err = service.GetWebhooks(ctx, 1)
+	assert.ErrorIs(t, err, webhook_errors.ErrNotOrganizationAdmin)
+}
+
+func TestGetWebhookByID_HidesWebhooksOfOtherOrganizations(t *testing.T) {
+	mockWebhookRepo, _, service := setupMocks()
+
+	mockWebhookRepo.On("FindByID", mock.Anything, 5).Return(&entities.Webhook{ID: 5, OrganizationID: 2}, nil)
+
+	_, err := service.GetWebhookByID(clientContext(), 1, 5)
+
+	assert.ErrorIs(t, err, webhook_errors.ErrWebhookNotFound)
+}
+
+func TestEnqueue_QueuesDeliveryForEverySubscribedWebhook(t *testing.T) {
+	mockWebhookRepo, _, service := setupMocks()
+
+	mockWebhookRepo.On("FindSubscribed", mock.Anything, []int{1, 2}, "bid.created").Return([]*entities.Webhook{{ID: 5}, {ID: 6}}, nil)
+	var queued []*entities.WebhookDelivery
+	mockWebhookRepo.On("CreateDeliveries", mock.Anything, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		queued = args.Get(1).([]*entities.WebhookDelivery)
+	})
+
+	err := service.Enqueue(context.Background(), webhook_models.WebhookEventModel{
+		Type:            constants.WebhookEventBidCreated,
+		OrganizationIDs: []int{2, 1, 2},
+		Data:            map[string]any{"bid": map[string]int{"id": 9}},
+	})
+
+	require.NoError(t, err)
+	require.Len(t, queued, 2)
+	assert.Equal(t, 5, queued[0].WebhookID)
+	assert.Equal(t, 6, queued[1].WebhookID)
+	assert.Equal(t, queued[0].EventID, queued[1].EventID)
+	for _, delivery := range queued {
+		assert.Equal(t, "pending", delivery.Status)
+		assert.Equal(t, "bid.created", delivery.EventType)
+		assert.WithinDuration(t, time.Now(), delivery.NextAttemptAt, time.Minute)
+	}
+
+	var payload map[string]any
+	require.NoError(t, json.Unmarshal([]byte(queued[0].Payload), &payload))
+	assert.Equal(t, queued[0].EventID, payload["id"])
+	assert.Equal(t, "bid.created", payload["type"])
+	assert.Equal(t, map[string]any{"bid": map[string]any{"id": float64(9)}}, payload["data"])
+}
+
+func TestEnqueue_SkipsEventsWithoutSubscribers(t *testing.T) {
+	mockWebhookRepo, _, service := setupMocks()
+
+	mockWebhookRepo.On("FindSubscribed", mock.Anything, []int{1}, "tender.closed").Return([]*entities.Webhook{}, nil)
+
+	err := service.Enqueue(context.Background(), webhook_models.WebhookEventModel{Type: constants.WebhookEventTenderClosed, OrganizationIDs: []int{1}})
+
+	assert.NoError(t, err)
+	mockWebhookRepo.AssertNotCalled(t, "CreateDeliveries", mock.Anything, mock.Anything)
+}
+
+func TestDeliverDue_SendsSignedPayload(t *testing.T) {
+	mockWebhookRepo, _, service := setupMocks()
+
+	payload := `{"id":"evt","type":"tender.published","data":{}}`
+	var received *http.Request
+	var body []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r
+		body, _ = io.ReadAll(r.Body)
+		w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	delivery := &entities.WebhookDelivery{
+		ID:        11,
+		EventType: "tender.published",
+		Payload:   payload,
+		Status:    "pending",
+		Webhook:   entities.Webhook{ID: 5, URL: server.URL, Secret: "whsec_test"},
+	}
+	mockWebhookRepo.On("ClaimDue", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return([]*entities.WebhookDelivery{delivery}, nil)
+	mockWebhookRepo.On("UpdateDelivery", mock.Anything, delivery).Return(nil)
+
+	attempted, err := service.DeliverDue(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, attempted)
+	assert.Equal(t, payload, string(body))
+	assert.Equal(t, "application/json", received.Header.Get("Content-Type"))
+	assert.Equal(t, "tender.published", received.Header.Get(webhook_service.HeaderEvent))
+	assert.Equal(t, "11", received.Header.Get(webhook_service.HeaderDelivery))
+	assert.Equal(t, webhook_service.Sign("whsec_test", *delivery.LastAttemptAt, body), received.Header.Get(webhook_service.HeaderSignature))
+
+	assert.Equal(t, "succeeded", delivery.Status)
+	assert.Equal(t, 1, delivery.Attempts)
+	assert.Equal(t, http.StatusOK, delivery.ResponseStatus)
+	assert.Equal(t, "ok", delivery.ResponseBody)
+	assert.NotNil(t, delivery.DeliveredAt)
+	mockWebhookRepo.AssertExpectations(t)
+}
+
+func TestDeliverDue_KeepsResponseExcerptAsValidText(t *testing.T) {
+	mockWebhookRepo, _, service := setupMocks()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// A NUL byte, an invalid byte, then two-byte runes running past the 1024 kept bytes
+		w.Write([]byte("ok\x00\xffa" + strings.Repeat("я", 600)))
+	}))
+	defer server.Close()
+
+	delivery := &entities.WebhookDelivery{
+		ID:        11,
+		EventType: "tender.published",
+		Payload:   `{}`,
+		Status:    "pending",
+		Webhook:   entities.Webhook{ID: 5, URL: server.URL, Secret: "whsec_test"},
+	}
+	mockWebhookRepo.On("ClaimDue", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return([]*entities.WebhookDelivery{delivery}, nil)
+	mockWebhookRepo.On("UpdateDelivery", mock.Anything, delivery).Return(nil)
+
+	_, err := service.DeliverDue(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, "ok\uFFFDa"+strings.Repeat("я", 509), delivery.ResponseBody)
+}
+
+func TestDeliverDue_RetriesWithBackoffThenGivesUp(t *testing.T) {
+	mockWebhookRepo, _, service := setupMocks()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "unavailable", http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	delivery := &entities.WebhookDelivery{ID: 11, Payload: `{}`, Status: "pending", Webhook: entities.Webhook{URL: server.URL}}
+	mockWebhookRepo.On("ClaimDue", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return([]*entities.WebhookDelivery{delivery}, nil)
+	mockWebhookRepo.On("UpdateDelivery", mock.Anything, delivery).Return(nil)
+
+	// Backoff after the first failure, doubled after the second but capped at MaxBackoff
+	for _, backoff := range []time.Duration{time.Minute, 90 * time.Second} {
+		_, err := service.DeliverDue(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, "pending", delivery.Status)
+		assert.Equal(t, http.StatusServiceUnavailable, delivery.ResponseStatus)
+		assert.Equal(t, "endpoint responded with status 503", delivery.Error)
+		assert.WithinDuration(t, delivery.LastAttemptAt.Add(backoff), delivery.NextAttemptAt, time.Second)
+	}
+
+	_, err := service.DeliverDue(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "failed", delivery.Status)
+	assert.Equal(t, 3, delivery.Attempts)
+	assert.Nil(t, delivery.DeliveredAt)
+}
+
+func TestDeliverDue_RecordsConnectionErrors(t *testing.T) {
+	mockWebhookRepo, mockOrgRepo, _ := setupMocks()
+	client := webhook_service.NewHTTPClient(time.Second, false)
+	service := webhook_service.NewWebhookService(mockWebhookRepo, mockOrgRepo, client, retryPolicy)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("a webhook to a loopback address was called")
+	}))
+	defer server.Close()
+
+	delivery := &entities.WebhookDelivery{ID: 11, Payload: `{}`, Status: "pending", Webhook: entities.Webhook{URL: server.URL}}
+	mockWebhookRepo.On("ClaimDue", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return([]*entities.WebhookDelivery{delivery}, nil)
+	mockWebhookRepo.On("UpdateDelivery", mock.Anything, delivery).Return(nil)
+
+	_, err := service.DeliverDue(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, "pending", delivery.Status)
+	assert.Zero(t, delivery.ResponseStatus)
+	assert.Contains(t, delivery.Error, "not publicly routable")
+}
+
+func TestRedeliver_QueuesCopyOfDelivery(t *testing.T) {
+	mockWebhookRepo, _, service := setupMocks()
+
+	mockWebhookRepo.On("FindByID", mock.Anything, 5).Return(&entities.Webhook{ID: 5, OrganizationID: 1}, nil)
+	mockWebhookRepo.On("FindDeliveryByID", mock.Anything, 11).Return(&entities.WebhookDelivery{
+		ID: 11, WebhookID: 5, EventID: "evt", EventType: "bid.approved", Payload: `{"id":"evt"}`, Status: "failed", Attempts: 3,
+	}, nil)
+	var queued []*entities.WebhookDelivery
+	mockWebhookRepo.On("CreateDeliveries", mock.Anything, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		queued = args.Get(1).([]*entities.WebhookDelivery)
+	})
+
+	delivery, err := service.Redeliver(clientContext(), 1, 5, 11)
+
+	require.NoError(t, err)
+	require.Len(t, queued, 1)
+	assert.Equal(t, "evt", queued[0].EventID)
+	assert.Equal(t, `{"id":"evt"}`, queued[0].Payload)
+	assert.Equal(t, 11, *queued[0].RedeliveryOf)
+	assert.Equal(t, "pending", delivery.Status)
+	assert.Zero(t, delivery.Attempts)
+	assert.NotNil(t, delivery.NextAttemptAt)
+}
+
+func TestRedeliver_RejectsDeliveryOfAnotherWebhook(t *testing.T) {
+	mockWebhookRepo, _, service := setupMocks()
+
+	mockWebhookRepo.On("FindByID", mock.Anything, 5).Return(&entities.Webhook{ID: 5, OrganizationID: 1}, nil)
+	mockWebhookRepo.On("FindDeliveryByID", mock.Anything, 11).Return(&entities.WebhookDelivery{ID: 11, WebhookID: 6}, nil)
+
+	_, err := service.Redeliver(clientContext(), 1, 5, 11)
+
+	assert.ErrorIs(t, err, webhook_errors.ErrDeliveryNotFound)
+	mockWebhookRepo.AssertNotCalled(t, "CreateDeliveries", mock.Anything, mock.Anything)
+}
+
+func TestSign(t *testing.T) {
+	signature := webhook_service.Sign("secret", time.Unix(1700000000, 0), []byte(`{"id":"evt"}`))
+
+	// echo -n '1700000000.{"id":"evt"}' | openssl dgst -sha256 -hmac secret
+	assert.Equal(t, "t=1700000000,v1=7c757099788fba43a4fe1e0c3b767303fdd971ab6183bc900d3de418c62b08b0", signature)
+}
@@ -0,0 +1,115 @@
+package shared_tests
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"avitoTest/shared/config"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeFile(t *testing.T, content string) string {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestLoad_Defaults(t *testing.T) {
+	t.Setenv("POSTGRES_CONN", "postgres://user:secret@db:5432/tenders")
+
+	conf, args, err := config.Load(nil, io.Discard)
+
+	require.NoError(t, err)
+	assert.Empty(t, args)
+	assert.Equal(t, ":8080", conf.Server.Address)
+	assert.Equal(t, 30*time.Second, conf.Server.ReadTimeout)
+	assert.Equal(t, "info", conf.Log.Level)
+	assert.Equal(t, 25, conf.Database.MaxOpenConns)
+	assert.Equal(t, "postgres://user:secret@db:5432/tenders", conf.Database.ConnectionString())
+}
+
+func TestLoad_FileThenEnvironmentThenFlags(t *testing.T) {
+	path := writeFile(t, `
+server:
+  address: ":9000"
+  read_timeout: 10s
+  cors_allowed_origins: [https://a.example]
+database:
+  host: db
+  user: tenders
+  password: secret
+  name: tenders
+log:
+  level: debug
+cache:
+  size: 50
+`)
+	t.Setenv(config.FileEnv, path)
+	t.Setenv("SERVER_READ_TIMEOUT", "20s")
+	t.Setenv("LOG_LEVEL", "warn")
+	t.Setenv("AUTH_API_KEYS", "erp:k1, crm:k2")
+
+	conf, args, err := config.Load([]string{"-log.level", "error", "-cache.size=70", "import-tenders", "-file", "x.csv"}, io.Discard)
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"import-tenders", "-file", "x.csv"}, args)
+	assert.Equal(t, ":9000", conf.Server.Address)
+	assert.Equal(t, []string{"https://a.example"}, conf.Server.CORSAllowedOrigins)
+	assert.Equal(t, 20*time.Second, conf.Server.ReadTimeout)
+	assert.Equal(t, "error", conf.Log.Level)
+	assert.Equal(t, 70, conf.Cache.Size)
+	assert.Equal(t, map[string]string{"erp": "k1", "crm": "k2"}, conf.Auth.APIKeys)
+	assert.Equal(t, "postgres://tenders:secret@db:5432/tenders", conf.Database.ConnectionString())
+}
+
+func TestLoad_ReportsEveryProblem(t *testing.T) {
+	t.Setenv("SERVER_ADDRESS", ":99999")
+	t.Setenv("SERVER_READ_TIMEOUT", "soon")
+	t.Setenv("DB_MAX_OPEN_CONNS", "5")
+	t.Setenv("LOG_LEVEL", "verbose")
+
+	_, _, err := config.Load([]string{"-cache.ttl", "0s"}, io.Discard)
+
+	var configErr *config.Error
+	require.ErrorAs(t, err, &configErr)
+	assert.ElementsMatch(t, []string{
+		`SERVER_READ_TIMEOUT: "soon" is not a duration such as 30s or 5m`,
+		`server.address must be host:port with a port between 1 and 65535, got ":99999"`,
+		"database.user is required when database.dsn is not set",
+		"database.name is required when database.dsn is not set",
+		"database.max_idle_conns must not exceed database.max_open_conns, got 10 > 5",
+		`log.level must be one of [trace debug info warn warning error fatal panic], got "verbose"`,
+		"cache.ttl must be a positive duration, got 0s",
+	}, configErr.Problems)
+}
+
+func TestLoad_RejectsUnknownFileSettings(t *testing.T) {
+	path := writeFile(t, "server:\n  adress: \":9000\"\n")
+
+	_, _, err := config.Load([]string{"-config", path}, io.Discard)
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "field adress not found")
+}
+
+func TestString_HidesSecrets(t *testing.T) {
+	t.Setenv("POSTGRES_CONN", "postgres://user:secret@db:5432/tenders")
+	t.Setenv("POSTGRES_PASSWORD", "secret")
+	t.Setenv("AUTH_API_KEYS", "erp:k3y-value")
+
+	conf, _, err := config.Load(nil, io.Discard)
+	require.NoError(t, err)
+	printed := conf.String()
+
+	assert.NotContains(t, printed, "secret")
+	assert.NotContains(t, printed, "k3y-value")
+	assert.Contains(t, printed, "auth.api_keys=map[erp:***]")
+	assert.Contains(t, printed, "database.dsn=***")
+	assert.Contains(t, printed, "database.password=***")
+	assert.Contains(t, printed, "server.read_timeout=30s")
+}